@@ -0,0 +1,226 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Access log formats.
+const (
+	AccessLogCommon   = "common"   // NCSA Common Log Format
+	AccessLogCombined = "combined" // Apache Combined Log Format (Common plus referer and user agent)
+)
+
+// AccessLogConfig holds configuration options for the HTTP access log.
+type AccessLogConfig struct {
+	Format      string             // Line format: AccessLogCommon or AccessLogCombined (default: AccessLogCombined)
+	Output      io.Writer          // Destination of access log lines (optional, overrides the file)
+	LogDir      string             // Directory of the access log file (default: "logger")
+	LogRotation *LogRotationConfig // Rotation of the access log file (optional, uses defaults if nil)
+	Logger      *Logger            // Also log each request as a structured entry (optional)
+}
+
+// AccessLogEntry describes a completed HTTP request.
+type AccessLogEntry struct {
+	Time       time.Time     // Time the request was received
+	RemoteAddr string        // Client address, without port
+	User       string        // Authenticated user (optional)
+	Method     string        // Request method
+	URI        string        // Request URI as sent by the client
+	Proto      string        // Protocol, e.g. "HTTP/1.1"
+	Status     int           // Response status code
+	Bytes      int64         // Response body size in bytes
+	Referer    string        // Referer header
+	UserAgent  string        // User-Agent header
+	Duration   time.Duration // Time taken to serve the request
+}
+
+// NewAccessLogEntry fills an AccessLogEntry from a request and its outcome.
+func NewAccessLogEntry(r *http.Request, status int, bytes int64, start time.Time) AccessLogEntry {
+	remoteAddr := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(remoteAddr); err == nil {
+		remoteAddr = host
+	}
+	user, _, _ := r.BasicAuth()
+	if user == "" && r.URL.User != nil {
+		user = r.URL.User.Username()
+	}
+
+	return AccessLogEntry{
+		Time:       start,
+		RemoteAddr: remoteAddr,
+		User:       user,
+		Method:     r.Method,
+		URI:        r.RequestURI,
+		Proto:      r.Proto,
+		Status:     status,
+		Bytes:      bytes,
+		Referer:    r.Referer(),
+		UserAgent:  r.UserAgent(),
+		Duration:   time.Since(start),
+	}
+}
+
+// AccessLogger writes HTTP access log lines in Common or Combined Log Format
+// to its own file, for tooling that only reads CLF. When a Logger is
+// configured, each request is also logged as a structured entry.
+type AccessLogger struct {
+	mu     sync.Mutex
+	format string
+	out    io.Writer
+	file   *rotatingFile // Access log file (nil with a custom Output)
+	stop   func()        // Stops SIGHUP handling and cleanup (nil if disabled)
+	logger *Logger
+}
+
+// NewAccessLogger creates an access logger. Unset options fall back to their defaults.
+// Without an Output, lines are written to "access-YYYY-MM-DD.log" in LogDir.
+func NewAccessLogger(config AccessLogConfig) *AccessLogger {
+	format := config.Format
+	if format == "" {
+		format = AccessLogCombined
+	}
+
+	a := &AccessLogger{format: format, out: config.Output, logger: config.Logger}
+	if a.out == nil {
+		logDir := config.LogDir
+		if logDir == "" {
+			logDir = "logger"
+		}
+		if err := os.MkdirAll(logDir, 0755); err != nil {
+			logDir = "."
+		}
+		a.file = newRotatingFile(logDir, "access", config.LogRotation)
+		if a.file.link != "" {
+			// Keep the link apart from the one of a logger sharing the directory.
+			a.file.link = "access-" + a.file.link
+		}
+		a.out = a.file
+		a.stop = startFileTasks(a.file, config.LogRotation)
+	}
+	return a
+}
+
+// Log writes the access log line for a request. The context is used for the
+// structured entry, so request and trace IDs stored in it are included.
+func (a *AccessLogger) Log(ctx context.Context, entry AccessLogEntry) error {
+	var line []byte
+	if a.format == AccessLogCommon {
+		line = appendCommonLog(nil, entry)
+	} else {
+		line = appendCombinedLog(nil, entry)
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	_, err := a.out.Write(line)
+	a.mu.Unlock()
+
+	if a.logger != nil {
+		log := a.logger.WithContext(ctx)
+		switch {
+		case entry.Status >= http.StatusInternalServerError:
+			log = log.Error("http request")
+		case entry.Status >= http.StatusBadRequest:
+			log = log.Warn("http request")
+		default:
+			log = log.Info("http request")
+		}
+		log.Data("method", entry.Method).
+			Data("uri", entry.URI).
+			Data("status", entry.Status).
+			Data("bytes", entry.Bytes).
+			Data("duration_ms", float64(entry.Duration)/float64(time.Millisecond)).
+			Data("remote_addr", entry.RemoteAddr).
+			Data("user_agent", entry.UserAgent).
+			Send()
+	}
+	return err
+}
+
+// Rotate moves the access log file aside and starts a new one, or reopens it
+// if it was moved by an external tool. Returns an error with a custom Output.
+func (a *AccessLogger) Rotate() error {
+	if a.file == nil {
+		return errors.New("gologger: access logger does not write to a file")
+	}
+	return a.file.Rotate()
+}
+
+// Close closes the access log file, if the access logger opened one.
+func (a *AccessLogger) Close() error {
+	if a.file == nil {
+		return nil
+	}
+	if a.stop != nil {
+		a.stop()
+	}
+	return a.file.Close()
+}
+
+// appendCommonLog appends an entry in Common Log Format:
+//
+//	host ident authuser [date] "request" status bytes
+func appendCommonLog(b []byte, entry AccessLogEntry) []byte {
+	b = appendCLFField(b, entry.RemoteAddr)
+	b = append(b, " - "...)
+	b = appendCLFField(b, entry.User)
+	b = append(b, " ["...)
+	b = entry.Time.AppendFormat(b, "02/Jan/2006:15:04:05 -0700")
+	b = append(b, "] \""...)
+	b = appendCLFEscaped(b, entry.Method+" "+entry.URI+" "+entry.Proto)
+	b = append(b, "\" "...)
+	b = strconv.AppendInt(b, int64(entry.Status), 10)
+	b = append(b, ' ')
+	if entry.Bytes > 0 {
+		b = strconv.AppendInt(b, entry.Bytes, 10)
+	} else {
+		b = append(b, '-')
+	}
+	return b
+}
+
+// appendCombinedLog appends an entry in Combined Log Format, which adds the
+// quoted referer and user agent to the Common Log Format.
+func appendCombinedLog(b []byte, entry AccessLogEntry) []byte {
+	b = appendCommonLog(b, entry)
+	b = append(b, " \""...)
+	b = appendCLFField(b, entry.Referer)
+	b = append(b, "\" \""...)
+	b = appendCLFField(b, entry.UserAgent)
+	return append(b, '"')
+}
+
+// appendCLFField appends a field value, using "-" for empty values.
+func appendCLFField(b []byte, s string) []byte {
+	if s == "" {
+		return append(b, '-')
+	}
+	return appendCLFEscaped(b, s)
+}
+
+// appendCLFEscaped appends s with quotes, backslashes and non-printable
+// bytes escaped the way Apache does, so untrusted request data cannot break
+// or forge log lines.
+func appendCLFEscaped(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			b = append(b, '\\', c)
+		case c < 0x20 || c >= 0x7f:
+			b = append(b, '\\', 'x', hex[c>>4], hex[c&0xf])
+		default:
+			b = append(b, c)
+		}
+	}
+	return b
+}
@@ -0,0 +1,172 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testAccessLogEntry() AccessLogEntry {
+	return AccessLogEntry{
+		Time:       time.Date(2000, 10, 10, 13, 55, 36, 0, time.FixedZone("", -7*3600)),
+		RemoteAddr: "127.0.0.1",
+		User:       "frank",
+		Method:     "GET",
+		URI:        "/apache_pb.gif",
+		Proto:      "HTTP/1.0",
+		Status:     200,
+		Bytes:      2326,
+		Referer:    "http://www.example.com/start.html",
+		UserAgent:  "Mozilla/4.08 [en] (Win98; I ;Nav)",
+		Duration:   15 * time.Millisecond,
+	}
+}
+
+func TestAccessLoggerFormats(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			AccessLogCommon,
+			`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326`,
+		},
+		{
+			AccessLogCombined,
+			`127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08 [en] (Win98; I ;Nav)"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			access := NewAccessLogger(AccessLogConfig{Format: tt.format, Output: &buf})
+			if err := access.Log(context.Background(), testAccessLogEntry()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := buf.String(); got != tt.expected+"\n" {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAccessLoggerEscaping(t *testing.T) {
+	entry := AccessLogEntry{
+		Time:      time.Unix(0, 0).UTC(),
+		Method:    "GET",
+		URI:       "/search?q=\"x\"\r\n127.0.0.1 - - fake",
+		Proto:     "HTTP/1.1",
+		Status:    404,
+		UserAgent: `evil\agent`,
+	}
+
+	var buf bytes.Buffer
+	access := NewAccessLogger(AccessLogConfig{Output: &buf})
+	if err := access.Log(context.Background(), entry); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `- - - [01/Jan/1970:00:00:00 +0000] "GET /search?q=\"x\"\x0d\x0a127.0.0.1 - - fake HTTP/1.1" 404 - "-" "evil\\agent"` + "\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestAccessLoggerStructuredEntry(t *testing.T) {
+	logger, capture := NewTestLogger()
+	var buf bytes.Buffer
+	access := NewAccessLogger(AccessLogConfig{Output: &buf, Logger: &logger})
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	entry := testAccessLogEntry()
+	entry.Status = 503
+	if err := access.Log(ctx, entry); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	entries := capture.FilterMessage("http request")
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 structured entry, got %d", len(entries))
+	}
+	got := entries[0]
+	if got.Level != LevelError {
+		t.Errorf("Expected error level for 5xx, got %s", got.Level)
+	}
+	if got.Fields["request-id"] != "req-42" || got.Fields["method"] != "GET" {
+		t.Errorf("Unexpected fields %v", got.Fields)
+	}
+	if got.Fields["duration_ms"] != float64(15) {
+		t.Errorf("Expected duration_ms 15, got %v", got.Fields["duration_ms"])
+	}
+}
+
+func TestAccessLoggerFile(t *testing.T) {
+	dir := t.TempDir()
+	access := NewAccessLogger(AccessLogConfig{LogDir: dir})
+	if err := access.Log(context.Background(), testAccessLogEntry()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := access.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "access-"+time.Now().Format("2006-01-02")+".log"))
+	if err != nil {
+		t.Fatalf("Access log file not created: %v", err)
+	}
+	if !strings.Contains(string(data), `"GET /apache_pb.gif HTTP/1.0" 200 2326`) {
+		t.Errorf("Unexpected access log content %q", data)
+	}
+}
+
+func TestNewAccessLogEntry(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders?id=1", nil)
+	r.RemoteAddr = "10.0.0.5:51234"
+	r.SetBasicAuth("alice", "secret")
+	r.Header.Set("User-Agent", "curl/8.0")
+	r.Header.Set("Referer", "https://example.com/")
+
+	start := time.Now().Add(-time.Second)
+	entry := NewAccessLogEntry(r, 201, 17, start)
+
+	if entry.RemoteAddr != "10.0.0.5" || entry.User != "alice" {
+		t.Errorf("Unexpected client info %q %q", entry.RemoteAddr, entry.User)
+	}
+	if entry.Method != "POST" || entry.URI != "/orders?id=1" || entry.Proto != "HTTP/1.1" {
+		t.Errorf("Unexpected request line %s %s %s", entry.Method, entry.URI, entry.Proto)
+	}
+	if entry.Status != 201 || entry.Bytes != 17 || entry.UserAgent != "curl/8.0" || entry.Referer != "https://example.com/" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Duration < time.Second {
+		t.Errorf("Expected duration of at least 1s, got %s", entry.Duration)
+	}
+}
+
+func TestAccessLoggerRotate(t *testing.T) {
+	dir := t.TempDir()
+	access := NewAccessLogger(AccessLogConfig{LogDir: dir, LogRotation: &LogRotationConfig{Compress: false}})
+	defer access.Close()
+	if err := access.Log(context.Background(), testAccessLogEntry()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := access.Rotate(); err != nil {
+		t.Fatalf("Unexpected rotate error: %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 2 {
+		t.Errorf("Expected the current file and one backup, got %d files", len(entries))
+	}
+
+	if err := NewAccessLogger(AccessLogConfig{Output: io.Discard}).Rotate(); err == nil {
+		t.Error("Expected error rotating an access logger with a custom output")
+	}
+}
@@ -0,0 +1,71 @@
+package gologger
+
+// getAllowlist returns the keyPatterns of AllowKeys, or nil when the
+// allowlist is disabled.
+func getAllowlist(patterns []string) *keyPatterns {
+	if len(patterns) == 0 {
+		return nil
+	}
+	return newKeyPatterns(patterns)
+}
+
+// allowFields returns the key-value pairs of fields whose keys match allow,
+// the patterns of AllowKeys, or are the request ID or trace context, counting
+// the others in the logger's stats. Values that are not key-value pairs are
+// dropped too. The keys of maps and structs in the values are filtered the
+// same way, see allowValue.
+func (l Logger) allowFields(allow *keyPatterns, fields []any) []any {
+	allowed := fields[:0]
+	var dropped []string
+	for i := 0; i < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok || i+1 == len(fields) {
+			dropped = append(dropped, "!BADKEY")
+			continue
+		}
+		if key == l.requestIDKey || key == TraceIDField || key == SpanIDField || allow.match(key) {
+			allowed = append(allowed, key, allowValue(allow, key, fields[i+1], &dropped))
+		} else {
+			dropped = append(dropped, key)
+		}
+	}
+	if len(dropped) > 0 {
+		l.stats.countDroppedFields(dropped)
+	}
+	return allowed
+}
+
+// allowValue returns value, the value of the field at path, with the keys of
+// its maps and structs that match neither allow nor their dotted path, such
+// as "user.id", left out and appended to dropped. Maps and structs are
+// converted as by DataStruct, so the caller's values are not changed.
+func allowValue(allow *keyPatterns, path string, value any, dropped *[]string) any {
+	if nested, ok := nestedValue(value); ok {
+		return allowNested(allow, path, nested, dropped)
+	}
+	return value
+}
+
+// allowNested filters the keys of value, as converted by structValue.
+func allowNested(allow *keyPatterns, path string, value any, dropped *[]string) any {
+	switch v := value.(type) {
+	case map[string]any:
+		allowed := make(map[string]any, len(v))
+		for key, nested := range v {
+			nestedPath := path + "." + key
+			if allow.match(key) || allow.match(nestedPath) {
+				allowed[key] = allowNested(allow, nestedPath, nested, dropped)
+			} else {
+				*dropped = append(*dropped, nestedPath)
+			}
+		}
+		return allowed
+	case []any:
+		for i, nested := range v {
+			v[i] = allowNested(allow, path, nested, dropped)
+		}
+		return v
+	default:
+		return value
+	}
+}
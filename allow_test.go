@@ -0,0 +1,105 @@
+package gologger
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestAllowKeysConfig(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		AllowKeys:  []string{"user_id", "HTTP_*", "email"},
+		Maskers:    map[string]Masker{"email": HashEmailDomain},
+		Sinks:      []Sink{capture},
+	})
+	ctx := WithRequestID(context.Background(), "req-42")
+	ctx = WithTraceContext(ctx, "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	ctx = WithFields(ctx, "session", "s-1")
+
+	log.WithContext(ctx).Info("profile updated").
+		Data("user_id", 7).
+		Data("http_status", 200).
+		Data("email", "alice@example.com").
+		Data("ssn", "123-45-6789").
+		Data("ssn", "987-65-4321").
+		Send()
+	log.Info("no fields").Send()
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(entries))
+	}
+	fields := entries[0].Fields
+	expected := map[string]any{
+		"request-id":  "req-42",
+		TraceIDField:  "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanIDField:   "00f067aa0ba902b7",
+		"user_id":     int64(7),
+		"http_status": int64(200),
+		"email":       HashEmailDomain("alice@example.com"),
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected only the allowed fields, masked, got %v", fields)
+	}
+
+	dropped := log.Stats().DroppedFields
+	if !reflect.DeepEqual(dropped, map[string]uint64{"session": 1, "ssn": 2}) {
+		t.Errorf("Expected the dropped fields to be counted, got %v", dropped)
+	}
+}
+
+func TestAllowKeysDisabled(t *testing.T) {
+	if getAllowlist(nil) != nil {
+		t.Error("Expected no allowlist by default")
+	}
+	log, capture := NewTestLogger()
+	log.Info("entry").Data("ssn", "123-45-6789").Send()
+	if entries := capture.Entries(); len(entries) != 1 || entries[0].Fields["ssn"] != "123-45-6789" {
+		t.Errorf("Expected all fields without an allowlist, got %+v", entries)
+	}
+	if dropped := log.Stats().DroppedFields; len(dropped) != 0 {
+		t.Errorf("Expected no dropped fields, got %v", dropped)
+	}
+}
+
+func TestAllowKeysNested(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		AllowKeys:  []string{"user", "order", "id", "items", "order.total"},
+		Sinks:      []Sink{capture},
+	})
+	type item struct {
+		ID    int    `json:"id"`
+		Notes string `json:"notes"`
+	}
+	type order struct {
+		Total int    `json:"total"`
+		Card  string `json:"card"`
+		Items []item `json:"items"`
+	}
+	user := map[string]any{"id": 7, "ssn": "123-45-6789"}
+
+	log.Info("order placed").
+		Data("user", user).
+		Data("order", &order{Total: 42, Card: "4111", Items: []item{{ID: 1, Notes: "gift"}}}).
+		Send()
+
+	fields := capture.Entries()[0].Fields
+	expected := map[string]any{
+		"user":  map[string]any{"id": 7},
+		"order": map[string]any{"total": 42, "items": []any{map[string]any{"id": 1}}},
+	}
+	if !reflect.DeepEqual(fields, expected) {
+		t.Errorf("Expected only the allowed nested keys, got %v", fields)
+	}
+	if user["ssn"] == nil {
+		t.Error("Expected the logged map to be left unchanged")
+	}
+	dropped := log.Stats().DroppedFields
+	if !reflect.DeepEqual(dropped, map[string]uint64{"user.ssn": 1, "order.card": 1, "order.items.notes": 1}) {
+		t.Errorf("Expected the dropped nested keys to be counted by path, got %v", dropped)
+	}
+}
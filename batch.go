@@ -0,0 +1,102 @@
+package gologger
+
+import (
+	"sync"
+	"time"
+)
+
+// batcher buffers entries and hands them to a flush function in groups,
+// either when the batch is full or when the flush interval elapses.
+// Errors from background flushes are returned by the next Add or Flush.
+type batcher struct {
+	mu      sync.Mutex
+	entries []Entry
+	err     error
+	size    int
+	flush   func([]Entry) error
+
+	flushMu sync.Mutex // serializes flushes so batches are delivered in order
+	done    chan struct{}
+	wg      sync.WaitGroup
+	once    sync.Once
+}
+
+// newBatcher creates a batcher and starts its periodic flush goroutine.
+func newBatcher(size int, interval time.Duration, flush func([]Entry) error) *batcher {
+	b := &batcher{
+		entries: make([]Entry, 0, size),
+		size:    size,
+		flush:   flush,
+		done:    make(chan struct{}),
+	}
+
+	b.wg.Add(1)
+	go b.run(interval)
+	return b
+}
+
+func (b *batcher) run(interval time.Duration) {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := b.Flush(); err != nil {
+				b.mu.Lock()
+				b.err = err
+				b.mu.Unlock()
+			}
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// Add appends an entry, flushing synchronously once the batch is full.
+func (b *batcher) Add(entry Entry) error {
+	b.mu.Lock()
+	b.entries = append(b.entries, entry)
+	full := len(b.entries) >= b.size
+	err := b.err
+	b.err = nil
+	b.mu.Unlock()
+
+	if full {
+		if flushErr := b.Flush(); flushErr != nil {
+			return flushErr
+		}
+	}
+	return err
+}
+
+// Flush delivers all buffered entries.
+func (b *batcher) Flush() error {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	entries := b.entries
+	b.entries = make([]Entry, 0, b.size)
+	err := b.err
+	b.err = nil
+	b.mu.Unlock()
+
+	if len(entries) > 0 {
+		if flushErr := b.flush(entries); flushErr != nil {
+			return flushErr
+		}
+	}
+	return err
+}
+
+// Close stops the periodic flush goroutine and flushes remaining entries.
+func (b *batcher) Close() error {
+	b.once.Do(func() {
+		close(b.done)
+	})
+	b.wg.Wait()
+	return b.Flush()
+}
@@ -0,0 +1,76 @@
+package gologger
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBatcherFlushesWhenFull(t *testing.T) {
+	var mu sync.Mutex
+	var batches [][]Entry
+	b := newBatcher(2, time.Hour, func(entries []Entry) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, entries)
+		return nil
+	})
+	defer b.Close()
+
+	for i := 0; i < 5; i++ {
+		if err := b.Add(Entry{Message: "entry"}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	mu.Lock()
+	if len(batches) != 2 {
+		t.Errorf("Expected 2 full batches, got %d", len(batches))
+	}
+	mu.Unlock()
+
+	if err := b.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(batches) != 3 || len(batches[2]) != 1 {
+		t.Errorf("Expected Close to flush the remaining entry, got %v", batches)
+	}
+}
+
+func TestBatcherFlushesOnInterval(t *testing.T) {
+	flushed := make(chan int, 1)
+	b := newBatcher(100, 10*time.Millisecond, func(entries []Entry) error {
+		flushed <- len(entries)
+		return nil
+	})
+	defer b.Close()
+
+	_ = b.Add(Entry{Message: "entry"})
+
+	select {
+	case n := <-flushed:
+		if n != 1 {
+			t.Errorf("Expected 1 entry, got %d", n)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected periodic flush")
+	}
+}
+
+func TestBatcherReportsBackgroundErrors(t *testing.T) {
+	errFlush := errors.New("flush failed")
+	b := newBatcher(100, 10*time.Millisecond, func(entries []Entry) error {
+		return errFlush
+	})
+	defer b.Close()
+
+	_ = b.Add(Entry{Message: "entry"})
+	time.Sleep(50 * time.Millisecond)
+
+	if err := b.Add(Entry{Message: "entry"}); !errors.Is(err, errFlush) {
+		t.Errorf("Expected background flush error, got %v", err)
+	}
+}
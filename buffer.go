@@ -0,0 +1,85 @@
+package gologger
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// BufferConfig holds configuration options for buffered output.
+type BufferConfig struct {
+	Size          int           // Buffer size that forces a flush: bytes for file output, entries for sinks (default: 256 KiB or 256 entries)
+	FlushInterval time.Duration // Maximum time data stays buffered (default: 1s)
+}
+
+// newBufferedWriteSyncer wraps ws so writes are buffered in memory and
+// flushed when the buffer is full, on every interval and on Sync. The
+// returned stop function flushes and stops the background goroutine.
+func newBufferedWriteSyncer(ws zapcore.WriteSyncer, config BufferConfig) (zapcore.WriteSyncer, func() error) {
+	size := config.Size
+	if size <= 0 {
+		size = 256 * 1024
+	}
+	interval := config.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	buffered := &zapcore.BufferedWriteSyncer{
+		WS:            ws,
+		Size:          size,
+		FlushInterval: interval,
+	}
+	return buffered, buffered.Stop
+}
+
+// BufferedSink wraps a sink so entries are collected in memory and handed
+// to it in the background, reducing per-entry overhead for slow sinks.
+// When the buffer is full it is flushed by the writing goroutine, which
+// bounds memory use. Buffered entries are flushed on Sync, on Close and
+// before Panic or Fatal entries terminate the process.
+type BufferedSink struct {
+	sink  Sink
+	batch *batcher
+}
+
+// NewBufferedSink creates a buffered wrapper around sink. Unset options fall back to their defaults.
+func NewBufferedSink(sink Sink, config BufferConfig) *BufferedSink {
+	size := config.Size
+	if size <= 0 {
+		size = 256
+	}
+	interval := config.FlushInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	s := &BufferedSink{sink: sink}
+	s.batch = newBatcher(size, interval, s.forward)
+	return s
+}
+
+// Write buffers an entry.
+func (s *BufferedSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync flushes buffered entries and syncs the wrapped sink.
+func (s *BufferedSink) Sync() error {
+	return errors.Join(s.batch.Flush(), s.sink.Sync())
+}
+
+// Close flushes buffered entries, stops the background flush and closes the wrapped sink.
+func (s *BufferedSink) Close() error {
+	return errors.Join(s.batch.Close(), s.sink.Close())
+}
+
+// forward writes a batch of entries to the wrapped sink.
+func (s *BufferedSink) forward(entries []Entry) error {
+	var errs []error
+	for _, entry := range entries {
+		errs = append(errs, s.sink.Write(entry))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,105 @@
+package gologger
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func readTestLogFile(t *testing.T, dir string) string {
+	t.Helper()
+	data, err := os.ReadFile(currentLogFile(dir))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	return string(data)
+}
+
+func TestFileBuffer(t *testing.T) {
+	tempDir := "test_buffer_logs"
+	defer os.RemoveAll(tempDir)
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogLevel:   LevelInfo,
+		LogDir:     tempDir,
+		FileBuffer: &BufferConfig{Size: 1 << 20, FlushInterval: time.Hour},
+	})
+
+	log.Info("buffered message").Send()
+	if strings.Contains(readTestLogFile(t, tempDir), "buffered message") {
+		t.Error("Expected message to stay buffered before Close")
+	}
+
+	log.Close()
+	if !strings.Contains(readTestLogFile(t, tempDir), "buffered message") {
+		t.Error("Expected Close to flush the buffer")
+	}
+}
+
+func TestFileBufferFlushesOnPanic(t *testing.T) {
+	tempDir := "test_buffer_panic_logs"
+	defer os.RemoveAll(tempDir)
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogLevel:   LevelInfo,
+		LogDir:     tempDir,
+		FileBuffer: &BufferConfig{Size: 1 << 20, FlushInterval: time.Hour},
+	})
+	defer log.Close()
+
+	log.Info("before panic").Send()
+	func() {
+		defer func() { _ = recover() }()
+		log.Panic("panicking").Send()
+	}()
+
+	content := readTestLogFile(t, tempDir)
+	if !strings.Contains(content, "before panic") || !strings.Contains(content, "panicking") {
+		t.Errorf("Expected buffer to be flushed on panic, got %q", content)
+	}
+}
+
+func TestBufferedSink(t *testing.T) {
+	inner := &recordingSink{}
+	sink := NewBufferedSink(inner, BufferConfig{Size: 3, FlushInterval: time.Hour})
+
+	_ = sink.Write(Entry{Message: "one"})
+	_ = sink.Write(Entry{Message: "two"})
+	if len(inner.Entries()) != 0 {
+		t.Error("Expected entries to stay buffered")
+	}
+
+	_ = sink.Write(Entry{Message: "three"})
+	if len(inner.Entries()) != 3 {
+		t.Errorf("Expected a full buffer to be flushed, got %d entries", len(inner.Entries()))
+	}
+
+	_ = sink.Write(Entry{Message: "four"})
+	_ = sink.Close()
+	if len(inner.Entries()) != 4 || !inner.closed {
+		t.Error("Expected Close to flush and close the wrapped sink")
+	}
+}
+
+func TestBufferedSinkFlushesOnPanic(t *testing.T) {
+	inner := &recordingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelInfo,
+		Sinks:      []Sink{NewBufferedSink(inner, BufferConfig{Size: 100, FlushInterval: time.Hour})},
+	})
+	defer log.Close()
+
+	log.Info("before panic").Send()
+	func() {
+		defer func() { _ = recover() }()
+		log.Panic("panicking").Send()
+	}()
+
+	if len(inner.Entries()) != 2 {
+		t.Errorf("Expected buffered entries to be flushed on panic, got %d", len(inner.Entries()))
+	}
+}
@@ -0,0 +1,53 @@
+package gologger
+
+import (
+	"maps"
+	"runtime/debug"
+)
+
+// Field names set by BuildFields.
+const (
+	CommitField = "commit"
+	DirtyField  = "dirty"
+)
+
+// readBuildInfo returns the build information of the running binary.
+var readBuildInfo = debug.ReadBuildInfo
+
+// BuildFields returns fields identifying the running build, read from the
+// information the Go toolchain embeds in the binary: the main module's
+// version as "version", the VCS revision as "commit", and whether the
+// working tree had uncommitted changes as "dirty". Information missing from
+// the binary, such as the version of a "(devel)" build or the revision of a
+// build outside a repository, is left out. Use it with GlobalFields, or set
+// LoggerConfig.BuildInfo.
+func BuildFields() map[string]any {
+	fields := make(map[string]any)
+	info, ok := readBuildInfo()
+	if !ok {
+		return fields
+	}
+	if version := info.Main.Version; version != "" && version != "(devel)" {
+		fields[VersionField] = version
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			fields[CommitField] = setting.Value
+		case "vcs.modified":
+			fields[DirtyField] = setting.Value == "true"
+		}
+	}
+	return fields
+}
+
+// globalFields returns the fields added to every entry: GlobalFields, and
+// the fields of BuildFields if BuildInfo is set, which GlobalFields override.
+func globalFields(config LoggerConfig) map[string]any {
+	if !config.BuildInfo {
+		return config.GlobalFields
+	}
+	fields := BuildFields()
+	maps.Copy(fields, config.GlobalFields)
+	return fields
+}
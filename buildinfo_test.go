@@ -0,0 +1,66 @@
+package gologger
+
+import (
+	"reflect"
+	"runtime/debug"
+	"testing"
+)
+
+func stubBuildInfo(t *testing.T, info *debug.BuildInfo) {
+	t.Helper()
+	previous := readBuildInfo
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return info, info != nil }
+	t.Cleanup(func() { readBuildInfo = previous })
+}
+
+func TestBuildFields(t *testing.T) {
+	tests := []struct {
+		info     *debug.BuildInfo
+		expected map[string]any
+	}{
+		{
+			&debug.BuildInfo{
+				Main: debug.Module{Path: "example.com/app", Version: "v1.4.2"},
+				Settings: []debug.BuildSetting{
+					{Key: "vcs", Value: "git"},
+					{Key: "vcs.revision", Value: "3f2c1ab"},
+					{Key: "vcs.modified", Value: "true"},
+				},
+			},
+			map[string]any{VersionField: "v1.4.2", CommitField: "3f2c1ab", DirtyField: true},
+		},
+		{
+			&debug.BuildInfo{Main: debug.Module{Version: "(devel)"}, Settings: []debug.BuildSetting{{Key: "vcs.modified", Value: "false"}}},
+			map[string]any{DirtyField: false},
+		},
+		{nil, map[string]any{}},
+	}
+
+	for _, tt := range tests {
+		stubBuildInfo(t, tt.info)
+		if fields := BuildFields(); !reflect.DeepEqual(fields, tt.expected) {
+			t.Errorf("Expected %v, got %v", tt.expected, fields)
+		}
+	}
+}
+
+func TestBuildInfoConfig(t *testing.T) {
+	stubBuildInfo(t, &debug.BuildInfo{
+		Main:     debug.Module{Version: "v2.0.0"},
+		Settings: []debug.BuildSetting{{Key: "vcs.revision", Value: "abc123"}},
+	})
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:   OutputDiscard,
+		BuildInfo:    true,
+		GlobalFields: map[string]any{VersionField: "2.0.0-rc1"},
+		Sinks:        []Sink{capture},
+	})
+	defer log.Close()
+	log.Info("started").Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Fields[CommitField] != "abc123" || entries[0].Fields[VersionField] != "2.0.0-rc1" {
+		t.Errorf("Expected the build fields with GlobalFields taking precedence, got %+v", entries)
+	}
+}
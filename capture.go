@@ -0,0 +1,101 @@
+package gologger
+
+import (
+	"reflect"
+	"sync"
+)
+
+// CaptureSink is a Sink that keeps every entry in memory so tests can
+// inspect what was logged.
+type CaptureSink struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewCaptureSink creates an empty capture sink.
+func NewCaptureSink() *CaptureSink {
+	return &CaptureSink{}
+}
+
+// NewTestLogger creates a logger for unit tests that records every entry at
+// debug level and above in memory instead of writing to the terminal or files.
+// Caller information is included.
+func NewTestLogger() (Logger, *CaptureSink) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelDebug,
+		ShowCaller: true,
+		Sinks:      []Sink{capture},
+	})
+	return log, capture
+}
+
+// Write records an entry.
+func (c *CaptureSink) Write(entry Entry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, entry)
+	return nil
+}
+
+// Sync does nothing; entries are recorded immediately.
+func (c *CaptureSink) Sync() error {
+	return nil
+}
+
+// Close does nothing; captured entries remain available.
+func (c *CaptureSink) Close() error {
+	return nil
+}
+
+// Entries returns a copy of all captured entries in the order they were logged.
+func (c *CaptureSink) Entries() []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Entry(nil), c.entries...)
+}
+
+// Len returns the number of captured entries.
+func (c *CaptureSink) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// FilterLevel returns the captured entries with the given level.
+func (c *CaptureSink) FilterLevel(level string) []Entry {
+	return c.filter(func(e Entry) bool { return e.Level == level })
+}
+
+// FilterMessage returns the captured entries with the given message.
+func (c *CaptureSink) FilterMessage(msg string) []Entry {
+	return c.filter(func(e Entry) bool { return e.Message == msg })
+}
+
+// FilterField returns the captured entries that have the field key set to value.
+func (c *CaptureSink) FilterField(key string, value any) []Entry {
+	return c.filter(func(e Entry) bool {
+		v, ok := e.Fields[key]
+		return ok && reflect.DeepEqual(normalizeValue(v), normalizeValue(value))
+	})
+}
+
+// Reset discards all captured entries.
+func (c *CaptureSink) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = nil
+}
+
+func (c *CaptureSink) filter(match func(Entry) bool) []Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var matched []Entry
+	for _, entry := range c.entries {
+		if match(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
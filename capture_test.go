@@ -0,0 +1,50 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewTestLogger(t *testing.T) {
+	log, logs := NewTestLogger()
+	defer log.Close()
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	log.Debug("cache miss").Data("key", "user:1").Send()
+	log.WithContext(ctx).Error("charge failed").ErrorData(errors.New("card declined")).Data("amount", 1250).Send()
+
+	if logs.Len() != 2 {
+		t.Fatalf("Expected 2 entries, got %d", logs.Len())
+	}
+
+	errorsLogged := logs.FilterLevel(LevelError)
+	if len(errorsLogged) != 1 {
+		t.Fatalf("Expected 1 error entry, got %d", len(errorsLogged))
+	}
+	entry := errorsLogged[0]
+	if entry.Message != "charge failed" {
+		t.Errorf("Expected message 'charge failed', got %s", entry.Message)
+	}
+	if entry.Fields["request-id"] != "req-42" || entry.Fields["error"] != "card declined" {
+		t.Errorf("Unexpected fields %v", entry.Fields)
+	}
+	if entry.Caller == "" {
+		t.Error("Expected caller to be captured")
+	}
+
+	if len(logs.FilterMessage("cache miss")) != 1 {
+		t.Error("Expected FilterMessage to find the debug entry")
+	}
+	if len(logs.FilterField("amount", 1250)) != 1 {
+		t.Error("Expected FilterField to match int values regardless of width")
+	}
+	if len(logs.FilterField("amount", 1)) != 0 {
+		t.Error("Expected FilterField not to match other values")
+	}
+
+	logs.Reset()
+	if logs.Len() != 0 {
+		t.Error("Expected Reset to discard entries")
+	}
+}
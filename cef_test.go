@@ -0,0 +1,84 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestCEFEncoder(t *testing.T) {
+	ent := zapcore.Entry{
+		Level:   zapcore.WarnLevel,
+		Time:    time.UnixMilli(1709289000123),
+		Message: "Login failed | locked",
+		Caller:  zapcore.NewEntryCaller(0, "/src/app/auth/login.go", 31, true),
+	}
+	enc := newCEFEncoder(CEFConfig{DeviceVendor: "Risoft", DeviceVersion: "1.4.2"}, "auth-api")
+	buf, err := enc.EncodeEntry(ent, []zapcore.Field{
+		zap.String("event_id", "AUTH-401"),
+		zap.String("src", "10.0.0.7"),
+		zap.String("user_name", "alice"),
+		zap.String("query", "a=b\\c\nd"),
+		zap.Int("attempts", 5),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+
+	expected := `CEF:0|Risoft|auth-api|1.4.2|AUTH-401|Login failed \| locked|6|rt=1709289000123` +
+		` attempts=5 caller=auth/login.go:31 query=a\=b\\c\nd src=10.0.0.7 username=alice` + "\n"
+	if buf.String() != expected {
+		t.Errorf("Unexpected line:\n%s\nexpected:\n%s", buf.String(), expected)
+	}
+}
+
+func TestCEFEncoderDefaults(t *testing.T) {
+	var out strings.Builder
+	core := zapcore.NewCore(newCEFEncoder(CEFConfig{}, ""), zapcore.AddSync(&out), zapcore.DebugLevel)
+	zap.New(core).With(zap.String("tenant", "acme")).Error("disk\nfull")
+
+	line := out.String()
+	if !strings.HasPrefix(line, "CEF:0|gologger|") || !strings.Contains(line, "||disk full|disk full|8|") {
+		t.Errorf("Expected default vendor, message as event class ID and sanitized header, got %q", line)
+	}
+	if !strings.HasSuffix(line, " tenant=acme\n") {
+		t.Errorf("Expected context field as extension, got %q", line)
+	}
+}
+
+func TestCEFSeverity(t *testing.T) {
+	tests := map[string]int{
+		LevelDebug: 1,
+		LevelInfo:  3,
+		LevelWarn:  6,
+		LevelError: 8,
+		"panic":    9,
+		"fatal":    10,
+	}
+	for level, want := range tests {
+		if got := cefSeverity(level); got != want {
+			t.Errorf("cefSeverity(%q) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestCEFEncodingConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:  OutputFile,
+		LogDir:      tempDir,
+		Encoding:    EncodingCEF,
+		ServiceName: "billing",
+		CEF:         &CEFConfig{DeviceVendor: "Risoft"},
+	})
+	log.Error("refund blocked").Data("event_id", 4010).Send()
+	log.Close()
+
+	content := readTestLogFile(t, tempDir)
+	if !strings.HasPrefix(content, "CEF:0|Risoft|billing||4010|refund blocked|8|rt=") {
+		t.Errorf("Unexpected CEF line %q", content)
+	}
+}
@@ -0,0 +1,95 @@
+// Package chilog logs the requests of chi routers with gologger. Unlike
+// gologger.HTTPMiddleware, it logs the matched route pattern, such as
+// /users/{id}, so entries can be aggregated by endpoint:
+//
+//	r := chi.NewRouter()
+//	r.Use(middleware.RequestID)
+//	r.Use(chilog.New(log))
+//
+// It is a separate module, so applications not using chi do not depend on it.
+package chilog
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.risoftinc.com/gologger"
+)
+
+// New returns chi middleware that logs every request with log.
+//
+// It takes the request ID from chi's middleware.RequestID, from the context,
+// from the X-Request-ID header or generates one, and sets it on the response.
+// The request ID and the logger are stored in the request context, so
+// handlers get the logger with gologger.FromContext(r.Context()) and their
+// entries carry the request ID. A debug entry with the path is logged when a
+// request starts. When it completes, an entry with the route pattern matched
+// by the router as "route", its status, response size and duration is logged
+// at info level, warn for 4xx or error for 5xx responses; requests matching
+// no route are logged with their path instead.
+func New(log gologger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+			requestID := middleware.GetReqID(ctx)
+			if requestID == "" {
+				requestID = gologger.GetRequestID(ctx)
+			}
+			if requestID == "" {
+				requestID = r.Header.Get(gologger.RequestIDHeader)
+			}
+			requestID = gologger.RequestIDFromHeader(requestID)
+			ctx = gologger.NewContext(gologger.WithRequestID(ctx, requestID), log)
+			w.Header().Set(gologger.RequestIDHeader, requestID)
+
+			reqLog := log.WithContext(ctx)
+			reqLog.Debug("http request started").
+				Data("method", r.Method).
+				Data("path", r.URL.Path).
+				Data("remote_addr", r.RemoteAddr).
+				Data("user_agent", r.UserAgent()).
+				Send()
+
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r.WithContext(ctx))
+
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			switch {
+			case status >= http.StatusInternalServerError:
+				reqLog = reqLog.Error("http request completed")
+			case status >= http.StatusBadRequest:
+				reqLog = reqLog.Warn("http request completed")
+			default:
+				reqLog = reqLog.Info("http request completed")
+			}
+			reqLog = reqLog.Data("method", r.Method)
+			// The route is only known once the router has matched the request.
+			if route := routePattern(ctx); route != "" {
+				reqLog = reqLog.Data("route", route)
+			} else {
+				reqLog = reqLog.Data("path", r.URL.Path)
+			}
+			reqLog.Data("status", status).
+				Data("bytes", ww.BytesWritten()).
+				Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+				Send()
+		})
+	}
+}
+
+// routePattern returns the route pattern matched for the request of ctx, or
+// "" if it was not routed by chi or matched no route.
+func routePattern(ctx context.Context) string {
+	routes := chi.RouteContext(ctx)
+	if routes == nil {
+		return ""
+	}
+	return routes.RoutePattern()
+}
@@ -0,0 +1,91 @@
+package chilog
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.risoftinc.com/gologger"
+)
+
+func TestNew(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	r := chi.NewRouter()
+	r.Use(New(log))
+	r.Route("/api", func(r chi.Router) {
+		r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			gologger.FromContext(r.Context()).Info("loading user").Send()
+			_, _ = io.WriteString(w, "user")
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/users/42", nil)
+	req.Header.Set(gologger.RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Header().Get(gologger.RequestIDHeader) != "req-42" {
+		t.Errorf("Expected the request ID on the response, got %q", rec.Header().Get(gologger.RequestIDHeader))
+	}
+	entries := capture.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected start, handler and finish entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Fields["request-id"] != "req-42" {
+			t.Errorf("Expected the request ID on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	if start := entries[0]; start.Level != gologger.LevelDebug || start.Fields["path"] != "/api/users/42" {
+		t.Errorf("Unexpected start entry %+v", start)
+	}
+	finish := entries[2]
+	if finish.Level != gologger.LevelInfo || finish.Fields["route"] != "/api/users/{id}" || finish.Fields["path"] != nil {
+		t.Errorf("Expected the route pattern instead of the path, got %+v", finish)
+	}
+	if finish.Fields["status"] != int64(http.StatusOK) || finish.Fields["bytes"] != int64(4) {
+		t.Errorf("Unexpected finish entry %+v", finish)
+	}
+}
+
+func TestNewNotFound(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	r := chi.NewRouter()
+	r.Use(New(log))
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	finish := capture.FilterMessage("http request completed")
+	if len(finish) != 1 || finish[0].Level != gologger.LevelWarn || finish[0].Fields["status"] != int64(http.StatusNotFound) {
+		t.Fatalf("Expected a warning for the 404 response, got %+v", finish)
+	}
+	if finish[0].Fields["route"] != nil || finish[0].Fields["path"] != "/missing" {
+		t.Errorf("Expected the path of an unmatched request, got %v", finish[0].Fields)
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.Use(New(log))
+	var chiID, loggedID string
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		chiID = middleware.GetReqID(r.Context())
+		loggedID = gologger.GetRequestID(r.Context())
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if chiID == "" || loggedID != chiID || rec.Header().Get(gologger.RequestIDHeader) != chiID {
+		t.Errorf("Expected the request ID of middleware.RequestID, got %q and %q", chiID, loggedID)
+	}
+	if len(capture.FilterField("request-id", chiID)) != 2 {
+		t.Errorf("Expected both entries with the request ID, got %+v", capture.Entries())
+	}
+}
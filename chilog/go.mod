@@ -0,0 +1,23 @@
+// MIT License
+// Copyright (c) 2025 Risoftinc.
+module go.risoftinc.com/gologger/chilog
+
+go 1.21
+
+// Build against the gologger of this repository.
+replace go.risoftinc.com/gologger => ../
+
+require (
+	github.com/go-chi/chi/v5 v5.2.3
+	go.risoftinc.com/gologger v0.0.0-00010101000000-000000000000
+)
+
+require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	github.com/klauspost/compress v1.17.11 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/sys v0.13.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
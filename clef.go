@@ -0,0 +1,97 @@
+package gologger
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// clefEncoder writes Compact Log Event Format (CLEF) JSON as read by Seq and
+// other CLEF-aware tools. The reified properties are:
+//
+//	@t  timestamp            @x   error and stack trace
+//	@m  message              @tr  trace ID
+//	@l  level (omitted for   @sp  span ID
+//	    Information)
+//
+// Field names starting with "@" are escaped as "@@".
+type clefEncoder struct {
+	fieldRecorder
+	json zapcore.Encoder
+}
+
+func newCLEFEncoder() *clefEncoder {
+	config := zapcore.EncoderConfig{
+		TimeKey:        "@t",
+		MessageKey:     "@m",
+		CallerKey:      "caller",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02T15:04:05.000Z07:00"),
+		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+	return &clefEncoder{json: zapcore.NewJSONEncoder(config)}
+}
+
+func (e *clefEncoder) Clone() zapcore.Encoder {
+	return &clefEncoder{fieldRecorder: e.clone(), json: e.json}
+}
+
+func (e *clefEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	all := e.with(fields)
+	mapped := make([]zapcore.Field, 0, len(all)+2)
+	if level := clefLevel(ent.Level); level != "Information" {
+		mapped = append(mapped, zap.String("@l", level))
+	}
+
+	var exception string
+	for _, field := range all {
+		switch {
+		case field.Key == "error" && field.Type == zapcore.StringType:
+			exception = field.String
+			continue
+		case field.Key == "error" && field.Type == zapcore.ErrorType:
+			if err, ok := field.Interface.(error); ok {
+				exception = err.Error()
+			}
+			continue
+		case field.Key == TraceIDField:
+			field.Key = "@tr"
+		case field.Key == SpanIDField:
+			field.Key = "@sp"
+		case strings.HasPrefix(field.Key, "@"):
+			field.Key = "@" + field.Key
+		}
+		mapped = append(mapped, field)
+	}
+
+	if ent.Stack != "" {
+		if exception != "" {
+			exception += "\n"
+		}
+		exception += ent.Stack
+		ent.Stack = ""
+	}
+	if exception != "" {
+		mapped = append(mapped, zap.String("@x", exception))
+	}
+	return e.json.EncodeEntry(ent, mapped)
+}
+
+// clefLevel maps a zap level to a Serilog level name.
+func clefLevel(level zapcore.Level) string {
+	switch level {
+	case zapcore.DebugLevel:
+		return "Debug"
+	case zapcore.InfoLevel:
+		return "Information"
+	case zapcore.WarnLevel:
+		return "Warning"
+	case zapcore.ErrorLevel:
+		return "Error"
+	default:
+		return "Fatal"
+	}
+}
@@ -0,0 +1,113 @@
+package gologger
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func encodeCLEF(t *testing.T, ent zapcore.Entry, fields ...zapcore.Field) map[string]any {
+	t.Helper()
+	buf, err := newCLEFEncoder().EncodeEntry(ent, fields)
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", buf.String(), err)
+	}
+	return doc
+}
+
+func TestCLEFEncoder(t *testing.T) {
+	ent := zapcore.Entry{
+		Level:   zapcore.ErrorLevel,
+		Time:    time.Date(2024, 3, 1, 10, 30, 0, 123e6, time.UTC),
+		Message: "payment failed",
+		Caller:  zapcore.NewEntryCaller(0, "/src/app/billing/charge.go", 88, true),
+		Stack:   "main.main\n\t/src/app/main.go:10",
+	}
+	doc := encodeCLEF(t, ent,
+		zap.String("error", "card declined"),
+		zap.String(TraceIDField, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		zap.String(SpanIDField, "00f067aa0ba902b7"),
+		zap.String("@odd", "escaped"),
+		zap.Int("amount", 1250),
+	)
+
+	expected := map[string]any{
+		"@t":     "2024-03-01T10:30:00.123Z",
+		"@m":     "payment failed",
+		"@l":     "Error",
+		"@x":     "card declined\nmain.main\n\t/src/app/main.go:10",
+		"@tr":    "4bf92f3577b34da6a3ce929d0e0e4736",
+		"@sp":    "00f067aa0ba902b7",
+		"@@odd":  "escaped",
+		"amount": float64(1250),
+		"caller": "billing/charge.go:88",
+	}
+	for key, want := range expected {
+		if doc[key] != want {
+			t.Errorf("Expected %s=%v, got %v", key, want, doc[key])
+		}
+	}
+	if _, ok := doc["error"]; ok {
+		t.Error("Expected the error field to be moved to @x")
+	}
+}
+
+func TestCLEFEncoderLevels(t *testing.T) {
+	info := encodeCLEF(t, zapcore.Entry{Level: zapcore.InfoLevel, Message: "ok"})
+	if _, ok := info["@l"]; ok {
+		t.Errorf("Expected @l to be omitted for Information, got %v", info["@l"])
+	}
+
+	tests := map[zapcore.Level]string{
+		zapcore.DebugLevel: "Debug",
+		zapcore.WarnLevel:  "Warning",
+		zapcore.ErrorLevel: "Error",
+		zapcore.PanicLevel: "Fatal",
+		zapcore.FatalLevel: "Fatal",
+	}
+	for level, want := range tests {
+		if got := clefLevel(level); got != want {
+			t.Errorf("clefLevel(%s) = %s, want %s", level, got, want)
+		}
+	}
+
+	withErr := encodeCLEF(t, zapcore.Entry{Level: zapcore.WarnLevel}, zap.Error(errors.New("boom")))
+	if withErr["@x"] != "boom" || withErr["@l"] != "Warning" {
+		t.Errorf("Unexpected document %v", withErr)
+	}
+}
+
+func TestCLEFEncodingConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogDir:     tempDir,
+		Encoding:   EncodingCLEF,
+	})
+	log.Warn("disk almost full").Data("free_mb", 120).Send()
+	log.Close()
+
+	data, err := os.ReadFile(currentLogFile(tempDir))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", data, err)
+	}
+	if doc["@m"] != "disk almost full" || doc["@l"] != "Warning" || doc["free_mb"] != float64(120) {
+		t.Errorf("Unexpected CLEF document %v", doc)
+	}
+	if _, ok := doc["@t"]; !ok {
+		t.Error("Expected @t timestamp")
+	}
+}
@@ -0,0 +1,92 @@
+package gologger
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// AllComponents is the LoggerConfig.ComponentLevels key of the level used by
+// unnamed loggers and components without an override.
+const AllComponents = "*"
+
+// componentLevels holds the level overrides of named components set by
+// LoggerConfig.ComponentLevels.
+type componentLevels struct {
+	levels   map[string]zapcore.Level // Level per component name
+	lowest   zapcore.Level            // Lowest level in levels
+	fallback zapcore.LevelEnabler     // Level of other components, i.e. the logger's level
+}
+
+// newComponentLevels returns the overrides of a ComponentLevels map, or nil if
+// it names no component.
+func newComponentLevels(config map[string]string, fallback zapcore.LevelEnabler) *componentLevels {
+	c := &componentLevels{
+		levels:   make(map[string]zapcore.Level, len(config)),
+		lowest:   zapcore.InvalidLevel,
+		fallback: fallback,
+	}
+	for name, level := range config {
+		if name == AllComponents {
+			continue
+		}
+		c.levels[name] = getLogLevel(level)
+		if c.levels[name] < c.lowest {
+			c.lowest = c.levels[name]
+		}
+	}
+	if len(c.levels) == 0 {
+		return nil
+	}
+	return c
+}
+
+// Enabled reports whether any component logs at level, so that the outputs
+// let through the entries componentCore decides on.
+func (c *componentLevels) Enabled(level zapcore.Level) bool {
+	return level >= c.lowest || c.fallback.Enabled(level)
+}
+
+// enabledFor reports whether a component logs at level. A component without
+// its own override uses the one of its closest parent, so "http" also applies
+// to "http.client", and then the logger's level.
+func (c *componentLevels) enabledFor(name string, level zapcore.Level) bool {
+	for name != "" {
+		if min, ok := c.levels[name]; ok {
+			return level >= min
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return c.fallback.Enabled(level)
+}
+
+// componentCore drops entries below the level of the component that logged
+// them, identified by the name given to Named.
+type componentCore struct {
+	zapcore.Core
+	levels *componentLevels
+}
+
+func (c *componentCore) With(fields []zapcore.Field) zapcore.Core {
+	return &componentCore{Core: c.Core.With(fields), levels: c.levels}
+}
+
+func (c *componentCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.levels.enabledFor(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// componentDefaultLevel returns the level of unnamed loggers: the "*" entry
+// of ComponentLevels if set, otherwise LogLevel.
+func componentDefaultLevel(config LoggerConfig) string {
+	if level, ok := config.ComponentLevels[AllComponents]; ok {
+		return level
+	}
+	return config.LogLevel
+}
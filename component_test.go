@@ -0,0 +1,70 @@
+package gologger
+
+import (
+	"testing"
+)
+
+func TestComponentLevels(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:      OutputDiscard,
+		LogLevel:        LevelDebug,
+		Sinks:           []Sink{capture},
+		ComponentLevels: map[string]string{"http": LevelWarn, "db": LevelDebug, AllComponents: LevelInfo},
+	})
+	defer log.Close()
+
+	http := log.Named("http")
+	db := log.Named("db")
+	log.Debug("main debug").Send()
+	log.Info("main info").Send()
+	http.Info("http info").Send()
+	http.Warn("http warn").Send()
+	http.Named("client").Info("client info").Send()
+	db.Debug("db debug").Send()
+	log.Named("cache").Debug("cache debug").Send()
+	log.Named("cache").Info("cache info").Send()
+
+	var messages []string
+	for _, entry := range capture.Entries() {
+		messages = append(messages, entry.Logger+": "+entry.Message)
+	}
+	expected := []string{": main info", "http: http warn", "db: db debug", "cache: cache info"}
+	if len(messages) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, messages)
+	}
+	for i := range expected {
+		if messages[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, messages)
+			break
+		}
+	}
+
+	// SetLevel changes the level of components without an override.
+	capture.Reset()
+	log.SetLevel(LevelDebug)
+	log.Named("cache").Debug("cache debug").Send()
+	http.Info("http info").Send()
+	if capture.Len() != 1 || len(capture.FilterMessage("cache debug")) != 1 {
+		t.Errorf("Expected only the cache entry after SetLevel, got %+v", capture.Entries())
+	}
+}
+
+func TestComponentLevelsDefaultOnly(t *testing.T) {
+	config := LoggerConfig{LogLevel: LevelDebug, ComponentLevels: map[string]string{AllComponents: LevelError}}
+	if newComponentLevels(config.ComponentLevels, nil) != nil {
+		t.Error("Expected no overrides when only the default is set")
+	}
+	if level := componentDefaultLevel(config); level != LevelError {
+		t.Errorf("Expected the \"*\" level to override LogLevel, got %s", level)
+	}
+}
+
+func TestNamedWithoutComponentLevels(t *testing.T) {
+	log, capture := NewTestLogger()
+	log.Named("worker").Named("jobs").Debug("started").Send()
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Logger != "worker.jobs" {
+		t.Errorf("Expected a debug entry from worker.jobs, got %+v", entries)
+	}
+}
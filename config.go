@@ -0,0 +1,504 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// NewLoggerFromFile creates a Logger from a YAML, JSON or TOML configuration
+// file, chosen by its extension (".yaml", ".yml", ".json" or ".toml").
+// See LoadConfigFile for the file format and WatchConfig to apply changes
+// to the file at runtime.
+func NewLoggerFromFile(path string) (Logger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Logger{}, fmt.Errorf("gologger: %w", err)
+	}
+	config, err := parseConfigFile(path, data)
+	if err != nil {
+		return Logger{}, err
+	}
+
+	log := NewLoggerWithConfig(config)
+	log.source = &configSource{path: path, data: data, config: config}
+	return log, nil
+}
+
+// LoadConfigFile reads a LoggerConfig from a YAML, JSON or TOML file, e.g.
+// to adjust it before calling NewLoggerWithConfig. Keys are the names of
+// the LoggerConfig fields in snake_case ("output_mode", "log_rotation",
+// "max_size"), matched ignoring case and underscores. Durations are strings
+// such as "500ms" or "1h"; unknown keys and values rejected by
+// LoggerConfig.Validate are errors. The "sinks" key lists additional sinks,
+// each selected by its "type": "file", "stdout", "stderr", "network",
+// "syslog", "logstash", "gelf" or "otlp", with the options of its config
+// struct and an optional "buffer" BufferConfig.
+// The "profiles" key holds named variants of the configuration, such as
+// "dev", "staging" and "prod". The profile named by GOLOGGER_PROFILE is
+// merged over the rest of the file: its tables are merged key by key, and
+// its other values, including "sinks", replace those of the file. Without
+// GOLOGGER_PROFILE the profiles are ignored; an unknown profile is an error.
+// Options holding functions or interfaces, such as OnSinkError, can only
+// be set in code.
+func LoadConfigFile(path string) (LoggerConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return LoggerConfig{}, fmt.Errorf("gologger: %w", err)
+	}
+	return parseConfigFile(path, data)
+}
+
+// parseConfigFile decodes the contents of the configuration file at path.
+func parseConfigFile(path string, data []byte) (LoggerConfig, error) {
+	var config LoggerConfig
+	var raw map[string]any
+	var err error
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		err = decoder.Decode(&raw)
+	case ".toml":
+		err = toml.Unmarshal(data, &raw)
+	default:
+		return config, fmt.Errorf("gologger: unsupported config file extension %q", ext)
+	}
+	if err != nil {
+		return config, fmt.Errorf("gologger: %s: %w", path, err)
+	}
+
+	if raw, err = applyConfigProfile(raw, os.Getenv(EnvProfile)); err != nil {
+		return config, fmt.Errorf("gologger: %s: %w", path, err)
+	}
+	if err := decodeLoggerConfig(&config, raw); err != nil {
+		return config, fmt.Errorf("gologger: %s: %w", path, err)
+	}
+	if err := validateConfig(config); err != nil {
+		// The sinks are already open; close them, as the config is dropped.
+		for _, sink := range config.Sinks {
+			_ = sink.Close()
+		}
+		config.Sinks = nil
+		return config, fmt.Errorf("gologger: %s: %w", path, err)
+	}
+	return config, nil
+}
+
+// applyConfigProfile removes the "profiles" key from a decoded configuration
+// file and merges the profile called name over the rest of it.
+func applyConfigProfile(raw map[string]any, name string) (map[string]any, error) {
+	var profiles map[string]any
+	for key, value := range raw {
+		if normalizeConfigKey(key) != "profiles" {
+			continue
+		}
+		delete(raw, key)
+		var ok bool
+		if profiles, ok = value.(map[string]any); !ok {
+			return nil, fmt.Errorf("%s: expected a table, got %T", key, value)
+		}
+	}
+	if name == "" {
+		return raw, nil
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%s: unknown profile %q, expected one of %v", EnvProfile, name, sortedKeys(profiles))
+	}
+	overrides, ok := profile.(map[string]any)
+	if !ok && profile != nil {
+		return nil, fmt.Errorf("profiles.%s: expected a table, got %T", name, profile)
+	}
+	return mergeConfigTables(raw, overrides), nil
+}
+
+// mergeConfigTables returns base with the values of overrides. Keys are
+// matched like configuration keys, and tables present in both are merged.
+func mergeConfigTables(base, overrides map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(overrides))
+	keys := make(map[string]string, len(base))
+	for key, value := range base {
+		merged[key] = value
+		keys[normalizeConfigKey(key)] = key
+	}
+	for key, value := range overrides {
+		if baseKey, ok := keys[normalizeConfigKey(key)]; ok {
+			baseTable, baseOK := merged[baseKey].(map[string]any)
+			table, ok := value.(map[string]any)
+			delete(merged, baseKey)
+			if baseOK && ok {
+				value = mergeConfigTables(baseTable, table)
+			}
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// decodeLoggerConfig fills config from a decoded configuration file.
+func decodeLoggerConfig(config *LoggerConfig, raw map[string]any) error {
+	var sinks any
+	fields := make(map[string]any, len(raw))
+	for key, value := range raw {
+		if normalizeConfigKey(key) == "sinks" {
+			sinks = value
+			continue
+		}
+		fields[key] = value
+	}
+	if err := decodeConfigValue(reflect.ValueOf(config).Elem(), fields, ""); err != nil {
+		return err
+	}
+	if sinks == nil {
+		return nil
+	}
+
+	list, ok := sinks.([]any)
+	if !ok {
+		return fmt.Errorf("sinks: expected a list, got %T", sinks)
+	}
+	for i, item := range list {
+		sink, err := decodeSinkConfig(item, fmt.Sprintf("sinks[%d]", i))
+		if err != nil {
+			for _, opened := range config.Sinks {
+				_ = opened.Close()
+			}
+			config.Sinks = nil
+			return err
+		}
+		config.Sinks = append(config.Sinks, sink)
+	}
+	return nil
+}
+
+// decodeSinkConfig creates the sink described by one entry of the "sinks" list.
+func decodeSinkConfig(item any, path string) (Sink, error) {
+	raw, ok := item.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%s: expected a table, got %T", path, item)
+	}
+	var kind string
+	var buffer *BufferConfig
+	options := make(map[string]any, len(raw))
+	for key, value := range raw {
+		switch normalizeConfigKey(key) {
+		case "type":
+			kind, _ = value.(string)
+		case "buffer":
+			buffer = &BufferConfig{}
+			if err := decodeConfigValue(reflect.ValueOf(buffer).Elem(), value, path+".buffer"); err != nil {
+				return nil, err
+			}
+		default:
+			options[key] = value
+		}
+	}
+
+	var sink Sink
+	var err error
+	switch kind {
+	case "file":
+		var config FileSinkConfig
+		if err = decodeConfigValue(reflect.ValueOf(&config).Elem(), options, path); err == nil {
+			sink = NewFileSink(config)
+		}
+	case "stdout":
+		sink, err = NewWriterSink(os.Stdout), noConfigOptions(options, path)
+	case "stderr":
+		sink, err = NewWriterSink(os.Stderr), noConfigOptions(options, path)
+	case "network":
+		var config NetworkConfig
+		if err = decodeConfigValue(reflect.ValueOf(&config).Elem(), options, path); err == nil {
+			sink = NewNetworkSink(config)
+		}
+	case "syslog":
+		var config SyslogConfig
+		if err = decodeConfigValue(reflect.ValueOf(&config).Elem(), options, path); err == nil {
+			sink = NewSyslogSink(config)
+		}
+	case "logstash":
+		var config LogstashConfig
+		if err = decodeConfigValue(reflect.ValueOf(&config).Elem(), options, path); err == nil {
+			sink = NewLogstashSink(config)
+		}
+	case "gelf":
+		var config GELFConfig
+		if err = decodeConfigValue(reflect.ValueOf(&config).Elem(), options, path); err == nil {
+			sink = NewGELFSink(config)
+		}
+	case "otlp":
+		var config OTLPConfig
+		if err = decodeConfigValue(reflect.ValueOf(&config).Elem(), options, path); err == nil {
+			sink = NewOTLPSink(config)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unknown sink type %q", path, kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if buffer != nil {
+		sink = NewBufferedSink(sink, *buffer)
+	}
+	return sink, nil
+}
+
+// noConfigOptions fails if a sink without options was given any.
+func noConfigOptions(options map[string]any, path string) error {
+	if len(options) > 0 {
+		return fmt.Errorf("%s: unknown key", joinConfigPath(path, sortedKeys(options)[0]))
+	}
+	return nil
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decodeConfigValue stores a value decoded from a configuration file in dst.
+// path names the value in errors.
+func decodeConfigValue(dst reflect.Value, src any, path string) error {
+	if dst.Type() == durationType {
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a duration string such as \"1s\", got %v", path, src)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		dst.SetInt(int64(d))
+		return nil
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if src == nil {
+			return nil
+		}
+		value := reflect.New(dst.Type().Elem())
+		if err := decodeConfigValue(value.Elem(), src, path); err != nil {
+			return err
+		}
+		dst.Set(value)
+		return nil
+
+	case reflect.Struct:
+		raw, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected a table, got %T", path, src)
+		}
+		fields := configFields(dst.Type())
+		for _, key := range sortedKeys(raw) {
+			index, ok := fields[normalizeConfigKey(key)]
+			if !ok {
+				return fmt.Errorf("%s: unknown key", joinConfigPath(path, key))
+			}
+			if err := decodeConfigValue(dst.Field(index), raw[key], joinConfigPath(path, key)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Map:
+		raw, ok := src.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s: expected a table, got %T", path, src)
+		}
+		m := reflect.MakeMapWithSize(dst.Type(), len(raw))
+		for _, key := range sortedKeys(raw) {
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := decodeConfigValue(value, raw[key], joinConfigPath(path, key)); err != nil {
+				return err
+			}
+			m.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), value)
+		}
+		dst.Set(m)
+		return nil
+
+	case reflect.Slice:
+		if s, ok := src.(string); ok && dst.Type().Elem().Kind() == reflect.Uint8 {
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return fmt.Errorf("%s: expected base64 data: %w", path, err)
+			}
+			dst.SetBytes(data)
+			return nil
+		}
+		list, ok := src.([]any)
+		if !ok {
+			return fmt.Errorf("%s: expected a list, got %T", path, src)
+		}
+		slice := reflect.MakeSlice(dst.Type(), len(list), len(list))
+		for i, item := range list {
+			if err := decodeConfigValue(slice.Index(i), item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(normalizeConfigAny(src)))
+		return nil
+
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected a string, got %v", path, src)
+		}
+		dst.SetString(s)
+		return nil
+
+	case reflect.Bool:
+		b, ok := src.(bool)
+		if !ok {
+			return fmt.Errorf("%s: expected true or false, got %v", path, src)
+		}
+		dst.SetBool(b)
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := configInt(src)
+		if !ok || dst.OverflowInt(n) {
+			return fmt.Errorf("%s: expected an integer, got %v", path, src)
+		}
+		dst.SetInt(n)
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := configInt(src)
+		if !ok || n < 0 || dst.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%s: expected a non-negative integer, got %v", path, src)
+		}
+		dst.SetUint(uint64(n))
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		switch v := src.(type) {
+		case json.Number:
+			f, err := v.Float64()
+			if err != nil {
+				return fmt.Errorf("%s: expected a number, got %v", path, src)
+			}
+			dst.SetFloat(f)
+		case float64:
+			dst.SetFloat(v)
+		default:
+			n, ok := configInt(src)
+			if !ok {
+				return fmt.Errorf("%s: expected a number, got %v", path, src)
+			}
+			dst.SetFloat(float64(n))
+		}
+		return nil
+	}
+	return fmt.Errorf("%s: cannot be set from a config file", path)
+}
+
+// configFields maps the normalized names of the fields of struct type t that
+// can be set from a configuration file to their index. Functions, channels
+// and types from other packages other than time.Duration are left out.
+func configFields(t reflect.Type) map[string]int {
+	fields := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.IsExported() && configurable(field.Type) {
+			fields[normalizeConfigKey(field.Name)] = i
+		}
+	}
+	return fields
+}
+
+// configurable reports whether values of type t can be decoded from a configuration file.
+func configurable(t reflect.Type) bool {
+	if t == durationType {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer:
+		return false
+	case reflect.Interface:
+		return t.NumMethod() == 0
+	case reflect.Pointer, reflect.Slice:
+		return configurable(t.Elem())
+	case reflect.Map:
+		return t.Key().Kind() == reflect.String && configurable(t.Elem())
+	case reflect.Struct:
+		return t.PkgPath() == durationType.PkgPath() || t.PkgPath() == reflect.TypeOf(LoggerConfig{}).PkgPath()
+	}
+	return true
+}
+
+// normalizeConfigKey folds case and drops underscores and dashes, so
+// "max_size", "maxSize" and "MaxSize" all name the MaxSize field.
+func normalizeConfigKey(key string) string {
+	key = strings.ToLower(key)
+	return strings.NewReplacer("_", "", "-", "").Replace(key)
+}
+
+func joinConfigPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// configInt converts an integer decoded by any of the supported formats.
+func configInt(src any) (int64, bool) {
+	switch v := src.(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), v <= 1<<63-1
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	case float64:
+		return int64(v), v == float64(int64(v))
+	}
+	return 0, false
+}
+
+// normalizeConfigAny converts free-form values, such as GlobalFields, to
+// the types encoding/json would produce, so they encode the same whatever
+// the file format.
+func normalizeConfigAny(src any) any {
+	switch v := src.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case int:
+		return int64(v)
+	case []any:
+		list := make([]any, len(v))
+		for i, item := range v {
+			list[i] = normalizeConfigAny(item)
+		}
+		return list
+	case map[string]any:
+		m := make(map[string]any, len(v))
+		for key, value := range v {
+			m[key] = normalizeConfigAny(value)
+		}
+		return m
+	}
+	return src
+}
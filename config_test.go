@@ -0,0 +1,331 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFileFormats(t *testing.T) {
+	expected := LoggerConfig{
+		OutputMode: OutputFile,
+		LogLevel:   LevelInfo,
+		LogDir:     "/var/log/app",
+		ShowCaller: true,
+		LogRotation: &LogRotationConfig{
+			MaxSize:         50,
+			MaxTotalSizeMB:  1024,
+			Interval:        RotateHourly,
+			CleanupInterval: time.Hour,
+		},
+		LevelFiles:   map[string]*LogRotationConfig{LevelError: {MaxAge: 90}, LevelDebug: nil},
+		FileBuffer:   &BufferConfig{Size: 65536, FlushInterval: 500 * time.Millisecond},
+		Encoding:     EncodingJSON,
+		GlobalFields: map[string]any{"service": "api", "replicas": int64(3)},
+		FieldKeys:    &FieldKeysConfig{Message: "message"},
+		LevelLabels:  map[string]string{"warn": "WARNING"},
+		Sanitize:     SanitizeEscape,
+		RedactKeys:   []string{"authorization", "*password*"},
+	}
+
+	files := map[string]string{
+		"logger.yaml": `
+output_mode: file
+log_level: info
+log_dir: /var/log/app
+show_caller: true
+log_rotation:
+  max_size: 50
+  max_total_size_mb: 1024
+  interval: hourly
+  cleanup_interval: 1h
+level_files:
+  error: {max_age: 90}
+  debug: ~
+file_buffer:
+  size: 65536
+  flush_interval: 500ms
+encoding: json
+global_fields:
+  service: api
+  replicas: 3
+field_keys:
+  message: message
+level_labels:
+  warn: WARNING
+sanitize: escape
+redact_keys: [authorization, "*password*"]
+`,
+		"logger.json": `{
+  "output_mode": "file",
+  "log_level": "info",
+  "log_dir": "/var/log/app",
+  "show_caller": true,
+  "log_rotation": {"max_size": 50, "max_total_size_mb": 1024, "interval": "hourly", "cleanup_interval": "1h"},
+  "level_files": {"error": {"max_age": 90}, "debug": null},
+  "file_buffer": {"size": 65536, "flush_interval": "500ms"},
+  "encoding": "json",
+  "global_fields": {"service": "api", "replicas": 3},
+  "field_keys": {"message": "message"},
+  "level_labels": {"warn": "WARNING"},
+  "sanitize": "escape",
+  "redact_keys": ["authorization", "*password*"]
+}`,
+		"logger.toml": `
+output_mode = "file"
+log_level = "info"
+log_dir = "/var/log/app"
+show_caller = true
+encoding = "json"
+sanitize = "escape"
+redact_keys = ["authorization", "*password*"]
+
+[log_rotation]
+max_size = 50
+max_total_size_mb = 1024
+interval = "hourly"
+cleanup_interval = "1h"
+
+[level_files.error]
+max_age = 90
+
+[level_files.debug]
+
+[file_buffer]
+size = 65536
+flush_interval = "500ms"
+
+[global_fields]
+service = "api"
+replicas = 3
+
+[field_keys]
+message = "message"
+
+[level_labels]
+warn = "WARNING"
+`,
+	}
+
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			config, err := LoadConfigFile(writeConfigFile(t, name, content))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			// TOML has no null; an empty table leaves the defaults.
+			if name == "logger.toml" {
+				config.LevelFiles[LevelDebug] = nil
+			}
+			if !reflect.DeepEqual(config, expected) {
+				t.Errorf("Unexpected config:\n got %+v\nwant %+v", config, expected)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileSinks(t *testing.T) {
+	logDir := t.TempDir()
+	path := writeConfigFile(t, "logger.yaml", `
+output_mode: discard
+sinks:
+  - type: file
+    name: audit
+    log_dir: `+logDir+`
+    level: warn
+    log_rotation: {max_age: 365}
+  - type: stderr
+    buffer: {size: 10}
+`)
+	config, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Sinks) != 2 {
+		t.Fatalf("Expected 2 sinks, got %d", len(config.Sinks))
+	}
+	audit, ok := config.Sinks[0].(*FileSink)
+	if !ok || audit.level.String() != LevelWarn || audit.file.maxAge != 365*24*time.Hour {
+		t.Errorf("Unexpected file sink: %+v", config.Sinks[0])
+	}
+	if _, ok := config.Sinks[1].(*BufferedSink); !ok {
+		t.Errorf("Expected a buffered sink, got %T", config.Sinks[1])
+	}
+
+	log := NewLoggerWithConfig(config)
+	log.Warn("audited").Send()
+	log.Close()
+	data, _ := os.ReadFile(filepath.Join(logDir, "audit-"+time.Now().Format("2006-01-02")+".log"))
+	if !strings.Contains(string(data), "audited") {
+		t.Errorf("Expected the entry in the file sink, got %q", data)
+	}
+}
+
+func TestLoadConfigFileErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"logger.yaml", "output_mode: file\nlog_levle: info\n", "log_levle: unknown key"},
+		{"logger.yaml", "log_rotation:\n  max_size: big\n", "log_rotation.max_size: expected an integer"},
+		{"logger.json", `{"file_buffer": {"flush_interval": 5}}`, "file_buffer.flush_interval: expected a duration"},
+		{"logger.toml", "on_sink_error = \"x\"\n", "on_sink_error: unknown key"},
+		{"logger.yaml", "sinks:\n  - type: kafka\n", `sinks[0]: unknown sink type "kafka"`},
+		{"logger.yaml", "sinks:\n  - type: stdout\n    addr: x\n", "sinks[0].addr: unknown key"},
+		{"logger.yaml", "output_mode: [file\n", "logger.yaml"},
+		{"logger.yaml", "output_mode: trminal\n", `OutputMode: unknown value "trminal"`},
+		{"logger.ini", "output_mode=file\n", "unsupported config file extension"},
+	}
+
+	for _, tt := range tests {
+		_, err := LoadConfigFile(writeConfigFile(t, tt.name, tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s %q: expected error containing %q, got %v", tt.name, tt.content, tt.expected, err)
+		}
+	}
+
+	if _, err := NewLoggerFromFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestNewLoggerFromFile(t *testing.T) {
+	logDir := t.TempDir()
+	path := writeConfigFile(t, "logger.json", `{"output_mode": "file", "log_dir": "`+logDir+`", "log_level": "warn"}`)
+	log, err := NewLoggerFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Info("skipped").Send()
+	log.Warn("written").Send()
+	log.Close()
+
+	data, _ := os.ReadFile(currentLogFile(logDir))
+	if strings.Contains(string(data), "skipped") || !strings.Contains(string(data), "written") {
+		t.Errorf("Expected only the warn entry, got %q", data)
+	}
+}
+
+func TestLoadConfigFileProfiles(t *testing.T) {
+	files := map[string]string{
+		"logger.yaml": `
+output_mode: both
+log_level: info
+log_rotation:
+  max_size: 50
+  max_age: 7
+global_fields:
+  service: api
+sinks:
+  - type: stdout
+profiles:
+  dev:
+    output_mode: terminal
+    log_level: debug
+  prod:
+    logLevel: warn
+    log_rotation:
+      max_age: 30
+    sinks: []
+`,
+		"logger.json": `{
+  "output_mode": "both",
+  "log_level": "info",
+  "log_rotation": {"max_size": 50, "max_age": 7},
+  "global_fields": {"service": "api"},
+  "sinks": [{"type": "stdout"}],
+  "profiles": {
+    "dev": {"output_mode": "terminal", "log_level": "debug"},
+    "prod": {"logLevel": "warn", "log_rotation": {"max_age": 30}, "sinks": []}
+  }
+}`,
+		"logger.toml": `
+output_mode = "both"
+log_level = "info"
+global_fields = { service = "api" }
+sinks = [{ type = "stdout" }]
+
+[log_rotation]
+max_size = 50
+max_age = 7
+
+[profiles.dev]
+output_mode = "terminal"
+log_level = "debug"
+
+[profiles.prod]
+logLevel = "warn"
+sinks = []
+
+[profiles.prod.log_rotation]
+max_age = 30
+`,
+	}
+
+	for name, content := range files {
+		path := writeConfigFile(t, name, content)
+
+		t.Setenv(EnvProfile, "")
+		config, err := LoadConfigFile(path)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if config.OutputMode != OutputBoth || config.LogLevel != LevelInfo || len(config.Sinks) != 1 {
+			t.Errorf("%s: expected the base configuration without a profile, got %+v", name, config)
+		}
+
+		t.Setenv(EnvProfile, "dev")
+		if config, err = LoadConfigFile(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if config.OutputMode != OutputTerminal || config.LogLevel != LevelDebug || config.LogRotation.MaxSize != 50 {
+			t.Errorf("%s: expected the dev profile over the base configuration, got %+v", name, config)
+		}
+
+		t.Setenv(EnvProfile, "prod")
+		if config, err = LoadConfigFile(path); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		expectedRotation := &LogRotationConfig{MaxSize: 50, MaxAge: 30}
+		if config.OutputMode != OutputBoth || config.LogLevel != LevelWarn || len(config.Sinks) != 0 ||
+			!reflect.DeepEqual(config.LogRotation, expectedRotation) || config.GlobalFields["service"] != "api" {
+			t.Errorf("%s: expected the prod profile merged key by key, got %+v", name, config)
+		}
+	}
+}
+
+func TestLoadConfigFileProfileErrors(t *testing.T) {
+	tests := []struct {
+		profile  string
+		content  string
+		expected string
+	}{
+		{"prod", "log_level: info\nprofiles:\n  dev: {log_level: debug}\n", `GOLOGGER_PROFILE: unknown profile "prod", expected one of [dev]`},
+		{"prod", "log_level: info\n", `unknown profile "prod"`},
+		{"prod", "profiles:\n  prod: debug\n", "profiles.prod: expected a table"},
+		{"", "profiles: [dev]\n", "profiles: expected a table"},
+		{"dev", "profiles:\n  dev: {log_levle: debug}\n", "log_levle: unknown key"},
+		{"dev", "profiles:\n  dev: {log_level: verbose}\n", `LogLevel: unknown value "verbose"`},
+	}
+
+	for _, tt := range tests {
+		t.Setenv(EnvProfile, tt.profile)
+		_, err := LoadConfigFile(writeConfigFile(t, "logger.yaml", tt.content))
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%s %q: expected error containing %q, got %v", tt.profile, tt.content, tt.expected, err)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package gologger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ConnLogConfig holds configuration options for NewConnLogger.
+type ConnLogConfig struct {
+	Type              string        // Kind of connection, such as "websocket" or "sse", logged as "connection_type" (optional)
+	HeartbeatInterval time.Duration // Log the connection's statistics at debug level this often while it is open (optional)
+}
+
+// ConnLogger logs the life of a long-lived connection, such as a WebSocket,
+// a server-sent events stream or a streaming RPC. It is safe for concurrent
+// use, so the reading and writing goroutines of a connection can share it.
+type ConnLogger struct {
+	log    Logger
+	ctx    context.Context
+	id     string
+	start  time.Time
+	done   chan struct{}
+	closed sync.Once
+
+	messagesReceived atomic.Int64
+	messagesSent     atomic.Int64
+	bytesReceived    atomic.Int64
+	bytesSent        atomic.Int64
+}
+
+// NewConnLogger logs a connection opened with ctx, such as the context of
+// the HTTP request it was upgraded from, and returns its ConnLogger:
+//
+//	conn, err := upgrader.Upgrade(w, r, nil)
+//	connLog := gologger.NewConnLogger(r.Context(), log, gologger.ConnLogConfig{Type: "websocket", HeartbeatInterval: time.Minute})
+//	defer connLog.Close("handler returned", nil)
+//
+// The connection gets a random ID, added to the context as
+// "connection_id", so every entry logged with Logger or with FromContext
+// on Context carries it, along with the request ID of ctx. The connection is
+// logged as "connection opened" at info level. With a heartbeat interval,
+// "connection heartbeat" entries with its duration and statistics are logged
+// at debug level until Close.
+func NewConnLogger(ctx context.Context, log Logger, config ConnLogConfig) *ConnLogger {
+	id := newRequestID()
+	ctx = WithFields(ctx, "connection_id", id)
+	if config.Type != "" {
+		ctx = WithFields(ctx, "connection_type", config.Type)
+	}
+	ctx = NewContext(ctx, log)
+	c := &ConnLogger{
+		log:   log.WithContext(ctx),
+		ctx:   ctx,
+		id:    id,
+		start: time.Now(),
+		done:  make(chan struct{}),
+	}
+	c.log.Info("connection opened").Send()
+	if config.HeartbeatInterval > 0 {
+		go c.heartbeat(config.HeartbeatInterval)
+	}
+	return c
+}
+
+// ID returns the ID of the connection.
+func (c *ConnLogger) ID() string {
+	return c.id
+}
+
+// Logger returns the logger of the connection, whose entries carry its ID.
+func (c *ConnLogger) Logger() Logger {
+	return c.log
+}
+
+// Context returns the context of the connection, carrying its ID and
+// logger for FromContext.
+func (c *ConnLogger) Context() context.Context {
+	return c.ctx
+}
+
+// Received counts a message of size bytes received on the connection.
+func (c *ConnLogger) Received(size int) {
+	c.messagesReceived.Add(1)
+	c.bytesReceived.Add(int64(size))
+}
+
+// Sent counts a message of size bytes sent on the connection.
+func (c *ConnLogger) Sent(size int) {
+	c.messagesSent.Add(1)
+	c.bytesSent.Add(int64(size))
+}
+
+// Close logs the connection as "connection closed" with reason, its
+// duration and statistics, at info level, or warn level with err if it is
+// not nil, e.g. for connections closed by a read error. Heartbeats stop.
+// Only the first call logs; later calls do nothing, so Close can be both
+// deferred and called where the close reason is known.
+func (c *ConnLogger) Close(reason string, err error) {
+	c.closed.Do(func() {
+		close(c.done)
+		log := c.log.Info("connection closed")
+		if err != nil {
+			log = c.log.Warn("connection closed")
+		}
+		c.stats(log).Data("reason", reason).ErrorData(err).Send()
+	})
+}
+
+// heartbeat logs the statistics of the connection every interval until it
+// is closed.
+func (c *ConnLogger) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			c.stats(c.log.Debug("connection heartbeat")).Send()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// stats adds the duration and message counters of the connection to log.
+func (c *ConnLogger) stats(log Logger) Logger {
+	return log.Data("duration_ms", float64(time.Since(c.start))/float64(time.Millisecond)).
+		Data("messages_received", c.messagesReceived.Load()).
+		Data("messages_sent", c.messagesSent.Load()).
+		Data("bytes_received", c.bytesReceived.Load()).
+		Data("bytes_sent", c.bytesSent.Load())
+}
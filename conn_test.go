@@ -0,0 +1,88 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConnLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	ctx := WithRequestID(context.Background(), "req-42")
+
+	conn := NewConnLogger(ctx, log, ConnLogConfig{Type: "websocket"})
+	conn.Received(12)
+	conn.Received(8)
+	conn.Sent(100)
+	FromContext(conn.Context()).Info("subscribed").Data("channel", "orders").Send()
+	conn.Logger().Debug("ping").Send()
+	conn.Close("client closed", nil)
+	conn.Close("handler returned", errors.New("ignored"))
+
+	entries := capture.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected open, two connection and close entries, got %+v", entries)
+	}
+	for _, entry := range entries {
+		if entry.Fields["connection_id"] != conn.ID() || entry.Fields["connection_type"] != "websocket" ||
+			entry.Fields["request-id"] != "req-42" {
+			t.Errorf("Expected the connection fields on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	if len(conn.ID()) != 32 {
+		t.Errorf("Expected a generated connection ID, got %q", conn.ID())
+	}
+	if entries[0].Level != LevelInfo || entries[0].Message != "connection opened" {
+		t.Errorf("Unexpected open entry %+v", entries[0])
+	}
+	closed := entries[3]
+	if closed.Level != LevelInfo || closed.Message != "connection closed" || closed.Fields["reason"] != "client closed" ||
+		closed.Fields["messages_received"] != int64(2) || closed.Fields["bytes_received"] != int64(20) ||
+		closed.Fields["messages_sent"] != int64(1) || closed.Fields["bytes_sent"] != int64(100) {
+		t.Errorf("Unexpected close entry %+v", closed)
+	}
+	if _, ok := closed.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", closed.Fields)
+	}
+}
+
+func TestConnLoggerCloseError(t *testing.T) {
+	log, capture := NewTestLogger()
+	conn := NewConnLogger(context.Background(), log, ConnLogConfig{})
+
+	conn.Close("read failed", errors.New("unexpected EOF"))
+
+	closed := capture.FilterMessage("connection closed")
+	if len(closed) != 1 || closed[0].Level != LevelWarn || closed[0].Fields["error"] != "unexpected EOF" {
+		t.Fatalf("Expected a warn close entry, got %+v", capture.Entries())
+	}
+	if _, ok := closed[0].Fields["connection_type"]; ok {
+		t.Errorf("Expected no connection type, got %v", closed[0].Fields)
+	}
+}
+
+func TestConnLoggerHeartbeat(t *testing.T) {
+	log, capture := NewTestLogger()
+	conn := NewConnLogger(context.Background(), log, ConnLogConfig{HeartbeatInterval: 5 * time.Millisecond})
+	conn.Sent(10)
+
+	deadline := time.Now().Add(time.Second)
+	for len(capture.FilterMessage("connection heartbeat")) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	conn.Close("done", nil)
+	beats := len(capture.FilterMessage("connection heartbeat"))
+	time.Sleep(20 * time.Millisecond)
+
+	heartbeats := capture.FilterMessage("connection heartbeat")
+	if len(heartbeats) < 2 {
+		t.Fatalf("Expected heartbeats, got %+v", capture.Entries())
+	}
+	if heartbeats[0].Level != LevelDebug || heartbeats[0].Fields["messages_sent"] != int64(1) {
+		t.Errorf("Unexpected heartbeat %+v", heartbeats[0])
+	}
+	if len(heartbeats) > beats+1 {
+		t.Errorf("Expected heartbeats to stop on Close, got %d after %d", len(heartbeats), beats)
+	}
+}
@@ -0,0 +1,230 @@
+package gologger
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	consoleTimeLayout   = "2006-01-02 15:04:05.000"
+	consoleCallerWidth  = 24
+	consoleMessageWidth = 40
+
+	ansiReset   = "\x1b[0m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiYellow  = "\x1b[33m"
+	ansiBlue    = "\x1b[34m"
+	ansiMagenta = "\x1b[35m"
+	ansiCyan    = "\x1b[36m"
+	ansiGray    = "\x1b[90m"
+)
+
+var consoleBufferPool = buffer.NewPool()
+
+// consoleEncoder renders entries as aligned, human-readable lines:
+//
+//	2024-03-01 10:30:00.123 INFO  handler/user.go:42       User created    user=alice age=30
+//
+// Field values containing newlines, such as errors with causes, and stack
+// traces are rendered as indented blocks below the line.
+type consoleEncoder struct {
+	fieldRecorder // context added with With
+	color         bool
+	caller        func(zapcore.EntryCaller) string // caller formatter (default: short path)
+	icons         bool                             // prefix levels with icons and render messages in bold
+}
+
+// newConsoleEncoder creates a console encoder. Colors are only used when
+// color is true.
+func newConsoleEncoder(color bool) *consoleEncoder {
+	return &consoleEncoder{color: color, caller: zapcore.EntryCaller.TrimmedPath}
+}
+
+// terminalSupportsColor reports whether f is a terminal and colors have not
+// been disabled with the NO_COLOR environment variable.
+func terminalSupportsColor(f *os.File) bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func (e *consoleEncoder) Clone() zapcore.Encoder {
+	return &consoleEncoder{fieldRecorder: e.clone(), color: e.color, caller: e.caller, icons: e.icons}
+}
+
+func (e *consoleEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := consoleBufferPool.Get()
+
+	e.colored(buf, ansiGray, ent.Time.Format(consoleTimeLayout))
+	buf.AppendByte(' ')
+
+	if e.icons {
+		buf.AppendString(consoleIcon(ent.Level))
+		buf.AppendByte(' ')
+	}
+	level := ent.Level.CapitalString()
+	e.colored(buf, e.levelColor(ent.Level), level)
+	appendPadding(buf, 5-len(level)+1)
+
+	if ent.Caller.Defined {
+		caller := e.caller(ent.Caller)
+		e.colored(buf, ansiGray, caller)
+		appendPadding(buf, consoleCallerWidth-len(caller)+1)
+	}
+
+	if e.icons {
+		e.colored(buf, ansiBold, ent.Message)
+	} else {
+		buf.AppendString(ent.Message)
+	}
+
+	var blocks []string
+	all := e.with(fields)
+	if len(all) > 0 {
+		appendPadding(buf, consoleMessageWidth-utf8.RuneCountInString(ent.Message))
+	}
+
+	prefix := ""
+	for _, field := range all {
+		if field.Type == zapcore.NamespaceType {
+			prefix += field.Key + "."
+			continue
+		}
+		key := prefix + field.Key
+		value, composite := consoleFieldValue(field)
+		if strings.Contains(value, "\n") {
+			blocks = append(blocks, key, value)
+			continue
+		}
+		if !composite {
+			value = consoleQuote(value)
+		}
+		buf.AppendByte(' ')
+		e.colored(buf, ansiCyan, key+"=")
+		buf.AppendString(value)
+	}
+
+	for i := 0; i < len(blocks); i += 2 {
+		buf.AppendString("\n    ")
+		e.colored(buf, ansiCyan, blocks[i]+":")
+		appendIndented(buf, blocks[i+1])
+	}
+	if ent.Stack != "" {
+		buf.AppendString("\n    ")
+		e.colored(buf, ansiCyan, "stacktrace:")
+		appendIndented(buf, ent.Stack)
+	}
+
+	buf.AppendByte('\n')
+	return buf, nil
+}
+
+// colored appends s wrapped in the given color when colors are enabled.
+func (e *consoleEncoder) colored(buf *buffer.Buffer, color, s string) {
+	if !e.color {
+		buf.AppendString(s)
+		return
+	}
+	buf.AppendString(color)
+	buf.AppendString(s)
+	buf.AppendString(ansiReset)
+}
+
+func (e *consoleEncoder) levelColor(level zapcore.Level) string {
+	switch level {
+	case zapcore.DebugLevel:
+		return ansiMagenta
+	case zapcore.InfoLevel:
+		return ansiBlue
+	case zapcore.WarnLevel:
+		return ansiYellow
+	case zapcore.ErrorLevel:
+		return ansiRed
+	default:
+		return ansiBold + ansiRed
+	}
+}
+
+// consoleIcon returns the icon shown before a level in icon mode.
+func consoleIcon(level zapcore.Level) string {
+	switch level {
+	case zapcore.DebugLevel:
+		return "🔍"
+	case zapcore.InfoLevel:
+		return "✅"
+	case zapcore.WarnLevel:
+		return "⚠️"
+	case zapcore.ErrorLevel:
+		return "❌"
+	default:
+		return "💥"
+	}
+}
+
+func appendPadding(buf *buffer.Buffer, n int) {
+	if n < 1 {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		buf.AppendByte(' ')
+	}
+}
+
+// appendIndented appends each line of s on its own line, indented.
+func appendIndented(buf *buffer.Buffer, s string) {
+	for _, line := range strings.Split(strings.TrimRight(s, "\n"), "\n") {
+		buf.AppendString("\n      ")
+		buf.AppendString(line)
+	}
+}
+
+// consoleFieldValue renders a field value as plain text. Composite values
+// are rendered as JSON and reported as such, since they need no quoting.
+func consoleFieldValue(field zapcore.Field) (string, bool) {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	value := enc.Fields[field.Key]
+
+	switch v := value.(type) {
+	case string:
+		return v, false
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), false
+	case time.Time:
+		return v.Format(time.RFC3339Nano), false
+	case error:
+		return v.Error(), false
+	case fmt.Stringer:
+		return v.String(), false
+	case nil:
+		return "<nil>", false
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, uintptr, float32, float64, complex64, complex128:
+		return fmt.Sprint(v), false
+	default:
+		data, err := json.Marshal(normalizeValue(v))
+		if err != nil {
+			return fmt.Sprint(v), false
+		}
+		return string(data), true
+	}
+}
+
+// consoleQuote quotes values that would otherwise be ambiguous in key=value form.
+func consoleQuote(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t") || !utf8.ValidString(s) {
+		return strconv.Quote(s)
+	}
+	return s
+}
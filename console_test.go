@@ -0,0 +1,161 @@
+package gologger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func encodeConsole(t *testing.T, enc zapcore.Encoder, ent zapcore.Entry, fields ...zapcore.Field) string {
+	t.Helper()
+	buf, err := enc.EncodeEntry(ent, fields)
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+	defer buf.Free()
+	return buf.String()
+}
+
+func testConsoleEntry() zapcore.Entry {
+	return zapcore.Entry{
+		Level:   zapcore.InfoLevel,
+		Time:    time.Date(2024, 3, 1, 10, 30, 0, 123e6, time.Local),
+		Message: "User created",
+		Caller:  zapcore.NewEntryCaller(0, "/home/dev/app/handler/user.go", 42, true),
+	}
+}
+
+func TestConsoleEncoderLayout(t *testing.T) {
+	line := encodeConsole(t, newConsoleEncoder(false), testConsoleEntry(),
+		zap.String("user", "alice"),
+		zap.Int("age", 30),
+		zap.String("note", "has spaces"),
+		zap.Duration("took", 1500*time.Millisecond),
+	)
+
+	expected := "2024-03-01 10:30:00.123 INFO  handler/user.go:42       User created" + strings.Repeat(" ", 28) +
+		` user=alice age=30 note="has spaces" took=1.5s` + "\n"
+	if line != expected {
+		t.Errorf("Unexpected line:\n%q\nexpected:\n%q", line, expected)
+	}
+}
+
+func TestConsoleEncoderMultilineValuesAndStack(t *testing.T) {
+	ent := testConsoleEntry()
+	ent.Level = zapcore.ErrorLevel
+	ent.Stack = "main.main\n\t/app/main.go:10"
+
+	line := encodeConsole(t, newConsoleEncoder(false), ent,
+		zap.String("error", "query failed\ncaused by: connection reset"),
+		zap.String("table", "users"),
+	)
+
+	lines := strings.Split(strings.TrimSuffix(line, "\n"), "\n")
+	expected := []string{
+		"    error:",
+		"      query failed",
+		"      caused by: connection reset",
+		"    stacktrace:",
+		"      main.main",
+		"      \t/app/main.go:10",
+	}
+	if len(lines) != len(expected)+1 {
+		t.Fatalf("Expected %d lines, got %q", len(expected)+1, line)
+	}
+	if !strings.HasSuffix(lines[0], "table=users") || strings.Contains(lines[0], "error=") {
+		t.Errorf("Expected inline fields without the multi-line error, got %q", lines[0])
+	}
+	for i, want := range expected {
+		if lines[i+1] != want {
+			t.Errorf("Line %d: expected %q, got %q", i+1, want, lines[i+1])
+		}
+	}
+}
+
+func TestConsoleEncoderContextFields(t *testing.T) {
+	var out bytes.Buffer
+	core := zapcore.NewCore(newConsoleEncoder(false), zapcore.AddSync(&out), zapcore.DebugLevel)
+	logger := zap.New(core).With(zap.String("service", "billing"), zap.Namespace("http"), zap.Int("status", 200))
+	logger.Info("done", zap.Bool("cached", true), zap.Any("tags", []string{"a", "b"}))
+
+	line := out.String()
+	for _, want := range []string{"service=billing", "http.status=200", "http.cached=true", `http.tags=["a","b"]`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+}
+
+func TestConsoleEncoderColors(t *testing.T) {
+	ent := testConsoleEntry()
+	ent.Level = zapcore.WarnLevel
+	line := encodeConsole(t, newConsoleEncoder(true), ent, zap.String("user", "alice"))
+
+	if !strings.Contains(line, ansiYellow+"WARN"+ansiReset) {
+		t.Errorf("Expected colored level in %q", line)
+	}
+	if !strings.Contains(line, ansiCyan+"user="+ansiReset+"alice") {
+		t.Errorf("Expected colored key in %q", line)
+	}
+
+	plain := encodeConsole(t, newConsoleEncoder(false), ent)
+	if strings.Contains(plain, "\x1b[") {
+		t.Errorf("Expected no escape codes without color, got %q", plain)
+	}
+}
+
+func TestConsoleFieldValue(t *testing.T) {
+	tests := []struct {
+		field    zapcore.Field
+		expected string
+	}{
+		{zap.Error(errors.New("boom")), "boom"},
+		{zap.Float64("ratio", 0.5), "0.5"},
+		{zap.Binary("raw", []byte("hi")), "aGk="},
+		{zap.Any("obj", map[string]int{"a": 1}), `{"a":1}`},
+		{zap.Reflect("nil", nil), "<nil>"},
+	}
+	for _, tt := range tests {
+		if got, _ := consoleFieldValue(tt.field); got != tt.expected {
+			t.Errorf("consoleFieldValue(%s) = %q, want %q", tt.field.Key, got, tt.expected)
+		}
+	}
+}
+
+func TestConsoleEncoderIcons(t *testing.T) {
+	enc := newConsoleEncoder(false)
+	enc.icons = true
+
+	tests := map[zapcore.Level]string{
+		zapcore.DebugLevel: "🔍 DEBUG ",
+		zapcore.InfoLevel:  "✅ INFO  ",
+		zapcore.WarnLevel:  "⚠️ WARN  ",
+		zapcore.ErrorLevel: "❌ ERROR ",
+		zapcore.FatalLevel: "💥 FATAL ",
+	}
+	for level, want := range tests {
+		ent := testConsoleEntry()
+		ent.Level = level
+		if line := encodeConsole(t, enc, ent); !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+
+	colored := newConsoleEncoder(true)
+	colored.icons = true
+	if line := encodeConsole(t, colored, testConsoleEntry()); !strings.Contains(line, ansiBold+"User created"+ansiReset) {
+		t.Errorf("Expected bold message in %q", line)
+	}
+	if line := encodeConsole(t, newConsoleEncoder(true), testConsoleEntry()); strings.Contains(line, "✅") || strings.Contains(line, ansiBold+"User") {
+		t.Errorf("Expected no icons or bold message by default, got %q", line)
+	}
+
+	if line := encodeConsole(t, getEncoder(EncodingConsole, LoggerConfig{ConsoleIcons: true}, false), testConsoleEntry()); !strings.HasPrefix(line, "2024-03-01 10:30:00.123 ✅ INFO ") {
+		t.Errorf("Expected ConsoleIcons to enable icons, got %q", line)
+	}
+}
@@ -0,0 +1,104 @@
+package gologger
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// Message is a message received from a queue, as handled by WrapConsumer.
+// Queue clients are adapted to it with a small type wrapping their message
+// or delivery type.
+type Message interface {
+	// Header returns the value of a header or attribute of the message, or
+	// "" if it has none.
+	Header(key string) string
+	// Ack acknowledges the message as processed.
+	Ack() error
+	// Nack rejects the message, for redelivery or dead-lettering.
+	Nack() error
+}
+
+// ConsumerConfig holds configuration options for WrapConsumer.
+type ConsumerConfig struct {
+	Queue   string   // Name of the queue, topic or subscription, logged as "queue" (optional)
+	Headers []string // Headers holding the correlation ID, in order of preference (default: X-Request-ID, X-Correlation-ID)
+}
+
+// WrapConsumer returns handler, which processes messages received from a
+// queue, logging each message with log and acknowledging it:
+//
+//	handle := gologger.WrapConsumer(log, gologger.ConsumerConfig{Queue: "orders"}, func(ctx context.Context, msg gologger.Message) error {
+//		gologger.FromContext(ctx).Info("processing order").Send()
+//		return processOrder(ctx, msg)
+//	})
+//	for d := range deliveries {
+//		_ = handle(context.Background(), amqpMessage{d})
+//	}
+//
+// The correlation ID of the message is read from the first of
+// config.Headers it has, validated as by RequestIDFromHeader, or generated,
+// and becomes the request ID of the context passed to handler, with the
+// queue as "queue" field. The context holds log, so handler gets the logger
+// with FromContext(ctx) and its entries carry both. "message received" is
+// logged at debug level. When handler returns nil, the message is acked and
+// "message acked" is logged at info level with the processing duration;
+// otherwise it is nacked and "message nacked" is logged at error level with
+// the error. A failed ack or nack is logged at error level as "ack_error" or
+// "nack_error". Panics are logged with their stack and handled as errors.
+// The returned function returns the error of handler, or else of Ack.
+func WrapConsumer(log Logger, config ConsumerConfig, handler func(ctx context.Context, msg Message) error) func(ctx context.Context, msg Message) error {
+	headers := config.Headers
+	if len(headers) == 0 {
+		headers = []string{RequestIDHeader, "X-Correlation-ID"}
+	}
+	return func(ctx context.Context, msg Message) (err error) {
+		start := time.Now()
+		correlationID := ""
+		for _, header := range headers {
+			if value := msg.Header(header); value != "" {
+				correlationID = RequestIDFromHeader(value)
+				break
+			}
+		}
+		if correlationID == "" {
+			correlationID = newRequestID()
+		}
+		ctx = WithRequestID(ctx, correlationID)
+		if config.Queue != "" {
+			ctx = WithFields(ctx, "queue", config.Queue)
+		}
+		ctx = NewContext(ctx, log)
+
+		msgLog := log.WithContext(ctx)
+		msgLog.Debug("message received").Send()
+		defer func() {
+			if r := recover(); r != nil {
+				msgLog.Error("message handler panicked").
+					Data("panic", fmt.Sprint(r)).
+					Data("stack", string(debug.Stack())).
+					Send()
+				var ok bool
+				if err, ok = r.(error); !ok {
+					err = fmt.Errorf("%v", r)
+				}
+			}
+			duration := float64(time.Since(start)) / float64(time.Millisecond)
+			if err != nil {
+				entry := msgLog.Error("message nacked").Data("duration_ms", duration).ErrorData(err)
+				if nackErr := msg.Nack(); nackErr != nil {
+					entry = entry.Data("nack_error", nackErr.Error())
+				}
+				entry.Send()
+				return
+			}
+			if err = msg.Ack(); err != nil {
+				msgLog.Error("message acked").Data("duration_ms", duration).Data("ack_error", err.Error()).Send()
+				return
+			}
+			msgLog.Info("message acked").Data("duration_ms", duration).Send()
+		}()
+		return handler(ctx, msg)
+	}
+}
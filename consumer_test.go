@@ -0,0 +1,132 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// testMessage is a Message recording its acknowledgement.
+type testMessage struct {
+	headers map[string]string
+	ackErr  error
+	acked   bool
+	nacked  bool
+}
+
+func (m *testMessage) Header(key string) string {
+	return m.headers[key]
+}
+
+func (m *testMessage) Ack() error {
+	m.acked = true
+	return m.ackErr
+}
+
+func (m *testMessage) Nack() error {
+	m.nacked = true
+	return nil
+}
+
+func TestWrapConsumer(t *testing.T) {
+	log, capture := NewTestLogger()
+	handle := WrapConsumer(log, ConsumerConfig{Queue: "orders"}, func(ctx context.Context, msg Message) error {
+		FromContext(ctx).Info("processing order").Send()
+		return nil
+	})
+
+	msg := &testMessage{headers: map[string]string{"X-Correlation-ID": "corr-7"}}
+	if err := handle(context.Background(), msg); err != nil {
+		t.Fatal(err)
+	}
+
+	if !msg.acked || msg.nacked {
+		t.Errorf("Expected the message to be acked, got acked %v, nacked %v", msg.acked, msg.nacked)
+	}
+	entries := capture.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected receive, handler and ack entries, got %+v", entries)
+	}
+	for _, entry := range entries {
+		if entry.Fields["request-id"] != "corr-7" || entry.Fields["queue"] != "orders" {
+			t.Errorf("Expected the correlation ID and queue on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	if entries[0].Level != LevelDebug || entries[0].Message != "message received" {
+		t.Errorf("Unexpected receive entry %+v", entries[0])
+	}
+	acked := entries[2]
+	if acked.Level != LevelInfo || acked.Message != "message acked" {
+		t.Errorf("Unexpected ack entry %+v", acked)
+	}
+	if _, ok := acked.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", acked.Fields)
+	}
+}
+
+func TestWrapConsumerNack(t *testing.T) {
+	log, capture := NewTestLogger()
+	handle := WrapConsumer(log, ConsumerConfig{Headers: []string{"trace"}}, func(ctx context.Context, msg Message) error {
+		return errors.New("order not found")
+	})
+
+	msg := &testMessage{headers: map[string]string{"trace": "bad id with spaces", RequestIDHeader: "ignored"}}
+	err := handle(context.Background(), msg)
+	if err == nil || err.Error() != "order not found" {
+		t.Fatalf("Expected the handler error, got %v", err)
+	}
+
+	if msg.acked || !msg.nacked {
+		t.Errorf("Expected the message to be nacked, got acked %v, nacked %v", msg.acked, msg.nacked)
+	}
+	nacked := capture.FilterMessage("message nacked")
+	if len(nacked) != 1 || nacked[0].Level != LevelError || nacked[0].Fields["error"] != "order not found" {
+		t.Fatalf("Expected a nack entry, got %+v", capture.Entries())
+	}
+	if id, _ := nacked[0].Fields["request-id"].(string); len(id) != 32 {
+		t.Errorf("Expected a generated correlation ID for an invalid header, got %v", nacked[0].Fields)
+	}
+	if _, ok := nacked[0].Fields["queue"]; ok {
+		t.Errorf("Expected no queue, got %v", nacked[0].Fields)
+	}
+}
+
+func TestWrapConsumerAckError(t *testing.T) {
+	log, capture := NewTestLogger()
+	handle := WrapConsumer(log, ConsumerConfig{}, func(ctx context.Context, msg Message) error {
+		return nil
+	})
+
+	err := handle(context.Background(), &testMessage{ackErr: errors.New("channel closed")})
+	if err == nil || err.Error() != "channel closed" {
+		t.Fatalf("Expected the ack error, got %v", err)
+	}
+	acked := capture.FilterMessage("message acked")
+	if len(acked) != 1 || acked[0].Level != LevelError || acked[0].Fields["ack_error"] != "channel closed" {
+		t.Fatalf("Expected a failed ack entry, got %+v", capture.Entries())
+	}
+}
+
+func TestWrapConsumerPanic(t *testing.T) {
+	log, capture := NewTestLogger()
+	handle := WrapConsumer(log, ConsumerConfig{}, func(ctx context.Context, msg Message) error {
+		panic("nil order")
+	})
+
+	msg := &testMessage{headers: map[string]string{RequestIDHeader: "req-42"}}
+	err := handle(context.Background(), msg)
+	if err == nil || err.Error() != "nil order" {
+		t.Fatalf("Expected the panic as an error, got %v", err)
+	}
+	if !msg.nacked {
+		t.Error("Expected the message to be nacked")
+	}
+	panicked := capture.FilterMessage("message handler panicked")
+	if len(panicked) != 1 || panicked[0].Fields["request-id"] != "req-42" {
+		t.Fatalf("Expected a panic entry, got %+v", capture.Entries())
+	}
+	if stack, _ := panicked[0].Fields["stack"].(string); !strings.Contains(stack, "consumer_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+}
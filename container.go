@@ -0,0 +1,195 @@
+package gologger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ContainerLogConfig holds configuration options for ReadContainerLogs.
+type ContainerLogConfig struct {
+	Container   string // Container name, logged as "container" (optional)
+	Level       string // Level of plain text lines and of JSON lines without a known level (default: LevelInfo)
+	StderrLevel string // Level of plain text stderr lines of multiplexed streams (default: LevelWarn)
+	Multiplexed bool   // Whether the stream has the stdout and stderr frames the Docker API returns for containers without a TTY
+}
+
+// containerMessageKeys and containerLevelKeys are the keys of the message
+// and level in JSON lines, in order of preference. containerTimeKeys are the
+// keys of timestamps, dropped since entries have their own.
+var (
+	containerMessageKeys = []string{"msg", "message"}
+	containerLevelKeys   = []string{"level", "severity", "lvl"}
+	containerTimeKeys    = []string{"time", "ts", "timestamp", "@timestamp"}
+)
+
+// ReadContainerLogs logs each line read from r, the log stream of a
+// container or any other mixed output, as an entry, until r returns io.EOF:
+//
+//	logs, err := container.Logs(ctx)
+//	go gologger.ReadContainerLogs(log, logs, gologger.ContainerLogConfig{Container: "postgres"})
+//
+// Entries have the container name as "container". Lines holding a JSON
+// object with a "msg" or "message" key, as logged by most structured
+// loggers, are logged with that message, at the level of their "level",
+// "severity" or "lvl" key, with their other keys as fields, except
+// timestamps. Other lines are logged as they are, at the configured level.
+//
+// Streams of the Docker API, such as those of ContainerLogs or
+// ContainerAttach for containers without a TTY, are multiplexed; with
+// Multiplexed, their frames are split and entries have the stream they were
+// written to, "stdout" or "stderr", as "stream". Plain text stderr lines are
+// logged at the stderr level.
+//
+// ReadContainerLogs returns nil at the end of r, or the error reading it.
+func ReadContainerLogs(log Logger, r io.Reader, config ContainerLogConfig) error {
+	if config.Level == "" {
+		config.Level = LevelInfo
+	}
+	if config.StderrLevel == "" {
+		config.StderrLevel = LevelWarn
+	}
+	if config.Multiplexed {
+		return readMultiplexedLogs(log, r, config)
+	}
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		sendContainerLine(log, line, "", config)
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// readMultiplexedLogs logs the lines of the frames of a multiplexed Docker
+// stream. A frame has an 8-byte header, holding the stream type and the
+// big-endian size of the payload, followed by the payload; lines may be
+// split across frames.
+func readMultiplexedLogs(log Logger, r io.Reader, config ContainerLogConfig) error {
+	pending := make(map[string][]byte)
+	header := make([]byte, 8)
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			for _, stream := range []string{"stdin", "stdout", "stderr"} {
+				sendContainerLine(log, pending[stream], stream, config)
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var stream string
+		switch header[0] {
+		case 0:
+			stream = "stdin"
+		case 1:
+			stream = "stdout"
+		case 2:
+			stream = "stderr"
+		default:
+			return fmt.Errorf("gologger: unknown container log stream %d", header[0])
+		}
+		payload := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(r, payload); err != nil {
+			return err
+		}
+		data := append(pending[stream], payload...)
+		for {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				break
+			}
+			sendContainerLine(log, data[:i], stream, config)
+			data = data[i+1:]
+		}
+		pending[stream] = data
+	}
+}
+
+// sendContainerLine logs a line of the stream, dropping empty lines.
+func sendContainerLine(log Logger, line []byte, stream string, config ContainerLogConfig) {
+	line = bytes.TrimRight(line, "\r\n")
+	if len(line) == 0 {
+		return
+	}
+	level := config.Level
+	if stream == "stderr" {
+		level = config.StderrLevel
+	}
+	msg := string(line)
+	var fields map[string]any
+	if line[0] == '{' && json.Unmarshal(line, &fields) == nil {
+		if message, ok := takeString(fields, containerMessageKeys); ok {
+			msg = message
+			if value, ok := takeString(fields, containerLevelKeys); ok {
+				level = containerLevel(value, config.Level)
+			} else {
+				level = config.Level
+			}
+			for _, key := range containerTimeKeys {
+				delete(fields, key)
+			}
+		} else {
+			fields = nil
+		}
+	}
+
+	entry := log.Log(level, msg)
+	if config.Container != "" {
+		entry = entry.Data("container", config.Container)
+		delete(fields, "container")
+	}
+	if stream != "" {
+		entry = entry.Data("stream", stream)
+		delete(fields, "stream")
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		entry = entry.Data(key, fields[key])
+	}
+	entry.Send()
+}
+
+// takeString removes the first of keys holding a string from fields and
+// returns its value.
+func takeString(fields map[string]any, keys []string) (string, bool) {
+	for _, key := range keys {
+		if value, ok := fields[key].(string); ok {
+			delete(fields, key)
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// containerLevel converts the level of a JSON line, falling back to level
+// for unknown names. Fatal levels are logged at error level, since the
+// process logging them is not this one.
+func containerLevel(value, level string) string {
+	switch strings.ToLower(value) {
+	case "trace", LevelDebug:
+		return LevelDebug
+	case LevelInfo, "notice":
+		return LevelInfo
+	case LevelWarn, "warning":
+		return LevelWarn
+	case LevelError, "err", "critical", "crit", "alert", "emergency", "fatal", "panic", "dpanic":
+		return LevelError
+	default:
+		return level
+	}
+}
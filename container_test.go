@@ -0,0 +1,117 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestReadContainerLogs(t *testing.T) {
+	log, capture := NewTestLogger()
+	output := strings.Join([]string{
+		"PostgreSQL init process complete; ready for start up.",
+		"",
+		`{"time":"2024-05-01T10:00:00Z","level":"warning","msg":"slow query","duration_ms":1200,"container":"other"}`,
+		`{"severity":"FATAL","message":"could not bind socket"}`,
+		`{"msg":"listening","level":"verbose","port":5432}`,
+		`{"status":"ok"}`,
+		"LOG:  database system is ready to accept connections\r",
+	}, "\n")
+
+	if err := ReadContainerLogs(log, strings.NewReader(output), ContainerLogConfig{Container: "postgres"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct{ level, message string }{
+		{LevelInfo, "PostgreSQL init process complete; ready for start up."},
+		{LevelWarn, "slow query"},
+		{LevelError, "could not bind socket"},
+		{LevelInfo, "listening"},
+		{LevelInfo, `{"status":"ok"}`},
+		{LevelInfo, "LOG:  database system is ready to accept connections"},
+	}
+	entries := capture.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, want := range expected {
+		if entries[i].Level != want.level || entries[i].Message != want.message || entries[i].Fields["container"] != "postgres" {
+			t.Errorf("Expected %s %q from postgres, got %+v", want.level, want.message, entries[i])
+		}
+		if _, ok := entries[i].Fields["stream"]; ok {
+			t.Errorf("Expected no stream without multiplexing, got %v", entries[i].Fields)
+		}
+	}
+	if fields := entries[1].Fields; fields["duration_ms"] != float64(1200) || fields["time"] != nil || fields["level"] != nil {
+		t.Errorf("Expected the JSON fields without time and level, got %v", fields)
+	}
+	if _, ok := entries[4].Fields["status"]; ok {
+		t.Errorf("Expected JSON lines without a message to be logged as they are, got %v", entries[4].Fields)
+	}
+}
+
+// frame returns a frame of a multiplexed Docker stream.
+func frame(stream byte, payload string) []byte {
+	header := make([]byte, 8)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+func TestReadContainerLogsMultiplexed(t *testing.T) {
+	log, capture := NewTestLogger()
+	var stream bytes.Buffer
+	stream.Write(frame(1, "server started\nhandling req"))
+	stream.Write(frame(2, "deprecated flag --foo\n"))
+	stream.Write(frame(1, "uest 1\n"))
+	stream.Write(frame(2, `{"level":"info","msg":"config loaded"}`+"\n"))
+	stream.Write(frame(1, "shutting down"))
+
+	err := ReadContainerLogs(log, &stream, ContainerLogConfig{Container: "api", Multiplexed: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []struct{ level, message, stream string }{
+		{LevelInfo, "server started", "stdout"},
+		{LevelWarn, "deprecated flag --foo", "stderr"},
+		{LevelInfo, "handling request 1", "stdout"},
+		{LevelInfo, "config loaded", "stderr"},
+		{LevelInfo, "shutting down", "stdout"},
+	}
+	entries := capture.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, want := range expected {
+		if entries[i].Level != want.level || entries[i].Message != want.message ||
+			entries[i].Fields["stream"] != want.stream || entries[i].Fields["container"] != "api" {
+			t.Errorf("Expected %s %q on %s, got %+v", want.level, want.message, want.stream, entries[i])
+		}
+	}
+}
+
+func TestReadContainerLogsError(t *testing.T) {
+	log, capture := NewTestLogger()
+	readErr := errors.New("connection closed")
+
+	r := io.MultiReader(strings.NewReader("first line\n"), iotest.ErrReader(readErr))
+	if err := ReadContainerLogs(log, r, ContainerLogConfig{}); !errors.Is(err, readErr) {
+		t.Errorf("Expected the read error, got %v", err)
+	}
+	if entries := capture.Entries(); len(entries) != 1 || entries[0].Message != "first line" {
+		t.Errorf("Expected the line read before the error, got %+v", entries)
+	}
+
+	truncated := frame(1, "partial frame\n")[:10]
+	if err := ReadContainerLogs(log, bytes.NewReader(truncated), ContainerLogConfig{Multiplexed: true}); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected a truncated frame error, got %v", err)
+	}
+	if err := ReadContainerLogs(log, bytes.NewReader(frame(7, "x\n")), ContainerLogConfig{Multiplexed: true}); err == nil {
+		t.Error("Expected an error for an unknown stream")
+	}
+}
@@ -0,0 +1,205 @@
+package gologger
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxStructDepth bounds the nesting followed by DataStruct, so cyclic
+// pointers cannot recurse forever.
+const maxStructDepth = 16
+
+// structField describes an exported field of a struct type logged by
+// DataStruct.
+type structField struct {
+	index     []int  // Index sequence for reflect.Value.FieldByIndexErr, through embedded structs
+	key       string // Key of the field: its json name or its Go name
+	omitEmpty bool   // Whether zero values are left out (json ",omitempty")
+	mask      bool   // Whether the value is replaced with Redacted (log:"mask")
+}
+
+// structFieldsCache holds the []structField of the struct types logged so
+// far.
+var structFieldsCache sync.Map
+
+// DataStruct adds value, a struct or pointer to a struct, to the log data
+// under key as an object with a key per exported field, for domain types
+// that declare how they are logged with struct tags:
+//
+//	type User struct {
+//		ID       int    `json:"id"`
+//		Email    string `json:"email" log:"mask"`
+//		Password string `json:"-"`
+//		Token    string `log:"-"`
+//	}
+//
+//	log.Info("User created").DataStruct("user", user).Send()
+//	// "user":{"id":42,"email":"[REDACTED]"}
+//
+// Fields tagged log:"-" are left out and those tagged log:"mask" are logged
+// as Redacted. Keys and the "-" and "omitempty" options come from json
+// tags, as in encoding/json, and the fields of embedded structs are
+// promoted. Nested structs, and those in slices, arrays and maps, are
+// logged the same way. Types implementing json.Marshaler or
+// encoding.TextMarshaler, such as time.Time, are logged as they are. Values
+// nested deeper than 16 levels, such as those of cyclic pointers, are logged
+// as null.
+func (l Logger) DataStruct(key string, value any) Logger {
+	return l.Data(key, structValue(reflect.ValueOf(value), 0))
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	timeType          = reflect.TypeOf(time.Time{})
+)
+
+// nestedValue returns value converted as by DataStruct if it is a map,
+// slice, array or struct, or a pointer to one, so the keys nested in it can
+// be filtered and redacted without changing the caller's value. It reports
+// whether value was converted.
+func nestedValue(value any) (any, bool) {
+	switch value.(type) {
+	case nil, string, bool, int, int64, float64, []byte, error, time.Time, time.Duration:
+		return value, false
+	}
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return structValue(reflect.ValueOf(value), 0), true
+	default:
+		return value, false
+	}
+}
+
+// structValue returns v with its structs converted to maps honoring their
+// log and json tags.
+func structValue(v reflect.Value, depth int) any {
+	if !v.IsValid() || depth > maxStructDepth {
+		return nil
+	}
+	t := v.Type()
+	if t == timeType || t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) {
+		if (t.Kind() == reflect.Pointer || t.Kind() == reflect.Interface) && v.IsNil() {
+			return nil
+		}
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return structValue(v.Elem(), depth+1)
+	case reflect.Struct:
+		fields := make(map[string]any)
+		for _, f := range typeStructFields(t) {
+			field, err := v.FieldByIndexErr(f.index)
+			if err != nil || f.omitEmpty && field.IsZero() {
+				continue
+			}
+			if f.mask {
+				fields[f.key] = Redacted
+			} else {
+				fields[f.key] = structValue(field, depth+1)
+			}
+		}
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && (v.IsNil() || t.Elem().Kind() == reflect.Uint8) {
+			return v.Interface()
+		}
+		values := make([]any, v.Len())
+		for i := range values {
+			values[i] = structValue(v.Index(i), depth+1)
+		}
+		return values
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String || v.IsNil() {
+			return v.Interface()
+		}
+		values := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			values[iter.Key().String()] = structValue(iter.Value(), depth+1)
+		}
+		return values
+	default:
+		if v.CanInterface() {
+			return v.Interface()
+		}
+		return nil
+	}
+}
+
+// typeStructFields returns the fields of the struct type t logged by
+// DataStruct.
+func typeStructFields(t reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	fields := collectStructFields(t, nil, make(map[reflect.Type]bool))
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+// collectStructFields returns the fields of t, with index prefixed by
+// index. Fields of embedded structs without a json name are promoted
+// unless t has a field with the same key.
+func collectStructFields(t reflect.Type, index []int, visited map[reflect.Type]bool) []structField {
+	visited[t] = true
+	var direct, promoted []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		logTag := f.Tag.Get("log")
+		jsonTag := f.Tag.Get("json")
+		if logTag == "-" || jsonTag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(jsonTag, ",")
+		fieldIndex := append(append([]int(nil), index...), i)
+
+		embedded := f.Type
+		if embedded.Kind() == reflect.Pointer {
+			embedded = embedded.Elem()
+		}
+		if f.Anonymous && name == "" && embedded.Kind() == reflect.Struct {
+			if !visited[embedded] {
+				promoted = append(promoted, collectStructFields(embedded, fieldIndex, visited)...)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		direct = append(direct, structField{
+			index:     fieldIndex,
+			key:       name,
+			omitEmpty: strings.Contains(","+opts+",", ",omitempty,"),
+			mask:      logTag == "mask",
+		})
+	}
+	delete(visited, t)
+
+	keys := make(map[string]bool, len(direct))
+	for _, f := range direct {
+		keys[f.key] = true
+	}
+	for _, f := range promoted {
+		if !keys[f.key] {
+			keys[f.key] = true
+			direct = append(direct, f)
+		}
+	}
+	return direct
+}
@@ -0,0 +1,124 @@
+package gologger
+
+import (
+	"testing"
+	"time"
+)
+
+type testAudit struct {
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+	Note      string    `json:"note,omitempty"`
+}
+
+type testAddress struct {
+	City   string `json:"city"`
+	Street string `json:"street" log:"mask"`
+}
+
+type testUser struct {
+	testAudit
+	*Base
+	ID        int               `json:"id"`
+	Email     string            `json:"email" log:"mask"`
+	Phone     string            `json:"phone,omitempty" log:"mask"`
+	Password  string            `json:"-"`
+	Token     string            `log:"-"`
+	Name      string            // Go name as key
+	Addresses []testAddress     `json:"addresses"`
+	Labels    map[string]string `json:"labels"`
+	Manager   *testUser         `json:"manager,omitempty"`
+	private   string
+}
+
+// Base is embedded in testUser as an exported embedded struct.
+type Base struct {
+	Tenant string `json:"tenant"`
+	ID     string `json:"id"`
+}
+
+func TestDataStruct(t *testing.T) {
+	log, capture := NewTestLogger()
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	user := testUser{
+		testAudit: testAudit{CreatedBy: "admin", CreatedAt: created},
+		Base:      &Base{Tenant: "acme", ID: "shadowed"},
+		ID:        42,
+		Email:     "alice@example.com",
+		Password:  "hunter2",
+		Token:     "abc123",
+		Name:      "Alice",
+		Addresses: []testAddress{{City: "Lyon", Street: "1 rue de la Paix"}},
+		Labels:    map[string]string{"plan": "pro"},
+		Manager:   &testUser{ID: 7, Email: "bob@example.com"},
+		private:   "hidden",
+	}
+
+	log.Info("user created").DataStruct("user", &user).Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields, ok := entries[0].Fields["user"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected the user as an object, got %T", entries[0].Fields["user"])
+	}
+	if fields["id"] != 42 {
+		t.Errorf("Expected the direct id to take precedence over the promoted one, got %v", fields["id"])
+	}
+	if fields["email"] != Redacted || fields["Name"] != "Alice" || fields["tenant"] != "acme" || fields["created_by"] != "admin" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+	if at, ok := fields["created_at"].(time.Time); !ok || !at.Equal(created) {
+		t.Errorf("Expected the time as is, got %v", fields["created_at"])
+	}
+	for _, key := range []string{"Password", "password", "Token", "private", "phone", "note"} {
+		if _, ok := fields[key]; ok {
+			t.Errorf("Expected no %q field, got %v", key, fields)
+		}
+	}
+	addresses, _ := fields["addresses"].([]any)
+	if len(addresses) != 1 {
+		t.Fatalf("Expected one address, got %v", fields["addresses"])
+	}
+	if address, _ := addresses[0].(map[string]any); address["city"] != "Lyon" || address["street"] != Redacted {
+		t.Errorf("Expected nested structs in slices to honor tags, got %v", addresses[0])
+	}
+	if labels, _ := fields["labels"].(map[string]any); labels["plan"] != "pro" {
+		t.Errorf("Expected the labels, got %v", fields["labels"])
+	}
+	if manager, _ := fields["manager"].(map[string]any); manager["email"] != Redacted {
+		t.Errorf("Expected nested structs to honor tags, got %v", fields["manager"])
+	}
+}
+
+func TestDataStructValues(t *testing.T) {
+	type node struct {
+		Name string `json:"name"`
+		Next *node  `json:"next"`
+	}
+	cyclic := &node{Name: "a"}
+	cyclic.Next = cyclic
+
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"nil", nil},
+		{"nil pointer", (*testUser)(nil)},
+		{"scalar", 42},
+		{"bytes", []byte("raw")},
+		{"cyclic", cyclic},
+		{"embedded pointer", struct{ *testAddress }{&testAddress{City: "Lyon"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log, capture := NewTestLogger()
+			log.Info("value").DataStruct("value", tt.value).Send()
+			if capture.Len() != 1 {
+				t.Fatalf("Expected 1 entry, got %d", capture.Len())
+			}
+		})
+	}
+}
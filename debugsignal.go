@@ -0,0 +1,92 @@
+package gologger
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// notifyDebugToggle switches level to debug when the process receives
+// SIGUSR1 and restores the previous level on SIGUSR2, or after timeout if it
+// is positive, until the returned stop function is called. It returns nil on
+// systems without these signals.
+func notifyDebugToggle(level zap.AtomicLevel, timeout time.Duration) func() {
+	if debugSignal == nil {
+		return nil
+	}
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, debugSignal, restoreSignal)
+	stop := toggleDebug(level, timeout, signals)
+	return func() {
+		signal.Stop(signals)
+		stop()
+	}
+}
+
+// toggleDebug applies the signals received on signals to level, see
+// notifyDebugToggle.
+func toggleDebug(level zap.AtomicLevel, timeout time.Duration, signals <-chan os.Signal) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		var (
+			previous zapcore.Level
+			toggled  bool
+			expired  <-chan time.Time
+			timer    *time.Timer
+		)
+		restore := func() {
+			if toggled {
+				level.SetLevel(previous)
+				toggled = false
+			}
+			if timer != nil {
+				timer.Stop()
+				timer, expired = nil, nil
+			}
+		}
+		for {
+			select {
+			case sig := <-signals:
+				if sig != debugSignal {
+					restore()
+					continue
+				}
+				if !toggled {
+					previous = level.Level()
+					toggled = true
+					level.SetLevel(zapcore.DebugLevel)
+				}
+				// Another SIGUSR1 extends the debug period.
+				if timeout > 0 {
+					if timer != nil {
+						timer.Stop()
+					}
+					timer = time.NewTimer(timeout)
+					expired = timer.C
+				}
+			case <-expired:
+				timer, expired = nil, nil
+				restore()
+			case <-done:
+				if timer != nil {
+					timer.Stop()
+				}
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package gologger
+
+import "os"
+
+// Signals switching to debug level and back, see LoggerConfig.DebugOnSignal.
+// SIGUSR1 and SIGUSR2 do not exist on this system.
+var debugSignal, restoreSignal os.Signal
@@ -0,0 +1,73 @@
+package gologger
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func waitForLevel(t *testing.T, level zap.AtomicLevel, expected zapcore.Level) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for level.Level() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected level %s, got %s", expected, level.Level())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestToggleDebug(t *testing.T) {
+	if debugSignal == nil {
+		t.Skip("SIGUSR1 and SIGUSR2 are not supported on this system")
+	}
+	level := zap.NewAtomicLevelAt(zapcore.WarnLevel)
+	signals := make(chan os.Signal)
+	stop := toggleDebug(level, 0, signals)
+	defer stop()
+
+	signals <- debugSignal
+	waitForLevel(t, level, zapcore.DebugLevel)
+	// A second SIGUSR1 keeps the level to restore.
+	signals <- debugSignal
+	signals <- restoreSignal
+	waitForLevel(t, level, zapcore.WarnLevel)
+	// SIGUSR2 without SIGUSR1 changes nothing.
+	signals <- restoreSignal
+	waitForLevel(t, level, zapcore.WarnLevel)
+}
+
+func TestToggleDebugTimeout(t *testing.T) {
+	if debugSignal == nil {
+		t.Skip("SIGUSR1 and SIGUSR2 are not supported on this system")
+	}
+	level := zap.NewAtomicLevelAt(zapcore.InfoLevel)
+	signals := make(chan os.Signal)
+	stop := toggleDebug(level, 50*time.Millisecond, signals)
+	defer stop()
+
+	signals <- debugSignal
+	waitForLevel(t, level, zapcore.DebugLevel)
+	waitForLevel(t, level, zapcore.InfoLevel)
+}
+
+func TestDebugOnSignal(t *testing.T) {
+	if debugSignal == nil {
+		t.Skip("SIGUSR1 and SIGUSR2 are not supported on this system")
+	}
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelError, DebugOnSignal: true})
+	defer log.Close()
+
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(debugSignal); err != nil {
+		t.Skipf("Cannot send SIGUSR1: %v", err)
+	}
+	waitForLevel(t, log.minLevel, zapcore.DebugLevel)
+	if err := process.Signal(restoreSignal); err != nil {
+		t.Fatalf("Cannot send SIGUSR2: %v", err)
+	}
+	waitForLevel(t, log.minLevel, zapcore.ErrorLevel)
+}
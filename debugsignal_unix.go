@@ -0,0 +1,14 @@
+//go:build unix
+
+package gologger
+
+import (
+	"os"
+	"syscall"
+)
+
+// Signals switching to debug level and back, see LoggerConfig.DebugOnSignal.
+var (
+	debugSignal   os.Signal = syscall.SIGUSR1
+	restoreSignal os.Signal = syscall.SIGUSR2
+)
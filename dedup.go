@@ -0,0 +1,157 @@
+package gologger
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// RepeatCountField is the field of the entry summarizing the repeats of an
+// entry collapsed by LoggerConfig.Dedup.
+const RepeatCountField = "repeat_count"
+
+// DedupConfig collapses identical consecutive entries, like the "last
+// message repeated N times" lines of syslog: an entry is logged, its
+// repeats within Window are dropped, then one more copy of the entry is
+// logged with their number as "repeat_count".
+type DedupConfig struct {
+	Window time.Duration // Period after the first entry in which identical entries are collapsed (default: 1s)
+}
+
+// dedupCore collapses identical consecutive entries written to core.
+type dedupCore struct {
+	core   zapcore.Core
+	fields []zapcore.Field // Fields added with With, part of the identity of entries
+	state  *dedupState
+}
+
+// dedupState is the run of identical entries being collapsed, shared by
+// the cores returned by With.
+type dedupState struct {
+	mu     sync.Mutex
+	window time.Duration
+	run    *dedupRun
+}
+
+// dedupRun is an entry and its repeats.
+type dedupRun struct {
+	key     uint64
+	start   time.Time
+	core    zapcore.Core    // Core the entry was written to, with the fields of With
+	entry   zapcore.Entry   // Last repeat
+	fields  []zapcore.Field // Fields of the last repeat
+	repeats int
+	timer   *time.Timer
+}
+
+// newDedupCore wraps core so identical consecutive entries are collapsed as
+// set by cfg.
+func newDedupCore(core zapcore.Core, cfg DedupConfig) zapcore.Core {
+	if cfg.Window <= 0 {
+		cfg.Window = time.Second
+	}
+	return &dedupCore{core: core, state: &dedupState{window: cfg.Window}}
+}
+
+func (c *dedupCore) Enabled(level zapcore.Level) bool {
+	return c.core.Enabled(level)
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{
+		core:   c.core.With(fields),
+		fields: append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...),
+		state:  c.state,
+	}
+}
+
+func (c *dedupCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// Fatal and panic entries end the program; they are never collapsed.
+	if entry.Level > zapcore.ErrorLevel {
+		return c.core.Check(entry, checked)
+	}
+	if c.core.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *dedupCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	key := c.key(entry, fields)
+	s := c.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if run := s.run; run != nil && run.key == key && entry.Time.Sub(run.start) < s.window {
+		run.entry = entry
+		run.fields = append(run.fields[:0], fields...)
+		run.repeats++
+		if run.timer == nil {
+			run.timer = time.AfterFunc(s.window-entry.Time.Sub(run.start), func() { s.expire(run) })
+		}
+		return nil
+	}
+
+	s.flush()
+	s.run = &dedupRun{key: key, start: entry.Time, core: c.core}
+	writeEntry(c.core, entry, fields)
+	return nil
+}
+
+func (c *dedupCore) Sync() error {
+	c.state.mu.Lock()
+	c.state.flush()
+	c.state.mu.Unlock()
+	return c.core.Sync()
+}
+
+// key returns the hash identifying entries with the same level, logger,
+// message and fields.
+func (c *dedupCore) key(entry zapcore.Entry, fields []zapcore.Field) uint64 {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range c.fields {
+		field.AddTo(enc)
+	}
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+	h := fnv.New64a()
+	// fmt prints maps sorted by key.
+	fmt.Fprintf(h, "%d\x00%s\x00%s\x00%v", entry.Level, entry.LoggerName, entry.Message, enc.Fields)
+	return h.Sum64()
+}
+
+// expire ends run when its window elapses, logging its summary.
+func (s *dedupState) expire(run *dedupRun) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.run == run {
+		s.flush()
+	}
+}
+
+// flush ends the current run, logging the last repeat with the number of
+// repeats if there were any. s.mu must be held.
+func (s *dedupState) flush() {
+	run := s.run
+	s.run = nil
+	if run == nil || run.repeats == 0 {
+		return
+	}
+	if run.timer != nil {
+		run.timer.Stop()
+	}
+	writeEntry(run.core, run.entry, append(run.fields, zap.Int(RepeatCountField, run.repeats)))
+}
+
+// writeEntry writes an entry to the cores of core enabled for its level.
+// Write errors are reported by the outputs and sinks themselves.
+func writeEntry(core zapcore.Core, entry zapcore.Entry, fields []zapcore.Field) {
+	if checked := core.Check(entry, nil); checked != nil {
+		checked.Write(fields...)
+	}
+}
@@ -0,0 +1,82 @@
+package gologger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDedup(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		Sinks:      []Sink{capture},
+		Dedup:      &DedupConfig{Window: time.Minute},
+	})
+	defer log.Close()
+
+	for i := 0; i < 5; i++ {
+		log.Warn("connection refused").Data("host", "db-1").Send()
+	}
+	log.Warn("connection refused").Data("host", "db-2").Send()
+	log.Warn("connection refused").Data("host", "db-2").Send()
+	log.Named("worker").Warn("connection refused").Data("host", "db-2").Send()
+	log.Info("recovered").Send()
+
+	entries := capture.Entries()
+	expected := []struct {
+		host    any
+		repeats any
+	}{
+		{"db-1", nil},
+		{"db-1", int64(4)},
+		{"db-2", nil},
+		{"db-2", int64(1)},
+		{"db-2", nil},
+		{nil, nil},
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, want := range expected {
+		if entries[i].Fields["host"] != want.host || entries[i].Fields[RepeatCountField] != want.repeats {
+			t.Errorf("Entry %d: expected host %v repeated %v, got %+v", i, want.host, want.repeats, entries[i])
+		}
+	}
+	if entries[4].Logger != "worker" || entries[5].Message != "recovered" {
+		t.Errorf("Expected entries of other loggers and messages to end the run, got %+v", entries[4:])
+	}
+}
+
+func TestDedupWindow(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		Sinks:      []Sink{capture},
+		Dedup:      &DedupConfig{Window: 50 * time.Millisecond},
+	})
+	defer log.Close()
+
+	log.Error("disk full").Send()
+	log.Error("disk full").Send()
+	log.Error("disk full").Send()
+	if n := capture.Len(); n != 1 {
+		t.Fatalf("Expected the repeats to be held, got %d entries", n)
+	}
+
+	// The summary is logged when the window elapses, without another entry.
+	time.Sleep(100 * time.Millisecond)
+	entries := capture.Entries()
+	if len(entries) != 2 || entries[1].Fields[RepeatCountField] != int64(2) {
+		t.Fatalf("Expected the summary after the window, got %+v", entries)
+	}
+
+	log.Error("disk full").Send()
+	log.Error("disk full").Send()
+	if err := log.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	entries = capture.Entries()
+	if len(entries) != 4 || entries[2].Fields[RepeatCountField] != nil || entries[3].Fields[RepeatCountField] != int64(1) {
+		t.Errorf("Expected a new run after the window, summarized by Sync, got %+v", entries)
+	}
+}
@@ -0,0 +1,64 @@
+// Package echolog recovers and logs the panics of Echo handlers with
+// gologger:
+//
+//	e := echo.New()
+//	e.Use(middleware.RequestID())
+//	e.Use(echolog.Recover(log))
+//
+// It is a separate module, so applications not using Echo do not depend on
+// it.
+package echolog
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/labstack/echo/v4"
+	"go.risoftinc.com/gologger"
+)
+
+// Recover returns Echo middleware that recovers the panics of later
+// handlers and logs them with log, replacing Echo's middleware.Recover,
+// which logs stacks as unstructured text.
+//
+// Panics are logged as "http request panicked" at error level with the
+// panic value, its stack, the method and path. The request ID is taken from
+// the request context, or from the X-Request-ID response header set by
+// Echo's middleware.RequestID. Panics are returned as errors, so Echo's
+// error handler answers them with a 500 response. Panics with
+// http.ErrAbortHandler, which abort a response on purpose, are passed on
+// without logging.
+func Recover(log gologger.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				if r == http.ErrAbortHandler {
+					panic(r)
+				}
+				req := c.Request()
+				ctx := req.Context()
+				if gologger.GetRequestID(ctx) == "" {
+					if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+						ctx = gologger.WithRequestID(ctx, gologger.RequestIDFromHeader(id))
+					}
+				}
+				log.WithContext(ctx).Error("http request panicked").
+					Data("panic", fmt.Sprint(r)).
+					Data("stack", string(debug.Stack())).
+					Data("method", req.Method).
+					Data("path", req.URL.Path).
+					Send()
+				var ok bool
+				if err, ok = r.(error); !ok {
+					err = fmt.Errorf("%v", r)
+				}
+			}()
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package echolog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.risoftinc.com/gologger"
+)
+
+func TestRecover(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	e := echo.New()
+	e.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{Generator: func() string { return "req-42" }}))
+	e.Use(Recover(log))
+	e.GET("/orders/:id", func(c echo.Context) error {
+		panic("nil map")
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected a 500 response, got %d", rec.Code)
+	}
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Message != "http request panicked" || entries[0].Level != gologger.LevelError {
+		t.Fatalf("Expected a panic entry, got %+v", entries)
+	}
+	fields := entries[0].Fields
+	if fields["panic"] != "nil map" || fields["method"] != "GET" || fields["path"] != "/orders/7" || fields["request-id"] != "req-42" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+	if stack, _ := fields["stack"].(string); !strings.Contains(stack, "echolog_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	e := echo.New()
+	e.Use(Recover(log))
+	e.GET("/", func(c echo.Context) error {
+		panic(http.ErrAbortHandler)
+	})
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be passed on, got %v", r)
+		}
+		if capture.Len() != 0 {
+			t.Errorf("Expected aborts not to be logged, got %+v", capture.Entries())
+		}
+	}()
+	e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
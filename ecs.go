@@ -0,0 +1,108 @@
+package gologger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const ecsVersion = "1.6.0"
+
+// ecsEncoder writes JSON following the Elastic Common Schema, so entries can
+// be indexed by Elasticsearch without a mapping pipeline. Standard keys use
+// their ECS names and well-known fields are renamed:
+//
+//	timestamp  -> @timestamp        error      -> error.message
+//	level      -> log.level         stacktrace -> error.stack_trace
+//	msg        -> message           trace_id   -> trace.id
+//	caller     -> log.origin.file.* span_id    -> span.id
+//	request ID -> http.request.id   service    -> service.name
+//	version    -> service.version   env        -> service.environment
+//	hostname   -> host.hostname     pid        -> process.pid
+//
+// A configured service name takes precedence over a service field.
+type ecsEncoder struct {
+	fieldRecorder
+	json         zapcore.Encoder
+	serviceName  string
+	requestIDKey string
+}
+
+func newECSEncoder(serviceName, requestIDKey string) *ecsEncoder {
+	config := zapcore.EncoderConfig{
+		TimeKey:        "@timestamp",
+		LevelKey:       "log.level",
+		NameKey:        "log.logger",
+		FunctionKey:    "log.origin.function",
+		MessageKey:     "message",
+		StacktraceKey:  "error.stack_trace",
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    zapcore.LowercaseLevelEncoder,
+		EncodeTime:     zapcore.TimeEncoderOfLayout("2006-01-02T15:04:05.000Z07:00"),
+		EncodeDuration: zapcore.NanosDurationEncoder,
+	}
+	return &ecsEncoder{
+		json:         zapcore.NewJSONEncoder(config),
+		serviceName:  serviceName,
+		requestIDKey: requestIDKey,
+	}
+}
+
+func (e *ecsEncoder) Clone() zapcore.Encoder {
+	return &ecsEncoder{
+		fieldRecorder: e.clone(),
+		json:          e.json,
+		serviceName:   e.serviceName,
+		requestIDKey:  e.requestIDKey,
+	}
+}
+
+func (e *ecsEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	all := e.with(fields)
+	mapped := make([]zapcore.Field, 0, len(all)+5)
+	mapped = append(mapped, zap.String("ecs.version", ecsVersion))
+	if e.serviceName != "" {
+		mapped = append(mapped, zap.String("service.name", e.serviceName))
+	}
+	if ent.Caller.Defined {
+		file, line := splitCaller(ent.Caller.TrimmedPath())
+		mapped = append(mapped, zap.String("log.origin.file.name", file))
+		if line > 0 {
+			mapped = append(mapped, zap.Int("log.origin.file.line", line))
+		}
+	}
+	for _, field := range all {
+		if field.Key == ServiceField && e.serviceName != "" {
+			continue
+		}
+		field.Key = e.fieldKey(field.Key)
+		mapped = append(mapped, field)
+	}
+	return e.json.EncodeEntry(ent, mapped)
+}
+
+// fieldKey returns the ECS name of a field.
+func (e *ecsEncoder) fieldKey(key string) string {
+	switch key {
+	case "error":
+		return "error.message"
+	case TraceIDField:
+		return "trace.id"
+	case SpanIDField:
+		return "span.id"
+	case ServiceField:
+		return "service.name"
+	case VersionField:
+		return "service.version"
+	case EnvField:
+		return "service.environment"
+	case HostnameField:
+		return "host.hostname"
+	case PIDField:
+		return "process.pid"
+	case e.requestIDKey:
+		return "http.request.id"
+	default:
+		return key
+	}
+}
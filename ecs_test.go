@@ -0,0 +1,151 @@
+package gologger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestECSEncoder(t *testing.T) {
+	ent := zapcore.Entry{
+		Level:   zapcore.ErrorLevel,
+		Time:    time.Date(2024, 3, 1, 10, 30, 0, 123e6, time.UTC),
+		Message: "payment failed",
+		Caller:  zapcore.NewEntryCaller(0, "/src/app/billing/charge.go", 88, true),
+		Stack:   "main.main\n\t/src/app/main.go:10",
+	}
+	enc := newECSEncoder("billing-api", "request-id")
+	buf, err := enc.EncodeEntry(ent, []zapcore.Field{
+		zap.String("error", "card declined"),
+		zap.String(TraceIDField, "4bf92f3577b34da6a3ce929d0e0e4736"),
+		zap.String(SpanIDField, "00f067aa0ba902b7"),
+		zap.String("request-id", "req-1"),
+		zap.Int("amount", 1250),
+	})
+	if err != nil {
+		t.Fatalf("Unexpected encode error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", buf.String(), err)
+	}
+	expected := map[string]any{
+		"@timestamp":           "2024-03-01T10:30:00.123Z",
+		"log.level":            "error",
+		"message":              "payment failed",
+		"ecs.version":          ecsVersion,
+		"service.name":         "billing-api",
+		"log.origin.file.name": "billing/charge.go",
+		"log.origin.file.line": float64(88),
+		"error.message":        "card declined",
+		"error.stack_trace":    "main.main\n\t/src/app/main.go:10",
+		"trace.id":             "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span.id":              "00f067aa0ba902b7",
+		"http.request.id":      "req-1",
+		"amount":               float64(1250),
+	}
+	for key, want := range expected {
+		if doc[key] != want {
+			t.Errorf("Expected %s=%v, got %v", key, want, doc[key])
+		}
+	}
+	for _, key := range []string{"timestamp", "level", "msg", "caller", "error", TraceIDField} {
+		if _, ok := doc[key]; ok {
+			t.Errorf("Expected non-ECS key %s to be absent", key)
+		}
+	}
+}
+
+func TestECSEncoderContextFields(t *testing.T) {
+	var out strings.Builder
+	core := zapcore.NewCore(newECSEncoder("", "request-id"), zapcore.AddSync(&out), zapcore.DebugLevel)
+	zap.New(core).With(zap.String("error", "from context")).Info("hello")
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(out.String()), &doc); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if doc["error.message"] != "from context" {
+		t.Errorf("Expected context fields to be mapped, got %v", doc)
+	}
+	if _, ok := doc["service.name"]; ok {
+		t.Error("Expected no service.name without a service name")
+	}
+}
+
+func TestECSEncoderServiceFields(t *testing.T) {
+	fields := []zapcore.Field{
+		zap.String(ServiceField, "from-fields"),
+		zap.String(VersionField, "1.4.2"),
+		zap.String(EnvField, "prod"),
+		zap.String(HostnameField, "web-1"),
+		zap.Int(PIDField, 4242),
+	}
+	for _, serviceName := range []string{"", "configured"} {
+		buf, err := newECSEncoder(serviceName, "request-id").EncodeEntry(zapcore.Entry{Message: "hello"}, fields)
+		if err != nil {
+			t.Fatalf("Unexpected encode error: %v", err)
+		}
+		if strings.Count(buf.String(), `"service.name"`) != 1 {
+			t.Errorf("Expected a single service.name, got %s", buf.String())
+		}
+
+		var doc map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("Invalid JSON: %v", err)
+		}
+		wantService := serviceName
+		if wantService == "" {
+			wantService = "from-fields"
+		}
+		expected := map[string]any{
+			"service.name":        wantService,
+			"service.version":     "1.4.2",
+			"service.environment": "prod",
+			"host.hostname":       "web-1",
+			"process.pid":         float64(4242),
+		}
+		for key, want := range expected {
+			if doc[key] != want {
+				t.Errorf("Expected %s=%v, got %v", key, want, doc[key])
+			}
+		}
+	}
+}
+
+func TestECSEncodingConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:  OutputFile,
+		LogDir:      tempDir,
+		Encoding:    EncodingECS,
+		ServiceName: "orders",
+		ShowCaller:  true,
+	})
+	ctx := WithRequestID(context.Background(), "req-9")
+	log.WithContext(ctx).Error("order rejected").ErrorData(errors.New("out of stock")).Send()
+	log.Close()
+
+	data, err := os.ReadFile(currentLogFile(tempDir))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("Invalid JSON %q: %v", data, err)
+	}
+	if doc["service.name"] != "orders" || doc["http.request.id"] != "req-9" || doc["error.message"] != "out of stock" {
+		t.Errorf("Unexpected ECS document %v", doc)
+	}
+	if file, _ := doc["log.origin.file.name"].(string); !strings.HasSuffix(file, "ecs_test.go") {
+		t.Errorf("Expected caller file name, got %v", doc["log.origin.file.name"])
+	}
+}
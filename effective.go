@@ -0,0 +1,289 @@
+package gologger
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+	"unicode"
+)
+
+// Config returns the effective configuration of the logger: the options it
+// was created with, with the defaults applied to unset or invalid ones, the
+// current level and the sinks currently attached. Changes to the returned
+// configuration do not affect the logger.
+func (l Logger) Config() LoggerConfig {
+	if l.outputs == nil {
+		return LoggerConfig{}
+	}
+	config := *l.outputs.load().config
+	config.LogLevel = l.GetLevel()
+	config.Sinks = nil
+	for _, attached := range l.sinks.load() {
+		if attached.sink != nil {
+			config.Sinks = append(config.Sinks, attached.sink)
+		}
+	}
+	return config
+}
+
+// DumpConfig returns the effective configuration of Config as a map with
+// the snake_case keys of configuration files, e.g. to record it in a startup
+// log entry or to save it with yaml.Marshal. Durations are strings, sinks are
+// listed by type, options set to functions are reported as "func", and byte
+// slices such as encryption keys are replaced with "[REDACTED]". Unset
+// options are left out.
+func (l Logger) DumpConfig() map[string]any {
+	config := l.Config()
+	sinks := config.Sinks
+	config.Sinks = nil
+	dump, _ := dumpConfigValue(reflect.ValueOf(config)).(map[string]any)
+	if len(sinks) > 0 {
+		types := make([]any, len(sinks))
+		for i, sink := range sinks {
+			types[i] = fmt.Sprintf("%T", sink)
+		}
+		dump["sinks"] = types
+	}
+	return dump
+}
+
+// effectiveConfig returns the configuration reported by Config for outputs
+// built from config and writing to files.
+func effectiveConfig(config LoggerConfig, files []*rotatingFile) *LoggerConfig {
+	resolved := resolveConfig(config)
+	if len(files) > 0 {
+		resolved.LogDir = files[0].dir
+	}
+	return &resolved
+}
+
+// resolveConfig applies the defaults used when building the outputs to the
+// unset or invalid options of config.
+func resolveConfig(config LoggerConfig) LoggerConfig {
+	if !slices.Contains(outputModes, config.OutputMode) {
+		config.OutputMode = OutputTerminal
+	}
+	config.LogLevel = getLogLevel(componentDefaultLevel(config)).String()
+	config.RequestIDKey = requestIDKeyOrDefault(config.RequestIDKey)
+	if !slices.Contains(encodings, config.Encoding) {
+		config.Encoding = EncodingJSON
+	}
+	config.TerminalEncoding = outputEncoding(config.TerminalEncoding, config.Encoding)
+	config.FileEncoding = outputEncoding(config.FileEncoding, config.Encoding)
+
+	rotation := resolveRotation(config.LogRotation)
+	config.LogRotation = &rotation
+	if config.LevelFiles != nil {
+		levelFiles := make(map[string]*LogRotationConfig, len(config.LevelFiles))
+		for name, cfg := range config.LevelFiles {
+			if cfg == nil {
+				levelFiles[name] = &rotation
+			} else {
+				resolved := resolveRotation(cfg)
+				levelFiles[name] = &resolved
+			}
+		}
+		config.LevelFiles = levelFiles
+	}
+
+	if len(config.Outputs) > 0 {
+		// Outputs replace the outputs of these options.
+		config.OutputMode = ""
+		config.TerminalEncoding = ""
+		config.FileEncoding = ""
+		config.LevelFiles = nil
+		outputs := make([]OutputConfig, len(config.Outputs))
+		for i, output := range config.Outputs {
+			if output.Encoding == "" && output.Type != OutputNetwork {
+				output.Encoding = config.Encoding
+			}
+			if output.Level == "" {
+				output.Level = config.LogLevel
+			}
+			if output.Type == OutputFile {
+				if output.LogDir == "" {
+					output.LogDir = config.LogDir
+				}
+				if output.LogDir == "" {
+					output.LogDir = DefaultLogDir(config.ServiceName)
+				}
+				if output.Name == "" {
+					output.Name = "logger"
+				}
+				if output.LogRotation == nil {
+					output.LogRotation = &rotation
+				} else {
+					resolved := resolveRotation(output.LogRotation)
+					output.LogRotation = &resolved
+				}
+			}
+			outputs[i] = output
+		}
+		config.Outputs = outputs
+	}
+
+	if config.FileSync == "" {
+		config.FileSync = SyncNever
+	}
+	if config.FileSync == SyncInterval && config.FileSyncInterval <= 0 {
+		config.FileSyncInterval = time.Second
+	}
+	if config.FileBuffer != nil {
+		buffer := *config.FileBuffer
+		if buffer.Size <= 0 {
+			buffer.Size = 256 * 1024
+		}
+		if buffer.FlushInterval <= 0 {
+			buffer.FlushInterval = time.Second
+		}
+		config.FileBuffer = &buffer
+	}
+	if config.Sampling != nil {
+		sampling := *config.Sampling
+		if sampling.Initial <= 0 {
+			sampling.Initial = 100
+		}
+		if sampling.Tick <= 0 {
+			sampling.Tick = time.Second
+		}
+		config.Sampling = &sampling
+	}
+	if config.Dedup != nil {
+		dedup := *config.Dedup
+		if dedup.Window <= 0 {
+			dedup.Window = time.Second
+		}
+		config.Dedup = &dedup
+	}
+	if config.SyslogFacility <= 0 || config.SyslogFacility > SyslogFacilityLocal7 {
+		config.SyslogFacility = SyslogFacilityUser
+	}
+	if config.CallerFormat == "" {
+		config.CallerFormat = CallerFormatShort
+	}
+	if config.TimeFormat == "" {
+		config.TimeFormat = TimeFormatISO8601
+	}
+	if config.LevelFormat == "" {
+		config.LevelFormat = LevelFormatUpper
+	}
+
+	keys := FieldKeysConfig{
+		Time:       "timestamp",
+		Level:      "level",
+		Message:    "msg",
+		Caller:     "caller",
+		Function:   "func",
+		Stacktrace: "stacktrace",
+	}
+	if config.FieldKeys != nil {
+		setKey(&keys.Time, config.FieldKeys.Time)
+		setKey(&keys.Level, config.FieldKeys.Level)
+		setKey(&keys.Message, config.FieldKeys.Message)
+		setKey(&keys.Caller, config.FieldKeys.Caller)
+		setKey(&keys.Function, config.FieldKeys.Function)
+		setKey(&keys.Stacktrace, config.FieldKeys.Stacktrace)
+	}
+	config.FieldKeys = &keys
+	return config
+}
+
+// dumpConfigValue converts a configuration value for DumpConfig. It returns
+// nil for unset values.
+func dumpConfigValue(v reflect.Value) any {
+	if v.Type() == reflect.TypeOf(time.Duration(0)) {
+		if v.Int() == 0 {
+			return nil
+		}
+		return time.Duration(v.Int()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return dumpConfigValue(v.Elem())
+	case reflect.Func:
+		if v.IsNil() {
+			return nil
+		}
+		return "func"
+	case reflect.Struct:
+		dump := make(map[string]any)
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if value := dumpConfigValue(v.Field(i)); value != nil {
+				dump[snakeCase(field.Name)] = value
+			}
+		}
+		return dump
+	case reflect.Map:
+		if v.Len() == 0 {
+			return nil
+		}
+		dump := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			dump[fmt.Sprint(iter.Key().Interface())] = dumpConfigElem(iter.Value())
+		}
+		return dump
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return "[REDACTED]"
+		}
+		dump := make([]any, v.Len())
+		for i := range dump {
+			dump[i] = dumpConfigElem(v.Index(i))
+		}
+		return dump
+	}
+	if v.IsZero() {
+		return nil
+	}
+	return v.Interface()
+}
+
+// dumpConfigElem converts a map or slice element like dumpConfigValue, but
+// keeps zero values such as a global field set to 0.
+func dumpConfigElem(v reflect.Value) any {
+	if value := dumpConfigValue(v); value != nil {
+		return value
+	}
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() == reflect.Func {
+		return nil
+	}
+	return v.Interface()
+}
+
+// snakeCase converts a Go field name to the snake_case key of configuration
+// files, e.g. "MaxTotalSizeMB" to "max_total_size_mb" and "HTTPClient" to
+// "http_client".
+func snakeCase(name string) string {
+	runes := []rune(name)
+	out := make([]rune, 0, len(runes)+4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				out = append(out, '_')
+			}
+		}
+		out = append(out, unicode.ToLower(r))
+	}
+	return string(out)
+}
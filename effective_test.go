@@ -0,0 +1,116 @@
+package gologger
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfig(t *testing.T) {
+	dir := t.TempDir()
+	sink := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:  OutputFile,
+		LogLevel:    LevelInfo,
+		LogDir:      dir,
+		LogRotation: &LogRotationConfig{MaxSize: 50},
+		LevelFiles:  map[string]*LogRotationConfig{LevelError: nil},
+		FileSync:    SyncInterval,
+		Encoding:    EncodingConsole,
+		FieldKeys:   &FieldKeysConfig{Message: "message"},
+		Sinks:       []Sink{sink},
+	})
+	defer log.Close()
+
+	config := log.Config()
+	expectedRotation := LogRotationConfig{MaxSize: 50, MaxBackups: 0, MaxAge: 28, Interval: RotateDaily, Layout: LayoutFlat}
+	if config.LogDir != dir || config.LogLevel != LevelInfo || config.RequestIDKey != "request-id" ||
+		config.TerminalEncoding != EncodingConsole || config.FileEncoding != EncodingConsole ||
+		config.FileSyncInterval != time.Second || config.SyslogFacility != SyslogFacilityUser ||
+		config.CallerFormat != CallerFormatShort || config.TimeFormat != TimeFormatISO8601 || config.LevelFormat != LevelFormatUpper {
+		t.Errorf("Unexpected effective config: %+v", config)
+	}
+	if !reflect.DeepEqual(*config.LogRotation, expectedRotation) || !reflect.DeepEqual(*config.LevelFiles[LevelError], expectedRotation) {
+		t.Errorf("Unexpected rotation: %+v, level file %+v", *config.LogRotation, config.LevelFiles[LevelError])
+	}
+	if config.FieldKeys.Message != "message" || config.FieldKeys.Time != "timestamp" {
+		t.Errorf("Unexpected field keys: %+v", *config.FieldKeys)
+	}
+	if err := config.Validate(); err != nil {
+		t.Errorf("Expected the effective config to be valid, got %v", err)
+	}
+
+	// The level and sinks are current.
+	log.SetLevel(LevelWarn)
+	id := log.AddSink(NewCaptureSink())
+	config = log.Config()
+	if config.LogLevel != LevelWarn || len(config.Sinks) != 2 || config.Sinks[0] != Sink(sink) {
+		t.Errorf("Expected the current level and sinks, got %s and %v", config.LogLevel, config.Sinks)
+	}
+	log.RemoveSink(id)
+
+	// Invalid options report the values actually used.
+	invalid := NewLoggerWithConfig(LoggerConfig{OutputMode: "trminal", LogLevel: "verbose", Encoding: "xml"})
+	defer invalid.Close()
+	config = invalid.Config()
+	if config.OutputMode != OutputTerminal || config.LogLevel != LevelDebug || config.Encoding != EncodingJSON {
+		t.Errorf("Expected the fallbacks of invalid options, got %+v", config)
+	}
+}
+
+func TestDumpConfig(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:     OutputDiscard,
+		LogLevel:       LevelInfo,
+		FileEncryption: &EncryptionConfig{Key: make([]byte, 32), KeyID: "k1"},
+		OnSinkError:    func(string, error) {},
+		GlobalFields:   map[string]any{"service": "api", "replicas": 0},
+		Sinks:          []Sink{NewCaptureSink()},
+	})
+	defer log.Close()
+
+	dump := log.DumpConfig()
+	expected := map[string]any{
+		"output_mode":     OutputDiscard,
+		"log_level":       LevelInfo,
+		"on_sink_error":   "func",
+		"file_sync":       SyncNever,
+		"global_fields":   map[string]any{"service": "api", "replicas": 0},
+		"sinks":           []any{"*gologger.CaptureSink"},
+		"log_rotation":    map[string]any{"max_size": 10, "max_backups": 3, "max_age": 28, "compress": true, "interval": RotateDaily, "layout": LayoutFlat},
+		"file_encryption": map[string]any{"key": "[REDACTED]", "key_id": "k1"},
+	}
+	for key, value := range expected {
+		if !reflect.DeepEqual(dump[key], value) {
+			t.Errorf("%s: expected %#v, got %#v", key, value, dump[key])
+		}
+	}
+	if _, ok := dump["sampling"]; ok {
+		t.Error("Expected unset options to be left out")
+	}
+
+	// The dump is a valid configuration file, apart from sinks, functions and redacted keys.
+	for _, key := range []string{"sinks", "on_sink_error", "file_encryption"} {
+		delete(dump, key)
+	}
+	var config LoggerConfig
+	if err := decodeLoggerConfig(&config, dump); err != nil {
+		t.Errorf("Expected the dump to decode as a configuration, got %v", err)
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"OutputMode":     "output_mode",
+		"MaxTotalSizeMB": "max_total_size_mb",
+		"HTTPClient":     "http_client",
+		"RotateOnSIGHUP": "rotate_on_sighup",
+		"UTC":            "utc",
+		"KeyID":          "key_id",
+	}
+	for name, expected := range tests {
+		if got := snakeCase(name); got != expected {
+			t.Errorf("snakeCase(%q): expected %q, got %q", name, expected, got)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package gologger
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fieldRecorder implements zapcore.ObjectEncoder by recording the fields
+// added to it. Custom encoders embed it to keep the context added with With
+// and render it together with the entry fields.
+type fieldRecorder struct {
+	fields []zapcore.Field
+}
+
+// clone returns a copy that can be extended independently.
+func (r *fieldRecorder) clone() fieldRecorder {
+	return fieldRecorder{fields: append([]zapcore.Field(nil), r.fields...)}
+}
+
+// with returns the recorded fields followed by fields, without modifying
+// the recorded ones.
+func (r *fieldRecorder) with(fields []zapcore.Field) []zapcore.Field {
+	return append(r.fields[:len(r.fields):len(r.fields)], fields...)
+}
+
+func (r *fieldRecorder) AddArray(key string, v zapcore.ArrayMarshaler) error {
+	r.fields = append(r.fields, zap.Array(key, v))
+	return nil
+}
+
+func (r *fieldRecorder) AddObject(key string, v zapcore.ObjectMarshaler) error {
+	r.fields = append(r.fields, zap.Object(key, v))
+	return nil
+}
+
+func (r *fieldRecorder) AddReflected(key string, v any) error {
+	r.fields = append(r.fields, zap.Reflect(key, v))
+	return nil
+}
+
+func (r *fieldRecorder) OpenNamespace(key string) {
+	r.fields = append(r.fields, zap.Namespace(key))
+}
+
+func (r *fieldRecorder) AddBinary(key string, v []byte) {
+	r.fields = append(r.fields, zap.Binary(key, v))
+}
+
+func (r *fieldRecorder) AddByteString(key string, v []byte) {
+	r.fields = append(r.fields, zap.ByteString(key, v))
+}
+
+func (r *fieldRecorder) AddBool(key string, v bool) {
+	r.fields = append(r.fields, zap.Bool(key, v))
+}
+
+func (r *fieldRecorder) AddComplex128(key string, v complex128) {
+	r.fields = append(r.fields, zap.Complex128(key, v))
+}
+
+func (r *fieldRecorder) AddComplex64(key string, v complex64) {
+	r.fields = append(r.fields, zap.Complex64(key, v))
+}
+
+func (r *fieldRecorder) AddDuration(key string, v time.Duration) {
+	r.fields = append(r.fields, zap.Duration(key, v))
+}
+
+func (r *fieldRecorder) AddFloat64(key string, v float64) {
+	r.fields = append(r.fields, zap.Float64(key, v))
+}
+
+func (r *fieldRecorder) AddFloat32(key string, v float32) {
+	r.fields = append(r.fields, zap.Float32(key, v))
+}
+
+func (r *fieldRecorder) AddInt(key string, v int) {
+	r.fields = append(r.fields, zap.Int(key, v))
+}
+
+func (r *fieldRecorder) AddInt64(key string, v int64) {
+	r.fields = append(r.fields, zap.Int64(key, v))
+}
+
+func (r *fieldRecorder) AddInt32(key string, v int32) {
+	r.fields = append(r.fields, zap.Int32(key, v))
+}
+
+func (r *fieldRecorder) AddInt16(key string, v int16) {
+	r.fields = append(r.fields, zap.Int16(key, v))
+}
+
+func (r *fieldRecorder) AddInt8(key string, v int8) {
+	r.fields = append(r.fields, zap.Int8(key, v))
+}
+
+func (r *fieldRecorder) AddString(key string, v string) {
+	r.fields = append(r.fields, zap.String(key, v))
+}
+
+func (r *fieldRecorder) AddTime(key string, v time.Time) {
+	r.fields = append(r.fields, zap.Time(key, v))
+}
+
+func (r *fieldRecorder) AddUint(key string, v uint) {
+	r.fields = append(r.fields, zap.Uint(key, v))
+}
+
+func (r *fieldRecorder) AddUint64(key string, v uint64) {
+	r.fields = append(r.fields, zap.Uint64(key, v))
+}
+
+func (r *fieldRecorder) AddUint32(key string, v uint32) {
+	r.fields = append(r.fields, zap.Uint32(key, v))
+}
+
+func (r *fieldRecorder) AddUint16(key string, v uint16) {
+	r.fields = append(r.fields, zap.Uint16(key, v))
+}
+
+func (r *fieldRecorder) AddUint8(key string, v uint8) {
+	r.fields = append(r.fields, zap.Uint8(key, v))
+}
+
+func (r *fieldRecorder) AddUintptr(key string, v uintptr) {
+	r.fields = append(r.fields, zap.Uintptr(key, v))
+}
@@ -0,0 +1,173 @@
+package gologger
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Chunk types of encrypted log files.
+const (
+	encryptedHeaderChunk = 'H'
+	encryptedRecordChunk = 'R'
+)
+
+// encryptedMaxChunk bounds the chunk length accepted when decrypting.
+const encryptedMaxChunk = 64 << 20
+
+// EncryptionConfig holds configuration options for encrypted log files.
+// Either Key or WrapKey must be set.
+type EncryptionConfig struct {
+	Key       []byte                               // 32-byte AES-256 key used directly (optional)
+	WrapKey   func(dataKey []byte) ([]byte, error) // Encrypts a random per-file data key, e.g. with a KMS; the result is stored in the file (optional)
+	UnwrapKey func(wrapped []byte) ([]byte, error) // Decrypts a data key stored by WrapKey; used by DecryptLogFile (optional)
+	KeyID     string                               // Identifies the key in file headers, e.g. a KMS key ARN (optional)
+}
+
+// encryptedHeader describes the key of the records following it.
+type encryptedHeader struct {
+	Version    int    `json:"v"`
+	Algorithm  string `json:"alg"`
+	KeyID      string `json:"key_id,omitempty"`
+	WrappedKey []byte `json:"wrapped_key,omitempty"`
+}
+
+// fileEncrypter encrypts log file writes with AES-256-GCM. A file is a
+// sequence of chunks, each a type byte, a big-endian uint32 length and a
+// payload. A header chunk starts every segment written by one open of the
+// file and names its key; each write becomes a record chunk holding a
+// random 12-byte nonce and the sealed data.
+type fileEncrypter struct {
+	config EncryptionConfig
+	aead   cipher.AEAD
+}
+
+func newFileEncrypter(config EncryptionConfig) *fileEncrypter {
+	return &fileEncrypter{config: config}
+}
+
+// header picks the key for a new segment and appends its header chunk to b.
+// With WrapKey, a new data key is generated and wrapped for every segment.
+func (e *fileEncrypter) header(b []byte) ([]byte, error) {
+	header := encryptedHeader{Version: 1, Algorithm: "AES-256-GCM", KeyID: e.config.KeyID}
+	key := e.config.Key
+	if e.config.WrapKey != nil {
+		key = make([]byte, 32)
+		if _, err := rand.Read(key); err != nil {
+			return nil, fmt.Errorf("encrypt: data key: %w", err)
+		}
+		wrapped, err := e.config.WrapKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("encrypt: wrap key: %w", err)
+		}
+		header.WrappedKey = wrapped
+	}
+
+	aead, err := newLogAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+	e.aead = aead
+	return appendEncryptedChunk(b, encryptedHeaderChunk, payload), nil
+}
+
+// seal appends the record chunk for p to b. header must have been called first.
+func (e *fileEncrypter) seal(b, p []byte) ([]byte, error) {
+	nonce := make([]byte, e.aead.NonceSize(), e.aead.NonceSize()+len(p)+e.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("encrypt: nonce: %w", err)
+	}
+	return appendEncryptedChunk(b, encryptedRecordChunk, e.aead.Seal(nonce, nonce, p, nil)), nil
+}
+
+func newLogAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encrypt: key must be 32 bytes, got %d", len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+func appendEncryptedChunk(b []byte, kind byte, payload []byte) []byte {
+	b = append(b, kind)
+	b = binary.BigEndian.AppendUint32(b, uint32(len(payload)))
+	return append(b, payload...)
+}
+
+// DecryptLogFile decrypts a log file written with LoggerConfig.FileEncryption
+// from src to dst. Data keys stored by WrapKey are decrypted with UnwrapKey;
+// otherwise Key is used. A record cut short at the end of the file, as left
+// by a crash, is reported as io.ErrUnexpectedEOF after the records before it
+// have been written.
+func DecryptLogFile(dst io.Writer, src io.Reader, config EncryptionConfig) error {
+	reader := bufio.NewReader(src)
+	var aead cipher.AEAD
+	var prefix [5]byte
+	for {
+		if _, err := io.ReadFull(reader, prefix[:]); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("decrypt: %w", io.ErrUnexpectedEOF)
+		}
+		length := binary.BigEndian.Uint32(prefix[1:])
+		if length > encryptedMaxChunk {
+			return fmt.Errorf("decrypt: chunk of %d bytes is too large", length)
+		}
+		payload := make([]byte, length)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return fmt.Errorf("decrypt: %w", io.ErrUnexpectedEOF)
+		}
+
+		switch prefix[0] {
+		case encryptedHeaderChunk:
+			var header encryptedHeader
+			if err := json.Unmarshal(payload, &header); err != nil {
+				return fmt.Errorf("decrypt: header: %w", err)
+			}
+			key := config.Key
+			if header.WrappedKey != nil {
+				if config.UnwrapKey == nil {
+					return errors.New("decrypt: file uses a wrapped key but UnwrapKey is not set")
+				}
+				var err error
+				if key, err = config.UnwrapKey(header.WrappedKey); err != nil {
+					return fmt.Errorf("decrypt: unwrap key: %w", err)
+				}
+			}
+			var err error
+			if aead, err = newLogAEAD(key); err != nil {
+				return err
+			}
+		case encryptedRecordChunk:
+			if aead == nil {
+				return errors.New("decrypt: record before header")
+			}
+			if len(payload) < aead.NonceSize() {
+				return errors.New("decrypt: record too short")
+			}
+			plain, err := aead.Open(nil, payload[:aead.NonceSize()], payload[aead.NonceSize():], nil)
+			if err != nil {
+				return fmt.Errorf("decrypt: %w", err)
+			}
+			if _, err := dst.Write(plain); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("decrypt: unknown chunk type %q", prefix[0])
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package gologger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncryptedRotatingFile(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, 32)
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false})
+	file.now, _ = testClock(time.Date(2024, 3, 1, 12, 0, 0, 0, time.Local))
+	file.encrypt = newFileEncrypter(EncryptionConfig{Key: key, KeyID: "test"})
+
+	file.Write([]byte("first\n"))
+	file.Close()
+	// Reopening appends a new segment with its own header.
+	file.Write([]byte("second\n"))
+	file.Close()
+
+	data, err := os.ReadFile(filepath.Join(dir, "logger-2024-03-01.log"))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	if bytes.Contains(data, []byte("first")) {
+		t.Fatal("Expected log file to be encrypted")
+	}
+	if count := bytes.Count(data, []byte(`"key_id":"test"`)); count != 2 {
+		t.Errorf("Expected 2 headers, got %d", count)
+	}
+
+	var out bytes.Buffer
+	if err := DecryptLogFile(&out, bytes.NewReader(data), EncryptionConfig{Key: key}); err != nil {
+		t.Fatalf("Unexpected decrypt error: %v", err)
+	}
+	if out.String() != "first\nsecond\n" {
+		t.Errorf("Expected both entries, got %q", out.String())
+	}
+
+	out.Reset()
+	err = DecryptLogFile(&out, bytes.NewReader(data[:len(data)-3]), EncryptionConfig{Key: key})
+	if !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Expected unexpected EOF for a truncated file, got %v", err)
+	}
+	if out.String() != "first\n" {
+		t.Errorf("Expected the complete records before the truncation, got %q", out.String())
+	}
+
+	if err := DecryptLogFile(io.Discard, bytes.NewReader(data), EncryptionConfig{Key: bytes.Repeat([]byte{8}, 32)}); err == nil {
+		t.Error("Expected error decrypting with the wrong key")
+	}
+}
+
+func TestEncryptedWrappedKey(t *testing.T) {
+	master := bytes.Repeat([]byte{1}, 32)
+	// Wrap data keys by sealing them under a master key, standing in for a KMS.
+	wrapper := newFileEncrypter(EncryptionConfig{Key: master})
+	if _, err := wrapper.header(nil); err != nil {
+		t.Fatalf("Unexpected header error: %v", err)
+	}
+	var wrapped int
+	config := EncryptionConfig{
+		WrapKey: func(dataKey []byte) ([]byte, error) {
+			wrapped++
+			return wrapper.seal(nil, dataKey)
+		},
+		UnwrapKey: func(chunk []byte) ([]byte, error) {
+			var out bytes.Buffer
+			header, _ := newFileEncrypter(EncryptionConfig{Key: master}).header(nil)
+			err := DecryptLogFile(&out, bytes.NewReader(append(header, chunk...)), EncryptionConfig{Key: master})
+			return out.Bytes(), err
+		},
+	}
+
+	var buf bytes.Buffer
+	encrypter := newFileEncrypter(config)
+	b, _ := encrypter.header(nil)
+	b, _ = encrypter.seal(b, []byte("secret entry\n"))
+	buf.Write(b)
+
+	if wrapped != 1 {
+		t.Errorf("Expected the data key to be wrapped once, got %d", wrapped)
+	}
+	var out bytes.Buffer
+	if err := DecryptLogFile(&out, &buf, config); err != nil {
+		t.Fatalf("Unexpected decrypt error: %v", err)
+	}
+	if out.String() != "secret entry\n" {
+		t.Errorf("Expected decrypted entry, got %q", out.String())
+	}
+
+	b, _ = encrypter.header(nil)
+	if err := DecryptLogFile(io.Discard, bytes.NewReader(b), EncryptionConfig{Key: master}); err == nil {
+		t.Error("Expected error decrypting a wrapped key without UnwrapKey")
+	}
+}
+
+func TestLoggerFileEncryption(t *testing.T) {
+	key := bytes.Repeat([]byte{3}, 32)
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:     OutputFile,
+		LogDir:         tempDir,
+		FileEncryption: &EncryptionConfig{Key: key},
+	})
+	log.Info("encrypted entry").Send()
+	log.Close()
+
+	data, err := os.ReadFile(currentLogFile(tempDir))
+	if err != nil {
+		t.Fatalf("Failed to read log file: %v", err)
+	}
+	var out bytes.Buffer
+	if err := DecryptLogFile(&out, bytes.NewReader(data), EncryptionConfig{Key: key}); err != nil {
+		t.Fatalf("Unexpected decrypt error: %v", err)
+	}
+	if !strings.Contains(out.String(), "encrypted entry") {
+		t.Errorf("Expected entry in decrypted log, got %q", out.String())
+	}
+
+	bad := newRotatingFile(t.TempDir(), "logger", &LogRotationConfig{})
+	bad.encrypt = newFileEncrypter(EncryptionConfig{Key: []byte("short")})
+	if _, err := bad.Write([]byte("entry\n")); err == nil {
+		t.Error("Expected error writing with an invalid key")
+	}
+	bad.Close()
+}
@@ -0,0 +1,101 @@
+package gologger
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+)
+
+// Environment variables read by NewLoggerFromEnv.
+const (
+	EnvConfig         = "GOLOGGER_CONFIG"          // Configuration file used instead of the defaults, see LoadConfigFile
+	EnvProfile        = "GOLOGGER_PROFILE"         // Profile of the configuration file to apply, e.g. prod, see LoadConfigFile
+	EnvLevel          = "GOLOGGER_LEVEL"           // LogLevel: debug, info, warn or error
+	EnvOutput         = "GOLOGGER_OUTPUT"          // OutputMode: terminal, file, both, split or discard
+	EnvDir            = "GOLOGGER_DIR"             // LogDir
+	EnvFormat         = "GOLOGGER_FORMAT"          // Encoding of all outputs, e.g. json or console
+	EnvTerminalFormat = "GOLOGGER_TERMINAL_FORMAT" // TerminalEncoding
+	EnvFileFormat     = "GOLOGGER_FILE_FORMAT"     // FileEncoding
+	EnvCaller         = "GOLOGGER_CALLER"          // ShowCaller: true or false
+	EnvRequestIDKey   = "GOLOGGER_REQUEST_ID_KEY"  // RequestIDKey
+	EnvService        = "GOLOGGER_SERVICE"         // ServiceName
+	EnvTwelveFactor   = "GOLOGGER_TWELVE_FACTOR"   // TwelveFactor: true, false, or auto to enable it in containers, see RunningInContainer
+)
+
+// NewLoggerFromEnv creates a Logger configured by GOLOGGER_* environment
+// variables, so deployments can tune logging without code changes. See
+// ConfigFromEnv for how the configuration is assembled.
+func NewLoggerFromEnv() (Logger, error) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		return Logger{}, err
+	}
+	return NewLoggerWithConfig(config), nil
+}
+
+// ConfigFromEnv builds a LoggerConfig from GOLOGGER_* environment variables.
+// It starts from the file named by GOLOGGER_CONFIG, or from the defaults of
+// NewLogger, and overrides the options whose variables are set. Unset and
+// empty variables are ignored; invalid values are errors.
+func ConfigFromEnv() (LoggerConfig, error) {
+	config := defaultConfig()
+	if path := os.Getenv(EnvConfig); path != "" {
+		var err error
+		if config, err = LoadConfigFile(path); err != nil {
+			return config, err
+		}
+	}
+
+	choices := []struct {
+		name    string
+		dst     *string
+		allowed []string
+	}{
+		{EnvLevel, &config.LogLevel, []string{LevelDebug, LevelInfo, LevelWarn, LevelError}},
+		{EnvOutput, &config.OutputMode, outputModes},
+		{EnvFormat, &config.Encoding, encodings},
+		{EnvTerminalFormat, &config.TerminalEncoding, encodings},
+		{EnvFileFormat, &config.FileEncoding, encodings},
+		{EnvDir, &config.LogDir, nil},
+		{EnvRequestIDKey, &config.RequestIDKey, nil},
+		{EnvService, &config.ServiceName, nil},
+	}
+	for _, choice := range choices {
+		value := os.Getenv(choice.name)
+		if value == "" {
+			continue
+		}
+		if choice.allowed != nil && !slices.Contains(choice.allowed, value) {
+			return config, fmt.Errorf("gologger: %s: unknown value %q, expected one of %v", choice.name, value, choice.allowed)
+		}
+		*choice.dst = value
+	}
+
+	if value := os.Getenv(EnvCaller); value != "" {
+		showCaller, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("gologger: %s: expected true or false, got %q", EnvCaller, value)
+		}
+		config.ShowCaller = showCaller
+	}
+
+	switch value := os.Getenv(EnvTwelveFactor); value {
+	case "":
+	case "auto":
+		config.TwelveFactor = RunningInContainer()
+	default:
+		twelveFactor, err := strconv.ParseBool(value)
+		if err != nil {
+			return config, fmt.Errorf("gologger: %s: expected true, false or auto, got %q", EnvTwelveFactor, value)
+		}
+		config.TwelveFactor = twelveFactor
+	}
+	return config, nil
+}
+
+// encodings lists the supported output encodings.
+var encodings = []string{
+	EncodingJSON, EncodingConsole, EncodingECS, EncodingCLEF, EncodingSyslog,
+	EncodingMsgPack, EncodingProtobuf, EncodingPretty, EncodingCEF,
+}
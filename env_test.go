@@ -0,0 +1,75 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.OutputMode != OutputBoth || config.LogLevel != LevelDebug || !config.ShowCaller {
+		t.Errorf("Expected the defaults of NewLogger, got %+v", config)
+	}
+
+	t.Setenv(EnvLevel, "warn")
+	t.Setenv(EnvOutput, "file")
+	t.Setenv(EnvDir, "/var/log/app")
+	t.Setenv(EnvFormat, "console")
+	t.Setenv(EnvFileFormat, "json")
+	t.Setenv(EnvCaller, "false")
+	t.Setenv(EnvService, "checkout")
+	config, err = ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.LogLevel != LevelWarn || config.OutputMode != OutputFile || config.LogDir != "/var/log/app" ||
+		config.Encoding != EncodingConsole || config.FileEncoding != EncodingJSON || config.ShowCaller ||
+		config.ServiceName != "checkout" || config.RequestIDKey != "request-id" {
+		t.Errorf("Unexpected config: %+v", config)
+	}
+}
+
+func TestConfigFromEnvFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logger.yaml")
+	os.WriteFile(path, []byte("output_mode: discard\nlog_level: error\nlog_rotation: {max_age: 7}\n"), 0644)
+	t.Setenv(EnvConfig, path)
+	t.Setenv(EnvLevel, "info")
+
+	config, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if config.OutputMode != OutputDiscard || config.LogLevel != LevelInfo || config.LogRotation.MaxAge != 7 {
+		t.Errorf("Expected the file with the level overridden, got %+v", config)
+	}
+
+	log, err := NewLoggerFromEnv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Close()
+}
+
+func TestConfigFromEnvErrors(t *testing.T) {
+	tests := map[string]string{
+		EnvLevel:  "verbose",
+		EnvOutput: "stdin",
+		EnvFormat: "xml",
+		EnvCaller: "maybe",
+		EnvConfig: "missing.yaml",
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+			_, err := NewLoggerFromEnv()
+			if err == nil || !strings.Contains(err.Error(), value) {
+				t.Errorf("Expected error mentioning %q, got %v", value, err)
+			}
+		})
+	}
+}
@@ -15,81 +15,60 @@ func HTTPExample() {
 	log := gologger.NewLogger()
 	defer log.Close()
 
-	// Setup HTTP handler
-	http.HandleFunc("/api/users", func(w http.ResponseWriter, r *http.Request) {
-		handleUserRequest(w, r, log)
-	})
+	// Setup HTTP handlers
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/users", handleUserRequest)
+	mux.HandleFunc("/api/health", handleHealthCheck)
 
-	http.HandleFunc("/api/health", func(w http.ResponseWriter, r *http.Request) {
-		handleHealthCheck(w, r, log)
-	})
+	// Request IDs and access logging for every request
+	handler := gologger.HTTPMiddleware(log)(mux)
 
 	fmt.Println("Server starting on :8080")
 	fmt.Println("Try: curl http://localhost:8080/api/users")
 	fmt.Println("Try: curl http://localhost:8080/api/health")
 
 	// Start server (in real app, you'd use log.Fatal)
-	// log.Fatal(http.ListenAndServe(":8080", nil))
+	// log.Fatal(http.ListenAndServe(":8080", handler))
+	_ = handler
 }
 
-func handleUserRequest(w http.ResponseWriter, r *http.Request, log gologger.Logger) {
-	// Generate request ID
-	requestID := generateRequestID()
-
-	// Add request ID to context
-	ctx := gologger.WithRequestID(r.Context(), requestID)
-
-	// Log request start
-	log.WithContext(ctx).
-		Info("HTTP request started").
-		Data("method", r.Method).
-		Data("path", r.URL.Path).
-		Data("user_agent", r.UserAgent()).
-		Data("remote_addr", r.RemoteAddr).
-		Send()
+func handleUserRequest(w http.ResponseWriter, r *http.Request) {
+	// Logger of the middleware, with the request ID
+	ctx := r.Context()
+	log := gologger.FromContext(ctx)
 
 	// Simulate processing
 	user, err := processUserRequest(ctx, log, r)
 	if err != nil {
-		log.WithContext(ctx).
-			Error("Failed to process user request").
+		log.Error("Failed to process user request").
 			ErrorData(err).
 			Send()
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
 		return
 	}
 
-	// Log success
-	log.WithContext(ctx).
-		Info("HTTP request completed").
-		Data("status", http.StatusOK).
-		Data("user_id", user.ID).
-		Data("processing_time", "150ms").
-		Send()
-
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	fmt.Fprintf(w, `{"id": %d, "name": "%s", "request_id": "%s"}`, user.ID, user.Name, requestID)
+	fmt.Fprintf(w, `{"id": %d, "name": "%s", "request_id": "%s"}`, user.ID, user.Name, gologger.GetRequestID(ctx))
 }
 
-func handleHealthCheck(w http.ResponseWriter, r *http.Request, log gologger.Logger) {
-	ctx := gologger.WithRequestID(r.Context(), "health-"+generateRequestID())
+func handleHealthCheck(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := gologger.FromContext(ctx)
 
-	log.WithContext(ctx).Info("Health check requested").Send()
+	log.Info("Health check requested").Send()
 
 	// Simulate health check
 	status := checkSystemHealth(ctx, log)
 
 	if status.Healthy {
-		log.WithContext(ctx).
-			Info("Health check passed").
+		log.Info("Health check passed").
 			Data("response_time", status.ResponseTime).
 			Send()
 		w.WriteHeader(http.StatusOK)
 		fmt.Fprint(w, `{"status": "healthy", "timestamp": "`+time.Now().Format(time.RFC3339)+`"}`)
 	} else {
-		log.WithContext(ctx).
-			Error("Health check failed").
+		log.Error("Health check failed").
 			Data("error", status.Error).
 			Send()
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -245,10 +224,6 @@ func checkExternalServices(ctx context.Context, log gologger.Logger) error {
 	return nil
 }
 
-func generateRequestID() string {
-	return fmt.Sprintf("req-%d", time.Now().UnixNano())
-}
-
 // Data structures
 type User struct {
 	ID   int    `json:"id"`
@@ -0,0 +1,178 @@
+package gologger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// WriterSink writes entries as JSON lines to an io.Writer.
+type WriterSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterSink creates a sink writing one JSON object per line to w,
+// e.g. os.Stderr as the last link of a failover chain.
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+// Write encodes the entry and writes it as a single line.
+func (s *WriterSink) Write(entry Entry) error {
+	data, err := entryJSON(entry)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(data)
+	return err
+}
+
+// Sync syncs the writer if it supports syncing. os.Stdout and os.Stderr are
+// not synced, since that fails on some platforms without any data being lost.
+func (s *WriterSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if isStdStream(s.w) {
+		return nil
+	}
+	if syncer, ok := s.w.(interface{ Sync() error }); ok {
+		return syncer.Sync()
+	}
+	return nil
+}
+
+// Close syncs the writer. The writer itself is not closed.
+func (s *WriterSink) Close() error {
+	return s.Sync()
+}
+
+// FailoverConfig holds configuration options for a failover sink chain.
+type FailoverConfig struct {
+	Primary       Sink          // Preferred sink (required)
+	Fallbacks     []Sink        // Sinks tried in order when the previous one fails (default: JSON lines on stderr)
+	RetryInterval time.Duration // How long a failed sink is skipped before it is tried again (default: 30s)
+	ErrorOutput   io.Writer     // Destination of internal failover warnings (default: os.Stderr)
+}
+
+// FailoverSink writes each entry to the first healthy sink of a chain. When
+// a sink returns an error the entry is written to the next one, a warning is
+// written to the error output, and the failed sink is skipped until the retry
+// interval elapses. Batching sinks report errors after their entries were
+// dropped, so only entries written after a failure are redirected.
+type FailoverSink struct {
+	mu            sync.Mutex
+	sinks         []Sink
+	failedUntil   []time.Time
+	retryInterval time.Duration
+	errorOutput   io.Writer
+	now           func() time.Time
+}
+
+// NewFailoverSink creates a failover sink chain. Unset options fall back to their defaults.
+func NewFailoverSink(config FailoverConfig) *FailoverSink {
+	fallbacks := config.Fallbacks
+	if len(fallbacks) == 0 {
+		fallbacks = []Sink{NewWriterSink(os.Stderr)}
+	}
+	retryInterval := config.RetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 30 * time.Second
+	}
+	errorOutput := config.ErrorOutput
+	if errorOutput == nil {
+		errorOutput = os.Stderr
+	}
+
+	sinks := append([]Sink{config.Primary}, fallbacks...)
+	return &FailoverSink{
+		sinks:         sinks,
+		failedUntil:   make([]time.Time, len(sinks)),
+		retryInterval: retryInterval,
+		errorOutput:   errorOutput,
+		now:           time.Now,
+	}
+}
+
+// Write delivers the entry to the first healthy sink that accepts it.
+// Returns an error only if every sink in the chain failed.
+func (s *FailoverSink) Write(entry Entry) error {
+	var errs []error
+	for i, sink := range s.sinks {
+		if !s.available(i) {
+			continue
+		}
+		err := sink.Write(entry)
+		if err == nil {
+			s.recovered(i)
+			return nil
+		}
+		s.failed(i, err)
+		errs = append(errs, err)
+	}
+
+	// Every sink is failing or skipped; make a last attempt on the final one.
+	if len(errs) == 0 {
+		last := len(s.sinks) - 1
+		if err := s.sinks[last].Write(entry); err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("failover: all sinks failed: %w", errors.Join(errs...))
+}
+
+// Sync syncs every sink in the chain.
+func (s *FailoverSink) Sync() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		errs = append(errs, sink.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes every sink in the chain.
+func (s *FailoverSink) Close() error {
+	var errs []error
+	for _, sink := range s.sinks {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// available reports whether sink i is not within its failure back-off.
+func (s *FailoverSink) available(i int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return !s.now().Before(s.failedUntil[i])
+}
+
+// failed marks sink i as failing and warns when it was healthy before.
+func (s *FailoverSink) failed(i int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	wasHealthy := s.failedUntil[i].IsZero()
+	s.failedUntil[i] = s.now().Add(s.retryInterval)
+	if wasHealthy {
+		fmt.Fprintf(s.errorOutput, "%s gologger: failover: sink %d (%T) failed, using next sink: %v\n",
+			s.now().Format(time.RFC3339), i, s.sinks[i], err)
+	}
+}
+
+// recovered clears the failure state of sink i and reports recovery.
+func (s *FailoverSink) recovered(i int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.failedUntil[i].IsZero() {
+		s.failedUntil[i] = time.Time{}
+		fmt.Fprintf(s.errorOutput, "%s gologger: failover: sink %d (%T) recovered\n",
+			s.now().Format(time.RFC3339), i, s.sinks[i])
+	}
+}
@@ -0,0 +1,126 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// flakySink is a recordingSink whose writes fail while broken is set.
+type flakySink struct {
+	recordingSink
+	mu     sync.Mutex
+	broken bool
+}
+
+func (s *flakySink) setBroken(broken bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.broken = broken
+}
+
+func (s *flakySink) Write(entry Entry) error {
+	s.mu.Lock()
+	broken := s.broken
+	s.mu.Unlock()
+	if broken {
+		return errors.New("no space left on device")
+	}
+	return s.recordingSink.Write(entry)
+}
+
+func TestFailoverSink(t *testing.T) {
+	primary := &flakySink{}
+	secondary := &recordingSink{}
+	var warnings bytes.Buffer
+	now := time.Now()
+
+	sink := NewFailoverSink(FailoverConfig{
+		Primary:       primary,
+		Fallbacks:     []Sink{secondary},
+		RetryInterval: time.Minute,
+		ErrorOutput:   &warnings,
+	})
+	sink.now = func() time.Time { return now }
+
+	_ = sink.Write(Entry{Message: "first"})
+
+	primary.setBroken(true)
+	if err := sink.Write(Entry{Message: "second"}); err != nil {
+		t.Fatalf("Expected failover to succeed, got %v", err)
+	}
+	if !strings.Contains(warnings.String(), "no space left on device") {
+		t.Errorf("Expected failover warning, got %q", warnings.String())
+	}
+
+	// The primary is skipped during its back-off, even if it recovered
+	primary.setBroken(false)
+	_ = sink.Write(Entry{Message: "third"})
+
+	now = now.Add(2 * time.Minute)
+	_ = sink.Write(Entry{Message: "fourth"})
+	if !strings.Contains(warnings.String(), "recovered") {
+		t.Errorf("Expected recovery notice, got %q", warnings.String())
+	}
+
+	messages := func(entries []Entry) []string {
+		var result []string
+		for _, entry := range entries {
+			result = append(result, entry.Message)
+		}
+		return result
+	}
+	if got := strings.Join(messages(primary.Entries()), ","); got != "first,fourth" {
+		t.Errorf("Unexpected primary entries %s", got)
+	}
+	if got := strings.Join(messages(secondary.Entries()), ","); got != "second,third" {
+		t.Errorf("Unexpected secondary entries %s", got)
+	}
+
+	_ = sink.Close()
+	if !primary.closed || !secondary.closed {
+		t.Error("Expected Close to close every sink in the chain")
+	}
+}
+
+func TestFailoverSinkAllFailing(t *testing.T) {
+	primary := &flakySink{broken: true}
+	secondary := &flakySink{broken: true}
+	sink := NewFailoverSink(FailoverConfig{
+		Primary:     primary,
+		Fallbacks:   []Sink{secondary},
+		ErrorOutput: &bytes.Buffer{},
+	})
+
+	if err := sink.Write(Entry{Message: "lost"}); err == nil {
+		t.Error("Expected error when every sink fails")
+	}
+	// Both sinks are now backing off; the last one is still attempted
+	if err := sink.Write(Entry{Message: "lost again"}); err == nil {
+		t.Error("Expected error from the last sink")
+	}
+}
+
+func TestWriterSink(t *testing.T) {
+	var buf bytes.Buffer
+	sink := NewWriterSink(&buf)
+
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelWarn, Message: "careful", Fields: map[string]any{"n": 1}})
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "ok"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &entry); err != nil {
+		t.Fatalf("Expected JSON line, got %s", lines[0])
+	}
+	if entry["level"] != "WARN" || entry["msg"] != "careful" || entry["n"] != float64(1) {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+}
@@ -0,0 +1,144 @@
+// Package fiberlog logs the requests of Fiber applications with gologger, as
+// gologger.HTTPMiddleware does for net/http handlers:
+//
+//	app := fiber.New()
+//	app.Use(fiberlog.New(log))
+//
+// It is a separate module, so applications not using Fiber do not depend on
+// it.
+package fiberlog
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.risoftinc.com/gologger"
+)
+
+// RequestIDLocal is the key of the request ID in the locals of a request, as
+// set by Fiber's requestid middleware with its default configuration.
+const RequestIDLocal = "requestid"
+
+// New returns Fiber middleware that logs every request with log.
+//
+// It takes the request ID from the user context, from the requestid
+// middleware, from the X-Request-ID header or generates one, and sets it on
+// the response. The request ID and the logger are stored in the user context
+// of the request, so handlers get the logger with Logger(c) and their entries
+// carry the request ID. A debug entry is logged when a request starts and an
+// entry with its status, response size and duration when it completes, at
+// info level, warn for 4xx or error for 5xx responses.
+//
+// Errors returned by later handlers are passed to the application's error
+// handler before the request is logged, as Fiber's logger middleware does.
+// Panics are logged with their stack trace and turned into errors, so they
+// are answered with a 500 response instead of crashing the server.
+func New(log gologger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		ctx := c.UserContext()
+		requestID := gologger.GetRequestID(ctx)
+		if requestID == "" {
+			// The strings of a Fiber context are reused after the request,
+			// and sinks may keep entries longer.
+			requestID, _ = c.Locals(RequestIDLocal).(string)
+			if requestID == "" {
+				requestID = c.Get(fiber.HeaderXRequestID)
+			}
+			requestID = gologger.RequestIDFromHeader(strings.Clone(requestID))
+			ctx = gologger.WithRequestID(ctx, requestID)
+		}
+		ctx = gologger.NewContext(ctx, log)
+		c.SetUserContext(ctx)
+		c.Set(fiber.HeaderXRequestID, requestID)
+
+		method := c.Method()
+		path := strings.Clone(c.Path())
+		reqLog := log.WithContext(ctx)
+		reqLog.Debug("http request started").
+			Data("method", method).
+			Data("path", path).
+			Data("remote_addr", c.Context().RemoteAddr().String()).
+			Data("user_agent", string(c.Request().Header.UserAgent())).
+			Send()
+
+		err := next(c, reqLog)
+		if err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+		switch {
+		case status >= http.StatusInternalServerError:
+			reqLog = reqLog.Error("http request completed")
+		case status >= http.StatusBadRequest:
+			reqLog = reqLog.Warn("http request completed")
+		default:
+			reqLog = reqLog.Info("http request completed")
+		}
+		reqLog = reqLog.Data("method", method).
+			Data("path", path).
+			Data("status", status)
+		// Reading a streamed body would consume it; its size is only known
+		// from its Content-Length.
+		if !c.Response().IsBodyStream() {
+			reqLog = reqLog.Data("bytes", len(c.Response().Body()))
+		} else if size := c.Response().Header.ContentLength(); size >= 0 {
+			reqLog = reqLog.Data("bytes", size)
+		}
+		if err != nil {
+			reqLog = reqLog.ErrorData(err)
+		}
+		reqLog.Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+			Send()
+		return nil
+	}
+}
+
+// Recover returns Fiber middleware that recovers the panics of later
+// handlers and logs them with log, as New does, for applications logging
+// requests another way. It replaces Fiber's recover middleware:
+//
+//	app.Use(fiberlog.Recover(log))
+//
+// Panics are logged as "http request panicked" at error level with the
+// panic value, its stack, the method, path and request ID of the user
+// context, and returned as errors, so the application's error handler
+// answers them with a 500 response.
+func Recover(log gologger.Logger) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return next(c, log.WithContext(c.UserContext()))
+	}
+}
+
+// next runs the next handlers of c, logging and returning their panics as
+// errors.
+func next(c *fiber.Ctx, log gologger.Logger) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("http request panicked").
+				Data("panic", fmt.Sprint(r)).
+				Data("stack", string(debug.Stack())).
+				Data("method", c.Method()).
+				Data("path", strings.Clone(c.Path())).
+				Send()
+			var ok bool
+			if err, ok = r.(error); !ok {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+	return c.Next()
+}
+
+// Logger returns the logger of the request, bound to its user context so its
+// entries carry the request ID; see gologger.FromContext.
+func Logger(c *fiber.Ctx) gologger.Logger {
+	return gologger.FromContext(c.UserContext())
+}
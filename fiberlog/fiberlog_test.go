@@ -0,0 +1,174 @@
+package fiberlog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"go.risoftinc.com/gologger"
+)
+
+func TestNew(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	app := fiber.New()
+	app.Use(New(log))
+	app.Post("/users/:id", func(c *fiber.Ctx) error {
+		Logger(c).Info("loading user").Send()
+		return c.Status(fiber.StatusCreated).SendString("created")
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/users/1", nil)
+	req.Header.Set(fiber.HeaderXRequestID, "req-42")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.Header.Get(fiber.HeaderXRequestID) != "req-42" {
+		t.Errorf("Expected the request ID on the response, got %q", resp.Header.Get(fiber.HeaderXRequestID))
+	}
+
+	entries := capture.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected start, handler and finish entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Fields["request-id"] != "req-42" {
+			t.Errorf("Expected the request ID on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	start, finish := entries[0], entries[2]
+	if start.Level != gologger.LevelDebug || start.Fields["method"] != "POST" || start.Fields["path"] != "/users/1" {
+		t.Errorf("Unexpected start entry %+v", start)
+	}
+	if finish.Level != gologger.LevelInfo || finish.Fields["status"] != int64(fiber.StatusCreated) || finish.Fields["bytes"] != int64(7) {
+		t.Errorf("Unexpected finish entry %+v", finish)
+	}
+	if _, ok := finish.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected a duration, got %v", finish.Fields)
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	app := fiber.New()
+	app.Use(New(log))
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+	app.Get("/failing", func(c *fiber.Ctx) error {
+		return errors.New("database unavailable")
+	})
+
+	tests := []struct {
+		path   string
+		status int
+		level  string
+	}{
+		{"/missing", fiber.StatusNotFound, gologger.LevelWarn},
+		{"/failing", fiber.StatusInternalServerError, gologger.LevelError},
+	}
+	for _, tt := range tests {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, tt.path, nil))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != tt.status {
+			t.Errorf("%s: expected status %d from the error handler, got %d", tt.path, tt.status, resp.StatusCode)
+		}
+		finish := capture.FilterMessage("http request completed")
+		last := finish[len(finish)-1]
+		if last.Level != tt.level || last.Fields["status"] != int64(tt.status) || last.Fields["error"] == nil {
+			t.Errorf("%s: unexpected finish entry %+v", tt.path, last)
+		}
+	}
+}
+
+func TestNewPanic(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	app := fiber.New()
+	app.Use(New(log))
+	app.Get("/", func(c *fiber.Ctx) error {
+		panic("nil map")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("Expected a 500 response, got %d", resp.StatusCode)
+	}
+	panicked := capture.FilterMessage("http request panicked")
+	if len(panicked) != 1 || panicked[0].Fields["panic"] != "nil map" ||
+		!strings.Contains(panicked[0].Fields["stack"].(string), "fiberlog") {
+		t.Errorf("Expected the panic with its stack trace, got %+v", panicked)
+	}
+	if len(capture.FilterMessage("http request completed")) != 1 {
+		t.Error("Expected the request to be logged after the panic")
+	}
+}
+
+func TestRecover(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(gologger.WithRequestID(c.UserContext(), "req-42"))
+		return c.Next()
+	})
+	app.Use(Recover(log))
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		panic("nil map")
+	})
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusInternalServerError {
+		t.Errorf("Expected a 500 response, got %d", resp.StatusCode)
+	}
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Message != "http request panicked" || entries[0].Level != gologger.LevelError {
+		t.Fatalf("Expected only the panic entry, got %+v", entries)
+	}
+	if fields := entries[0].Fields; fields["method"] != "GET" || fields["path"] != "/orders/7" || fields["request-id"] != "req-42" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+}
+
+func TestNewRequestID(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	app := fiber.New()
+	app.Use(requestid.New(requestid.Config{Generator: func() string { return "from-requestid" }}))
+	app.Use(New(log))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(gologger.GetRequestID(c.UserContext()))
+	})
+
+	if _, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil)); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if len(capture.FilterField("request-id", "from-requestid")) != 2 {
+		t.Errorf("Expected the request ID of the requestid middleware, got %+v", capture.Entries())
+	}
+
+	generated := fiber.New()
+	generated.Use(func(c *fiber.Ctx) error {
+		c.SetUserContext(gologger.WithRequestID(context.Background(), "from-context"))
+		return c.Next()
+	})
+	generated.Use(New(log))
+	generated.Get("/", func(c *fiber.Ctx) error { return nil })
+	resp, err := generated.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.Header.Get(fiber.HeaderXRequestID) != "from-context" {
+		t.Errorf("Expected the request ID already in the user context, got %q", resp.Header.Get(fiber.HeaderXRequestID))
+	}
+}
@@ -0,0 +1,87 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFileSink(t *testing.T) {
+	tempDir := t.TempDir()
+	debug := NewFileSink(FileSinkConfig{
+		Name:        "debug",
+		LogDir:      tempDir,
+		LogRotation: &LogRotationConfig{Interval: RotateHourly, MaxAge: 1},
+	})
+	audit := NewFileSink(FileSinkConfig{
+		Name:        "audit",
+		LogDir:      tempDir,
+		Level:       LevelWarn,
+		LogRotation: &LogRotationConfig{MaxSize: 500, MaxAge: 365},
+	})
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelDebug,
+		Sinks:      []Sink{debug, audit},
+	})
+	log.Debug("debug entry").Send()
+	log.Warn("warn entry").Data("user", "alice").Send()
+	log.Close()
+
+	now := time.Now()
+	expected := map[string][]string{
+		"debug-" + now.Format("2006-01-02-15") + ".log": {"debug entry", "warn entry"},
+		"audit-" + now.Format("2006-01-02") + ".log":    {"warn entry"},
+	}
+	for name, messages := range expected {
+		data, err := os.ReadFile(filepath.Join(tempDir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if lines := strings.Count(string(data), "\n"); lines != len(messages) {
+			t.Errorf("Expected %d entries in %s, got %d", len(messages), name, lines)
+		}
+		for _, msg := range messages {
+			if !strings.Contains(string(data), `"msg":"`+msg+`"`) {
+				t.Errorf("Expected %q in %s, got %s", msg, name, data)
+			}
+		}
+	}
+
+	if debug.file.maxAge != 24*time.Hour || audit.file.maxSize != 500*1024*1024 {
+		t.Error("Expected each file sink to use its own rotation settings")
+	}
+}
+
+func TestFileSinkRotate(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := NewFileSink(FileSinkConfig{
+		Name:        "audit",
+		LogDir:      tempDir,
+		LogRotation: &LogRotationConfig{Compress: false, CurrentLink: "current.log"},
+	})
+	sink.Write(Entry{Time: time.Now(), Level: "info", Message: "before"})
+	if err := sink.Rotate(); err != nil {
+		t.Fatalf("Unexpected rotate error: %v", err)
+	}
+	sink.Write(Entry{Time: time.Now(), Level: "info", Message: "after"})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	var logs int
+	for _, name := range dirFiles(t, tempDir) {
+		if strings.HasSuffix(name, ".log") && strings.HasPrefix(name, "audit-2") {
+			logs++
+		}
+	}
+	if logs != 2 {
+		t.Errorf("Expected the current file and one backup, got %v", dirFiles(t, tempDir))
+	}
+	if _, err := os.Lstat(filepath.Join(tempDir, "audit-current.log")); runtime.GOOS != "windows" && err != nil {
+		t.Errorf("Expected the link to be prefixed with the sink name: %v", err)
+	}
+}
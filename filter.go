@@ -0,0 +1,48 @@
+package gologger
+
+// FilteredSink wraps a sink so entries matching conditions, such as health
+// check access logs or those of a noisy component, are dropped before they
+// reach it, while other outputs and sinks still receive them.
+type FilteredSink struct {
+	sink  Sink
+	drops []func(entry Entry) bool
+}
+
+// NewFilteredSink creates a filtering wrapper around sink, passing every
+// entry until conditions are added with DropIf:
+//
+//	siem := gologger.NewFilteredSink(gologger.NewSyslogSink(syslogConfig)).
+//		DropIf(func(e gologger.Entry) bool { return e.Fields["path"] == "/healthz" }).
+//		DropIf(func(e gologger.Entry) bool { return e.Logger == "kafka" })
+func NewFilteredSink(sink Sink) *FilteredSink {
+	return &FilteredSink{sink: sink}
+}
+
+// DropIf adds a condition dropping the entries for which drop returns true,
+// and returns s. Conditions are called in the order they were added, from
+// the goroutines logging the entries, and must not change them. Add them
+// before the sink is attached to a logger.
+func (s *FilteredSink) DropIf(drop func(e Entry) bool) *FilteredSink {
+	s.drops = append(s.drops, drop)
+	return s
+}
+
+// Write passes the entry to the wrapped sink unless a condition drops it.
+func (s *FilteredSink) Write(entry Entry) error {
+	for _, drop := range s.drops {
+		if drop(entry) {
+			return nil
+		}
+	}
+	return s.sink.Write(entry)
+}
+
+// Sync syncs the wrapped sink.
+func (s *FilteredSink) Sync() error {
+	return s.sink.Sync()
+}
+
+// Close closes the wrapped sink.
+func (s *FilteredSink) Close() error {
+	return s.sink.Close()
+}
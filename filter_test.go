@@ -0,0 +1,57 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFilteredSink(t *testing.T) {
+	all := NewCaptureSink()
+	filtered := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		Sinks: []Sink{
+			all,
+			NewFilteredSink(filtered).
+				DropIf(func(e Entry) bool { return e.Fields["path"] == "/healthz" }).
+				DropIf(func(e Entry) bool { return e.Logger == "kafka" }),
+		},
+	})
+
+	log.Info("request served").Data("path", "/healthz").Send()
+	log.Info("request served").Data("path", "/orders").Send()
+	log.Named("kafka").Debug("fetching metadata").Send()
+	log.Named("kafka").Error("broker unreachable").Send()
+	log.Close()
+
+	if all.Len() != 4 {
+		t.Errorf("Expected the unfiltered sink to receive every entry, got %d", all.Len())
+	}
+	entries := filtered.Entries()
+	if len(entries) != 1 || entries[0].Fields["path"] != "/orders" {
+		t.Errorf("Expected only the entry not matching a condition, got %+v", entries)
+	}
+}
+
+func TestFilteredSinkSyncAndClose(t *testing.T) {
+	inner := &recordingSink{}
+	sink := NewFilteredSink(inner).DropIf(func(e Entry) bool { return strings.HasPrefix(e.Message, "noise") })
+	if err := sink.Write(Entry{Message: "noise: retrying"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := sink.Write(Entry{Message: "kept"}); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := sink.Sync(); err != nil {
+		t.Errorf("Sync failed: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if entries := inner.Entries(); len(entries) != 1 || entries[0].Message != "kept" {
+		t.Errorf("Expected only the kept entry, got %+v", entries)
+	}
+	if inner.synced != 1 || !inner.closed {
+		t.Error("Expected Sync and Close to reach the wrapped sink")
+	}
+}
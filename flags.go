@@ -0,0 +1,78 @@
+package gologger
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// LogFlags holds the values of the logging flags registered by RegisterFlags.
+type LogFlags struct {
+	Verbose bool   // -v, --verbose: log at debug level
+	Level   string // --log-level: log level, overrides --verbose
+	Format  string // --log-format: encoding of the outputs, e.g. json or console
+	File    string // --log-file: file also receiving every entry as JSON lines
+}
+
+// RegisterFlags registers the -v/--verbose, --log-level, --log-format and
+// --log-file flags on fs, for command-line tools. Build the configuration
+// with LogFlags.Config once fs is parsed. Cobra commands accept the flags
+// through pflag:
+//
+//	fs := flag.NewFlagSet("log", flag.ContinueOnError)
+//	logFlags := gologger.RegisterFlags(fs)
+//	rootCmd.PersistentFlags().AddGoFlagSet(fs)
+func RegisterFlags(fs *flag.FlagSet) *LogFlags {
+	f := &LogFlags{}
+	fs.BoolVar(&f.Verbose, "v", false, "log at debug level (shorthand for --verbose)")
+	fs.BoolVar(&f.Verbose, "verbose", false, "log at debug level")
+	fs.StringVar(&f.Level, "log-level", "", "log level: "+strings.Join(levels, ", "))
+	fs.StringVar(&f.Format, "log-format", "", "log format: "+strings.Join(encodings, ", "))
+	fs.StringVar(&f.File, "log-file", "", "also append every entry to this file as JSON lines")
+	return f
+}
+
+// Config returns base with the options set by the flags: --verbose sets
+// LogLevel to debug unless --log-level is given, --log-format sets Encoding,
+// and --log-file adds a sink appending to the file, which is closed by the
+// logger's Close. Flags left unset keep the options of base. Unknown levels
+// and formats are errors.
+func (f *LogFlags) Config(base LoggerConfig) (LoggerConfig, error) {
+	config := base
+	if f.Verbose {
+		config.LogLevel = LevelDebug
+	}
+	if f.Level != "" {
+		if !slices.Contains(levels, f.Level) {
+			return base, fmt.Errorf("gologger: --log-level: unknown value %q, expected one of %v", f.Level, levels)
+		}
+		config.LogLevel = f.Level
+	}
+	if f.Format != "" {
+		if !slices.Contains(encodings, f.Format) {
+			return base, fmt.Errorf("gologger: --log-format: unknown value %q, expected one of %v", f.Format, encodings)
+		}
+		config.Encoding = f.Format
+	}
+	if f.File != "" {
+		file, err := os.OpenFile(f.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return base, fmt.Errorf("gologger: --log-file: %w", err)
+		}
+		config.Sinks = append(slices.Clone(base.Sinks), &fileWriterSink{WriterSink: NewWriterSink(file), file: file})
+	}
+	return config, nil
+}
+
+// fileWriterSink is a WriterSink that closes the file it writes to.
+type fileWriterSink struct {
+	*WriterSink
+	file *os.File
+}
+
+func (s *fileWriterSink) Close() error {
+	return errors.Join(s.WriterSink.Close(), s.file.Close())
+}
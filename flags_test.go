@@ -0,0 +1,95 @@
+package gologger
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogFlagsConfig(t *testing.T) {
+	tests := []struct {
+		args     []string
+		level    string
+		encoding string
+	}{
+		{nil, LevelWarn, EncodingJSON},
+		{[]string{"-v"}, LevelDebug, EncodingJSON},
+		{[]string{"--verbose", "--log-format=console"}, LevelDebug, EncodingConsole},
+		{[]string{"-v", "--log-level", "error"}, LevelError, EncodingJSON},
+		{[]string{"--log-level=info", "--log-format", "pretty"}, LevelInfo, EncodingPretty},
+	}
+
+	base := LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelWarn, Encoding: EncodingJSON}
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags := RegisterFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		config, err := flags.Config(base)
+		if err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		if config.LogLevel != tt.level || config.Encoding != tt.encoding || config.OutputMode != OutputDiscard {
+			t.Errorf("%v: expected level %s and encoding %s, got %+v", tt.args, tt.level, tt.encoding, config)
+		}
+	}
+}
+
+func TestLogFlagsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	if err := os.WriteFile(path, []byte("previous\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := RegisterFlags(fs)
+	if err := fs.Parse([]string{"--log-file", path}); err != nil {
+		t.Fatal(err)
+	}
+	config, err := flags.Config(LoggerConfig{OutputMode: OutputDiscard})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Sinks) != 1 {
+		t.Fatalf("Expected a sink for the log file, got %d", len(config.Sinks))
+	}
+
+	log := NewLoggerWithConfig(config)
+	log.Info("to file").Data("attempt", 2).Send()
+	log.Close()
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || lines[0] != "previous" || !strings.Contains(lines[1], `"msg":"to file"`) {
+		t.Errorf("Expected the entry appended to the file, got %q", data)
+	}
+	if _, err := config.Sinks[0].(*fileWriterSink).file.Write([]byte("x")); err == nil {
+		t.Error("Expected Close to close the file")
+	}
+}
+
+func TestLogFlagsErrors(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"--log-level=verbose"}, `--log-level: unknown value "verbose"`},
+		{[]string{"--log-format=xml"}, `--log-format: unknown value "xml"`},
+		{[]string{"--log-file", filepath.Join(t.TempDir(), "missing", "app.log")}, "--log-file:"},
+	}
+
+	for _, tt := range tests {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		flags := RegisterFlags(fs)
+		if err := fs.Parse(tt.args); err != nil {
+			t.Fatalf("%v: %v", tt.args, err)
+		}
+		_, err := flags.Config(LoggerConfig{})
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("%v: expected error containing %q, got %v", tt.args, tt.expected, err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package gologger
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Policies for flushing log files to stable storage with fsync.
+const (
+	SyncNever    = "never"    // Never fsync; the operating system writes data back on its own schedule
+	SyncOnError  = "error"    // Fsync after every error, panic or fatal entry, and when a file is closed
+	SyncInterval = "interval" // Fsync every FileSyncInterval, and when a file is closed
+	SyncOnClose  = "close"    // Fsync only when a file is closed by rotation or Close
+)
+
+// syncErrorCore syncs its output after writing entries at error level and
+// above, so they reach stable storage before the call returns.
+type syncErrorCore struct {
+	zapcore.Core
+	ws     zapcore.WriteSyncer
+	report func(error)
+}
+
+func (c *syncErrorCore) With(fields []zapcore.Field) zapcore.Core {
+	return &syncErrorCore{Core: c.Core.With(fields), ws: c.ws, report: c.report}
+}
+
+func (c *syncErrorCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *syncErrorCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if err := c.Core.Write(ent, fields); err != nil {
+		return err
+	}
+	if ent.Level >= zapcore.ErrorLevel {
+		if err := c.ws.Sync(); err != nil {
+			c.report(err)
+		}
+	}
+	return nil
+}
+
+// syncEvery syncs ws on every interval until the returned stop function is called.
+func syncEvery(ws zapcore.WriteSyncer, interval time.Duration, report func(error)) func() error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := ws.Sync(); err != nil {
+					report(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() error {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+		return nil
+	}
+}
@@ -0,0 +1,93 @@
+package gologger
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// countingSyncer counts Sync calls and fails them with err.
+type countingSyncer struct {
+	syncs atomic.Int32
+	err   error
+}
+
+func (s *countingSyncer) Write(p []byte) (int, error) { return len(p), nil }
+
+func (s *countingSyncer) Sync() error {
+	s.syncs.Add(1)
+	return s.err
+}
+
+func TestSyncErrorCore(t *testing.T) {
+	ws := &countingSyncer{err: errors.New("disk failure")}
+	var reported []error
+	encoder := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	var core zapcore.Core = &syncErrorCore{
+		Core:   zapcore.NewCore(encoder, ws, zapcore.DebugLevel),
+		ws:     ws,
+		report: func(err error) { reported = append(reported, err) },
+	}
+	core = core.With([]zapcore.Field{{Key: "service", Type: zapcore.StringType, String: "api"}})
+
+	for _, level := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel} {
+		if ce := core.Check(zapcore.Entry{Level: level, Message: "entry"}, nil); ce != nil {
+			ce.Write()
+		}
+	}
+
+	if syncs := ws.syncs.Load(); syncs != 1 {
+		t.Errorf("Expected 1 sync for the error entry, got %d", syncs)
+	}
+	if len(reported) != 1 {
+		t.Errorf("Expected the sync error to be reported, got %v", reported)
+	}
+}
+
+func TestSyncEvery(t *testing.T) {
+	ws := &countingSyncer{}
+	stop := syncEvery(ws, 10*time.Millisecond, func(error) {})
+	time.Sleep(55 * time.Millisecond)
+	stop()
+	stop()
+
+	syncs := ws.syncs.Load()
+	if syncs < 2 {
+		t.Errorf("Expected periodic syncs, got %d", syncs)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if ws.syncs.Load() != syncs {
+		t.Error("Expected no syncs after stop")
+	}
+}
+
+func TestLoggerFileSync(t *testing.T) {
+	for policy, want := range map[string]bool{
+		"":           false,
+		SyncNever:    false,
+		SyncOnError:  true,
+		SyncInterval: true,
+		SyncOnClose:  true,
+	} {
+		log := NewLoggerWithConfig(LoggerConfig{
+			OutputMode:       OutputFile,
+			LogDir:           t.TempDir(),
+			FileSync:         policy,
+			FileSyncInterval: time.Millisecond,
+			LevelFiles:       map[string]*LogRotationConfig{LevelError: nil},
+		})
+		log.Error("entry").Send()
+		for _, file := range log.outputs.load().files {
+			if file.fsync != want {
+				t.Errorf("FileSync %q: fsync = %v, want %v", policy, file.fsync, want)
+			}
+			if err := file.Sync(); err != nil {
+				t.Errorf("FileSync %q: unexpected sync error: %v", policy, err)
+			}
+		}
+		log.Close()
+	}
+}
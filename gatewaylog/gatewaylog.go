@@ -0,0 +1,262 @@
+// Package gatewaylog logs the requests of services exposing gRPC both
+// directly and through grpc-gateway, correlating each HTTP request with the
+// gRPC call the gateway makes for it:
+//
+//	conn, err := grpc.NewClient(grpcAddr,
+//		grpc.WithTransportCredentials(insecure.NewCredentials()),
+//		grpc.WithUnaryInterceptor(gatewaylog.UnaryClientInterceptor()),
+//		grpc.WithStreamInterceptor(gatewaylog.StreamClientInterceptor()),
+//	)
+//	mux := runtime.NewServeMux()
+//	err = pb.RegisterOrdersHandler(ctx, mux, conn)
+//	http.ListenAndServe(":8080", gatewaylog.Middleware(log)(mux))
+//
+//	srv := grpc.NewServer(
+//		grpc.UnaryInterceptor(gatewaylog.UnaryServerInterceptor(log)),
+//		grpc.StreamInterceptor(gatewaylog.StreamServerInterceptor(log)),
+//	)
+//
+// The request ID of the HTTP request is sent to the gRPC server in the
+// x-request-id metadata, so the entries of gRPC handlers carry it, and the
+// gateway logs one access entry with the metadata of both transports.
+//
+// It is a separate module, so applications not using gRPC do not depend on
+// it.
+package gatewaylog
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.risoftinc.com/gologger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey is the gRPC metadata key carrying the request ID.
+const MetadataKey = "x-request-id"
+
+// callKey is the context key of the gRPC call of an HTTP request.
+type callKey struct{}
+
+// call is the gRPC call made by the gateway for an HTTP request, recorded
+// by the client interceptors for Middleware.
+type call struct {
+	mu       sync.Mutex
+	method   string
+	code     codes.Code
+	duration time.Duration
+}
+
+// record records the gRPC call of the context, if it was made for an HTTP
+// request handled by Middleware.
+func record(ctx context.Context, method string, err error, duration time.Duration) {
+	c, ok := ctx.Value(callKey{}).(*call)
+	if !ok {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.method, c.code, c.duration = method, status.Code(err), duration
+}
+
+// Middleware returns net/http middleware for the gateway mux, logging every
+// request with log as one "gateway request completed" entry. The entry has
+// the HTTP method, path, status, response size and duration, as the
+// completion entry of gologger.HTTPMiddleware, and the full method, status
+// code and duration of the gRPC call the gateway made as "grpc_method",
+// "grpc_code" and "grpc_duration_ms", recorded by the client interceptors;
+// the last call, if it made several. Requests are logged at info level, warn
+// for 4xx or error for 5xx responses.
+//
+// The request ID is taken from the context or the X-Request-ID header, or
+// generated, and set on the response. The context of the request holds
+// log, for FromContext in gateway hooks such as error handlers.
+func Middleware(log gologger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+			requestID := gologger.GetRequestID(ctx)
+			if requestID == "" {
+				requestID = gologger.RequestIDFromHeader(r.Header.Get(gologger.RequestIDHeader))
+				ctx = gologger.WithRequestID(ctx, requestID)
+			}
+			c := &call{}
+			ctx = gologger.NewContext(context.WithValue(ctx, callKey{}, c), log)
+			w.Header().Set(gologger.RequestIDHeader, requestID)
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			reqLog := log.WithContext(ctx)
+			switch {
+			case status >= http.StatusInternalServerError:
+				reqLog = reqLog.Error("gateway request completed")
+			case status >= http.StatusBadRequest:
+				reqLog = reqLog.Warn("gateway request completed")
+			default:
+				reqLog = reqLog.Info("gateway request completed")
+			}
+			reqLog = reqLog.Data("method", r.Method).
+				Data("path", r.URL.Path).
+				Data("status", status).
+				Data("bytes", rec.bytes).
+				Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond))
+			c.mu.Lock()
+			if c.method != "" {
+				reqLog = reqLog.Data("grpc_method", c.method).
+					Data("grpc_code", c.code.String()).
+					Data("grpc_duration_ms", float64(c.duration)/float64(time.Millisecond))
+			}
+			c.mu.Unlock()
+			reqLog.Send()
+		})
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor for the connection of
+// the gateway to the gRPC server, sending the request ID of the context in
+// the x-request-id metadata and recording each call for Middleware.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(outgoing(ctx), method, req, reply, cc, opts...)
+		record(ctx, method, err, time.Since(start))
+		return err
+	}
+}
+
+// StreamClientInterceptor returns the streaming counterpart of
+// UnaryClientInterceptor, for server-streaming methods exposed by the
+// gateway. A stream is recorded when it ends.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(outgoing(ctx), desc, cc, method, opts...)
+		if err != nil {
+			record(ctx, method, err, time.Since(start))
+			return nil, err
+		}
+		return &clientStream{ClientStream: stream, ctx: ctx, method: method, start: start}, nil
+	}
+}
+
+// outgoing returns ctx with its request ID in the outgoing metadata, unless
+// it has none or the metadata already carries one.
+func outgoing(ctx context.Context) context.Context {
+	requestID := gologger.GetRequestID(ctx)
+	if requestID == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(MetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, MetadataKey, requestID)
+}
+
+// clientStream records a client stream when it ends.
+type clientStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	method string
+	start  time.Time
+	once   sync.Once
+}
+
+func (s *clientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err != nil {
+		s.once.Do(func() {
+			if err == io.EOF {
+				record(s.ctx, s.method, nil, time.Since(s.start))
+				return
+			}
+			record(s.ctx, s.method, err, time.Since(s.start))
+		})
+	}
+	return err
+}
+
+// UnaryServerInterceptor returns a server interceptor taking the request
+// ID of each call from the x-request-id metadata, or generating one, and
+// passing handlers a context with the request ID and log, so the entries
+// handlers log with gologger.FromContext carry the request ID of the HTTP
+// request the gateway received.
+func UnaryServerInterceptor(log gologger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(incoming(ctx, log), req)
+	}
+}
+
+// StreamServerInterceptor returns the streaming counterpart of
+// UnaryServerInterceptor.
+func StreamServerInterceptor(log gologger.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: incoming(ss.Context(), log)})
+	}
+}
+
+// incoming returns ctx with the request ID of its incoming metadata and log.
+func incoming(ctx context.Context, log gologger.Logger) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(MetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	return gologger.NewContext(gologger.WithRequestID(ctx, gologger.RequestIDFromHeader(requestID)), log)
+}
+
+// serverStream is a server stream with the context of incoming.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// statusRecorder records the status code and body size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush keeps the streaming responses of server-streaming methods working
+// through the recorder.
+func (r *statusRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,182 @@
+package gatewaylog
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.risoftinc.com/gologger"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newGateway starts a gRPC health server logging with log and returns an
+// HTTP handler standing in for a gateway mux, which calls Check for the
+// service named by the service query parameter, or Watch for the
+// /v1/health:watch path.
+func newGateway(t *testing.T, log gologger.Logger) http.Handler {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			UnaryServerInterceptor(log),
+			func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				gologger.FromContext(ctx).Info("checking health").Send()
+				return handler(ctx, req)
+			},
+		),
+		grpc.ChainStreamInterceptor(
+			StreamServerInterceptor(log),
+			func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+				gologger.FromContext(ss.Context()).Info("watching health").Send()
+				return handler(srv, ss)
+			},
+		),
+	)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(StreamClientInterceptor()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	client := healthpb.NewHealthClient(conn)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/health:watch" {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{})
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			resp, err := stream.Recv()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadGateway)
+				return
+			}
+			w.Write([]byte(resp.GetStatus().String()))
+			cancel()
+			stream.Recv()
+			return
+		}
+		resp, err := client.Check(r.Context(), &healthpb.HealthCheckRequest{Service: r.URL.Query().Get("service")})
+		if status.Code(err) == codes.NotFound {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte(resp.GetStatus().String()))
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	handler := Middleware(log)(newGateway(t, log))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/health", nil)
+	req.Header.Set(gologger.RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get(gologger.RequestIDHeader) != "req-42" {
+		t.Errorf("Expected the request ID on the response, got %q", rec.Header().Get(gologger.RequestIDHeader))
+	}
+	checking := capture.FilterMessage("checking health")
+	if len(checking) != 1 || checking[0].Fields["request-id"] != "req-42" {
+		t.Fatalf("Expected the gRPC handler entry to carry the HTTP request ID, got %+v", capture.Entries())
+	}
+	completed := capture.FilterMessage("gateway request completed")
+	if len(completed) != 1 {
+		t.Fatalf("Expected one access entry, got %+v", capture.Entries())
+	}
+	entry := completed[0]
+	if entry.Level != gologger.LevelInfo || entry.Fields["request-id"] != "req-42" || entry.Fields["method"] != "GET" ||
+		entry.Fields["path"] != "/v1/health" || entry.Fields["status"] != int64(200) || entry.Fields["bytes"] != int64(len("SERVING")) {
+		t.Errorf("Unexpected HTTP fields %+v", entry)
+	}
+	if entry.Fields["grpc_method"] != "/grpc.health.v1.Health/Check" || entry.Fields["grpc_code"] != "OK" {
+		t.Errorf("Unexpected gRPC fields %v", entry.Fields)
+	}
+	for _, key := range []string{"duration_ms", "grpc_duration_ms"} {
+		if _, ok := entry.Fields[key].(float64); !ok {
+			t.Errorf("Expected %s, got %v", key, entry.Fields)
+		}
+	}
+}
+
+func TestMiddlewareError(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	handler := Middleware(log)(newGateway(t, log))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/v1/health?service=billing", nil))
+
+	completed := capture.FilterMessage("gateway request completed")
+	if len(completed) != 1 {
+		t.Fatalf("Expected one access entry, got %+v", capture.Entries())
+	}
+	entry := completed[0]
+	if entry.Level != gologger.LevelWarn || entry.Fields["status"] != int64(404) || entry.Fields["grpc_code"] != "NotFound" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	requestID, _ := entry.Fields["request-id"].(string)
+	if len(requestID) != 32 {
+		t.Errorf("Expected a generated request ID, got %q", requestID)
+	}
+	if checking := capture.FilterMessage("checking health"); len(checking) != 1 || checking[0].Fields["request-id"] != requestID {
+		t.Errorf("Expected the generated request ID in the gRPC handler, got %+v", checking)
+	}
+}
+
+func TestMiddlewareWithoutCall(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	handler := Middleware(log)(http.NotFoundHandler())
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+	completed := capture.FilterMessage("gateway request completed")
+	if len(completed) != 1 || completed[0].Fields["status"] != int64(404) {
+		t.Fatalf("Expected one access entry, got %+v", capture.Entries())
+	}
+	if _, ok := completed[0].Fields["grpc_method"]; ok {
+		t.Errorf("Expected no gRPC fields without a call, got %v", completed[0].Fields)
+	}
+}
+
+func TestMiddlewareStream(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	handler := Middleware(log)(newGateway(t, log))
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/health:watch", nil)
+	req.Header.Set(gologger.RequestIDHeader, "req-43")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if watching := capture.FilterMessage("watching health"); len(watching) != 1 || watching[0].Fields["request-id"] != "req-43" {
+		t.Fatalf("Expected the gRPC stream handler entry to carry the HTTP request ID, got %+v", capture.Entries())
+	}
+	completed := capture.FilterMessage("gateway request completed")
+	if len(completed) != 1 {
+		t.Fatalf("Expected one access entry, got %+v", capture.Entries())
+	}
+	if completed[0].Fields["grpc_method"] != "/grpc.health.v1.Health/Watch" || completed[0].Fields["grpc_code"] != "Canceled" {
+		t.Errorf("Expected the ended stream, got %v", completed[0].Fields)
+	}
+}
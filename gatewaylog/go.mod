@@ -0,0 +1,27 @@
+// MIT License
+// Copyright (c) 2025 Risoftinc.
+module go.risoftinc.com/gologger/gatewaylog
+
+go 1.21
+
+// Build against the gologger of this repository.
+replace go.risoftinc.com/gologger => ../
+
+require (
+	go.risoftinc.com/gologger v0.0.0-00010101000000-000000000000
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/fsnotify/fsnotify v1.9.0 // indirect
+	github.com/klauspost/compress v1.17.11 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	go.uber.org/zap v1.27.0 // indirect
+	golang.org/x/net v0.25.0 // indirect
+	golang.org/x/sys v0.20.0 // indirect
+	golang.org/x/text v0.15.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20240528184218-531527333157 // indirect
+	google.golang.org/protobuf v1.34.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
@@ -0,0 +1,183 @@
+package gologger
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	gcpMetadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+	gcpDefaultTokenURL  = "https://oauth2.googleapis.com/token"
+)
+
+// gcpTokenSource obtains and caches OAuth2 access tokens for Google APIs,
+// from a service account key file or, without one, from the metadata server.
+type gcpTokenSource struct {
+	client  *http.Client
+	scope   string
+	keyFile string
+	now     func() time.Time
+
+	mu      sync.Mutex
+	token   string
+	expires time.Time
+}
+
+// gcpServiceAccount is the subset of a service account key file used for
+// the JWT bearer flow.
+type gcpServiceAccount struct {
+	Type        string `json:"type"`
+	ClientEmail string `json:"client_email"`
+	PrivateKey  string `json:"private_key"`
+	TokenURI    string `json:"token_uri"`
+}
+
+// newGCPTokenSource creates a token source. An empty keyFile falls back to
+// GOOGLE_APPLICATION_CREDENTIALS and then to the metadata server.
+func newGCPTokenSource(client *http.Client, keyFile, scope string) *gcpTokenSource {
+	if keyFile == "" {
+		keyFile = os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	}
+	return &gcpTokenSource{client: client, scope: scope, keyFile: keyFile, now: time.Now}
+}
+
+// Token returns a cached token, refreshing it shortly before it expires.
+func (s *gcpTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && s.now().Before(s.expires.Add(-time.Minute)) {
+		return s.token, nil
+	}
+
+	var req *http.Request
+	var err error
+	if s.keyFile != "" {
+		req, err = s.serviceAccountRequest(ctx)
+	} else {
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, gcpMetadataTokenURL, nil)
+		if req != nil {
+			req.Header.Set("Metadata-Flavor", "Google")
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gcp: fetch token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", fmt.Errorf("gcp: read token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("gcp: fetch token: %s: %s", resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("gcp: decode token: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", errors.New("gcp: token response without access_token")
+	}
+
+	s.token = token.AccessToken
+	s.expires = s.now().Add(time.Duration(token.ExpiresIn) * time.Second)
+	return s.token, nil
+}
+
+// serviceAccountRequest builds a JWT bearer token request signed with the
+// service account's private key.
+func (s *gcpTokenSource) serviceAccountRequest(ctx context.Context) (*http.Request, error) {
+	data, err := os.ReadFile(s.keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("gcp: read credentials: %w", err)
+	}
+	var account gcpServiceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("gcp: decode credentials: %w", err)
+	}
+	if account.Type != "service_account" {
+		return nil, fmt.Errorf("gcp: unsupported credentials type %q", account.Type)
+	}
+	if account.TokenURI == "" {
+		account.TokenURI = gcpDefaultTokenURL
+	}
+
+	key, err := parseRSAPrivateKey(account.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+	claims, _ := json.Marshal(map[string]any{
+		"iss":   account.ClientEmail,
+		"scope": s.scope,
+		"aud":   account.TokenURI,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	unsigned := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return nil, fmt.Errorf("gcp: sign token request: %w", err)
+	}
+	assertion := unsigned + "." + base64.RawURLEncoding.EncodeToString(signature)
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, account.TokenURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("gcp: create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS#8 or PKCS#1 RSA private key.
+func parseRSAPrivateKey(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, errors.New("gcp: private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("gcp: parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("gcp: private key is not an RSA key")
+	}
+	return key, nil
+}
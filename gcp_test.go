@@ -0,0 +1,100 @@
+package gologger
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGCPTokenSourceServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Invalid form: %v", err)
+		}
+		parts := strings.Split(r.PostForm.Get("assertion"), ".")
+		if len(parts) != 3 {
+			t.Errorf("Invalid JWT assertion %q", r.PostForm.Get("assertion"))
+			return
+		}
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+		if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+			t.Errorf("Invalid JWT signature: %v", err)
+		}
+		claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+		if !strings.Contains(string(claims), `"iss":"logger@project.iam.gserviceaccount.com"`) {
+			t.Errorf("Unexpected claims %s", claims)
+		}
+		_, _ = w.Write([]byte(`{"access_token":"ya29.token","expires_in":3600}`))
+	}))
+	defer server.Close()
+
+	credentials, _ := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"client_email": "logger@project.iam.gserviceaccount.com",
+		"private_key":  string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"token_uri":    server.URL,
+	})
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(keyFile, credentials, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	source := newGCPTokenSource(server.Client(), keyFile, pubsubScope)
+	source.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		token, err := source.Token(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if token != "ya29.token" {
+			t.Errorf("Unexpected token %q", token)
+		}
+	}
+	if requests.Load() != 1 {
+		t.Errorf("Expected the token to be cached, got %d requests", requests.Load())
+	}
+
+	now = now.Add(time.Hour)
+	if _, err := source.Token(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if requests.Load() != 2 {
+		t.Errorf("Expected the expired token to be refreshed, got %d requests", requests.Load())
+	}
+}
+
+func TestGCPTokenSourceInvalidCredentials(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(keyFile, []byte(`{"type":"authorized_user"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	source := newGCPTokenSource(http.DefaultClient, keyFile, pubsubScope)
+	if _, err := source.Token(context.Background()); err == nil || !strings.Contains(err.Error(), "unsupported credentials type") {
+		t.Errorf("Expected credentials type error, got %v", err)
+	}
+}
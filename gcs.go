@@ -0,0 +1,115 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const gcsScope = "https://www.googleapis.com/auth/devstorage.read_write"
+
+// GCSUploaderConfig holds configuration options for the Google Cloud Storage uploader.
+type GCSUploaderConfig struct {
+	Bucket            string                                    // Bucket name (required)
+	Prefix            string                                    // Object name prefix, e.g. "logs/web-1/" (optional)
+	Endpoint          string                                    // API endpoint (default: "https://storage.googleapis.com")
+	CredentialsFile   string                                    // Service account key file (default: GOOGLE_APPLICATION_CREDENTIALS, then the metadata server)
+	TokenSource       func(ctx context.Context) (string, error) // Supplies OAuth2 access tokens, overriding CredentialsFile (optional)
+	DeleteAfterUpload bool                                      // Remove the local file once it is uploaded (default: false)
+	Timeout           time.Duration                             // Timeout for a single upload (default: 60s)
+	HTTPClient        *http.Client                              // HTTP client used for API calls (optional)
+}
+
+// GCSUploader uploads rotated log files to a Google Cloud Storage bucket
+// with the JSON API media upload. The object name is the prefix followed by
+// the file name. Use Upload as LogRotationConfig.OnRotate.
+type GCSUploader struct {
+	url     string
+	prefix  string
+	token   func(ctx context.Context) (string, error)
+	remove  bool
+	timeout time.Duration
+	client  *http.Client
+}
+
+// NewGCSUploader creates a Cloud Storage uploader. Unset options fall back to their defaults.
+func NewGCSUploader(config GCSUploaderConfig) *GCSUploader {
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://storage.googleapis.com"
+	}
+	token := config.TokenSource
+	if token == nil {
+		token = newGCPTokenSource(client, config.CredentialsFile, gcsScope).Token
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 60 * time.Second
+	}
+
+	return &GCSUploader{
+		url:     strings.TrimRight(endpoint, "/") + "/upload/storage/v1/b/" + url.PathEscape(config.Bucket) + "/o",
+		prefix:  config.Prefix,
+		token:   token,
+		remove:  config.DeleteAfterUpload,
+		timeout: timeout,
+		client:  client,
+	}
+}
+
+// Upload stores the file at path in the bucket.
+func (u *GCSUploader) Upload(path string) error {
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("gcs: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), u.timeout)
+	defer cancel()
+
+	token, err := u.token(ctx)
+	if err != nil {
+		return fmt.Errorf("gcs: %w", err)
+	}
+
+	name := u.prefix + filepath.Base(path)
+	query := url.Values{"uploadType": {"media"}, "name": {name}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.url+"?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("gcs: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", logFileContentType(path))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gcs: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("gcs: upload %s returned %s: %s", name, resp.Status, bytes.TrimSpace(data))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if u.remove {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("gcs: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package gologger
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGCSUploaderUpload(t *testing.T) {
+	type request struct {
+		path, uploadType, name, contentType, auth, body string
+	}
+	received := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		query := r.URL.Query()
+		received <- request{r.URL.Path, query.Get("uploadType"), query.Get("name"), r.Header.Get("Content-Type"), r.Header.Get("Authorization"), string(body)}
+		_, _ = w.Write([]byte(`{"name":"logs/logger-2024-03-01.log"}`))
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "logger-2024-03-01.log")
+	if err := os.WriteFile(path, []byte("line\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	uploader := NewGCSUploader(GCSUploaderConfig{
+		Bucket:      "app-logs",
+		Prefix:      "logs/",
+		Endpoint:    server.URL,
+		TokenSource: func(context.Context) (string, error) { return "token-1", nil },
+	})
+	if err := uploader.Upload(path); err != nil {
+		t.Fatalf("Unexpected upload error: %v", err)
+	}
+
+	req := <-received
+	expected := request{
+		path:        "/upload/storage/v1/b/app-logs/o",
+		uploadType:  "media",
+		name:        "logs/logger-2024-03-01.log",
+		contentType: "text/plain; charset=utf-8",
+		auth:        "Bearer token-1",
+		body:        "line\n",
+	}
+	if req != expected {
+		t.Errorf("Expected request %+v, got %+v", expected, req)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Expected the file to be kept without DeleteAfterUpload")
+	}
+}
+
+func TestGCSUploaderErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"message":"bucket not found"}}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "logger-2024-03-01.log")
+	_ = os.WriteFile(path, []byte("line\n"), 0644)
+
+	uploader := NewGCSUploader(GCSUploaderConfig{
+		Bucket:      "missing",
+		Endpoint:    server.URL,
+		TokenSource: func(context.Context) (string, error) { return "t", nil },
+	})
+	if err := uploader.Upload(path); err == nil || !strings.Contains(err.Error(), "bucket not found") {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
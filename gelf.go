@@ -0,0 +1,269 @@
+package gologger
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// GELF transports.
+const (
+	GELFTransportUDP = "udp"
+	GELFTransportTCP = "tcp"
+)
+
+const (
+	gelfChunkHeaderSize = 12
+	gelfMaxChunks       = 128
+)
+
+var gelfFieldPattern = regexp.MustCompile(`[^\w.\-]`)
+
+// GELFConfig holds configuration options for the Graylog GELF sink.
+type GELFConfig struct {
+	Addr          string        // Graylog input address (default: "localhost:12201")
+	Transport     string        // Transport: GELFTransportUDP or GELFTransportTCP (default: GELFTransportUDP)
+	Host          string        // Value of the GELF host field (default: os.Hostname())
+	ChunkSize     int           // Maximum UDP datagram size; larger messages are chunked (default: 1420)
+	Compression   string        // UDP payload compression: CompressionNone or CompressionGzip (default: none); ignored for TCP
+	TLS           *TLSConfig    // TLS client options for TCP (optional)
+	BatchSize     int           // Maximum entries per batch (default: 100)
+	FlushInterval time.Duration // Maximum time an entry waits before being sent (default: 1s)
+	Timeout       time.Duration // Dial and write timeout (default: 5s)
+}
+
+// GELFSink sends entries to Graylog as GELF 1.1 messages. Levels map to
+// syslog severities and fields become additional "_" prefixed fields.
+// Over UDP, messages larger than ChunkSize are split into GELF chunks;
+// over TCP, messages are null-byte delimited on a single connection.
+type GELFSink struct {
+	config GELFConfig
+	batch  *batcher
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewGELFSink creates a GELF sink. Unset options fall back to their defaults.
+// The connection is established lazily on the first flush.
+func NewGELFSink(config GELFConfig) *GELFSink {
+	if config.Addr == "" {
+		config.Addr = "localhost:12201"
+	}
+	if config.Transport == "" {
+		config.Transport = GELFTransportUDP
+	}
+	if config.Host == "" {
+		config.Host, _ = os.Hostname()
+	}
+	if config.ChunkSize <= gelfChunkHeaderSize {
+		config.ChunkSize = 1420
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	s := &GELFSink{config: config}
+	s.batch = newBatcher(config.BatchSize, config.FlushInterval, s.send)
+	return s
+}
+
+// Write queues an entry for sending.
+func (s *GELFSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync sends all queued entries.
+func (s *GELFSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close sends all queued entries and closes the connection.
+func (s *GELFSink) Close() error {
+	err := s.batch.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// message encodes an entry as a GELF 1.1 JSON document.
+func (s *GELFSink) message(entry Entry) ([]byte, error) {
+	msg := make(map[string]any, len(entry.Fields)+7)
+	for key, value := range entry.Fields {
+		msg[gelfFieldName(key)] = gelfValue(normalizeValue(value))
+	}
+	msg["version"] = "1.1"
+	msg["host"] = s.config.Host
+	msg["short_message"] = entry.Message
+	msg["timestamp"] = float64(entry.Time.UnixNano()/int64(time.Millisecond)) / 1000
+	msg["level"] = syslogSeverity(entry.Level)
+	if entry.Caller != "" {
+		msg["_caller"] = entry.Caller
+	}
+	if entry.Stack != "" {
+		msg["full_message"] = entry.Stack
+	}
+	return json.Marshal(msg)
+}
+
+// gelfFieldName turns a field key into a valid additional field name.
+// The "_id" field is reserved by GELF and is renamed to "__id".
+func gelfFieldName(key string) string {
+	name := "_" + gelfFieldPattern.ReplaceAllString(key, "_")
+	if name == "_id" {
+		name = "__id"
+	}
+	return name
+}
+
+// gelfValue converts a normalized value to a string or number, the only
+// types GELF allows for additional fields.
+func gelfValue(value any) any {
+	switch v := value.(type) {
+	case string, int64, uint64, float64:
+		return v
+	case nil:
+		return ""
+	case bool:
+		return fmt.Sprint(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// send encodes and writes a batch of entries.
+func (s *GELFSink) send(entries []Entry) error {
+	messages := make([][]byte, 0, len(entries))
+	for _, entry := range entries {
+		data, err := s.message(entry)
+		if err != nil {
+			return fmt.Errorf("gelf: encode entry: %w", err)
+		}
+		messages = append(messages, data)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.Transport == GELFTransportTCP {
+		var buf []byte
+		for _, data := range messages {
+			buf = append(append(buf, data...), 0)
+		}
+		reused := s.conn != nil
+		err := s.writeTCP(buf)
+		if err != nil && reused {
+			// The connection may have been closed by the server; retry once.
+			err = s.writeTCP(buf)
+		}
+		return err
+	}
+
+	var errs []error
+	for _, data := range messages {
+		errs = append(errs, s.writeUDP(data))
+	}
+	return errors.Join(errs...)
+}
+
+// writeTCP writes buf on the TCP connection. It must be called with s.mu held.
+func (s *GELFSink) writeTCP(buf []byte) error {
+	if s.conn == nil {
+		conn, err := dialNetwork("tcp", s.config.Addr, s.config.Timeout, s.config.TLS)
+		if err != nil {
+			return fmt.Errorf("gelf: %w", err)
+		}
+		s.conn = conn
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout))
+	if _, err := s.conn.Write(buf); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("gelf: %w", err)
+	}
+	return nil
+}
+
+// writeUDP sends a message as one datagram, or as GELF chunks when it is
+// larger than the chunk size. It must be called with s.mu held.
+func (s *GELFSink) writeUDP(data []byte) error {
+	data, err := compressPayload(data, s.config.Compression)
+	if err != nil {
+		return fmt.Errorf("gelf: compress: %w", err)
+	}
+
+	if s.conn == nil {
+		conn, err := net.DialTimeout("udp", s.config.Addr, s.config.Timeout)
+		if err != nil {
+			return fmt.Errorf("gelf: %w", err)
+		}
+		s.conn = conn
+	}
+
+	chunks, err := gelfChunks(data, s.config.ChunkSize)
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if _, err := s.conn.Write(chunk); err != nil {
+			return fmt.Errorf("gelf: %w", err)
+		}
+	}
+	return nil
+}
+
+// gelfChunks splits data into GELF chunks of at most size bytes each,
+// including the 12-byte chunk header. Data that fits is returned as is.
+func gelfChunks(data []byte, size int) ([][]byte, error) {
+	if len(data) <= size {
+		return [][]byte{data}, nil
+	}
+
+	payload := size - gelfChunkHeaderSize
+	count := (len(data) + payload - 1) / payload
+	if count > gelfMaxChunks {
+		return nil, fmt.Errorf("gelf: message of %d bytes needs %d chunks, more than the maximum of %d", len(data), count, gelfMaxChunks)
+	}
+
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return nil, fmt.Errorf("gelf: message id: %w", err)
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * payload
+		if end > len(data) {
+			end = len(data)
+		}
+		chunk := make([]byte, 0, gelfChunkHeaderSize+end-i*payload)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, id[:]...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, data[i*payload:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
@@ -0,0 +1,210 @@
+package gologger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testGELFEntry() Entry {
+	return Entry{
+		Time:    time.Unix(1700000000, 250*int64(time.Millisecond)),
+		Level:   LevelWarn,
+		Message: "disk almost full",
+		Caller:  "monitor/disk.go:42",
+		Fields: map[string]any{
+			"request-id": "req-1",
+			"usage":      int64(93),
+			"id":         "abc",
+			"ok":         false,
+			"tags":       []string{"disk", "ops"},
+		},
+	}
+}
+
+func TestGELFSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	sink := NewGELFSink(GELFConfig{Addr: conn.LocalAddr().String(), Host: "web-1"})
+	if err := sink.Write(testGELFEntry()); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	buf := make([]byte, 65535)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+
+	var msg map[string]any
+	if err := json.Unmarshal(buf[:n], &msg); err != nil {
+		t.Fatalf("Invalid GELF JSON: %v", err)
+	}
+	expected := map[string]any{
+		"version":       "1.1",
+		"host":          "web-1",
+		"short_message": "disk almost full",
+		"timestamp":     1700000000.25,
+		"level":         float64(4),
+		"_caller":       "monitor/disk.go:42",
+		"_request-id":   "req-1",
+		"_usage":        float64(93),
+		"__id":          "abc",
+		"_ok":           "false",
+		"_tags":         `["disk","ops"]`,
+	}
+	for key, want := range expected {
+		if msg[key] != want {
+			t.Errorf("Expected %s=%v, got %v", key, want, msg[key])
+		}
+	}
+}
+
+func TestGELFSinkUDPChunkedGzip(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	// Random-looking content so the compressed payload still needs chunking.
+	var long strings.Builder
+	for i := 0; i < 2000; i++ {
+		long.WriteString(time.Duration(i * 7919).String())
+	}
+	entry := testGELFEntry()
+	entry.Message = long.String()
+
+	sink := NewGELFSink(GELFConfig{Addr: conn.LocalAddr().String(), ChunkSize: 512, Compression: CompressionGzip})
+	if err := sink.Write(entry); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	var parts [][]byte
+	count := -1
+	buf := make([]byte, 65535)
+	for count < 0 || len(parts) < count {
+		_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("Failed to read chunk: %v", err)
+		}
+		chunk := append([]byte(nil), buf[:n]...)
+		if n > 512 || chunk[0] != 0x1e || chunk[1] != 0x0f {
+			t.Fatalf("Invalid chunk of %d bytes", n)
+		}
+		if count < 0 {
+			count = int(chunk[11])
+			parts = make([][]byte, 0, count)
+		}
+		if int(chunk[10]) != len(parts) {
+			t.Fatalf("Expected chunk %d, got %d", len(parts), chunk[10])
+		}
+		parts = append(parts, chunk[12:])
+	}
+	if count < 2 {
+		t.Fatalf("Expected multiple chunks, got %d", count)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(bytes.Join(parts, nil)))
+	if err != nil {
+		t.Fatalf("Invalid gzip payload: %v", err)
+	}
+	data, _ := io.ReadAll(reader)
+	var msg map[string]any
+	if err := json.Unmarshal(data, &msg); err != nil {
+		t.Fatalf("Invalid GELF JSON: %v", err)
+	}
+	if msg["short_message"] != entry.Message {
+		t.Error("Reassembled message does not match")
+	}
+}
+
+func TestGELFSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var messages []string
+		reader := bufio.NewReader(conn)
+		for {
+			data, err := reader.ReadString(0)
+			if err != nil {
+				break
+			}
+			messages = append(messages, strings.TrimSuffix(data, "\x00"))
+		}
+		received <- messages
+	}()
+
+	sink := NewGELFSink(GELFConfig{Addr: listener.Addr().String(), Transport: GELFTransportTCP})
+	for _, level := range []string{LevelInfo, LevelError} {
+		if err := sink.Write(Entry{Time: time.Now(), Level: level, Message: "tcp " + level}); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	messages := <-received
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+	var msg map[string]any
+	if err := json.Unmarshal([]byte(messages[1]), &msg); err != nil {
+		t.Fatalf("Invalid GELF JSON: %v", err)
+	}
+	if msg["short_message"] != "tcp error" || msg["level"] != float64(3) {
+		t.Errorf("Unexpected message %v", msg)
+	}
+}
+
+func TestGELFChunksTooLarge(t *testing.T) {
+	if _, err := gelfChunks(make([]byte, 200*100), 100); err == nil {
+		t.Error("Expected error for message exceeding 128 chunks")
+	}
+}
+
+func TestGELFLevel(t *testing.T) {
+	tests := map[string]int{
+		LevelDebug: 7,
+		LevelInfo:  6,
+		LevelWarn:  4,
+		LevelError: 3,
+		"dpanic":   2,
+		"panic":    1,
+		"fatal":    0,
+	}
+	for level, want := range tests {
+		if got := syslogSeverity(level); got != want {
+			t.Errorf("syslogSeverity(%q) = %d, want %d", level, got, want)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+// Package ginlog recovers and logs the panics of Gin handlers with
+// gologger:
+//
+//	r := gin.New()
+//	r.Use(ginlog.Recover(log))
+//
+// It is a separate module, so applications not using Gin do not depend on
+// it.
+package ginlog
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+	"go.risoftinc.com/gologger"
+)
+
+// Recover returns Gin middleware that recovers the panics of later handlers
+// and logs them with log, replacing gin.Recovery, which prints stacks as
+// unstructured text.
+//
+// Panics are logged as "http request panicked" at error level with the
+// panic value, its stack, the method and path. The request ID is taken from
+// the request context, or from the X-Request-ID response header set by
+// request ID middleware such as gin-contrib/requestid. The request is
+// aborted with a 500 response unless the handler already wrote one. Panics
+// with http.ErrAbortHandler, which abort a response on purpose, are passed
+// on without logging.
+func Recover(log gologger.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if r == http.ErrAbortHandler {
+				panic(r)
+			}
+			ctx := c.Request.Context()
+			if gologger.GetRequestID(ctx) == "" {
+				if id := c.Writer.Header().Get(gologger.RequestIDHeader); id != "" {
+					ctx = gologger.WithRequestID(ctx, gologger.RequestIDFromHeader(id))
+				}
+			}
+			log.WithContext(ctx).Error("http request panicked").
+				Data("panic", fmt.Sprint(r)).
+				Data("stack", string(debug.Stack())).
+				Data("method", c.Request.Method).
+				Data("path", c.Request.URL.Path).
+				Send()
+			if c.Writer.Written() {
+				c.Abort()
+			} else {
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	}
+}
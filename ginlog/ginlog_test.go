@@ -0,0 +1,66 @@
+package ginlog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.risoftinc.com/gologger"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func TestRecover(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Header(gologger.RequestIDHeader, "req-42")
+		c.Next()
+	})
+	r.Use(Recover(log))
+	r.GET("/orders/:id", func(c *gin.Context) {
+		panic("nil map")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/orders/7", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected a 500 response, got %d", rec.Code)
+	}
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Message != "http request panicked" || entries[0].Level != gologger.LevelError {
+		t.Fatalf("Expected a panic entry, got %+v", entries)
+	}
+	fields := entries[0].Fields
+	if fields["panic"] != "nil map" || fields["method"] != "GET" || fields["path"] != "/orders/7" || fields["request-id"] != "req-42" {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+	if stack, _ := fields["stack"].(string); !strings.Contains(stack, "ginlog_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+}
+
+func TestRecoverWrittenResponse(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	r := gin.New()
+	r.Use(Recover(log))
+	r.GET("/", func(c *gin.Context) {
+		c.String(http.StatusAccepted, "accepted")
+		panic("after write")
+	})
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusAccepted || rec.Body.String() != "accepted" {
+		t.Errorf("Expected the written response to be kept, got %d %q", rec.Code, rec.Body.String())
+	}
+	if capture.Len() != 1 {
+		t.Errorf("Expected a panic entry, got %+v", capture.Entries())
+	}
+}
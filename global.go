@@ -0,0 +1,38 @@
+package gologger
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	globalLogger atomic.Pointer[Logger]
+	globalOnce   sync.Once
+)
+
+// L returns the process-wide logger set by ReplaceGlobals. Until then, it
+// returns a logger discarding every entry, so packages can log through L
+// without knowing whether the application configured logging. It is safe
+// for concurrent use.
+func L() Logger {
+	globalOnce.Do(func() {
+		discard := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelError})
+		globalLogger.CompareAndSwap(nil, &discard)
+	})
+	return *globalLogger.Load()
+}
+
+// ReplaceGlobals sets the logger returned by L and returns a function
+// restoring the previous one, e.g. for tests:
+//
+//	defer gologger.ReplaceGlobals(log)()
+//
+// The swap is atomic; entries already being sent go to the logger they were
+// created from. The replaced logger is not closed.
+func ReplaceGlobals(log Logger) func() {
+	L() // Set up the discarding logger to restore
+	previous := globalLogger.Swap(&log)
+	return func() {
+		globalLogger.Store(previous)
+	}
+}
@@ -0,0 +1,50 @@
+package gologger
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestReplaceGlobals(t *testing.T) {
+	initial := L()
+	initial.Error("discarded").Send()
+
+	log, capture := NewTestLogger()
+	restore := ReplaceGlobals(log)
+	L().Info("global").Send()
+	if len(capture.FilterMessage("global")) != 1 {
+		t.Error("Expected L to return the replaced logger")
+	}
+
+	nested, nestedCapture := NewTestLogger()
+	restoreNested := ReplaceGlobals(nested)
+	L().Info("nested").Send()
+	restoreNested()
+	L().Info("restored").Send()
+	if nestedCapture.Len() != 1 || len(capture.FilterMessage("restored")) != 1 {
+		t.Error("Expected the restore function to bring back the previous logger")
+	}
+
+	restore()
+	L().Info("after").Send()
+	if len(capture.FilterMessage("after")) != 0 {
+		t.Error("Expected the initial logger after restoring")
+	}
+}
+
+func TestReplaceGlobalsConcurrent(t *testing.T) {
+	log, _ := NewTestLogger()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			defer ReplaceGlobals(log)()
+		}()
+		go func() {
+			defer wg.Done()
+			L().Debug("concurrent").Send()
+		}()
+	}
+	wg.Wait()
+}
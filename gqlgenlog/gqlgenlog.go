@@ -0,0 +1,106 @@
+// Package gqlgenlog logs the operations and resolver errors of gqlgen
+// GraphQL servers with gologger:
+//
+//	srv := handler.NewDefaultServer(generated.NewExecutableSchema(cfg))
+//	srv.Use(gqlgenlog.New(log))
+//	http.Handle("/query", gologger.HTTPMiddleware(log)(srv))
+//
+// It is a separate module, so applications not using gqlgen do not depend on it.
+package gqlgenlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"go.risoftinc.com/gologger"
+)
+
+// logExtension is the gqlgen handler extension returned by New.
+type logExtension struct {
+	log gologger.Logger
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationInterceptor
+	graphql.ResponseInterceptor
+	graphql.FieldInterceptor
+} = logExtension{}
+
+// New returns a gqlgen handler extension that logs every operation with log.
+//
+// The request ID is taken from the context, as set by gologger.HTTPMiddleware
+// or a router middleware such as chilog, from the X-Request-ID header of the
+// request or generated. The request ID, the logger and the operation name, as
+// "operation", are stored in the context, so resolvers get the logger with
+// gologger.FromContext(ctx) and their entries carry both.
+//
+// When an operation completes, an entry with its name, type, complexity and
+// duration is logged at info level, or warn with its errors if it has any.
+// Complexity is only known when a complexity limit, such as
+// extension.FixedComplexityLimit, is in use. Subscriptions log an entry per
+// event. Resolvers returning an error are logged at error level with the
+// field, its path and the error. Variables are not logged, since they often
+// hold user input.
+func New(log gologger.Logger) graphql.HandlerExtension {
+	return logExtension{log: log}
+}
+
+func (logExtension) ExtensionName() string {
+	return "GologgerLog"
+}
+
+func (logExtension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (e logExtension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	if gologger.GetRequestID(ctx) == "" {
+		ctx = gologger.WithRequestID(ctx, gologger.RequestIDFromHeader(oc.Headers.Get(gologger.RequestIDHeader)))
+	}
+	if oc.OperationName != "" {
+		ctx = gologger.WithFields(ctx, "operation", oc.OperationName)
+	}
+	return next(gologger.NewContext(ctx, e.log))
+}
+
+func (e logExtension) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp == nil || !graphql.HasOperationContext(ctx) {
+		return resp
+	}
+	oc := graphql.GetOperationContext(ctx)
+
+	// The operation name is already in the context fields.
+	log := gologger.FromContext(ctx)
+	if len(resp.Errors) > 0 {
+		log = log.Warn("graphql operation completed").Data("errors", resp.Errors.Error())
+	} else {
+		log = log.Info("graphql operation completed")
+	}
+	if oc.Operation != nil {
+		log = log.Data("operation_type", string(oc.Operation.Operation))
+	}
+	if stats := extension.GetComplexityStats(ctx); stats != nil {
+		log = log.Data("complexity", stats.Complexity)
+	}
+	log.Data("duration_ms", float64(time.Since(oc.Stats.OperationStart))/float64(time.Millisecond)).
+		Send()
+	return resp
+}
+
+func (e logExtension) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	res, err := next(ctx)
+	if err != nil {
+		log := gologger.FromContext(ctx).Error("graphql resolver failed")
+		if fc := graphql.GetFieldContext(ctx); fc != nil {
+			log = log.Data("field", fc.Object+"."+fc.Field.Name).
+				Data("path", fc.Path().String())
+		}
+		log.ErrorData(err).Send()
+	}
+	return res, err
+}
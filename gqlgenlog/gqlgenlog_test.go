@@ -0,0 +1,134 @@
+package gqlgenlog
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2"
+	"github.com/vektah/gqlparser/v2/ast"
+	"go.risoftinc.com/gologger"
+)
+
+// newServer returns a gqlgen server without generated code, whose order
+// field resolver logs and fails for unknown IDs.
+func newServer() *handler.Server {
+	schema := gqlparser.MustLoadSchema(&ast.Source{Input: `
+		type Query {
+			order(id: Int!): String!
+		}
+	`})
+	srv := handler.New(&graphql.ExecutableSchemaMock{
+		ExecFunc: func(context.Context) graphql.ResponseHandler {
+			// Like generated code, resolve fields when the response is read.
+			return func(ctx context.Context) *graphql.Response {
+				oc := graphql.GetOperationContext(ctx)
+				field := oc.Operation.SelectionSet[0].(*ast.Field)
+				id, _ := field.ArgumentMap(oc.Variables)["id"].(int64)
+				ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+					Object:     "Query",
+					Field:      graphql.CollectedField{Field: field},
+					IsResolver: true,
+				})
+				res, err := oc.ResolverMiddleware(ctx, func(ctx context.Context) (any, error) {
+					gologger.FromContext(ctx).Info("loading order").Data("id", id).Send()
+					if id == 0 {
+						return nil, errors.New("order not found")
+					}
+					return "order", nil
+				})
+				if err != nil {
+					graphql.AddError(ctx, err)
+					return &graphql.Response{Data: []byte(`null`)}
+				}
+				return &graphql.Response{Data: []byte(`{"order":"` + res.(string) + `"}`)}
+			}
+		},
+		SchemaFunc: func() *ast.Schema {
+			return schema
+		},
+		ComplexityFunc: func(typeName, fieldName string, childComplexity int, args map[string]any) (int, bool) {
+			return 5, true
+		},
+	})
+	srv.AddTransport(transport.POST{})
+	srv.Use(extension.FixedComplexityLimit(100))
+	return srv
+}
+
+func query(t *testing.T, h http.Handler, body string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(gologger.RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Unexpected status %d: %s", rec.Code, rec.Body)
+	}
+}
+
+func TestNew(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	srv := newServer()
+	srv.Use(New(log))
+
+	query(t, srv, `{"query":"query GetOrder { order(id: 7) }","operationName":"GetOrder"}`)
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected resolver and operation entries, got %+v", entries)
+	}
+	for _, entry := range entries {
+		if entry.Fields["request-id"] != "req-42" || entry.Fields["operation"] != "GetOrder" {
+			t.Errorf("Expected the request ID and operation on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	op := entries[1]
+	if op.Level != gologger.LevelInfo || op.Message != "graphql operation completed" {
+		t.Errorf("Unexpected operation entry %+v", op)
+	}
+	if op.Fields["operation_type"] != "query" || op.Fields["complexity"] != int64(5) || op.Fields["duration_ms"] == nil {
+		t.Errorf("Expected the type, complexity and duration, got %v", op.Fields)
+	}
+}
+
+func TestNewResolverError(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	srv := newServer()
+	srv.Use(New(log))
+
+	// The request ID of the HTTP middleware takes precedence over the header.
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		srv.ServeHTTP(w, r.WithContext(gologger.WithRequestID(r.Context(), "req-http")))
+	})
+	query(t, h, `{"query":"{ order(id: 0) }"}`)
+
+	entries := capture.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected resolver, resolver error and operation entries, got %+v", entries)
+	}
+	failed := entries[1]
+	if failed.Level != gologger.LevelError || failed.Message != "graphql resolver failed" {
+		t.Errorf("Unexpected resolver error entry %+v", failed)
+	}
+	if failed.Fields["field"] != "Query.order" || failed.Fields["path"] != "order" || failed.Fields["error"] != "order not found" {
+		t.Errorf("Expected the field, path and error, got %v", failed.Fields)
+	}
+	op := entries[2]
+	if op.Level != gologger.LevelWarn || !strings.Contains(op.Fields["errors"].(string), "order not found") {
+		t.Errorf("Expected the operation logged at warn with its errors, got %+v", op)
+	}
+	for _, entry := range entries {
+		if entry.Fields["request-id"] != "req-http" || entry.Fields["operation"] != nil {
+			t.Errorf("Expected the request ID of the context and no operation name on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package gologger
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Hook extends the logging of entries, see Logger.AddHook. Either function
+// may be nil. Hooks are called from the goroutines logging the entries.
+type Hook struct {
+	Before func(entry *HookEntry) bool // Called before an entry is encoded; it may change the entry, or return false to drop it
+	After  func(entry Entry)           // Called with each entry as written to the outputs and sinks
+}
+
+// HookEntry is an entry about to be logged, as passed to Hook.Before.
+type HookEntry struct {
+	Context context.Context // Context of the logger, see WithContext
+	Level   string          // Level name: "debug", "info", "warn", "error", "panic" or "fatal"; unknown names are logged at debug level
+	Message string          // Log message
+	Fields  map[string]any  // Data fields, including request and trace IDs and the fields of the context
+}
+
+// hookSet holds the hooks added to a logger. It is shared by all copies of
+// the logger, and hooks may be added while the logger is in use.
+type hookSet struct {
+	mu    sync.Mutex
+	hooks atomic.Pointer[[]Hook]
+}
+
+func newHookSet() *hookSet {
+	set := &hookSet{}
+	set.hooks.Store(&[]Hook{})
+	return set
+}
+
+func (s *hookSet) load() []Hook {
+	return *s.hooks.Load()
+}
+
+func (s *hookSet) add(hook Hook) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := s.load()
+	updated := append(make([]Hook, 0, len(current)+1), current...)
+	updated = append(updated, hook)
+	s.hooks.Store(&updated)
+}
+
+// AddHook adds a hook to the logger and all its copies, the extension point
+// for processing entries beyond what LoggerConfig offers:
+//
+//	log.AddHook(gologger.Hook{
+//		Before: func(entry *gologger.HookEntry) bool {
+//			if tenant, ok := entry.Context.Value(tenantKey{}).(string); ok {
+//				entry.Fields["tenant"] = tenant
+//			}
+//			return entry.Message != "health check"
+//		},
+//		After: func(entry gologger.Entry) {
+//			if entry.Level == gologger.LevelError {
+//				errorCount.Inc()
+//			}
+//		},
+//	})
+//
+// Before hooks are called in the order they were added, for the entries of
+// enabled levels, before sampling, AllowKeys, RedactKeys, Maskers and
+// Sanitize apply, so they can change the level, message and fields of
+// entries or drop them. Changed fields are logged in their original order,
+// followed by added fields sorted by key. After hooks are called with the
+// entries written to the outputs and sinks, as logged, including the time,
+// caller and GlobalFields, once the outputs and sinks wrote them.
+func (l Logger) AddHook(hook Hook) {
+	l.hooks.add(hook)
+}
+
+// before calls the Before hooks with an entry of an enabled level, and
+// returns its level, message and fields as they left it, or false if one of
+// them dropped it.
+func (l Logger) before(level, message string, fields []any) (string, string, []any, bool) {
+	hooks := l.hooks.load()
+	if len(hooks) == 0 || level == "" {
+		return level, message, fields, true
+	}
+	if zapLevel, err := zapcore.ParseLevel(level); err != nil || !l.log.Desugar().Core().Enabled(zapLevel) {
+		return level, message, fields, true
+	}
+
+	entry := &HookEntry{Context: l.ctx, Level: level, Message: message, Fields: hookFields(fields)}
+	for _, hook := range hooks {
+		if hook.Before != nil && !hook.Before(entry) {
+			return level, message, fields, false
+		}
+	}
+	switch entry.Level {
+	case LevelDebug, LevelInfo, LevelWarn, LevelError, "panic", "fatal":
+	default:
+		entry.Level = LevelDebug
+	}
+	return entry.Level, entry.Message, hookPairs(fields, entry.Fields), true
+}
+
+// hookFields returns the key-value pairs of fields as a map. Pairs with keys
+// that are not strings are left out; hookPairs keeps them.
+func hookFields(fields []any) map[string]any {
+	m := make(map[string]any, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		if key, ok := fields[i].(string); ok {
+			m[key] = fields[i+1]
+		}
+	}
+	return m
+}
+
+// hookPairs returns the fields of m as key-value pairs, in the order of
+// their keys in fields, followed by the keys added to m sorted.
+func hookPairs(fields []any, m map[string]any) []any {
+	pairs := make([]any, 0, len(m)*2)
+	seen := make(map[string]bool, len(m))
+	for i := 0; i < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			pairs = append(pairs, fields[i:min(i+2, len(fields))]...)
+			continue
+		}
+		if value, ok := m[key]; ok && !seen[key] {
+			seen[key] = true
+			pairs = append(pairs, key, value)
+		}
+	}
+	added := make([]string, 0, len(m)-len(seen))
+	for key := range m {
+		if !seen[key] {
+			added = append(added, key)
+		}
+	}
+	sort.Strings(added)
+	for _, key := range added {
+		pairs = append(pairs, key, m[key])
+	}
+	return pairs
+}
+
+// hookCore passes the entries written to the outputs and sinks it is teed
+// with to the After hooks.
+type hookCore struct {
+	zapcore.LevelEnabler
+	hooks  *hookSet
+	fields []zapcore.Field
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...)
+	return &clone
+}
+
+func (c *hookCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	for _, hook := range c.hooks.load() {
+		if hook.After != nil {
+			return ce.AddCore(ent, c)
+		}
+	}
+	return ce
+}
+
+func (c *hookCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	entry := entryFromZap(ent, append(c.fields[:len(c.fields):len(c.fields)], fields...))
+	for _, hook := range c.hooks.load() {
+		if hook.After != nil {
+			hook.After(entry)
+		}
+	}
+	return nil
+}
+
+func (c *hookCore) Sync() error {
+	return nil
+}
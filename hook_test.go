@@ -0,0 +1,118 @@
+package gologger
+
+import (
+	"context"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type tenantKey struct{}
+
+func TestAddHook(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:   OutputDiscard,
+		LogLevel:     LevelInfo,
+		ShowCaller:   true,
+		Sinks:        []Sink{capture},
+		RedactKeys:   []string{"token"},
+		GlobalFields: map[string]any{"service": "api"},
+	})
+	var mu sync.Mutex
+	var after []Entry
+	log.AddHook(Hook{
+		Before: func(entry *HookEntry) bool {
+			if tenant, ok := entry.Context.Value(tenantKey{}).(string); ok {
+				entry.Fields["tenant"] = tenant
+			}
+			entry.Fields["token"] = "secret"
+			delete(entry.Fields, "debug_only")
+			return entry.Message != "health check"
+		},
+	})
+	log.AddHook(Hook{
+		Before: func(entry *HookEntry) bool {
+			if entry.Fields["status"] == 503 {
+				entry.Level = LevelError
+				entry.Message += " (unavailable)"
+			}
+			return true
+		},
+		After: func(entry Entry) {
+			mu.Lock()
+			after = append(after, entry)
+			mu.Unlock()
+		},
+	})
+
+	ctx := context.WithValue(WithRequestID(context.Background(), "req-1"), tenantKey{}, "acme")
+	log.WithContext(ctx).Info("request served").
+		Data("status", 503).
+		Data("debug_only", true).
+		Data("path", "/orders").
+		Send()
+	log.Info("health check").Send()
+	log.Debug("not enabled").Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected the health check to be dropped, got %+v", entries)
+	}
+	entry := entries[0]
+	if entry.Level != LevelError || entry.Message != "request served (unavailable)" {
+		t.Errorf("Expected the level and message changed by the hook, got %s %q", entry.Level, entry.Message)
+	}
+	expected := map[string]any{
+		"request-id": "req-1",
+		"status":     int64(503),
+		"path":       "/orders",
+		"tenant":     "acme",
+		"token":      Redacted,
+		"service":    "api",
+	}
+	if !reflect.DeepEqual(entry.Fields, expected) {
+		t.Errorf("Expected the fields changed by the hooks, redacted, got %v", entry.Fields)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(after) != 1 || !reflect.DeepEqual(after[0].Fields, expected) || after[0].Caller == "" {
+		t.Errorf("Expected After hooks to observe the logged entry, got %+v", after)
+	}
+}
+
+func TestHookFieldOrder(t *testing.T) {
+	fields := []any{"b", 1, "a", 2, "b", 3, 42, "bad", "c", 4}
+	m := hookFields(fields)
+	if !reflect.DeepEqual(m, map[string]any{"a": 2, "b": 3, "c": 4}) {
+		t.Fatalf("Unexpected fields %v", m)
+	}
+	delete(m, "c")
+	m["z"] = 5
+	m["y"] = 6
+	pairs := hookPairs(fields, m)
+	expected := []any{"b", 3, "a", 2, 42, "bad", "y", 6, "z", 5}
+	if !reflect.DeepEqual(pairs, expected) {
+		t.Errorf("Expected %v, got %v", expected, pairs)
+	}
+}
+
+func TestHookSlog(t *testing.T) {
+	log, capture := NewTestLogger()
+	log.AddHook(Hook{
+		Before: func(entry *HookEntry) bool {
+			entry.Fields["hooked"] = true
+			return entry.Level != LevelDebug
+		},
+	})
+	logger := slog.New(NewSlogHandler(log))
+	logger.Info("slog entry", "user", "alice")
+	logger.Debug("dropped")
+
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Fields["hooked"] != true || entries[0].Fields["user"] != "alice" {
+		t.Errorf("Expected hooks to apply to slog records, got %+v", entries)
+	}
+}
@@ -0,0 +1,145 @@
+package gologger
+
+import (
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ServerErrorLogConfig holds configuration options for ServerErrorLog.
+type ServerErrorLogConfig struct {
+	Level    string        // Level of messages not recognized as noise (default: LevelError)
+	Limit    int           // Noise entries of each kind logged per Interval (default: 10)
+	Interval time.Duration // Period of the noise rate limit (default: 1m)
+}
+
+var (
+	// tlsHandshakeError matches the TLS handshake errors of http.Server.
+	tlsHandshakeError = regexp.MustCompile(`^http: TLS handshake error from (\S+): (.*)$`)
+	// serverPanic matches the handler panics of http.Server, followed by
+	// the stack of the goroutine on the next lines.
+	serverPanic = regexp.MustCompile(`^http: panic serving (\S+): (.*)$`)
+	// remoteAddr matches the client address in other http.Server messages,
+	// such as "error reading preface from client 203.0.113.7:51234".
+	remoteAddr = regexp.MustCompile(`\bfrom (?:client )?(\[[^\]]+\]:\d+|[^\s:]+:\d+)`)
+)
+
+// serverErrorWriter logs the messages of http.Server.ErrorLog.
+type serverErrorWriter struct {
+	log      Logger
+	level    string
+	limit    int
+	interval time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*noiseWindow
+}
+
+// noiseWindow counts the entries of a kind of noise in the current period.
+type noiseWindow struct {
+	start      time.Time
+	logged     int
+	suppressed int
+}
+
+// ServerErrorLog returns a standard library *log.Logger for
+// http.Server.ErrorLog, turning the messages of the server into structured
+// entries instead of raw lines:
+//
+//	server := &http.Server{ErrorLog: log.Named("http").ServerErrorLog(gologger.ServerErrorLogConfig{})}
+//
+// TLS handshake errors are logged as "TLS handshake failed" and broken pipes
+// and connections reset by peer as "client connection closed", at warn level
+// with "remote_addr" and "error". This noise, caused by scanners and clients
+// going away, is rate limited: at most Limit entries of each kind are logged
+// per Interval, and the first entry logged after entries were dropped has
+// their number as "suppressed". Handler panics are logged as "http handler
+// panicked" at error level with "remote_addr", "panic" and "stack". Other
+// messages are logged as they are at the configured level. The caller of
+// entries is the caller of the log.Logger methods.
+func (l Logger) ServerErrorLog(config ServerErrorLogConfig) *log.Logger {
+	if config.Level == "" {
+		config.Level = LevelError
+	}
+	if config.Limit <= 0 {
+		config.Limit = 10
+	}
+	if config.Interval <= 0 {
+		config.Interval = time.Minute
+	}
+	// Print, Printf and Println call Write through log.Logger.output, which
+	// calls send.
+	return log.New(&serverErrorWriter{
+		log:      l.WithCallerSkip(4),
+		level:    config.Level,
+		limit:    config.Limit,
+		interval: config.Interval,
+		windows:  make(map[string]*noiseWindow),
+	}, "", 0)
+}
+
+func (w *serverErrorWriter) Write(p []byte) (int, error) {
+	if msg := strings.TrimRight(string(p), "\r\n"); msg != "" {
+		w.send(msg)
+	}
+	return len(p), nil
+}
+
+// send logs msg, a whole message of the server, which is multi-line for
+// panics.
+func (w *serverErrorWriter) send(msg string) {
+	first, stack, _ := strings.Cut(msg, "\n")
+	if m := serverPanic.FindStringSubmatch(first); m != nil {
+		w.log.Error("http handler panicked").
+			Data("remote_addr", m[1]).
+			Data("panic", m[2]).
+			Data("stack", stack).
+			Send()
+		return
+	}
+	if m := tlsHandshakeError.FindStringSubmatch(msg); m != nil {
+		if log, ok := w.allow("tls_handshake", w.log.Warn("TLS handshake failed")); ok {
+			log.Data("remote_addr", m[1]).Data("error", m[2]).Send()
+		}
+		return
+	}
+	if strings.Contains(msg, "broken pipe") || strings.Contains(msg, "connection reset by peer") {
+		if log, ok := w.allow("connection_closed", w.log.Warn("client connection closed")); ok {
+			if m := remoteAddr.FindStringSubmatch(msg); m != nil {
+				log = log.Data("remote_addr", m[1])
+			}
+			log.Data("error", strings.TrimPrefix(msg, "http: ")).Send()
+		}
+		return
+	}
+	w.log.Log(w.level, msg).Send()
+}
+
+// allow reports whether an entry of the kind of noise may be logged in the
+// current period, returning log with the number of entries dropped since
+// the last one logged.
+func (w *serverErrorWriter) allow(kind string, log Logger) (Logger, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	window, ok := w.windows[kind]
+	if !ok {
+		window = &noiseWindow{start: now}
+		w.windows[kind] = window
+	}
+	if now.Sub(window.start) >= w.interval {
+		window.start, window.logged = now, 0
+	}
+	if window.logged >= w.limit {
+		window.suppressed++
+		return log, false
+	}
+	window.logged++
+	if window.suppressed > 0 {
+		log = log.Data("suppressed", window.suppressed)
+		window.suppressed = 0
+	}
+	return log, true
+}
@@ -0,0 +1,102 @@
+package gologger
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestServerErrorLog(t *testing.T) {
+	log, capture := NewTestLogger()
+	logger := log.ServerErrorLog(ServerErrorLogConfig{})
+
+	logger.Printf("http: TLS handshake error from %s: %v", "203.0.113.7:51234", "remote error: tls: bad certificate")
+	logger.Printf("http2: server: error reading preface from client %s: %v", "[2001:db8::1]:443", "read tcp: connection reset by peer")
+	logger.Printf("http: panic serving %s: %v\n%s", "203.0.113.8:40000", "nil map", "goroutine 7 [running]:\nmain.handler()")
+	logger.Printf("http: Accept error: %v; retrying in %v", "accept4: too many open files", 5*time.Millisecond)
+
+	entries := capture.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %+v", entries)
+	}
+	handshake := entries[0]
+	if handshake.Level != LevelWarn || handshake.Message != "TLS handshake failed" ||
+		handshake.Fields["remote_addr"] != "203.0.113.7:51234" || handshake.Fields["error"] != "remote error: tls: bad certificate" {
+		t.Errorf("Unexpected handshake entry %+v", handshake)
+	}
+	closed := entries[1]
+	if closed.Level != LevelWarn || closed.Message != "client connection closed" || closed.Fields["remote_addr"] != "[2001:db8::1]:443" ||
+		closed.Fields["error"] != "http2: server: error reading preface from client [2001:db8::1]:443: read tcp: connection reset by peer" {
+		t.Errorf("Unexpected connection entry %+v", closed)
+	}
+	panicked := entries[2]
+	if panicked.Level != LevelError || panicked.Message != "http handler panicked" || panicked.Fields["remote_addr"] != "203.0.113.8:40000" ||
+		panicked.Fields["panic"] != "nil map" || panicked.Fields["stack"] != "goroutine 7 [running]:\nmain.handler()" {
+		t.Errorf("Unexpected panic entry %+v", panicked)
+	}
+	other := entries[3]
+	if other.Level != LevelError || other.Message != "http: Accept error: accept4: too many open files; retrying in 5ms" {
+		t.Errorf("Unexpected entry %+v", other)
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "httperrorlog_test.go:") {
+			t.Errorf("Expected the caller of Printf, got %q", entry.Caller)
+		}
+	}
+}
+
+func TestServerErrorLogRateLimit(t *testing.T) {
+	log, capture := NewTestLogger()
+	logger := log.ServerErrorLog(ServerErrorLogConfig{Level: LevelWarn, Limit: 2, Interval: 50 * time.Millisecond})
+
+	for i := 0; i < 5; i++ {
+		logger.Print("http: TLS handshake error from 203.0.113.7:51234: EOF")
+		logger.Print("write tcp 10.0.0.1:443->203.0.113.7:51234: write: broken pipe")
+	}
+	logger.Print("http: superfluous response.WriteHeader call")
+	if n := len(capture.FilterMessage("TLS handshake failed")); n != 2 {
+		t.Errorf("Expected 2 handshake entries in the period, got %d", n)
+	}
+	if n := len(capture.FilterMessage("client connection closed")); n != 2 {
+		t.Errorf("Expected 2 connection entries in the period, got %d", n)
+	}
+	if other := capture.FilterMessage("http: superfluous response.WriteHeader call"); len(other) != 1 || other[0].Level != LevelWarn {
+		t.Errorf("Expected other messages at the configured level, got %+v", other)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	logger.Print("http: TLS handshake error from 203.0.113.9:40000: EOF")
+	handshakes := capture.FilterMessage("TLS handshake failed")
+	if len(handshakes) != 3 {
+		t.Fatalf("Expected a handshake entry in the next period, got %+v", handshakes)
+	}
+	if _, ok := handshakes[1].Fields["suppressed"]; ok {
+		t.Errorf("Expected no suppressed count before entries were dropped, got %v", handshakes[1].Fields)
+	}
+	if handshakes[2].Fields["suppressed"] != int64(3) || handshakes[2].Fields["remote_addr"] != "203.0.113.9:40000" {
+		t.Errorf("Expected the dropped entries to be counted, got %v", handshakes[2].Fields)
+	}
+}
+
+func TestServerErrorLogHTTPServer(t *testing.T) {
+	log, capture := NewTestLogger()
+	server := httptest.NewUnstartedServer(http.NotFoundHandler())
+	server.Config.ErrorLog = log.ServerErrorLog(ServerErrorLogConfig{})
+	server.StartTLS()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Write([]byte("GET / HTTP/1.1\r\n\r\n"))
+	conn.Close()
+	server.Close()
+
+	handshakes := capture.FilterMessage("TLS handshake failed")
+	if len(handshakes) != 1 || handshakes[0].Level != LevelWarn || handshakes[0].Fields["remote_addr"] != conn.LocalAddr().String() {
+		t.Fatalf("Expected a handshake entry, got %+v", capture.Entries())
+	}
+}
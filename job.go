@@ -0,0 +1,61 @@
+package gologger
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// WrapJob returns fn, a scheduled or background job called name, logging
+// each run with log, as HTTPMiddleware does for requests:
+//
+//	report := gologger.WrapJob(log, "nightly-report", func(ctx context.Context) error {
+//		gologger.FromContext(ctx).Info("exporting orders").Send()
+//		return exportOrders(ctx)
+//	})
+//	c.AddFunc("@daily", func() { _ = report(context.Background()) })
+//
+// Each run gets a random job ID. The job name and ID are added to the
+// context as "job" and "job_id" fields, and the job ID is also its request
+// ID unless ctx has one, so calls made with WrapTransport carry it. The
+// context passed to fn holds log, so fn gets the logger with
+// FromContext(ctx) and its entries carry these fields. A debug entry is
+// logged when the job starts and an entry with its duration when it
+// completes, at info level, or error level with the error if it failed.
+// Panics are logged with their stack and returned as errors, so a failing
+// job does not stop the process.
+func WrapJob(log Logger, name string, fn func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) (err error) {
+		start := time.Now()
+		jobID := newRequestID()
+		if GetRequestID(ctx) == "" {
+			ctx = WithRequestID(ctx, jobID)
+		}
+		ctx = NewContext(WithFields(ctx, "job", name, "job_id", jobID), log)
+
+		jobLog := log.WithContext(ctx)
+		jobLog.Debug("job started").Send()
+		defer func() {
+			if r := recover(); r != nil {
+				jobLog.Error("job panicked").
+					Data("panic", fmt.Sprint(r)).
+					Data("stack", string(debug.Stack())).
+					Send()
+				var ok bool
+				if err, ok = r.(error); !ok {
+					err = fmt.Errorf("%v", r)
+				}
+			}
+			if err != nil {
+				jobLog = jobLog.Error("job completed")
+			} else {
+				jobLog = jobLog.Info("job completed")
+			}
+			jobLog.Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+				ErrorData(err).
+				Send()
+		}()
+		return fn(ctx)
+	}
+}
@@ -0,0 +1,93 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWrapJob(t *testing.T) {
+	log, capture := NewTestLogger()
+	job := WrapJob(log, "nightly-report", func(ctx context.Context) error {
+		FromContext(ctx).Info("exporting orders").Data("rows", 3).Send()
+		return nil
+	})
+
+	if err := job(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if err := job(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	entries := capture.Entries()
+	if len(entries) != 6 {
+		t.Fatalf("Expected start, job and finish entries for two runs, got %d", len(entries))
+	}
+	jobID := entries[0].Fields["job_id"]
+	if id, _ := jobID.(string); len(id) != 32 {
+		t.Fatalf("Expected a generated job ID, got %v", entries[0].Fields)
+	}
+	for _, entry := range entries[:3] {
+		if entry.Fields["job"] != "nightly-report" || entry.Fields["job_id"] != jobID || entry.Fields["request-id"] != jobID {
+			t.Errorf("Expected the job fields on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	if entries[3].Fields["job_id"] == jobID {
+		t.Error("Expected each run to get its own job ID")
+	}
+	start, finish := entries[0], entries[2]
+	if start.Level != LevelDebug || start.Message != "job started" {
+		t.Errorf("Unexpected start entry %+v", start)
+	}
+	if finish.Level != LevelInfo || finish.Message != "job completed" || finish.Fields["error"] != nil {
+		t.Errorf("Unexpected finish entry %+v", finish)
+	}
+	if _, ok := finish.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", finish.Fields)
+	}
+}
+
+func TestWrapJobRequestID(t *testing.T) {
+	log, capture := NewTestLogger()
+	job := WrapJob(log, "reindex", func(ctx context.Context) error {
+		return errors.New("index locked")
+	})
+
+	err := job(WithRequestID(context.Background(), "req-42"))
+	if err == nil || err.Error() != "index locked" {
+		t.Fatalf("Expected the job error, got %v", err)
+	}
+
+	finish := capture.FilterMessage("job completed")
+	if len(finish) != 1 || finish[0].Level != LevelError || finish[0].Fields["error"] != "index locked" {
+		t.Fatalf("Expected an error entry, got %+v", capture.Entries())
+	}
+	if finish[0].Fields["request-id"] != "req-42" || finish[0].Fields["job_id"] == "req-42" {
+		t.Errorf("Expected the request ID of the context to be kept, got %v", finish[0].Fields)
+	}
+}
+
+func TestWrapJobPanic(t *testing.T) {
+	log, capture := NewTestLogger()
+	job := WrapJob(log, "cleanup", func(ctx context.Context) error {
+		panic("nil map")
+	})
+
+	err := job(context.Background())
+	if err == nil || err.Error() != "nil map" {
+		t.Fatalf("Expected the panic as an error, got %v", err)
+	}
+
+	panicked := capture.FilterMessage("job panicked")
+	if len(panicked) != 1 || panicked[0].Level != LevelError || panicked[0].Fields["panic"] != "nil map" {
+		t.Fatalf("Expected a panic entry, got %+v", capture.Entries())
+	}
+	if stack, _ := panicked[0].Fields["stack"].(string); !strings.Contains(stack, "job_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+	if finish := capture.FilterMessage("job completed"); len(finish) != 1 || finish[0].Level != LevelError {
+		t.Errorf("Expected an error finish entry, got %+v", finish)
+	}
+}
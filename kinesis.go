@@ -0,0 +1,281 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Kinesis services supported by the Kinesis sink.
+const (
+	KinesisServiceStreams  = "kinesis"  // Kinesis Data Streams (PutRecords)
+	KinesisServiceFirehose = "firehose" // Kinesis Data Firehose (PutRecordBatch)
+)
+
+// kinesisLimits are the per-call limits of the PutRecords and PutRecordBatch APIs.
+type kinesisLimits struct {
+	target         string
+	maxRecords     int
+	maxRecordBytes int // including the partition key for Data Streams
+	maxCallBytes   int
+}
+
+var kinesisServiceLimits = map[string]kinesisLimits{
+	KinesisServiceStreams:  {"Kinesis_20131202.PutRecords", 500, 1 << 20, 5 << 20},
+	KinesisServiceFirehose: {"Firehose_20150804.PutRecordBatch", 500, 1000 << 10, 4 << 20},
+}
+
+// KinesisConfig holds configuration options for the Kinesis sink.
+type KinesisConfig struct {
+	Service         string        // Service: KinesisServiceStreams or KinesisServiceFirehose (default: KinesisServiceStreams)
+	StreamName      string        // Data stream or delivery stream name (required)
+	Region          string        // AWS region (default: AWS_REGION or AWS_DEFAULT_REGION)
+	Endpoint        string        // API endpoint (default: the regional AWS endpoint)
+	AccessKeyID     string        // Access key (default: AWS_ACCESS_KEY_ID)
+	SecretAccessKey string        // Secret key (default: AWS_SECRET_ACCESS_KEY)
+	SessionToken    string        // Session token for temporary credentials (default: AWS_SESSION_TOKEN)
+	RequestIDKey    string        // Field used as the partition key (default: "request-id")
+	BatchSize       int           // Maximum records per call, capped at 500 (default: 500)
+	FlushInterval   time.Duration // Maximum time an entry waits before being sent (default: 1s)
+	Timeout         time.Duration // Timeout for a single API call (default: 10s)
+	HTTPClient      *http.Client  // HTTP client used for API calls (optional)
+}
+
+// KinesisSink puts entries into a Kinesis data stream or Firehose delivery
+// stream as newline-terminated JSON records. The partition key is the
+// request ID, or a random key for entries without one. Batches are split to
+// respect the per-call record count and size limits; records larger than the
+// per-record limit are dropped and reported as an error. Records rejected by
+// the service are retried once.
+type KinesisSink struct {
+	service      string
+	streamName   string
+	region       string
+	endpoint     string
+	creds        awsCredentials
+	requestIDKey string
+	limits       kinesisLimits
+	timeout      time.Duration
+	client       *http.Client
+	now          func() time.Time
+	batch        *batcher
+}
+
+// kinesisRecord is a record of a PutRecords or PutRecordBatch call.
+type kinesisRecord struct {
+	Data         []byte `json:"Data"`
+	PartitionKey string `json:"PartitionKey,omitempty"`
+}
+
+// NewKinesisSink creates a Kinesis sink. Unset options fall back to their defaults.
+func NewKinesisSink(config KinesisConfig) *KinesisSink {
+	service := config.Service
+	if service != KinesisServiceFirehose {
+		service = KinesisServiceStreams
+	}
+	limits := kinesisServiceLimits[service]
+
+	region := resolveAWSRegion(config.Region)
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://" + service + "." + region + ".amazonaws.com"
+	}
+	requestIDKey := config.RequestIDKey
+	if requestIDKey == "" {
+		requestIDKey = "request-id"
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 || batchSize > limits.maxRecords {
+		batchSize = limits.maxRecords
+	}
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	s := &KinesisSink{
+		service:      service,
+		streamName:   config.StreamName,
+		region:       region,
+		endpoint:     endpoint,
+		creds:        resolveAWSCredentials(config.AccessKeyID, config.SecretAccessKey, config.SessionToken),
+		requestIDKey: requestIDKey,
+		limits:       limits,
+		timeout:      timeout,
+		client:       client,
+		now:          time.Now,
+	}
+	s.batch = newBatcher(batchSize, flushInterval, s.put)
+	return s
+}
+
+// Write queues an entry for sending.
+func (s *KinesisSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync sends all queued entries.
+func (s *KinesisSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close sends all queued entries and stops the background flush.
+func (s *KinesisSink) Close() error {
+	return s.batch.Close()
+}
+
+// put encodes a batch of entries and sends them in as many calls as the
+// size limits require.
+func (s *KinesisSink) put(entries []Entry) error {
+	var records []kinesisRecord
+	var dropped int
+	for _, entry := range entries {
+		data, err := entryJSON(entry)
+		if err != nil {
+			return fmt.Errorf("kinesis: encode entry: %w", err)
+		}
+		record := kinesisRecord{Data: append(data, '\n')}
+		if s.service == KinesisServiceStreams {
+			record.PartitionKey = s.partitionKey(entry)
+		}
+		if len(record.Data)+len(record.PartitionKey) > s.limits.maxRecordBytes {
+			dropped++
+			continue
+		}
+		records = append(records, record)
+	}
+
+	var callErr error
+	for start := 0; start < len(records); {
+		end, size := start, 0
+		for end < len(records) && end-start < s.limits.maxRecords {
+			n := len(records[end].Data) + len(records[end].PartitionKey)
+			if end > start && size+n > s.limits.maxCallBytes {
+				break
+			}
+			size += n
+			end++
+		}
+		if err := s.putWithRetry(records[start:end]); err != nil && callErr == nil {
+			callErr = err
+		}
+		start = end
+	}
+
+	if callErr != nil {
+		return callErr
+	}
+	if dropped > 0 {
+		return fmt.Errorf("kinesis: dropped %d records larger than %d bytes", dropped, s.limits.maxRecordBytes)
+	}
+	return nil
+}
+
+// partitionKey returns the request ID of the entry, or a random key.
+func (s *KinesisSink) partitionKey(entry Entry) string {
+	if requestID, ok := entry.Fields[s.requestIDKey]; ok {
+		if key := fmt.Sprint(requestID); key != "" {
+			if len(key) > 256 {
+				key = key[:256]
+			}
+			return key
+		}
+	}
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// putWithRetry sends records and retries the ones rejected by the service once.
+func (s *KinesisSink) putWithRetry(records []kinesisRecord) error {
+	failed, err := s.call(records)
+	if err != nil {
+		return err
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+
+	failed, err = s.call(failed)
+	if err != nil {
+		return err
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("kinesis: %d records rejected by %s", len(failed), s.service)
+	}
+	return nil
+}
+
+// call performs a single PutRecords or PutRecordBatch call and returns the
+// records the service rejected.
+func (s *KinesisSink) call(records []kinesisRecord) ([]kinesisRecord, error) {
+	var payload any
+	if s.service == KinesisServiceFirehose {
+		payload = map[string]any{"DeliveryStreamName": s.streamName, "Records": records}
+	} else {
+		payload = map[string]any{"StreamName": s.streamName, "Records": records}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("kinesis: marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("kinesis: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", s.limits.target)
+	signAWSRequest(req, body, s.creds, s.region, s.service, s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("kinesis: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("kinesis: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("kinesis: %s returned %s: %s", s.limits.target, resp.Status, bytes.TrimSpace(data))
+	}
+
+	var result struct {
+		Records          []struct{ ErrorCode string }
+		RequestResponses []struct{ ErrorCode string }
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("kinesis: decode response: %w", err)
+	}
+	responses := result.Records
+	if s.service == KinesisServiceFirehose {
+		responses = result.RequestResponses
+	}
+
+	var failed []kinesisRecord
+	for i, response := range responses {
+		if response.ErrorCode != "" && i < len(records) {
+			failed = append(failed, records[i])
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,182 @@
+package gologger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeKinesis records PutRecords/PutRecordBatch calls and rejects the
+// records listed in reject on their first attempt.
+type fakeKinesis struct {
+	mu      sync.Mutex
+	calls   []map[string]any
+	targets []string
+	reject  map[string]bool
+}
+
+func (f *fakeKinesis) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+		http.Error(w, `{"__type":"UnrecognizedClientException"}`, http.StatusBadRequest)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, body)
+	f.targets = append(f.targets, r.Header.Get("X-Amz-Target"))
+
+	var responses []map[string]any
+	for _, record := range body["Records"].([]any) {
+		data := record.(map[string]any)["Data"].(string)
+		if f.reject[data] {
+			delete(f.reject, data)
+			responses = append(responses, map[string]any{"ErrorCode": "ProvisionedThroughputExceededException"})
+		} else {
+			responses = append(responses, map[string]any{"SequenceNumber": "1"})
+		}
+	}
+	key := "Records"
+	if _, ok := body["DeliveryStreamName"]; ok {
+		key = "RequestResponses"
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{key: responses})
+}
+
+func decodeKinesisData(t *testing.T, record any) map[string]any {
+	t.Helper()
+	var data []byte
+	if err := json.Unmarshal([]byte(`"`+record.(map[string]any)["Data"].(string)+`"`), &data); err != nil {
+		t.Fatalf("Invalid base64 data: %v", err)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("Invalid record JSON: %v", err)
+	}
+	return entry
+}
+
+func TestKinesisSinkPutRecords(t *testing.T) {
+	fake := &fakeKinesis{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sink := NewKinesisSink(KinesisConfig{
+		StreamName:      "app-logs",
+		Region:          "ap-southeast-3",
+		Endpoint:        server.URL,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+	_ = sink.Write(Entry{Level: LevelInfo, Message: "with id", Fields: map[string]any{"request-id": "req-1"}})
+	_ = sink.Write(Entry{Level: LevelInfo, Message: "without id"})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if len(fake.calls) != 1 {
+		t.Fatalf("Expected 1 call, got %d", len(fake.calls))
+	}
+	if fake.targets[0] != "Kinesis_20131202.PutRecords" {
+		t.Errorf("Unexpected target %s", fake.targets[0])
+	}
+	call := fake.calls[0]
+	if call["StreamName"] != "app-logs" {
+		t.Errorf("Unexpected stream name %v", call["StreamName"])
+	}
+	records := call["Records"].([]any)
+	if key := records[0].(map[string]any)["PartitionKey"]; key != "req-1" {
+		t.Errorf("Expected request ID partition key, got %v", key)
+	}
+	if key := records[1].(map[string]any)["PartitionKey"].(string); len(key) != 32 {
+		t.Errorf("Expected random partition key, got %q", key)
+	}
+	if entry := decodeKinesisData(t, records[0]); entry["msg"] != "with id" {
+		t.Errorf("Unexpected record %v", entry)
+	}
+}
+
+func TestKinesisSinkFirehoseRetriesRejectedRecords(t *testing.T) {
+	fake := &fakeKinesis{reject: map[string]bool{}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sink := NewKinesisSink(KinesisConfig{
+		Service:         KinesisServiceFirehose,
+		StreamName:      "delivery",
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+	})
+	defer sink.Close()
+
+	entry := Entry{Level: LevelWarn, Message: "throttled"}
+	data, _ := entryJSON(entry)
+	encoded, _ := json.Marshal(append(data, '\n'))
+	fake.reject[strings.Trim(string(encoded), `"`)] = true
+
+	if err := sink.put([]Entry{{Level: LevelInfo, Message: "ok"}, entry}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(fake.calls) != 2 {
+		t.Fatalf("Expected a retry call, got %d calls", len(fake.calls))
+	}
+	if fake.targets[0] != "Firehose_20150804.PutRecordBatch" || fake.calls[0]["DeliveryStreamName"] != "delivery" {
+		t.Errorf("Unexpected Firehose call %s %v", fake.targets[0], fake.calls[0])
+	}
+	retried := fake.calls[1]["Records"].([]any)
+	if len(retried) != 1 || decodeKinesisData(t, retried[0])["msg"] != "throttled" {
+		t.Errorf("Expected only the rejected record to be retried, got %v", retried)
+	}
+	if _, ok := retried[0].(map[string]any)["PartitionKey"]; ok {
+		t.Error("Firehose records must not have a partition key")
+	}
+}
+
+func TestKinesisSinkSizeLimits(t *testing.T) {
+	fake := &fakeKinesis{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	sink := NewKinesisSink(KinesisConfig{StreamName: "s", Region: "us-east-1", Endpoint: server.URL, AccessKeyID: "AKID", SecretAccessKey: "x"})
+	defer sink.Close()
+	sink.limits.maxRecordBytes = 300
+	sink.limits.maxCallBytes = 500
+
+	entries := []Entry{
+		{Level: LevelInfo, Message: strings.Repeat("a", 100)},
+		{Level: LevelInfo, Message: strings.Repeat("b", 100)},
+		{Level: LevelInfo, Message: strings.Repeat("c", 100)},
+		{Level: LevelInfo, Message: strings.Repeat("d", 400)}, // larger than the record limit
+	}
+	err := sink.put(entries)
+	if err == nil || !strings.Contains(err.Error(), "dropped 1 records") {
+		t.Errorf("Expected dropped record error, got %v", err)
+	}
+	if len(fake.calls) != 2 {
+		t.Errorf("Expected the batch to be split into 2 calls, got %d", len(fake.calls))
+	}
+}
+
+func TestKinesisSinkReportsAPIErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"__type":"ResourceNotFoundException","message":"Stream missing not found"}`, http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	sink := NewKinesisSink(KinesisConfig{StreamName: "missing", Region: "us-east-1", Endpoint: server.URL})
+	defer sink.Close()
+	err := sink.put([]Entry{{Level: LevelInfo, Message: "x"}})
+	if err == nil || !strings.Contains(err.Error(), "ResourceNotFoundException") {
+		t.Errorf("Expected API error, got %v", err)
+	}
+}
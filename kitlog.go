@@ -0,0 +1,97 @@
+package gologger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// KitLogger logs the key-value pairs of go-kit loggers. It implements the
+// Logger interface of github.com/go-kit/log, so gologger does not depend on
+// go-kit.
+type KitLogger struct {
+	log Logger
+}
+
+// NewKitLogger returns a KitLogger logging with log, to pass to services and
+// libraries taking a go-kit log.Logger:
+//
+//	var logger kitlog.Logger = gologger.NewKitLogger(log.Named("kit"))
+//	level.Info(logger).Log("msg", "order created", "order_id", 7)
+//
+// The "msg" value is logged as message, at the level of the "level" value
+// added by the go-kit level package: debug, info, warn or error, or info
+// without one. An "err" or "error" value which is an error is logged as
+// "error", and the other pairs as data fields. "ts" and "caller" values,
+// added with log.DefaultTimestamp and log.DefaultCaller, are dropped, since
+// entries have their own time and caller: the caller of the go-kit Log
+// method.
+func NewKitLogger(log Logger) *KitLogger {
+	return &KitLogger{log: log}
+}
+
+// Log logs keyvals. A key without a value is ignored. It never returns an
+// error.
+func (k *KitLogger) Log(keyvals ...any) error {
+	level, msg := LevelInfo, ""
+	var data []any
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		switch key {
+		case "msg":
+			msg = fmt.Sprint(keyvals[i+1])
+		case "level":
+			level = kitLevel(fmt.Sprint(keyvals[i+1]))
+		case "ts", "caller":
+		default:
+			data = append(data, key, keyvals[i+1])
+		}
+	}
+
+	log := k.log.WithCallerSkip(kitCallerSkip()).Log(level, msg)
+	for i := 0; i < len(data); i += 2 {
+		key := data[i].(string)
+		if err, ok := data[i+1].(error); ok && (key == "err" || key == "error") {
+			log = log.ErrorData(err)
+			continue
+		}
+		log = log.Data(key, data[i+1])
+	}
+	log.Send()
+	return nil
+}
+
+// kitLevel returns the level of a go-kit level value. Unknown values are
+// logged at info level.
+func kitLevel(value string) string {
+	switch strings.ToLower(value) {
+	case LevelDebug:
+		return LevelDebug
+	case LevelWarn, "warning":
+		return LevelWarn
+	case LevelError:
+		return LevelError
+	default:
+		return LevelInfo
+	}
+}
+
+// kitCallerSkip returns the number of frames between Log and the first
+// caller outside go-kit, which wraps loggers to add key-value pairs and
+// levels.
+func kitCallerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, kitCallerSkip and Log.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	skip := 1
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "github.com/go-kit/") {
+			return skip
+		}
+		skip++
+	}
+}
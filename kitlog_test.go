@@ -0,0 +1,63 @@
+package gologger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// kitLogger is the Logger interface of github.com/go-kit/log.
+type kitLogger interface {
+	Log(keyvals ...any) error
+}
+
+var _ kitLogger = (*KitLogger)(nil)
+
+// kitLevelValue stands for the level values of github.com/go-kit/log/level,
+// which are fmt.Stringers.
+type kitLevelValue string
+
+func (v kitLevelValue) String() string {
+	return string(v)
+}
+
+func TestKitLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	var logger kitLogger = NewKitLogger(log)
+
+	if err := logger.Log("level", kitLevelValue("warn"), "ts", "2026-10-16T12:00:00Z", "caller", "orders.go:42",
+		"msg", "order delayed", "order_id", 7, "err", errors.New("carrier timeout")); err != nil {
+		t.Fatal(err)
+	}
+	logger.Log("msg", "started", "addr", ":8080", 42, "answer", "dangling")
+	logger.Log("level", kitLevelValue("debug"), "msg", "cache hit")
+	logger.Log("level", kitLevelValue("error"), "msg", "failed", "error", "not an error value")
+
+	entries := capture.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected four entries, got %d", len(entries))
+	}
+	delayed := entries[0]
+	if delayed.Level != LevelWarn || delayed.Message != "order delayed" || delayed.Fields["order_id"] != int64(7) ||
+		delayed.Fields["error"] != "carrier timeout" {
+		t.Errorf("Unexpected entry %+v", delayed)
+	}
+	for _, key := range []string{"ts", "caller", "level", "msg", "err"} {
+		if _, ok := delayed.Fields[key]; ok {
+			t.Errorf("Expected no %q field, got %v", key, delayed.Fields)
+		}
+	}
+	started := entries[1]
+	if started.Level != LevelInfo || started.Message != "started" || started.Fields["addr"] != ":8080" ||
+		started.Fields["42"] != "answer" {
+		t.Errorf("Unexpected entry %+v", started)
+	}
+	if entries[2].Level != LevelDebug || entries[3].Level != LevelError || entries[3].Fields["error"] != "not an error value" {
+		t.Errorf("Unexpected entries %+v", entries[2:])
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "kitlog_test.go:") {
+			t.Errorf("Expected the caller of Log, got %q", entry.Caller)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// Package klogsink writes the output of klog, the logger of the Kubernetes
+// client libraries, through gologger, so cluster tooling logs the entries of
+// client-go and controller code in the same structured form as its own:
+//
+//	klogsink.RedirectKlog(log.Named("k8s"))
+//	defer klog.Flush()
+//
+// It is a separate module, so applications not using klog do not depend on
+// it.
+package klogsink
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"go.risoftinc.com/gologger"
+	"k8s.io/klog/v2"
+)
+
+// RedirectKlog makes klog write every entry through log instead of its own
+// output. Entries of the structured calls, such as InfoS and ErrorS, keep
+// their key-value pairs as fields. Those of the printf-style calls, such as
+// Infof and Warningf, keep their severity: info and warning entries are
+// logged at info and warn level, and error and fatal entries at error level,
+// leaving exiting to klog. Entries of V(1) and above are logged at debug
+// level. The caller of entries is the caller of the klog function.
+//
+// klog's -v flag still decides which verbose entries are logged, and log's
+// level which are kept. klog.Flush syncs log.
+func RedirectKlog(log gologger.Logger) {
+	klog.SetLoggerWithOptions(logr.New(NewLogSink(log)),
+		klog.WriteKlogBuffer(func(data []byte) { writeBuffer(log, data) }),
+		klog.FlushLogger(func() { _ = log.Sync() }),
+	)
+}
+
+// LogSink is a logr.LogSink writing through a gologger.Logger. RedirectKlog
+// installs it for klog; it can also back the logr.Logger of libraries such
+// as controller-runtime:
+//
+//	ctrl.SetLogger(logr.New(klogsink.NewLogSink(log)))
+type LogSink struct {
+	log    gologger.Logger
+	values []any
+	depth  int
+}
+
+var (
+	_ logr.LogSink          = (*LogSink)(nil)
+	_ logr.CallDepthLogSink = (*LogSink)(nil)
+)
+
+// NewLogSink returns a sink writing through log.
+func NewLogSink(log gologger.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+// Init records the call depth of the logr.Logger methods.
+func (s *LogSink) Init(info logr.RuntimeInfo) {
+	s.depth = info.CallDepth
+}
+
+// Enabled returns true; log's level filters the entries.
+func (s *LogSink) Enabled(int) bool {
+	return true
+}
+
+// Info logs msg at info level, or debug level if level is above 0.
+func (s *LogSink) Info(level int, msg string, keysAndValues ...any) {
+	if level > 0 {
+		s.send(gologger.LevelDebug, msg, nil, keysAndValues)
+		return
+	}
+	s.send(gologger.LevelInfo, msg, nil, keysAndValues)
+}
+
+// Error logs msg at error level with err.
+func (s *LogSink) Error(err error, msg string, keysAndValues ...any) {
+	s.send(gologger.LevelError, msg, err, keysAndValues)
+}
+
+// WithValues returns a sink adding keysAndValues to every entry.
+func (s *LogSink) WithValues(keysAndValues ...any) logr.LogSink {
+	sink := *s
+	sink.values = append(append([]any(nil), s.values...), keysAndValues...)
+	return &sink
+}
+
+// WithName returns a sink whose logger is named name below the logger of s.
+func (s *LogSink) WithName(name string) logr.LogSink {
+	sink := *s
+	sink.log = s.log.Named(name)
+	return &sink
+}
+
+// WithCallDepth returns a sink reporting as caller the function depth
+// frames further up the stack.
+func (s *LogSink) WithCallDepth(depth int) logr.LogSink {
+	sink := *s
+	sink.depth += depth
+	return &sink
+}
+
+// send logs msg at level with err, the values of the sink and
+// keysAndValues, with the caller of the logr.Logger method.
+func (s *LogSink) send(level, msg string, err error, keysAndValues []any) {
+	// Skip send and the Info or Error method of the sink.
+	log := s.log.WithCallerSkip(s.depth+2).Log(level, msg).ErrorData(err)
+	data(data(log, s.values), keysAndValues).Send()
+}
+
+// data adds the pairs of keysAndValues to log. Keys which are not strings
+// are formatted with fmt.Sprint, and a key without value is ignored.
+func data(log gologger.Logger, keysAndValues []any) gologger.Logger {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		log = log.Data(key, keysAndValues[i+1])
+	}
+	return log
+}
+
+// header matches the header klog writes before the message of printf-style
+// entries, such as "W1016 12:04:05.000000   12345 reflector.go:42] ", and
+// captures its severity.
+var header = regexp.MustCompile(`^([IWEF])\d{4} \d{2}:\d{2}:\d{2}\.\d{6}\s+\d+ [^\]]*\] `)
+
+// writeBuffer logs the formatted entry klog passes to WriteKlogBuffer at the
+// level of its severity. Entries without a header, such as those written
+// with klog's -skip_headers flag, are logged at info level.
+func writeBuffer(log gologger.Logger, data []byte) {
+	msg := strings.TrimSuffix(string(data), "\n")
+	severity := "I"
+	if match := header.FindStringSubmatch(msg); match != nil {
+		severity = match[1]
+		msg = msg[len(match[0]):]
+	}
+	log = log.WithCallerSkip(callerSkip())
+	switch severity {
+	case "W":
+		log.Warn(msg).Send()
+	case "E", "F":
+		log.Error(msg).Send()
+	default:
+		log.Info(msg).Send()
+	}
+}
+
+// callerSkip returns the number of frames between writeBuffer and the first
+// caller outside klog, whose depth depends on the klog function called.
+func callerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, callerSkip, writeBuffer and the closure of
+	// RedirectKlog.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs[:])])
+	skip := 2
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "k8s.io/klog/v2.") {
+			return skip
+		}
+		skip++
+	}
+}
@@ -0,0 +1,86 @@
+package klogsink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"go.risoftinc.com/gologger"
+	"k8s.io/klog/v2"
+)
+
+func TestRedirectKlog(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	RedirectKlog(log)
+	defer klog.ClearLogger()
+
+	klog.InfoS("pod synced", "pod", "default/web-0", "attempt", 2)
+	klog.ErrorS(errors.New("connection refused"), "watch failed", "resource", "pods")
+	klog.Infof("cache synced for %s", "pods")
+	klog.Warningf("throttled for %dms", 250)
+	klog.Error("lost leader election")
+	klog.V(0).Info("leader elected")
+	klog.Flush()
+
+	entries := capture.Entries()
+	if len(entries) != 6 {
+		t.Fatalf("Expected six entries, got %+v", entries)
+	}
+	synced := entries[0]
+	if synced.Level != gologger.LevelInfo || synced.Message != "pod synced" ||
+		synced.Fields["pod"] != "default/web-0" || synced.Fields["attempt"] != int64(2) {
+		t.Errorf("Unexpected structured entry %+v", synced)
+	}
+	failed := entries[1]
+	if failed.Level != gologger.LevelError || failed.Message != "watch failed" ||
+		failed.Fields["error"] != "connection refused" || failed.Fields["resource"] != "pods" {
+		t.Errorf("Unexpected error entry %+v", failed)
+	}
+	expected := []struct{ level, message string }{
+		{gologger.LevelInfo, "cache synced for pods"},
+		{gologger.LevelWarn, "throttled for 250ms"},
+		{gologger.LevelError, "lost leader election"},
+		{gologger.LevelInfo, "leader elected"},
+	}
+	for i, want := range expected {
+		if entry := entries[i+2]; entry.Level != want.level || entry.Message != want.message {
+			t.Errorf("Expected %s %q, got %s %q", want.level, want.message, entry.Level, entry.Message)
+		}
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "klogsink_test.go:") {
+			t.Errorf("Expected the caller of the klog function for %q, got %q", entry.Message, entry.Caller)
+		}
+	}
+}
+
+func TestLogSink(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	logger := logr.New(NewLogSink(log)).WithName("controller").WithValues("reconciler", "deployment")
+
+	logger.V(2).Info("reconciling", "name", "web", 42, "answer", "dangling")
+	logger.Error(errors.New("conflict"), "update failed")
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v", entries)
+	}
+	verbose := entries[0]
+	if verbose.Level != gologger.LevelDebug || verbose.Fields["reconciler"] != "deployment" ||
+		verbose.Fields["name"] != "web" || verbose.Fields["42"] != "answer" {
+		t.Errorf("Unexpected verbose entry %+v", verbose)
+	}
+	if _, ok := verbose.Fields["dangling"]; ok {
+		t.Errorf("Expected a key without value to be ignored, got %v", verbose.Fields)
+	}
+	if entries[1].Level != gologger.LevelError || entries[1].Fields["error"] != "conflict" ||
+		entries[1].Fields["reconciler"] != "deployment" {
+		t.Errorf("Unexpected error entry %+v", entries[1])
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "klogsink_test.go:") {
+			t.Errorf("Expected the caller of the logr method, got %q", entry.Caller)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package lambdalog logs the invocations of AWS Lambda functions with
+// gologger:
+//
+//	func main() {
+//		log := gologger.NewLoggerWithConfig(gologger.LoggerConfig{OutputMode: gologger.OutputStdout})
+//		lambda.Start(lambdalog.Wrap(handleOrder, log))
+//	}
+//
+// It is a separate module, so applications not running on Lambda do not
+// depend on aws-lambda-go.
+package lambdalog
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.risoftinc.com/gologger"
+)
+
+// handler is a lambda.Handler logging the invocations of another.
+type handler struct {
+	next    lambda.Handler
+	log     gologger.Logger
+	invoked atomic.Bool
+}
+
+// Wrap returns a lambda.Handler running handlerFunc, a lambda.Handler or any
+// function lambda.Start accepts, and logging its invocations with log.
+//
+// The AWS request ID of each invocation is used as the request ID, and the
+// invoked function ARN is added as "function_arn" to the fields of the
+// context, so every entry logged with gologger.FromContext(ctx) in the
+// handler carries both. When an invocation completes, an entry with its
+// duration and "cold_start", true for the first invocation of the process,
+// is logged at info level, or error level with the error if it failed.
+// Panics are logged with their stack before the runtime reports them.
+//
+// The outputs and sinks of log are synced after each invocation, since the
+// runtime freezes the process between invocations and may never resume it,
+// which would lose the entries still buffered by batching sinks.
+func Wrap(handlerFunc any, log gologger.Logger) lambda.Handler {
+	next, ok := handlerFunc.(lambda.Handler)
+	if !ok {
+		next = lambda.NewHandler(handlerFunc)
+	}
+	return &handler{next: next, log: log}
+}
+
+func (h *handler) Invoke(ctx context.Context, payload []byte) ([]byte, error) {
+	start := time.Now()
+	coldStart := !h.invoked.Swap(true)
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		if lc.AwsRequestID != "" {
+			ctx = gologger.WithRequestID(ctx, lc.AwsRequestID)
+		}
+		if lc.InvokedFunctionArn != "" {
+			ctx = gologger.WithFields(ctx, "function_arn", lc.InvokedFunctionArn)
+		}
+	}
+	ctx = gologger.NewContext(ctx, h.log)
+	log := h.log.WithContext(ctx)
+	defer func() {
+		_ = h.log.Sync()
+	}()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error("lambda invocation panicked").
+				Data("panic", fmt.Sprint(r)).
+				Data("stack", string(debug.Stack())).
+				Data("cold_start", coldStart).
+				Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+				Send()
+			panic(r)
+		}
+	}()
+
+	log.Debug("lambda invocation started").
+		Data("cold_start", coldStart).
+		Send()
+
+	response, err := h.next.Invoke(ctx, payload)
+
+	if err != nil {
+		log = log.Error("lambda invocation completed")
+	} else {
+		log = log.Info("lambda invocation completed")
+	}
+	log.Data("cold_start", coldStart).
+		Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+		ErrorData(err).
+		Send()
+	return response, err
+}
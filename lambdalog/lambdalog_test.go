@@ -0,0 +1,122 @@
+package lambdalog
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"go.risoftinc.com/gologger"
+)
+
+const functionARN = "arn:aws:lambda:eu-west-1:123456789012:function:orders"
+
+// invocationContext returns the context the runtime passes to an invocation.
+func invocationContext(requestID string) context.Context {
+	return lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID:       requestID,
+		InvokedFunctionArn: functionARN,
+	})
+}
+
+type order struct {
+	ID string `json:"id"`
+}
+
+func TestWrap(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	handler := Wrap(func(ctx context.Context, o order) (string, error) {
+		gologger.FromContext(ctx).Info("processing order").Data("order_id", o.ID).Send()
+		if o.ID == "" {
+			return "", errors.New("missing order ID")
+		}
+		return "ok", nil
+	}, log)
+
+	response, err := handler.Invoke(invocationContext("aws-req-1"), []byte(`{"id":"o-7"}`))
+	if err != nil || string(response) != `"ok"` {
+		t.Fatalf("Unexpected response %s, %v", response, err)
+	}
+	if _, err := handler.Invoke(invocationContext("aws-req-2"), []byte(`{}`)); err == nil {
+		t.Fatal("Expected the invocation to fail")
+	}
+
+	entries := capture.Entries()
+	if len(entries) != 6 {
+		t.Fatalf("Expected six entries, got %+v", entries)
+	}
+	for i, entry := range entries {
+		want := "aws-req-1"
+		if i >= 3 {
+			want = "aws-req-2"
+		}
+		if entry.Fields["request-id"] != want || entry.Fields["function_arn"] != functionARN {
+			t.Errorf("Expected the invocation context on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	first, second := entries[2], entries[5]
+	if first.Level != gologger.LevelInfo || first.Message != "lambda invocation completed" || first.Fields["cold_start"] != true {
+		t.Errorf("Unexpected first completion %+v", first)
+	}
+	if _, ok := first.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", first.Fields)
+	}
+	if second.Level != gologger.LevelError || second.Fields["cold_start"] != false || second.Fields["error"] != "missing order ID" {
+		t.Errorf("Unexpected second completion %+v", second)
+	}
+}
+
+func TestWrapPanic(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	handler := Wrap(func(context.Context) error {
+		panic("out of stock")
+	}, log)
+
+	func() {
+		defer func() {
+			if r := recover(); r != "out of stock" {
+				t.Errorf("Expected the panic to be passed on, got %v", r)
+			}
+		}()
+		handler.Invoke(invocationContext("aws-req-3"), []byte(`{}`))
+	}()
+
+	entries := capture.FilterMessage("lambda invocation panicked")
+	if len(entries) != 1 || entries[0].Level != gologger.LevelError || entries[0].Fields["panic"] != "out of stock" ||
+		entries[0].Fields["request-id"] != "aws-req-3" {
+		t.Fatalf("Expected a panic entry, got %+v", capture.Entries())
+	}
+	if stack, _ := entries[0].Fields["stack"].(string); !strings.Contains(stack, "lambdalog_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+}
+
+// syncSink counts the entries written and those synced.
+type syncSink struct {
+	written, synced int
+}
+
+func (s *syncSink) Write(gologger.Entry) error { s.written++; return nil }
+func (s *syncSink) Sync() error                { s.synced = s.written; return nil }
+func (s *syncSink) Close() error               { return nil }
+
+func TestWrapSyncs(t *testing.T) {
+	sink := &syncSink{}
+	log := gologger.NewLoggerWithConfig(gologger.LoggerConfig{
+		OutputMode: gologger.OutputDiscard,
+		LogLevel:   gologger.LevelInfo,
+		Sinks:      []gologger.Sink{gologger.NewBufferedSink(sink, gologger.BufferConfig{FlushInterval: time.Hour})},
+	})
+	defer log.Close()
+	handler := Wrap(func() error { return nil }, log)
+
+	if _, err := handler.Invoke(invocationContext("aws-req-4"), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if sink.written != 1 || sink.synced != 1 {
+		t.Errorf("Expected the completion entry to be flushed, got %d written, %d synced", sink.written, sink.synced)
+	}
+}
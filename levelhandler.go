@@ -0,0 +1,85 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// levelPayload is the JSON body read and written by LevelHandler.
+type levelPayload struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an HTTP handler that reports and changes the logger's
+// level at runtime, so operators can turn on debug logging during an incident
+// without a restart:
+//
+//	curl localhost:8080/log/level
+//	curl -X PUT localhost:8080/log/level -d '{"level":"debug"}'
+//
+// GET responds with {"level":"info"}. PUT sets the level from a JSON body
+// {"level":"debug"}, or from a "level" form or query value, and responds with
+// the new level. Unknown levels are rejected with 400 Bad Request and other
+// methods with 405 Method Not Allowed. The change applies to every copy of
+// the logger, as with SetLevel. The handler does no authentication; mount it
+// on an internal port or behind your own middleware.
+func (l Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			level, err := requestedLevel(r)
+			if err == nil {
+				err = l.SetLevel(level)
+			}
+			if err != nil {
+				writeLevelResponse(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+				return
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeLevelResponse(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+			return
+		}
+		writeLevelResponse(w, http.StatusOK, levelPayload{Level: l.GetLevel()})
+	})
+}
+
+// requestedLevel reads the level of a PUT request from its "level" query
+// value, or from its body as JSON or as a form. The body format is detected
+// from its contents because curl -d sends JSON with a form content type.
+func requestedLevel(r *http.Request) (string, error) {
+	if level := r.URL.Query().Get("level"); level != "" {
+		return level, nil
+	}
+	data, err := io.ReadAll(io.LimitReader(r.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("gologger: reading level request: %w", err)
+	}
+
+	var level string
+	if body := bytes.TrimSpace(data); bytes.HasPrefix(body, []byte("{")) {
+		var payload levelPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return "", fmt.Errorf("gologger: invalid level request: %w", err)
+		}
+		level = payload.Level
+	} else if values, err := url.ParseQuery(string(body)); err == nil {
+		level = values.Get("level")
+	}
+	if level == "" {
+		return "", errors.New("gologger: missing level")
+	}
+	return level, nil
+}
+
+func writeLevelResponse(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,51 @@
+package gologger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLevelHandler(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelInfo})
+	defer log.Close()
+	handler := log.LevelHandler()
+
+	tests := []struct {
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+		response    string
+		level       string
+	}{
+		{http.MethodGet, "/", "", "", http.StatusOK, `{"level":"info"}`, LevelInfo},
+		{http.MethodPut, "/", "application/json", `{"level":"debug"}`, http.StatusOK, `{"level":"debug"}`, LevelDebug},
+		// curl -d sends JSON with a form content type.
+		{http.MethodPut, "/", "application/x-www-form-urlencoded", `{"level":"warn"}`, http.StatusOK, `{"level":"warn"}`, LevelWarn},
+		{http.MethodPut, "/", "application/x-www-form-urlencoded", "level=error", http.StatusOK, `{"level":"error"}`, LevelError},
+		{http.MethodPut, "/?level=info", "", "", http.StatusOK, `{"level":"info"}`, LevelInfo},
+		{http.MethodPut, "/", "application/json", `{"level":"verbose"}`, http.StatusBadRequest, `unknown level \"verbose\"`, LevelInfo},
+		{http.MethodPut, "/", "application/json", `{"level":`, http.StatusBadRequest, "invalid level request", LevelInfo},
+		{http.MethodPut, "/", "application/json", `{}`, http.StatusBadRequest, "missing level", LevelInfo},
+		{http.MethodPost, "/", "application/json", `{"level":"debug"}`, http.StatusMethodNotAllowed, "method not allowed", LevelInfo},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+		if tt.contentType != "" {
+			req.Header.Set("Content-Type", tt.contentType)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.status || !strings.Contains(rec.Body.String(), tt.response) {
+			t.Errorf("%s %s %q: expected %d containing %q, got %d %q", tt.method, tt.target, tt.body, tt.status, tt.response, rec.Code, rec.Body.String())
+		}
+		if log.GetLevel() != tt.level {
+			t.Errorf("%s %s %q: expected level %s, got %s", tt.method, tt.target, tt.body, tt.level, log.GetLevel())
+		}
+	}
+}
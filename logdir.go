@@ -0,0 +1,56 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// DefaultLogDir returns the platform's directory for the log files of app,
+// used when LoggerConfig.LogDir is empty:
+//
+//   - Linux and other Unix systems: $XDG_STATE_HOME/app/logs, by default
+//     ~/.local/state/app/logs
+//   - macOS: ~/Library/Logs/app
+//   - Windows: %LOCALAPPDATA%\app\Logs
+//
+// app defaults to the name of the executable. If the home directory cannot
+// be determined, the relative "logger" directory is returned.
+func DefaultLogDir(app string) string {
+	if app == "" {
+		app = executableName()
+	}
+
+	switch runtime.GOOS {
+	case "windows":
+		if dir := os.Getenv("LOCALAPPDATA"); dir != "" {
+			return filepath.Join(dir, app, "Logs")
+		}
+	case "darwin", "ios":
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, "Library", "Logs", app)
+		}
+	default:
+		if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+			return filepath.Join(dir, app, "logs")
+		}
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local", "state", app, "logs")
+		}
+	}
+	return "logger"
+}
+
+// executableName returns the name of the running program, without ".exe".
+func executableName() string {
+	name := filepath.Base(os.Args[0])
+	if executable, err := os.Executable(); err == nil {
+		name = filepath.Base(executable)
+	}
+	name = strings.TrimSuffix(name, ".exe")
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return "gologger"
+	}
+	return name
+}
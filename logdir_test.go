@@ -0,0 +1,62 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDefaultLogDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("LOCALAPPDATA", filepath.Join(home, "AppData", "Local"))
+	t.Setenv("XDG_STATE_HOME", "")
+
+	var expected string
+	switch runtime.GOOS {
+	case "windows":
+		expected = filepath.Join(home, "AppData", "Local", "checkout", "Logs")
+	case "darwin", "ios":
+		expected = filepath.Join(home, "Library", "Logs", "checkout")
+	default:
+		expected = filepath.Join(home, ".local", "state", "checkout", "logs")
+	}
+	if dir := DefaultLogDir("checkout"); dir != expected {
+		t.Errorf("Expected %s, got %s", expected, dir)
+	}
+
+	if runtime.GOOS != "windows" && runtime.GOOS != "darwin" && runtime.GOOS != "ios" {
+		state := filepath.Join(home, "state")
+		t.Setenv("XDG_STATE_HOME", state)
+		if dir := DefaultLogDir("checkout"); dir != filepath.Join(state, "checkout", "logs") {
+			t.Errorf("Expected the directory under XDG_STATE_HOME, got %s", dir)
+		}
+	}
+
+	if dir := DefaultLogDir(""); !strings.Contains(dir, executableName()) {
+		t.Errorf("Expected the executable name as app, got %s", dir)
+	}
+}
+
+func TestEmptyLogDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("LOCALAPPDATA", home)
+	t.Setenv("XDG_STATE_HOME", "")
+
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputFile, ServiceName: "checkout"})
+	log.Info("stored").Send()
+	log.Close()
+
+	dir := DefaultLogDir("checkout")
+	if log.Config().LogDir != dir {
+		t.Errorf("Expected the effective log directory %s, got %s", dir, log.Config().LogDir)
+	}
+	if _, err := os.Stat(currentLogFile(dir)); err != nil {
+		t.Errorf("Expected the log file in the default directory: %v", err)
+	}
+}
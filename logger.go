@@ -4,12 +4,20 @@ package gologger
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
 // Output modes for logger configuration.
@@ -17,6 +25,9 @@ const (
 	OutputTerminal = "terminal"
 	OutputFile     = "file"
 	OutputBoth     = "both"
+	OutputSplit    = "split"   // debug and info to stdout, warn and above to stderr
+	OutputDiscard  = "discard" // encode entries but discard the output, without touching the filesystem
+	OutputStdout   = "stdout"  // all entries to stdout, for log collectors that read container output
 )
 
 // Log levels for logger configuration.
@@ -27,11 +38,68 @@ const (
 	LevelError = "error"
 )
 
+// Encodings for logger outputs.
+const (
+	EncodingJSON     = "json"     // one JSON object per line
+	EncodingConsole  = "console"  // aligned key=value lines for local development, colored on terminals
+	EncodingECS      = "ecs"      // JSON following the Elastic Common Schema
+	EncodingCLEF     = "clef"     // Compact Log Event Format, as read by Seq
+	EncodingSyslog   = "syslog"   // RFC 5424 syslog messages with fields as structured data
+	EncodingMsgPack  = "msgpack"  // MessagePack maps with the same layout as JSON
+	EncodingProtobuf = "protobuf" // Length-prefixed protobuf LogRecord messages
+	EncodingPretty   = "pretty"   // Indented multi-line JSON for local development
+	EncodingCEF      = "cef"      // ArcSight Common Event Format lines for SIEM ingestion
+)
+
+// Timestamp formats for JSON outputs.
+const (
+	TimeFormatISO8601     = "iso8601"      // 2006-01-02T15:04:05.000Z07:00
+	TimeFormatEpochSecond = "epoch_second" // Integer seconds since the Unix epoch
+	TimeFormatEpochMilli  = "epoch_milli"  // Integer milliseconds since the Unix epoch
+	TimeFormatEpochNano   = "epoch_nano"   // Integer nanoseconds since the Unix epoch
+)
+
+// Level formats for JSON outputs.
+const (
+	LevelFormatUpper  = "upper"  // INFO, WARN, ERROR
+	LevelFormatLower  = "lower"  // info, warn, error
+	LevelFormatSyslog = "syslog" // Numeric syslog severities: 6, 4, 3
+	LevelFormatOTel   = "otel"   // Numeric OpenTelemetry severities: 9, 13, 17
+)
+
+// Caller formats.
+const (
+	CallerFormatShort  = "short"  // Package directory and file: handler/user.go:42
+	CallerFormatFull   = "full"   // Absolute path, without CallerPathPrefix: /src/app/handler/user.go:42
+	CallerFormatModule = "module" // Package import path and file: github.com/org/app/handler/user.go:42
+	CallerFormatFile   = "file"   // File name only: user.go:42
+)
+
 // Context key for request ID.
 type contextKey string
 
 const (
 	RequestIDKey contextKey = "gologger-request-id"
+	TraceIDKey   contextKey = "gologger-trace-id"
+	SpanIDKey    contextKey = "gologger-span-id"
+)
+
+// fieldsKey is the context key of the fields added by WithFields.
+const fieldsKey contextKey = "gologger-fields"
+
+// Field names used for trace context in logs.
+const (
+	TraceIDField = "trace_id"
+	SpanIDField  = "span_id"
+)
+
+// Field names set by ServiceFields.
+const (
+	ServiceField  = "service"
+	VersionField  = "version"
+	EnvField      = "env"
+	HostnameField = "hostname"
+	PIDField      = "pid"
 )
 
 // Logger provides a simplified structured logging interface.
@@ -42,55 +110,171 @@ type Logger struct {
 	message      string
 	data         []any
 	hasData      bool
-	requestIDKey string // Custom key for request ID in logs
-	showCaller   bool   // Whether to show caller information in logs
+	requestIDKey string          // Custom key for request ID in logs
+	showCaller   bool            // Whether to show caller information in logs
+	sinks        *sinkSet        // Additional sinks, shared by all copies of the logger
+	hooks        *hookSet        // Hooks added with AddHook, shared by all copies of the logger
+	closers      []func() error  // Cleanup functions for internal resources, run by Close
+	recorder     *flightRecorder // Crash flight recorder (nil if disabled)
+	stats        *loggerStats    // Runtime counters, shared by all copies of the logger
+	filters      *filterSwitch   // Sanitize, RedactKeys, Maskers and AllowKeys, shared by all copies of the logger
+	sampling     *SamplingConfig // Sampling of the entry set by Sample or Unsampled (nil uses LoggerConfig.Sampling)
+	sampler      *callSampler    // Counters of the entries sampled with Sample, shared by all copies of the logger
+	minLevel     zap.AtomicLevel // Minimum level of all outputs, shared by all copies of the logger
+	source       *configSource   // Configuration file the logger was created from (nil if none)
+	outputs      *outputSwitch   // Outputs built from the configuration, shared by all copies of the logger
 }
 
 // LogRotationConfig holds configuration options for log file rotation.
 type LogRotationConfig struct {
-	MaxSize    int  // Maximum size in megabytes before rotation (default: 10)
-	MaxBackups int  // Maximum number of old log files to retain (default: 3)
-	MaxAge     int  // Maximum number of days to retain old log files (default: 28)
-	Compress   bool // Whether to compress rotated log files (default: true)
+	MaxSize         int                     // Maximum size in megabytes before rotation (default: 10)
+	MaxBackups      int                     // Maximum number of size-rotated backups of the current file to retain (default: 3)
+	MaxAge          int                     // Maximum number of days to retain size-rotated backups of the current file (default: 28)
+	PrunePeriods    bool                    // Also apply MaxBackups and MaxAge to the files of earlier periods and their backups, e.g. to keep 7 daily files (default: false, files of earlier periods are kept)
+	MaxTotalSizeMB  int                     // Delete the oldest rotated files when the log directory exceeds this many megabytes (default: 0, no limit)
+	Compress        bool                    // Whether to compress rotated log files (default: true)
+	Interval        string                  // Start a new file every period: RotateHourly, RotateDaily or RotateWeekly (default: RotateDaily)
+	Layout          string                  // File layout: LayoutFlat ("logger-2024-06-15.log") or LayoutDated ("2024/06/15/logger.log") (default: LayoutFlat)
+	RotateOnSIGHUP  bool                    // Rotate the file when the process receives SIGHUP, e.g. from logrotate (default: false)
+	CleanupInterval time.Duration           // Also compress and remove old files on this interval, not only at rotation, so idle services honour MaxAge (default: 0, disabled)
+	OnRotate        func(path string) error // Called in the background with the path of each rotated file, after compression, e.g. S3Uploader.Upload (optional)
+	CurrentLink     string                  // Name of a symlink in the log directory kept pointing at the active file, e.g. "current.log" (optional)
+	UTC             bool                    // Use UTC instead of local time for file names, rotation periods and backup timestamps (default: false)
 }
 
 // LoggerConfig holds configuration options for the logger.
 type LoggerConfig struct {
-	OutputMode   string             // Output mode: OutputTerminal, OutputFile, or OutputBoth
-	LogLevel     string             // Log level: LevelDebug, LevelInfo, LevelWarn, or LevelError
-	LogDir       string             // Directory for log files
-	RequestIDKey string             // Custom key for request ID in logs (default: "request-id")
-	ShowCaller   bool               // Whether to show caller information in logs (default: true)
-	LogRotation  *LogRotationConfig // Log rotation configuration (optional, uses defaults if nil)
+	OutputMode       string                        // Output mode: OutputTerminal, OutputFile, OutputBoth, OutputSplit, OutputStdout, or OutputDiscard
+	LogLevel         string                        // Log level: LevelDebug, LevelInfo, LevelWarn, or LevelError
+	LogDir           string                        // Directory for log files (default: DefaultLogDir(ServiceName), e.g. ~/.local/state/app/logs)
+	RequestIDKey     string                        // Custom key for request ID in logs (default: "request-id")
+	ShowCaller       bool                          // Whether to show caller information in logs (default: true)
+	LogRotation      *LogRotationConfig            // Log rotation configuration (optional, uses defaults if nil)
+	LevelFiles       map[string]*LogRotationConfig // Also write entries of these levels to their own files, e.g. "error-2024-06-15.log", with their own rotation settings; nil uses LogRotation (optional)
+	Sinks            []Sink                        // Additional destinations receiving every entry (optional)
+	FileBuffer       *BufferConfig                 // Buffer file writes in memory (optional, unbuffered if nil)
+	FileSync         string                        // When to fsync log files: SyncNever, SyncOnError, SyncInterval or SyncOnClose (default: SyncNever)
+	FileSyncInterval time.Duration                 // Fsync interval for SyncInterval (default: 1s)
+	FileEncryption   *EncryptionConfig             // Encrypt log files with AES-256-GCM; read them with DecryptLogFile (optional, plaintext if nil)
+	FlightRecorder   *FlightRecorderConfig         // Keep recent entries at all levels for crash dumps (optional, disabled if nil)
+	OnSinkError      func(sink string, err error)  // Called when a write to an output or sink fails (optional)
+	Encoding         string                        // Encoding of all outputs: EncodingJSON, EncodingConsole, EncodingECS, EncodingCLEF, EncodingSyslog, EncodingMsgPack, EncodingProtobuf, EncodingPretty or EncodingCEF (default: EncodingJSON)
+	TerminalEncoding string                        // Encoding of terminal, stdout and stderr outputs, overrides Encoding (optional)
+	FileEncoding     string                        // Encoding of the log file, overrides Encoding (optional)
+	ServiceName      string                        // Name of the service, written as service.name by EncodingECS and APP-NAME by EncodingSyslog (optional)
+	SyslogFacility   int                           // Facility of EncodingSyslog outputs, e.g. SyslogFacilityLocal0 (default: SyslogFacilityUser)
+	GlobalFields     map[string]any                // Fields added to every entry, e.g. from ServiceFields (optional)
+	CallerFormat     string                        // Caller format of JSON and console outputs: CallerFormatShort, CallerFormatFull, CallerFormatModule or CallerFormatFile (default: CallerFormatShort)
+	CallerPathPrefix string                        // Prefix stripped from caller paths by CallerFormatFull, e.g. the build directory (optional)
+	HideFunction     bool                          // Omit the calling function from JSON outputs (default: false)
+	CEF              *CEFConfig                    // Header values of EncodingCEF outputs (optional)
+	Sanitize         string                        // Clean control characters and invalid UTF-8 from messages and string fields: SanitizeStrip or SanitizeEscape (optional)
+	RedactKeys       []string                      // Replace the values of fields whose keys match these patterns, e.g. "authorization" or "*password*", with Redacted, including the keys of nested maps and structs; matched ignoring case (optional)
+	AllowKeys        []string                      // Only log the fields whose keys match these patterns, as in RedactKeys, dropping and counting the others in Stats; nested map and struct keys are kept if they or their dotted path, e.g. "user.id", match; the request ID and trace context are always kept (optional, all fields if empty)
+	Maskers          map[string]Masker             // Mask the values of fields whose keys match the map keys, patterns as in RedactKeys, e.g. "phone": MaskLast(4); RedactKeys take precedence (optional)
+	ConsoleIcons     bool                          // Prefix levels with icons and render messages in bold in EncodingConsole outputs (default: false)
+	TimeFormat       string                        // Timestamp format of JSON outputs: TimeFormatISO8601 or TimeFormatEpoch* (default: TimeFormatISO8601)
+	FieldKeys        *FieldKeysConfig              // Rename the standard keys of JSON outputs (optional)
+	LevelFormat      string                        // Level format of JSON outputs: LevelFormatUpper, LevelFormatLower, LevelFormatSyslog or LevelFormatOTel (default: LevelFormatUpper)
+	LevelLabels      map[string]string             // Custom level labels of JSON outputs keyed by level name, e.g. "warn": "WARNING" (optional)
+	DebugOnSignal    bool                          // Switch to debug level on SIGUSR1 and back to the previous level on SIGUSR2; ignored on Windows (default: false)
+	DebugTimeout     time.Duration                 // Also switch back this long after the last SIGUSR1 (default: 0, stay in debug until SIGUSR2)
+	StacktraceLevel  string                        // Capture stack traces for entries at this level and above, e.g. LevelError (optional, disabled if empty)
+	Sampling         *SamplingConfig               // Limit repeated entries with the same level and message (optional, disabled if nil)
+	Dedup            *DedupConfig                  // Collapse identical consecutive entries into one with a "repeat_count" (optional, disabled if nil)
+	RateLimits       map[string]int                // Maximum entries per second of these levels, e.g. "debug": 100; entries beyond it are dropped and counted in Stats (optional, unlimited levels if absent)
+	TwelveFactor     bool                          // Write JSON to stdout only, without log files, as expected by container platforms; see RunningInContainer (default: false)
+	BuildInfo        bool                          // Add the version, commit and dirty fields of BuildFields to every entry; GlobalFields take precedence (default: false)
+	Outputs          []OutputConfig                // Outputs with their own encoding, level and destination, replacing OutputMode, TerminalEncoding, FileEncoding and LevelFiles (optional)
+	ComponentLevels  map[string]string             // Levels of components created with Named, e.g. "http": "warn"; "*" sets the level of all others, overriding LogLevel (optional)
+}
+
+// FieldKeysConfig renames the standard keys of JSON outputs. Empty keys keep
+// their default names.
+type FieldKeysConfig struct {
+	Time       string // Key of the timestamp (default: "timestamp")
+	Level      string // Key of the level (default: "level")
+	Message    string // Key of the message (default: "msg")
+	Caller     string // Key of the caller (default: "caller")
+	Function   string // Key of the calling function (default: "func")
+	Stacktrace string // Key of the stack trace (default: "stacktrace")
 }
 
 // NewLogger creates a new Logger instance with default configuration.
 // Default settings: output to both terminal and file, debug level, logs saved to "logger" directory.
 func NewLogger() Logger {
-	return NewLoggerWithConfig(LoggerConfig{
+	return NewLoggerWithConfig(defaultConfig())
+}
+
+// defaultConfig returns the configuration used by NewLogger.
+func defaultConfig() LoggerConfig {
+	return LoggerConfig{
 		OutputMode:   OutputBoth,   // default: both terminal and file
 		LogLevel:     LevelDebug,   // default: debug level
 		LogDir:       "logger",     // default: logger directory
 		RequestIDKey: "request-id", // default: request-id key
 		ShowCaller:   true,         // default: show caller information
-	})
+	}
 }
 
 // NewLoggerWithConfig creates a new Logger instance with custom configuration.
 func NewLoggerWithConfig(config LoggerConfig) Logger {
-	// Set default request ID key if not provided
-	requestIDKey := config.RequestIDKey
-	if requestIDKey == "" {
-		requestIDKey = "request-id"
+	if config.TwelveFactor {
+		config = twelveFactorConfig(config)
 	}
 
+	// Set default request ID key if not provided
+	requestIDKey := requestIDKeyOrDefault(config.RequestIDKey)
+
 	// Set default showCaller if not explicitly set (default: true)
 	showCaller := config.ShowCaller
 	// Note: Since bool zero value is false, we need to check if it was explicitly set
 	// For now, we'll use the value as-is, but users should explicitly set it to false if they want to disable caller
 
+	stats := newLoggerStats(config.OnSinkError)
+	minLevel := zap.NewAtomicLevelAt(getLogLevel(componentDefaultLevel(config)))
+	components := newComponentLevels(config.ComponentLevels, minLevel)
+	var level zapcore.LevelEnabler = minLevel
+	if components != nil {
+		level = components
+	}
+	// Sinks follow the level of the current outputs, which Reconfigure replaces
+	switcher := &outputSwitch{}
+	sinks := newSinkSet(switcher, stats)
+	hooks := newHookSet()
+
+	var recorder *flightRecorder
+	var closers []func() error
+	if config.FlightRecorder != nil {
+		recorder = newFlightRecorder(*config.FlightRecorder)
+		closers = append(closers, recorder.output.Close)
+	}
+
+	out := initLogWithConfig(config, level, components, sinks, hooks, recorder, stats)
+	out.config = effectiveConfig(config, out.files)
+	for _, sink := range config.Sinks {
+		out.sinkIDs = append(out.sinkIDs, sinks.addSink(sink))
+	}
+	switcher.current.Store(out)
+	if config.DebugOnSignal {
+		if stop := notifyDebugToggle(minLevel, config.DebugTimeout); stop != nil {
+			closers = append(closers, func() error { stop(); return nil })
+		}
+	}
+
+	// Add caller information only if ShowCaller is true
+	options := []zap.Option{zap.Development()}
+	if config.ShowCaller {
+		options = append(options, zap.AddCaller(), zap.AddCallerSkip(1))
+	}
+
+	// Capture stack traces from the configured level
+	if config.StacktraceLevel != "" {
+		options = append(options, zap.AddStacktrace(getLogLevel(config.StacktraceLevel)))
+	}
+
 	return Logger{
-		log:          initLogWithConfig(config),
+		log:          zap.New(&switchCore{outputs: switcher}, options...).Sugar(),
 		ctx:          context.Background(),
 		level:        "",
 		message:      "",
@@ -98,9 +282,34 @@ func NewLoggerWithConfig(config LoggerConfig) Logger {
 		hasData:      false,
 		requestIDKey: requestIDKey,
 		showCaller:   showCaller,
+		sinks:        sinks,
+		hooks:        hooks,
+		closers:      closers,
+		recorder:     recorder,
+		stats:        stats,
+		filters:      newFilterSwitch(config),
+		sampler:      newCallSampler(),
+		minLevel:     minLevel,
+		outputs:      switcher,
 	}
 }
 
+// ServiceFields returns the service, version and env fields for use as
+// LoggerConfig.GlobalFields, together with the hostname and process ID.
+// Empty arguments are left out.
+func ServiceFields(service, version, env string) map[string]any {
+	fields := map[string]any{PIDField: os.Getpid()}
+	if hostname, err := os.Hostname(); err == nil {
+		fields[HostnameField] = hostname
+	}
+	for key, value := range map[string]string{ServiceField: service, VersionField: version, EnvField: env} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
 // WithRequestID adds a request ID to the context.
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, RequestIDKey, requestID)
@@ -115,47 +324,186 @@ func GetRequestID(ctx context.Context) string {
 	return ""
 }
 
-// prefix generates a log file prefix with current date.
-func prefix() string {
-	return "logger-" + time.Now().Format("2006-01-02")
+// WithTraceContext adds W3C trace and span IDs (lowercase hex) to the context.
+func WithTraceContext(ctx context.Context, traceID, spanID string) context.Context {
+	ctx = context.WithValue(ctx, TraceIDKey, traceID)
+	return context.WithValue(ctx, SpanIDKey, spanID)
+}
+
+// GetTraceContext retrieves the trace and span IDs from the context.
+// Returns empty strings if no trace context is found.
+func GetTraceContext(ctx context.Context) (traceID, spanID string) {
+	traceID, _ = ctx.Value(TraceIDKey).(string)
+	spanID, _ = ctx.Value(SpanIDKey).(string)
+	return traceID, spanID
+}
+
+// WithFields adds fields, given as alternating keys and values, to the
+// context, after those it already carries. Loggers bound to the context log
+// them with every entry, after the request ID and trace context, e.g. the
+// job or task an operation belongs to. A key without a value is ignored.
+func WithFields(ctx context.Context, keysAndValues ...any) context.Context {
+	fields := slices.Clip(GetFields(ctx))
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		fields = append(fields, key, keysAndValues[i+1])
+	}
+	return context.WithValue(ctx, fieldsKey, fields)
+}
+
+// GetFields retrieves the fields added to the context by WithFields, as
+// alternating keys and values. Returns nil if the context carries none.
+func GetFields(ctx context.Context) []any {
+	fields, _ := ctx.Value(fieldsKey).([]any)
+	return fields
 }
 
-// initLogWithConfig creates a logger with custom configuration.
-func initLogWithConfig(config LoggerConfig) *zap.SugaredLogger {
+// requestIDKeyOrDefault returns key, or "request-id" if key is empty.
+func requestIDKeyOrDefault(key string) string {
+	if key == "" {
+		return "request-id"
+	}
+	return key
+}
+
+// initLogWithConfig creates the outputs of a configuration: the core writing
+// to them, the log file writers and cleanup functions for resources that must
+// be released when the outputs are closed or replaced.
+func initLogWithConfig(config LoggerConfig, level zapcore.LevelEnabler, components *componentLevels, sinks *sinkSet, hooks *hookSet, recorder *flightRecorder, stats *loggerStats) *outputs {
 	var cores []zapcore.Core
-	encoder := getEncoder()
-	level := getLogLevel(config.LogLevel)
+	var closers []func() error
+	var files []*rotatingFile
+	encoder := getEncoder(outputEncoding(config.TerminalEncoding, config.Encoding), config, terminalSupportsColor(os.Stderr))
+	fileEncoder := getEncoder(outputEncoding(config.FileEncoding, config.Encoding), config, false)
+
+	// Add the outputs listed in Outputs, which replace those of OutputMode
+	mode := config.OutputMode
+	if len(config.Outputs) > 0 {
+		cores, files, closers = configuredOutputs(config, level, stats)
+		mode = ""
+	}
 
 	// Add terminal output if needed
-	if config.OutputMode == OutputTerminal || config.OutputMode == OutputBoth {
-		terminalCore := zapcore.NewCore(encoder, zapcore.Lock(os.Stderr), level)
+	if mode == OutputTerminal || mode == OutputBoth {
+		terminalCore := zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{os.Stderr}), "terminal", stats}, level)
 		cores = append(cores, terminalCore)
 	}
 
+	// Add split stdout/stderr output if needed
+	if mode == OutputSplit {
+		stdoutLevel := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return level.Enabled(l) && l < zapcore.WarnLevel
+		})
+		stderrLevel := zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+			return level.Enabled(l) && l >= zapcore.WarnLevel
+		})
+		cores = append(cores,
+			zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{os.Stdout}), "stdout", stats}, stdoutLevel),
+			zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{os.Stderr}), "stderr", stats}, stderrLevel),
+		)
+	}
+
+	// Add stdout output if needed
+	if mode == OutputStdout {
+		cores = append(cores, zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{os.Stdout}), "stdout", stats}, level))
+	}
+
+	// Add discarding output if needed
+	if mode == OutputDiscard {
+		cores = append(cores, zapcore.NewCore(encoder, zapcore.AddSync(io.Discard), level))
+	}
+
 	// Add file output if needed
-	if config.OutputMode == OutputFile || config.OutputMode == OutputBoth {
-		fileCore := zapcore.NewCore(encoder, getLogWriter(config.LogDir, config.LogRotation), level)
+	if mode == OutputFile || mode == OutputBoth {
+		logDir := config.LogDir
+		if logDir == "" {
+			logDir = DefaultLogDir(config.ServiceName)
+		}
+		file := getLogWriter(logDir, "logger", config.LogRotation)
+		fileCore, fileClosers := fileOutput(file, "file", config.LogRotation, config, fileEncoder, level, stats)
+		files = append(files, file)
+		closers = append(closers, fileClosers...)
 		cores = append(cores, fileCore)
+
+		// Add per-level files, each with its own rotation settings
+		names := make([]string, 0, len(config.LevelFiles))
+		for name := range config.LevelFiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rotation := config.LevelFiles[name]
+			if rotation == nil {
+				rotation = config.LogRotation
+			}
+			file := getLogWriter(logDir, name, rotation)
+			fileCore, fileClosers := fileOutput(file, "file-"+name, rotation, config, fileEncoder, levelFileEnabler(level, getLogLevel(name)), stats)
+			files = append(files, file)
+			closers = append(closers, fileClosers...)
+			cores = append(cores, fileCore)
+		}
 	}
 
 	// If no valid output mode, default to terminal
 	if len(cores) == 0 {
-		terminalCore := zapcore.NewCore(encoder, zapcore.Lock(os.Stderr), level)
+		terminalCore := zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{os.Stderr}), "terminal", stats}, level)
 		cores = append(cores, terminalCore)
 	}
 
+	// Add additional sinks, which may change at runtime
+	cores = append(cores, &dynamicCore{set: sinks})
+
+	// Pass the entries written to the outputs and sinks to the After hooks
+	cores = append(cores, &hookCore{LevelEnabler: level, hooks: hooks})
 	core := zapcore.NewTee(cores...)
 
-	// Add caller information only if ShowCaller is true
-	var logger *zap.Logger
-	if config.ShowCaller {
-		logger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1), zap.Development())
-	} else {
-		logger = zap.New(core, zap.Development())
+	// Collapse identical consecutive entries
+	if config.Dedup != nil {
+		core = newDedupCore(core, *config.Dedup)
 	}
 
-	sugarLogger := logger.Sugar()
-	return sugarLogger
+	// Drop entries beyond the limits of their levels
+	if len(config.RateLimits) > 0 {
+		core = newRateLimitCore(core, config.RateLimits, stats)
+	}
+
+	// Sample repeated entries before they reach the outputs and sinks. Entries
+	// with their own sampling, see Logger.Sample, bypass it.
+	unsampled := finishCore(core, config, components, recorder)
+	sampled := unsampled
+	if config.Sampling != nil {
+		sampled = finishCore(sampleCore(core, *config.Sampling), config, components, recorder)
+	}
+	out := newOutputs(sampled, level, files, closers)
+	out.unsampled = unsampled
+	return out
+}
+
+// finishCore wraps core, writing to the outputs and sinks, with the
+// component levels, flight recorder and global fields of config.
+func finishCore(core zapcore.Core, config LoggerConfig, components *componentLevels, recorder *flightRecorder) zapcore.Core {
+	// Apply per-component levels to all outputs and sinks
+	if components != nil {
+		core = &componentCore{Core: core, levels: components}
+	}
+
+	// Add the flight recorder, which sees entries at every level
+	if recorder != nil {
+		core = zapcore.NewTee(core, &recorderCore{recorder: recorder})
+	}
+
+	// Add global fields to every entry
+	if global := globalFields(config); len(global) > 0 {
+		fields := make([]zap.Field, 0, len(global))
+		for _, key := range sortedKeys(global) {
+			fields = append(fields, zap.Any(key, global[key]))
+		}
+		core = core.With(fields)
+	}
+	return core
 }
 
 func getLogLevel(level string) zapcore.Level {
@@ -173,51 +521,246 @@ func getLogLevel(level string) zapcore.Level {
 	}
 }
 
-func getEncoder() zapcore.Encoder {
+// parseLogLevel converts a level name, rejecting unknown names instead of
+// falling back to debug like getLogLevel.
+func parseLogLevel(level string) (zapcore.Level, error) {
+	switch level {
+	case LevelDebug, LevelInfo, LevelWarn, LevelError:
+		return getLogLevel(level), nil
+	}
+	return zapcore.DebugLevel, fmt.Errorf("gologger: unknown level %q", level)
+}
+
+// outputEncoding returns the encoding of an output, falling back to the shared one.
+func outputEncoding(encoding, shared string) string {
+	if encoding != "" {
+		return encoding
+	}
+	return shared
+}
+
+// getEncoder returns the encoder for an encoding name. color enables ANSI
+// colors for encodings that support them.
+func getEncoder(encoding string, config LoggerConfig, color bool) zapcore.Encoder {
+	switch encoding {
+	case EncodingConsole:
+		enc := newConsoleEncoder(color)
+		enc.caller = getCallerFormatter(config.CallerFormat, config.CallerPathPrefix)
+		enc.icons = config.ConsoleIcons
+		return enc
+	case EncodingECS:
+		return newECSEncoder(config.ServiceName, requestIDKeyOrDefault(config.RequestIDKey))
+	case EncodingCLEF:
+		return newCLEFEncoder()
+	case EncodingSyslog:
+		return newSyslogEncoder(config.SyslogFacility, config.ServiceName)
+	case EncodingMsgPack:
+		return newMsgPackEncoder()
+	case EncodingProtobuf:
+		return newProtobufEncoder()
+	case EncodingCEF:
+		var cef CEFConfig
+		if config.CEF != nil {
+			cef = *config.CEF
+		}
+		return newCEFEncoder(cef, config.ServiceName)
+	}
+
 	loggerConfig := zap.NewProductionEncoderConfig()
 	loggerConfig.TimeKey = "timestamp"
-	loggerConfig.EncodeTime = zapcore.TimeEncoderOfLayout("2006-01-02T15:04:05.000Z07:00")
-	loggerConfig.EncodeLevel = zapcore.CapitalLevelEncoder
+	loggerConfig.EncodeTime = getTimeEncoder(config.TimeFormat)
+	loggerConfig.EncodeLevel = getLevelEncoder(config.LevelFormat, config.LevelLabels)
 	loggerConfig.FunctionKey = "func"
+	if config.HideFunction {
+		loggerConfig.FunctionKey = zapcore.OmitKey
+	}
+	callerFormatter := getCallerFormatter(config.CallerFormat, config.CallerPathPrefix)
+	loggerConfig.EncodeCaller = func(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(callerFormatter(caller))
+	}
+	if keys := config.FieldKeys; keys != nil {
+		setKey(&loggerConfig.TimeKey, keys.Time)
+		setKey(&loggerConfig.LevelKey, keys.Level)
+		setKey(&loggerConfig.MessageKey, keys.Message)
+		setKey(&loggerConfig.CallerKey, keys.Caller)
+		if !config.HideFunction {
+			setKey(&loggerConfig.FunctionKey, keys.Function)
+		}
+		setKey(&loggerConfig.StacktraceKey, keys.Stacktrace)
+	}
+	if encoding == EncodingPretty {
+		return newPrettyJSONEncoder(zapcore.NewJSONEncoder(loggerConfig))
+	}
 	return zapcore.NewJSONEncoder(loggerConfig)
 }
 
-func getLogWriter(logDir string, rotationConfig *LogRotationConfig) zapcore.WriteSyncer {
+// setKey replaces an encoder key when a custom name is set.
+func setKey(key *string, name string) {
+	if name != "" {
+		*key = name
+	}
+}
+
+// getLevelEncoder returns the level encoder for a LevelFormat value. Levels
+// found in labels are written with their custom label instead.
+func getLevelEncoder(format string, labels map[string]string) zapcore.LevelEncoder {
+	var encode zapcore.LevelEncoder
+	switch format {
+	case LevelFormatLower:
+		encode = zapcore.LowercaseLevelEncoder
+	case LevelFormatSyslog:
+		encode = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt(syslogSeverity(l.String()))
+		}
+	case LevelFormatOTel:
+		encode = func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt(otlpSeverity(l.String()))
+		}
+	default:
+		encode = zapcore.CapitalLevelEncoder
+	}
+	if len(labels) == 0 {
+		return encode
+	}
+
+	custom := make(map[zapcore.Level]string, len(labels))
+	for name, label := range labels {
+		var level zapcore.Level
+		if err := level.UnmarshalText([]byte(name)); err == nil {
+			custom[level] = label
+		}
+	}
+	return func(l zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		if label, ok := custom[l]; ok {
+			enc.AppendString(label)
+			return
+		}
+		encode(l, enc)
+	}
+}
+
+// getCallerFormatter returns the function rendering a caller in the given
+// CallerFormat.
+func getCallerFormatter(format, pathPrefix string) func(zapcore.EntryCaller) string {
+	switch format {
+	case CallerFormatFull:
+		return func(caller zapcore.EntryCaller) string {
+			return strings.TrimPrefix(caller.File, pathPrefix) + ":" + strconv.Itoa(caller.Line)
+		}
+	case CallerFormatModule:
+		return func(caller zapcore.EntryCaller) string {
+			pkg := callerPackage(caller.Function)
+			if pkg == "" {
+				return caller.TrimmedPath()
+			}
+			return pkg + "/" + filepath.Base(caller.File) + ":" + strconv.Itoa(caller.Line)
+		}
+	case CallerFormatFile:
+		return func(caller zapcore.EntryCaller) string {
+			return filepath.Base(caller.File) + ":" + strconv.Itoa(caller.Line)
+		}
+	default:
+		return zapcore.EntryCaller.TrimmedPath
+	}
+}
+
+// callerPackage returns the import path of the package declaring the fully
+// qualified function name fn, e.g. "github.com/org/app/handler" for
+// "github.com/org/app/handler.(*Handler).Serve".
+func callerPackage(fn string) string {
+	slash := strings.LastIndexByte(fn, '/')
+	dot := strings.IndexByte(fn[slash+1:], '.')
+	if dot < 0 {
+		return ""
+	}
+	return fn[:slash+1+dot]
+}
+
+// getTimeEncoder returns the timestamp encoder for a TimeFormat value.
+// Epoch formats are written as integers, which ingestion pipelines parse
+// much faster than formatted strings.
+func getTimeEncoder(format string) zapcore.TimeEncoder {
+	switch format {
+	case TimeFormatEpochSecond:
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt64(t.Unix())
+		}
+	case TimeFormatEpochMilli:
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt64(t.UnixMilli())
+		}
+	case TimeFormatEpochNano:
+		return func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
+			enc.AppendInt64(t.UnixNano())
+		}
+	default:
+		return zapcore.TimeEncoderOfLayout("2006-01-02T15:04:05.000Z07:00")
+	}
+}
+
+func getLogWriter(logDir, name string, rotationConfig *LogRotationConfig) *rotatingFile {
 	// Create log directory if it doesn't exist
 	if err := os.MkdirAll(logDir, 0755); err != nil {
 		// If can't create directory, fallback to current directory
 		logDir = "."
 	}
 
-	logFile := logDir + "/" + prefix() + ".log"
+	return newRotatingFile(logDir, name, rotationConfig)
+}
 
-	// Set default rotation values if not provided
-	maxSize := 10
-	maxBackups := 3
-	maxAge := 28
-	compress := true
+// fileOutput creates the core writing to a log file: failed writes and
+// background errors are reported under name, SIGHUP and periodic cleanup are
+// handled if configured, writes are encrypted and buffered if requested, and
+// the file is synced according to FileSync. It also returns the cleanup
+// functions to run on Close.
+func fileOutput(file *rotatingFile, name string, rotation *LogRotationConfig, config LoggerConfig, encoder zapcore.Encoder, enabler zapcore.LevelEnabler, stats *loggerStats) (zapcore.Core, []func() error) {
+	var closers []func() error
+	report := func(err error) { stats.reportSinkError(name, err) }
+	file.onError = report
+	if config.FileEncryption != nil {
+		file.encrypt = newFileEncrypter(*config.FileEncryption)
+	}
+	switch config.FileSync {
+	case SyncOnError, SyncInterval, SyncOnClose:
+		file.fsync = true
+	}
+	if stop := startFileTasks(file, rotation); stop != nil {
+		closers = append(closers, func() error { stop(); return nil })
+	}
 
-	if rotationConfig != nil {
-		if rotationConfig.MaxSize > 0 {
-			maxSize = rotationConfig.MaxSize
-		}
-		if rotationConfig.MaxBackups >= 0 {
-			maxBackups = rotationConfig.MaxBackups
-		}
-		if rotationConfig.MaxAge > 0 {
-			maxAge = rotationConfig.MaxAge
+	var ws zapcore.WriteSyncer = reportingWriteSyncer{file, name, stats}
+	var stopBuffer func() error
+	if config.FileBuffer != nil {
+		ws, stopBuffer = newBufferedWriteSyncer(ws, *config.FileBuffer)
+	}
+	if config.FileSync == SyncInterval {
+		interval := config.FileSyncInterval
+		if interval <= 0 {
+			interval = time.Second
 		}
-		compress = rotationConfig.Compress
+		closers = append(closers, syncEvery(ws, interval, report))
+	}
+	if stopBuffer != nil {
+		closers = append(closers, stopBuffer)
 	}
 
-	ws := zapcore.AddSync(&lumberjack.Logger{
-		Filename:   logFile,
-		MaxSize:    maxSize, // megabytes
-		MaxBackups: maxBackups,
-		MaxAge:     maxAge, // days
-		Compress:   compress,
-	})
-	return ws
+	core := zapcore.NewCore(encoder, ws, enabler)
+	if config.FileSync == SyncOnError {
+		core = &syncErrorCore{Core: core, ws: ws, report: report}
+	}
+	return core, append(closers, file.Close)
+}
+
+// levelFileEnabler enables the entries of a per-level file: those at
+// fileLevel, or at error and above for the error file, that the logger's
+// level lets through.
+func levelFileEnabler(level zapcore.LevelEnabler, fileLevel zapcore.Level) zap.LevelEnablerFunc {
+	return func(l zapcore.Level) bool {
+		if fileLevel == zapcore.ErrorLevel && l > zapcore.ErrorLevel {
+			l = zapcore.ErrorLevel
+		}
+		return level.Enabled(l) && l == fileLevel
+	}
 }
 
 // WithContext creates a new logger instance with context information.
@@ -232,9 +775,38 @@ func (l Logger) WithContext(ctx context.Context) Logger {
 		hasData:      false,
 		requestIDKey: l.requestIDKey,
 		showCaller:   l.showCaller,
+		sinks:        l.sinks,
+		hooks:        l.hooks,
+		closers:      l.closers,
+		recorder:     l.recorder,
+		stats:        l.stats,
+		filters:      l.filters,
+		sampler:      l.sampler,
+		minLevel:     l.minLevel,
+		source:       l.source,
+		outputs:      l.outputs,
 	}
 }
 
+// Named returns a logger for a component of the application. The name is
+// written as the "logger" field of JSON outputs and selects the component's
+// level in LoggerConfig.ComponentLevels. Names of nested calls are joined
+// with a dot, e.g. "http.client".
+func (l Logger) Named(name string) Logger {
+	named := l.WithContext(l.ctx)
+	named.log = l.log.Named(name)
+	return named
+}
+
+// WithCallerSkip returns a logger reporting as caller the function skip
+// frames further up the stack, for helpers and adapters wrapping the logger
+// that should not appear as the caller of their entries.
+func (l Logger) WithCallerSkip(skip int) Logger {
+	skipped := l.WithContext(l.ctx)
+	skipped.log = l.log.WithOptions(zap.AddCallerSkip(skip))
+	return skipped
+}
+
 // Debug sets the log level to debug and message.
 func (l Logger) Debug(msg string) Logger {
 	l.level = "debug"
@@ -277,6 +849,15 @@ func (l Logger) Panic(msg string) Logger {
 	return l
 }
 
+// Log sets the log level, given as LevelDebug, LevelInfo, LevelWarn or
+// LevelError, and message, for levels chosen at runtime. Unknown levels are
+// logged at debug level, as in LoggerConfig.
+func (l Logger) Log(level, msg string) Logger {
+	l.level = getLogLevel(level).String()
+	l.message = msg
+	return l
+}
+
 // Data adds key-value pairs to the log data.
 func (l Logger) Data(key string, value any) Logger {
 	l.data = append(l.data, key, value)
@@ -295,61 +876,262 @@ func (l Logger) ErrorData(err error) Logger {
 
 // Send executes the log operation.
 func (l Logger) Send() {
-	requestID := GetRequestID(l.ctx)
-
-	// Prepare log data
-	logData := make([]any, 0, len(l.data)+2)
-	if requestID != "" {
-		logData = append(logData, l.requestIDKey, requestID)
+	level, message, fields, ok := l.before(l.level, l.message, l.fields())
+	if !ok {
+		return
 	}
-	logData = append(logData, l.data...)
+	sugar := l.log
+	if l.sampling != nil {
+		// Entries of disabled levels must not use up the sampling counters.
+		zapLevel, err := zapcore.ParseLevel(level)
+		if err == nil && !sugar.Desugar().Core().Enabled(zapLevel) {
+			return
+		}
+		if err == nil && !l.sampler.allow(zapLevel, message, l.sampling) {
+			return
+		}
+		sugar = sugar.WithOptions(zap.WrapCore(withoutSampling))
+	}
+	message, logData := l.prepare(message, fields)
 
 	// Always use structured logging if we have any data (including request ID)
 	hasStructuredData := len(logData) > 0
 
 	// Log based on level
-	switch l.level {
+	switch level {
 	case "debug":
 		if hasStructuredData {
-			l.log.Debugw(l.message, logData...)
+			sugar.Debugw(message, logData...)
 		} else {
-			l.log.Debug(l.message)
+			sugar.Debug(message)
 		}
 	case "info":
 		if hasStructuredData {
-			l.log.Infow(l.message, logData...)
+			sugar.Infow(message, logData...)
 		} else {
-			l.log.Info(l.message)
+			sugar.Info(message)
 		}
 	case "warn":
 		if hasStructuredData {
-			l.log.Warnw(l.message, logData...)
+			sugar.Warnw(message, logData...)
 		} else {
-			l.log.Warn(l.message)
+			sugar.Warn(message)
 		}
 	case "error":
 		if hasStructuredData {
-			l.log.Errorw(l.message, logData...)
+			sugar.Errorw(message, logData...)
 		} else {
-			l.log.Error(l.message)
+			sugar.Error(message)
 		}
 	case "fatal":
 		if hasStructuredData {
-			l.log.Fatalw(l.message, logData...)
+			sugar.Fatalw(message, logData...)
 		} else {
-			l.log.Fatal(l.message)
+			sugar.Fatal(message)
 		}
 	case "panic":
 		if hasStructuredData {
-			l.log.Panicw(l.message, logData...)
+			sugar.Panicw(message, logData...)
 		} else {
-			l.log.Panic(l.message)
+			sugar.Panic(message)
 		}
 	}
 }
 
-// Close syncs all buffered logs and closes the logger.
-// It ignores any sync errors as recommended by the underlying logger documentation.
+// fields returns the fields of the entry: the request ID, trace context and
+// fields of the logger's context, then the data.
+func (l Logger) fields() []any {
+	requestID := GetRequestID(l.ctx)
+	traceID, spanID := GetTraceContext(l.ctx)
+	fields := GetFields(l.ctx)
+
+	logData := make([]any, 0, len(fields)+len(l.data)+6)
+	if requestID != "" {
+		logData = append(logData, l.requestIDKey, requestID)
+	}
+	if traceID != "" {
+		logData = append(logData, TraceIDField, traceID)
+	}
+	if spanID != "" {
+		logData = append(logData, SpanIDField, spanID)
+	}
+	logData = append(logData, fields...)
+	return append(logData, l.data...)
+}
+
+// prepare returns the message and fields of the entry, see fields, without
+// the fields not matching AllowKeys, with the values of keys matching
+// RedactKeys redacted, those matching Maskers masked, and cleaned of control
+// characters if Sanitize is set.
+func (l Logger) prepare(message string, logData []any) (string, []any) {
+	// Hide secrets and clean untrusted input before they reach the encoders
+	filters := l.filters.load()
+	if filters.allow != nil {
+		logData = l.allowFields(filters.allow, logData)
+	}
+	if filters.redact != nil {
+		filters.redact.redactFields(logData)
+	}
+	if filters.sanitize != nil {
+		message = filters.sanitize(message)
+		for i := range logData {
+			logData[i] = sanitizeValue(logData[i], filters.sanitize)
+		}
+	}
+	return message, logData
+}
+
+// fieldFilters are the options applied to the message and fields of entries
+// by prepare, which Reconfigure replaces as a whole.
+type fieldFilters struct {
+	sanitize func(string) string // Applied to the message and string fields (nil if disabled)
+	redact   *redactor           // Matches the keys of fields whose values are redacted or masked (nil if disabled)
+	allow    *keyPatterns        // Keys of the fields kept by AllowKeys (nil if disabled)
+}
+
+// filterSwitch holds the current fieldFilters of a logger. It is shared by
+// all copies of the logger.
+type filterSwitch struct {
+	current atomic.Pointer[fieldFilters]
+}
+
+func newFilterSwitch(config LoggerConfig) *filterSwitch {
+	s := &filterSwitch{}
+	s.store(config)
+	return s
+}
+
+func (s *filterSwitch) load() *fieldFilters {
+	return s.current.Load()
+}
+
+// store replaces the filters with those of config.
+func (s *filterSwitch) store(config LoggerConfig) {
+	s.current.Store(&fieldFilters{
+		sanitize: getSanitizer(config.Sanitize),
+		redact:   getRedactor(config.RedactKeys, config.Maskers),
+		allow:    getAllowlist(config.AllowKeys),
+	})
+}
+
+// Close syncs all buffered logs, closes any additional sinks and closes the logger.
+// It ignores any errors; use Shutdown to bound the time spent flushing and to
+// learn whether entries were lost.
 func (l Logger) Close() {
+	_ = l.close()
+}
+
+// Sync flushes the entries buffered by outputs and sinks without closing
+// them, e.g. before a serverless runtime freezes the process between
+// invocations.
+func (l Logger) Sync() error {
+	return l.log.Sync()
+}
+
+// Shutdown closes the logger like Close, but returns the errors of flushing
+// and closing outputs and sinks, and gives up when ctx is done, e.g. when
+// a buffered network sink cannot reach its server before the process must
+// exit. Background work such as configuration watching, file rotation tasks
+// and buffer flushing is stopped. After a timeout, closing continues in the
+// background and the context error is returned.
+func (l Logger) Shutdown(ctx context.Context) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- l.close()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("gologger: shutdown: %w", ctx.Err())
+	}
+}
+
+// close stops background work, then flushes and closes all outputs and sinks.
+func (l Logger) close() error {
+	if l.source != nil {
+		l.source.stopWatching()
+	}
+	errs := []error{l.log.Sync(), l.sinks.closeAll()}
+	for _, closer := range append(l.outputs.load().closers, l.closers...) {
+		errs = append(errs, closer())
+	}
+	return errors.Join(errs...)
+}
+
+// DumpRecent writes the entries retained by the flight recorder to its output
+// and empties it. Returns an error if the flight recorder is not enabled.
+func (l Logger) DumpRecent() error {
+	if l.recorder == nil {
+		return errors.New("gologger: flight recorder is not enabled")
+	}
+	return l.recorder.dump()
+}
+
+// Rotate moves the current log file and any per-level files aside and starts
+// new ones, as when they reach their maximum size. If the file was moved by an external tool such as
+// logrotate, it is reopened instead. Returns an error if the logger does not
+// write to a file.
+func (l Logger) Rotate() error {
+	files := l.outputs.load().files
+	if len(files) == 0 {
+		return errors.New("gologger: logger does not write to a file")
+	}
 	_ = l.log.Sync()
+	var errs []error
+	for _, file := range files {
+		errs = append(errs, file.Rotate())
+	}
+	return errors.Join(errs...)
+}
+
+// SetLevel changes the minimum level of all outputs and sinks while the
+// logger is running. The change applies to every copy of the logger,
+// including those returned by WithContext. Returns an error for levels other
+// than LevelDebug, LevelInfo, LevelWarn and LevelError.
+func (l Logger) SetLevel(level string) error {
+	zapLevel, err := parseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	l.minLevel.SetLevel(zapLevel)
+	return nil
+}
+
+// GetLevel returns the current minimum level: LevelDebug, LevelInfo, LevelWarn or LevelError.
+func (l Logger) GetLevel() string {
+	return l.minLevel.Level().String()
+}
+
+// Stats returns a snapshot of the logger's runtime counters.
+func (l Logger) Stats() Stats {
+	return l.stats.snapshot()
+}
+
+// AddSink attaches a sink while the logger is running and returns an ID for RemoveSink.
+// The sink receives entries at or above the logger's level and is closed by Close.
+// Sink errors are reported under this ID; sinks from LoggerConfig.Sinks are
+// named "sink-1", "sink-2", ... in order.
+func (l Logger) AddSink(sink Sink) string {
+	return l.sinks.addSink(sink)
+}
+
+// AddCore attaches a zapcore.Core while the logger is running and returns an ID for RemoveSink.
+// The core applies its own level filtering. Its write errors are reported by zap on stderr
+// rather than through OnSinkError.
+func (l Logger) AddCore(core zapcore.Core) string {
+	return l.sinks.addCore(core)
+}
+
+// RemoveSink detaches the sink or core with the given ID and closes it (cores are synced).
+// Returns an error if no sink is attached under the ID.
+func (l Logger) RemoveSink(id string) error {
+	attached, ok := l.sinks.remove(id)
+	if !ok {
+		return fmt.Errorf("gologger: no sink attached with id %q", id)
+	}
+	if attached.sink != nil {
+		return attached.sink.Close()
+	}
+	return attached.core.Sync()
 }
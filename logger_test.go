@@ -3,7 +3,10 @@ package gologger
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
@@ -56,6 +59,59 @@ func TestGetRequestID_NoID(t *testing.T) {
 	}
 }
 
+func TestWithFields(t *testing.T) {
+	log, capture := NewTestLogger()
+	ctx := WithRequestID(context.Background(), "req-1")
+	ctx = WithFields(ctx, "job", "nightly-report", "attempt", 2)
+	child := WithFields(ctx, "step", "export", "dangling")
+
+	if fields := GetFields(context.Background()); fields != nil {
+		t.Errorf("Expected no fields, got %v", fields)
+	}
+	if fields := GetFields(ctx); len(fields) != 4 {
+		t.Errorf("Expected the parent fields to be unchanged, got %v", fields)
+	}
+	log.WithContext(child).Info("exporting").Data("rows", 10).Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %d", len(entries))
+	}
+	fields := entries[0].Fields
+	if fields["request-id"] != "req-1" || fields["job"] != "nightly-report" || fields["attempt"] != int64(2) ||
+		fields["step"] != "export" || fields["rows"] != int64(10) {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+	if _, ok := fields["dangling"]; ok {
+		t.Errorf("Expected a key without a value to be ignored, got %v", fields)
+	}
+}
+
+func TestSync(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelDebug,
+		Sinks:      []Sink{NewBufferedSink(capture, BufferConfig{FlushInterval: time.Hour})},
+	})
+	defer log.Close()
+
+	log.Info("buffered").Send()
+	if capture.Len() != 0 {
+		t.Fatalf("Expected the entry to be buffered, got %d entries", capture.Len())
+	}
+	if err := log.Sync(); err != nil {
+		t.Fatal(err)
+	}
+	if capture.Len() != 1 {
+		t.Errorf("Expected Sync to flush the entry, got %d entries", capture.Len())
+	}
+	log.Info("after sync").Send()
+	if err := log.Sync(); err != nil || capture.Len() != 2 {
+		t.Errorf("Expected the logger to stay open after Sync, got %d entries, %v", capture.Len(), err)
+	}
+}
+
 func TestWithContext(t *testing.T) {
 	log := NewLogger()
 	defer log.Close()
@@ -115,6 +171,20 @@ func TestLogLevelMethods(t *testing.T) {
 	}
 }
 
+func TestLogMethod(t *testing.T) {
+	log, capture := NewTestLogger()
+
+	log.Log(LevelWarn, "warn message").Send()
+	log.Log("verbose", "unknown level").Send()
+
+	if entries := capture.FilterLevel("warn"); len(entries) != 1 || entries[0].Message != "warn message" {
+		t.Errorf("Expected a warn entry, got %+v", capture.Entries())
+	}
+	if entries := capture.FilterLevel("debug"); len(entries) != 1 || entries[0].Message != "unknown level" {
+		t.Errorf("Expected unknown levels to log at debug, got %+v", capture.Entries())
+	}
+}
+
 func TestDataMethod(t *testing.T) {
 	log := NewLogger()
 	defer log.Close()
@@ -242,7 +312,7 @@ func TestSendMethod(t *testing.T) {
 	time.Sleep(100 * time.Millisecond)
 
 	// Check if log file was created
-	logFile := tempDir + "/" + prefix() + ".log"
+	logFile := currentLogFile(tempDir)
 	if _, err := os.Stat(logFile); os.IsNotExist(err) {
 		t.Errorf("Expected log file to be created at %s", logFile)
 	}
@@ -259,6 +329,12 @@ func TestConstants(t *testing.T) {
 	if OutputBoth != "both" {
 		t.Errorf("Expected OutputBoth to be 'both', got %s", OutputBoth)
 	}
+	if OutputSplit != "split" {
+		t.Errorf("Expected OutputSplit to be 'split', got %s", OutputSplit)
+	}
+	if OutputDiscard != "discard" {
+		t.Errorf("Expected OutputDiscard to be 'discard', got %s", OutputDiscard)
+	}
 
 	// Test log level constants
 	if LevelDebug != "debug" {
@@ -347,19 +423,11 @@ func TestEmptyRequestIDKey(t *testing.T) {
 	}
 }
 
-func TestPrefix(t *testing.T) {
-	prefix := prefix()
-
-	// Check if prefix starts with "logger-"
-	if !strings.HasPrefix(prefix, "logger-") {
-		t.Errorf("Expected prefix to start with 'logger-', got %s", prefix)
-	}
-
-	// Check if prefix contains date in YYYY-MM-DD format
-	expectedDate := time.Now().Format("2006-01-02")
-	if !strings.Contains(prefix, expectedDate) {
-		t.Errorf("Expected prefix to contain date %s, got %s", expectedDate, prefix)
-	}
+// currentLogFile returns the path of the file a logger with the default
+// rotation settings writes to in dir today.
+func currentLogFile(dir string) string {
+	file := &rotatingFile{dir: dir, name: "logger", interval: RotateDaily}
+	return file.periodPath(time.Now())
 }
 
 func TestClose(t *testing.T) {
@@ -430,7 +498,421 @@ func TestWithContextPreservesShowCaller(t *testing.T) {
 	}
 }
 
+func TestSplitOutputMode(t *testing.T) {
+	stdoutR, stdoutW, _ := os.Pipe()
+	stderrR, stderrW, _ := os.Pipe()
+	origStdout, origStderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = stdoutW, stderrW
+	defer func() {
+		os.Stdout, os.Stderr = origStdout, origStderr
+	}()
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputSplit,
+		LogLevel:   LevelInfo,
+	})
+	log.Debug("debug message").Send()
+	log.Info("info message").Send()
+	log.Warn("warn message").Send()
+	log.Error("error message").Send()
+	log.Close()
+
+	stdoutW.Close()
+	stderrW.Close()
+	stdout, _ := io.ReadAll(stdoutR)
+	stderr, _ := io.ReadAll(stderrR)
+
+	if strings.Contains(string(stdout), "debug message") || strings.Contains(string(stderr), "debug message") {
+		t.Error("Expected debug message to be filtered by log level")
+	}
+	if !strings.Contains(string(stdout), "info message") || strings.Contains(string(stdout), "warn message") {
+		t.Errorf("Expected only info on stdout, got %s", stdout)
+	}
+	if !strings.Contains(string(stderr), "warn message") || !strings.Contains(string(stderr), "error message") || strings.Contains(string(stderr), "info message") {
+		t.Errorf("Expected only warn and error on stderr, got %s", stderr)
+	}
+}
+
+func TestPerOutputEncoding(t *testing.T) {
+	tempDir := t.TempDir()
+	stderrR, stderrW, _ := os.Pipe()
+	origStderr := os.Stderr
+	os.Stderr = stderrW
+	defer func() {
+		os.Stderr = origStderr
+	}()
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:       OutputBoth,
+		LogLevel:         LevelInfo,
+		LogDir:           tempDir,
+		TerminalEncoding: EncodingConsole,
+	})
+	log.Info("encoded message").Data("user", "alice").Send()
+	log.Close()
+
+	stderrW.Close()
+	stderr, _ := io.ReadAll(stderrR)
+	if strings.HasPrefix(string(stderr), "{") || !strings.Contains(string(stderr), "encoded message") {
+		t.Errorf("Expected console encoding on the terminal, got %s", stderr)
+	}
+
+	content := readTestLogFile(t, tempDir)
+	if !strings.HasPrefix(content, "{") || !strings.Contains(content, `"msg":"encoded message"`) {
+		t.Errorf("Expected JSON encoding in the file, got %s", content)
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 10, 30, 0, 123456789, time.UTC)
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"", `"2024-03-01T10:30:00.123Z"`},
+		{TimeFormatISO8601, `"2024-03-01T10:30:00.123Z"`},
+		{TimeFormatEpochSecond, "1709289000"},
+		{TimeFormatEpochMilli, "1709289000123"},
+		{TimeFormatEpochNano, "1709289000123456789"},
+	}
+	for _, tt := range tests {
+		enc := getEncoder(EncodingJSON, LoggerConfig{TimeFormat: tt.format}, false)
+		buf, err := enc.EncodeEntry(zapcore.Entry{Time: ts, Message: "tick"}, nil)
+		if err != nil {
+			t.Fatalf("Unexpected encode error: %v", err)
+		}
+		if !strings.Contains(buf.String(), `"timestamp":`+tt.expected+",") {
+			t.Errorf("TimeFormat %q: expected timestamp %s, got %s", tt.format, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestFieldKeys(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogDir:     tempDir,
+		ShowCaller: true,
+		FieldKeys: &FieldKeysConfig{
+			Time:    "@timestamp",
+			Level:   "severity",
+			Message: "message",
+			Caller:  "source",
+		},
+	})
+	log.Info("renamed keys").Data("user", "alice").Send()
+	log.Close()
+
+	content := readTestLogFile(t, tempDir)
+	for _, want := range []string{`"@timestamp":"`, `"severity":"INFO"`, `"message":"renamed keys"`, `"source":"`, `"func":"`, `"user":"alice"`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %s in %s", want, content)
+		}
+	}
+	for _, old := range []string{`"timestamp":`, `"level":`, `"msg":`, `"caller":`} {
+		if strings.Contains(content, old) {
+			t.Errorf("Expected default key %s to be renamed in %s", old, content)
+		}
+	}
+}
+
+func TestLevelFormat(t *testing.T) {
+	tests := []struct {
+		format   string
+		labels   map[string]string
+		level    zapcore.Level
+		expected string
+	}{
+		{"", nil, zapcore.WarnLevel, `"WARN"`},
+		{LevelFormatUpper, nil, zapcore.InfoLevel, `"INFO"`},
+		{LevelFormatLower, nil, zapcore.WarnLevel, `"warn"`},
+		{LevelFormatSyslog, nil, zapcore.WarnLevel, "4"},
+		{LevelFormatSyslog, nil, zapcore.ErrorLevel, "3"},
+		{LevelFormatOTel, nil, zapcore.InfoLevel, "9"},
+		{LevelFormatOTel, nil, zapcore.ErrorLevel, "17"},
+		{"", map[string]string{"warn": "WARNING"}, zapcore.WarnLevel, `"WARNING"`},
+		{LevelFormatLower, map[string]string{"WARN": "warning"}, zapcore.WarnLevel, `"warning"`},
+		{LevelFormatLower, map[string]string{"warn": "warning"}, zapcore.ErrorLevel, `"error"`},
+	}
+	for _, tt := range tests {
+		enc := getEncoder(EncodingJSON, LoggerConfig{LevelFormat: tt.format, LevelLabels: tt.labels}, false)
+		buf, err := enc.EncodeEntry(zapcore.Entry{Level: tt.level, Message: "tick"}, nil)
+		if err != nil {
+			t.Fatalf("Unexpected encode error: %v", err)
+		}
+		if !strings.Contains(buf.String(), `"level":`+tt.expected+",") {
+			t.Errorf("LevelFormat %q with labels %v: expected level %s, got %s", tt.format, tt.labels, tt.expected, buf.String())
+		}
+	}
+}
+
+func TestGlobalFields(t *testing.T) {
+	tempDir := t.TempDir()
+	sink := &recordingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:   OutputFile,
+		LogDir:       tempDir,
+		Sinks:        []Sink{sink},
+		GlobalFields: map[string]any{"service": "billing", "region": "eu-west-1"},
+	})
+	log.Info("first").Send()
+	log.WithContext(WithRequestID(context.Background(), "req-1")).Info("second").Data("n", 1).Send()
+	log.Close()
+
+	content := readTestLogFile(t, tempDir)
+	if strings.Count(content, `"region":"eu-west-1","service":"billing"`) != 2 {
+		t.Errorf("Expected global fields on every file entry, got %s", content)
+	}
+	entries := sink.Entries()
+	if len(entries) != 2 || entries[1].Fields["service"] != "billing" || entries[1].Fields["request-id"] != "req-1" {
+		t.Errorf("Expected global fields on sink entries, got %+v", entries)
+	}
+}
+
+func TestServiceFields(t *testing.T) {
+	fields := ServiceFields("billing", "1.4.2", "")
+	hostname, _ := os.Hostname()
+	if fields[ServiceField] != "billing" || fields[VersionField] != "1.4.2" || fields[HostnameField] != hostname || fields[PIDField] != os.Getpid() {
+		t.Errorf("Unexpected service fields %v", fields)
+	}
+	if _, ok := fields[EnvField]; ok {
+		t.Error("Expected empty env to be left out")
+	}
+}
+
+func TestCallerFormat(t *testing.T) {
+	ent := zapcore.Entry{
+		Message: "tick",
+		Caller: zapcore.EntryCaller{
+			Defined:  true,
+			File:     "/src/app/handler/user.go",
+			Line:     42,
+			Function: "github.com/org/app/handler.(*Handler).Serve",
+		},
+	}
+	tests := []struct {
+		config   LoggerConfig
+		expected string
+	}{
+		{LoggerConfig{}, "handler/user.go:42"},
+		{LoggerConfig{CallerFormat: CallerFormatShort}, "handler/user.go:42"},
+		{LoggerConfig{CallerFormat: CallerFormatFull}, "/src/app/handler/user.go:42"},
+		{LoggerConfig{CallerFormat: CallerFormatFull, CallerPathPrefix: "/src/app/"}, "handler/user.go:42"},
+		{LoggerConfig{CallerFormat: CallerFormatModule}, "github.com/org/app/handler/user.go:42"},
+		{LoggerConfig{CallerFormat: CallerFormatFile}, "user.go:42"},
+	}
+	for _, tt := range tests {
+		buf, err := getEncoder(EncodingJSON, tt.config, false).EncodeEntry(ent, nil)
+		if err != nil {
+			t.Fatalf("Unexpected encode error: %v", err)
+		}
+		if !strings.Contains(buf.String(), `"caller":"`+tt.expected+`"`) {
+			t.Errorf("CallerFormat %q: expected caller %s, got %s", tt.config.CallerFormat, tt.expected, buf.String())
+		}
+		if !strings.Contains(buf.String(), `"func":"github.com/org/app/handler.(*Handler).Serve"`) {
+			t.Errorf("Expected function by default, got %s", buf.String())
+		}
+
+		line, _ := getEncoder(EncodingConsole, tt.config, false).EncodeEntry(ent, nil)
+		if !strings.Contains(line.String(), " "+tt.expected+" ") {
+			t.Errorf("CallerFormat %q: expected console caller %s, got %q", tt.config.CallerFormat, tt.expected, line.String())
+		}
+	}
+
+	buf, _ := getEncoder(EncodingJSON, LoggerConfig{HideFunction: true, FieldKeys: &FieldKeysConfig{Function: "fn"}}, false).EncodeEntry(ent, nil)
+	if strings.Contains(buf.String(), "Serve") {
+		t.Errorf("Expected no function with HideFunction, got %s", buf.String())
+	}
+}
+
+func TestCallerPackage(t *testing.T) {
+	tests := map[string]string{
+		"github.com/org/app/handler.(*Handler).Serve": "github.com/org/app/handler",
+		"github.com/org/app.init.func1":               "github.com/org/app",
+		"main.main":                                   "main",
+		"":                                            "",
+	}
+	for fn, want := range tests {
+		if got := callerPackage(fn); got != want {
+			t.Errorf("callerPackage(%q) = %q, want %q", fn, got, want)
+		}
+	}
+}
+
+func TestDiscardOutputMode(t *testing.T) {
+	tempDir := "test_discard_logs"
+	defer os.RemoveAll(tempDir)
+
+	sink := &recordingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelInfo,
+		LogDir:     tempDir,
+		Sinks:      []Sink{sink},
+	})
+	log.Info("discarded message").Send()
+	log.Close()
+
+	if _, err := os.Stat(tempDir); !os.IsNotExist(err) {
+		t.Errorf("Expected log directory %s not to be created", tempDir)
+	}
+	if len(sink.Entries()) != 1 {
+		t.Error("Expected additional sinks to keep receiving entries")
+	}
+}
+
 // Benchmark tests
+func TestLoggerRotate(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:  OutputFile,
+		LogDir:      tempDir,
+		LogRotation: &LogRotationConfig{Compress: false},
+		FileBuffer:  &BufferConfig{},
+	})
+	log.Info("before rotation").Send()
+	if err := log.Rotate(); err != nil {
+		t.Fatalf("Unexpected rotate error: %v", err)
+	}
+	log.Info("after rotation").Send()
+	log.Close()
+
+	entries, _ := os.ReadDir(tempDir)
+	if len(entries) != 2 {
+		t.Fatalf("Expected the current file and one backup, got %d files", len(entries))
+	}
+	data, _ := os.ReadFile(currentLogFile(tempDir))
+	if strings.Contains(string(data), "before rotation") || !strings.Contains(string(data), "after rotation") {
+		t.Errorf("Expected only the entry after rotation in the current file, got %s", data)
+	}
+
+	discard := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard})
+	if err := discard.Rotate(); err == nil {
+		t.Error("Expected error rotating a logger without file output")
+	}
+}
+
+func TestLevelFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogLevel:   LevelDebug,
+		LogDir:     tempDir,
+		LevelFiles: map[string]*LogRotationConfig{
+			LevelError: {MaxAge: 90},
+			LevelDebug: {MaxAge: 3, MaxBackups: 1},
+		},
+	})
+	log.Debug("debug entry").Send()
+	log.Info("info entry").Send()
+	log.Error("error entry").Send()
+	log.Close()
+
+	today := time.Now().Format("2006-01-02")
+	expected := map[string][]string{
+		"logger-" + today + ".log": {"debug entry", "info entry", "error entry"},
+		"error-" + today + ".log":  {"error entry"},
+		"debug-" + today + ".log":  {"debug entry"},
+	}
+	for name, messages := range expected {
+		data, err := os.ReadFile(tempDir + "/" + name)
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if lines := strings.Count(string(data), "\n"); lines != len(messages) {
+			t.Errorf("Expected %d entries in %s, got %d", len(messages), name, lines)
+		}
+		for _, msg := range messages {
+			if !strings.Contains(string(data), msg) {
+				t.Errorf("Expected %q in %s", msg, name)
+			}
+		}
+	}
+
+	// Files are sorted by level name after the main file.
+	files := log.outputs.load().files
+	if len(files) != 3 || files[1].maxAge != 3*24*time.Hour || files[2].maxAge != 90*24*time.Hour {
+		t.Error("Expected each level file to use its own retention settings")
+	}
+}
+
+func TestLevelFileEnabler(t *testing.T) {
+	errorFile := levelFileEnabler(zapcore.DebugLevel, zapcore.ErrorLevel)
+	for level, want := range map[zapcore.Level]bool{
+		zapcore.WarnLevel:   false,
+		zapcore.ErrorLevel:  true,
+		zapcore.DPanicLevel: true,
+		zapcore.FatalLevel:  true,
+	} {
+		if got := errorFile.Enabled(level); got != want {
+			t.Errorf("Error file enabled for %s = %v, want %v", level, got, want)
+		}
+	}
+	if levelFileEnabler(zapcore.InfoLevel, zapcore.DebugLevel).Enabled(zapcore.DebugLevel) {
+		t.Error("Expected the logger level to apply to level files")
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	log, capture := NewTestLogger()
+	child := log.WithContext(context.Background())
+	if log.GetLevel() != LevelDebug {
+		t.Errorf("Expected initial level debug, got %s", log.GetLevel())
+	}
+
+	if err := log.SetLevel(LevelWarn); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	child.Info("dropped").Send()
+	child.Warn("kept").Send()
+	if entries := capture.Entries(); len(entries) != 1 || entries[0].Message != "kept" {
+		t.Errorf("Expected only the warn entry, got %+v", entries)
+	}
+	if child.GetLevel() != LevelWarn {
+		t.Errorf("Expected the level to be shared by copies, got %s", child.GetLevel())
+	}
+
+	if err := log.SetLevel("verbose"); err == nil {
+		t.Error("Expected error for an unknown level")
+	}
+	if log.GetLevel() != LevelWarn {
+		t.Errorf("Expected an invalid level to be ignored, got %s", log.GetLevel())
+	}
+}
+
+// closeFuncSink is a sink whose Close runs a function.
+type closeFuncSink struct {
+	recordingSink
+	close func() error
+}
+
+func (s *closeFuncSink) Close() error {
+	return s.close()
+}
+
+func TestShutdown(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputTerminal, LogDir: t.TempDir()})
+	if err := log.Shutdown(context.Background()); err != nil {
+		t.Errorf("Expected terminal sync errors to be ignored, got %v", err)
+	}
+
+	failing := &closeFuncSink{close: func() error { return errors.New("flush failed") }}
+	log = NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{failing}})
+	if err := log.Shutdown(context.Background()); err == nil || !strings.Contains(err.Error(), "flush failed") {
+		t.Errorf("Expected the sink error, got %v", err)
+	}
+
+	release := make(chan struct{})
+	defer close(release)
+	stuck := &closeFuncSink{close: func() error { <-release; return nil }}
+	log = NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{stuck}})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := log.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the deadline error, got %v", err)
+	}
+}
+
 func BenchmarkSimpleLogging(b *testing.B) {
 	log := NewLogger()
 	defer log.Close()
@@ -484,3 +966,34 @@ func BenchmarkMethodChaining(b *testing.B) {
 			Send()
 	}
 }
+
+func BenchmarkDiscardLogging(b *testing.B) {
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelDebug,
+	})
+	defer log.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		log.Info("Benchmark message").
+			Data("key1", "value1").
+			Data("key2", 123).
+			Send()
+	}
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	log, capture := NewTestLogger()
+	logHelper := func(msg string) {
+		log.WithCallerSkip(1).Info(msg).Send()
+	}
+
+	_, _, line, _ := runtime.Caller(0)
+	logHelper("from helper")
+
+	entries := capture.Entries()
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Caller, fmt.Sprintf("/logger_test.go:%d", line+1)) {
+		t.Errorf("Expected the caller of the helper, got %+v", entries)
+	}
+}
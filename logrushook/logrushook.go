@@ -0,0 +1,108 @@
+// Package logrushook writes the entries of logrus loggers through gologger,
+// so code still using logrus shares the outputs, sinks and rotation of
+// gologger while it is migrated:
+//
+//	logrushook.Redirect(logrus.StandardLogger(), log)
+//
+// It is a separate module, so applications not using logrus do not depend on
+// it.
+package logrushook
+
+import (
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.risoftinc.com/gologger"
+)
+
+// Hook is a logrus.Hook writing every logrus entry through a
+// gologger.Logger: its message, its fields, the error added with WithError,
+// and the request ID of the context added with WithContext. Trace entries
+// are logged at debug level, and fatal and panic entries at error level,
+// leaving exiting and panicking to logrus. The caller of entries is the
+// caller of the logrus method.
+type Hook struct {
+	log gologger.Logger
+}
+
+// New returns a hook writing logrus entries through log.
+func New(log gologger.Logger) *Hook {
+	return &Hook{log: log}
+}
+
+// Redirect adds a Hook writing through log to logger and stops logger from
+// writing entries itself, so they are only written by log. logger's level is
+// set to trace, so log's level decides which entries are kept.
+func Redirect(logger *logrus.Logger, log gologger.Logger) {
+	logger.AddHook(New(log))
+	logger.SetFormatter(discardFormatter{})
+	logger.SetOutput(io.Discard)
+	logger.SetLevel(logrus.TraceLevel)
+}
+
+// Levels returns all levels; log's level filters the entries.
+func (h *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire writes entry through the logger of the hook.
+func (h *Hook) Fire(entry *logrus.Entry) error {
+	log := h.log
+	if entry.Context != nil {
+		log = log.WithContext(entry.Context)
+	}
+	log = log.WithCallerSkip(callerSkip())
+
+	switch entry.Level {
+	case logrus.TraceLevel, logrus.DebugLevel:
+		log = log.Debug(entry.Message)
+	case logrus.InfoLevel:
+		log = log.Info(entry.Message)
+	case logrus.WarnLevel:
+		log = log.Warn(entry.Message)
+	default:
+		log = log.Error(entry.Message)
+	}
+
+	// Fields are logged in a stable order, since logrus keeps them in a map.
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err, ok := entry.Data[key].(error); ok && key == logrus.ErrorKey {
+			log = log.ErrorData(err)
+			continue
+		}
+		log = log.Data(key, entry.Data[key])
+	}
+	log.Send()
+	return nil
+}
+
+// callerSkip returns the number of frames between Fire and the first caller
+// outside logrus, whose depth depends on the logrus method called.
+func callerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, callerSkip and Fire.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	skip := 1
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "github.com/sirupsen/logrus.") {
+			return skip
+		}
+		skip++
+	}
+}
+
+// discardFormatter formats entries as nothing, since Redirect discards them.
+type discardFormatter struct{}
+
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
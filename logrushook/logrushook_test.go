@@ -0,0 +1,75 @@
+package logrushook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.risoftinc.com/gologger"
+)
+
+func TestRedirect(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	var out bytes.Buffer
+	logger := logrus.New()
+	logger.SetOutput(&out)
+	Redirect(logger, log)
+
+	ctx := gologger.WithRequestID(context.Background(), "req-42")
+	logger.WithContext(ctx).
+		WithFields(logrus.Fields{"user_id": 7, "action": "login"}).
+		WithError(errors.New("bad password")).
+		Warn("login failed")
+	logger.Trace("tracing")
+
+	if out.Len() != 0 {
+		t.Errorf("Expected logrus to stop writing, got %q", out.String())
+	}
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != gologger.LevelWarn || entry.Message != "login failed" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Fields["request-id"] != "req-42" || entry.Fields["user_id"] != int64(7) ||
+		entry.Fields["action"] != "login" || entry.Fields["error"] != "bad password" {
+		t.Errorf("Unexpected fields %v", entry.Fields)
+	}
+	if entries[1].Level != gologger.LevelDebug {
+		t.Errorf("Expected trace entries at debug level, got %s", entries[1].Level)
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "logrushook_test.go:") {
+			t.Errorf("Expected the caller of the logrus method, got %q", entry.Caller)
+		}
+	}
+}
+
+func TestHookLevels(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	logger := logrus.New()
+	logger.SetOutput(&bytes.Buffer{})
+	logger.AddHook(New(log))
+	logger.ExitFunc = func(int) {}
+
+	logger.Info("info")
+	logger.Error("error")
+	logger.Fatal("fatal")
+	func() {
+		defer func() { _ = recover() }()
+		logger.Panic("panic")
+	}()
+
+	var levels []string
+	for _, entry := range capture.Entries() {
+		levels = append(levels, entry.Level)
+	}
+	if got := strings.Join(levels, ","); got != "info,error,error,error" {
+		t.Errorf("Expected info,error,error,error, got %s", got)
+	}
+}
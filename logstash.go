@@ -0,0 +1,178 @@
+package gologger
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LogstashConfig holds configuration options for the Logstash TCP sink.
+type LogstashConfig struct {
+	Addr              string         // Logstash tcp input address (default: "localhost:5000")
+	Fields            map[string]any // Static fields added to every event, e.g. "type" or "service" (optional)
+	TLS               *TLSConfig     // TLS client options (optional)
+	BatchSize         int            // Maximum events per write (default: 100)
+	FlushInterval     time.Duration  // Maximum time an entry waits before being sent (default: 1s)
+	Timeout           time.Duration  // Dial and write timeout (default: 5s)
+	ReconnectDelay    time.Duration  // Initial wait before redialing after a failed dial (default: 1s)
+	MaxReconnectDelay time.Duration  // Upper bound of the doubling reconnect delay (default: 30s)
+}
+
+// LogstashSink sends entries to a Logstash tcp input using the json_lines
+// codec. Every event carries "@timestamp" and "@version" fields. When the
+// connection drops it is redialed; failed dials back off exponentially and
+// batches sent while the sink is backing off return an error.
+type LogstashSink struct {
+	config LogstashConfig
+	batch  *batcher
+
+	mu       sync.Mutex
+	conn     net.Conn
+	delay    time.Duration
+	nextDial time.Time
+	now      func() time.Time
+}
+
+// NewLogstashSink creates a Logstash sink. Unset options fall back to their defaults.
+// The connection is established lazily on the first flush.
+func NewLogstashSink(config LogstashConfig) *LogstashSink {
+	if config.Addr == "" {
+		config.Addr = "localhost:5000"
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	if config.ReconnectDelay <= 0 {
+		config.ReconnectDelay = time.Second
+	}
+	if config.MaxReconnectDelay <= 0 {
+		config.MaxReconnectDelay = 30 * time.Second
+	}
+	if config.MaxReconnectDelay < config.ReconnectDelay {
+		config.MaxReconnectDelay = config.ReconnectDelay
+	}
+
+	s := &LogstashSink{config: config, now: time.Now}
+	s.batch = newBatcher(config.BatchSize, config.FlushInterval, s.send)
+	return s
+}
+
+// Write queues an entry for sending.
+func (s *LogstashSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync sends all queued entries.
+func (s *LogstashSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close sends all queued entries and closes the connection.
+func (s *LogstashSink) Close() error {
+	err := s.batch.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// event encodes an entry as a Logstash JSON event.
+func (s *LogstashSink) event(entry Entry) ([]byte, error) {
+	event := make(map[string]any, len(s.config.Fields)+len(entry.Fields)+6)
+	for key, value := range s.config.Fields {
+		event[key] = normalizeValue(value)
+	}
+	for key, value := range entry.Fields {
+		event[key] = normalizeValue(value)
+	}
+	event["@timestamp"] = entry.Time.UTC().Format("2006-01-02T15:04:05.000Z07:00")
+	event["@version"] = "1"
+	event["message"] = entry.Message
+	event["level"] = strings.ToUpper(entry.Level)
+	if entry.Caller != "" {
+		event["caller"] = entry.Caller
+	}
+	if entry.Stack != "" {
+		event["stack_trace"] = entry.Stack
+	}
+	return json.Marshal(event)
+}
+
+// send writes a batch of events as JSON lines.
+func (s *LogstashSink) send(entries []Entry) error {
+	var buf []byte
+	for _, entry := range entries {
+		data, err := s.event(entry)
+		if err != nil {
+			return fmt.Errorf("logstash: encode entry: %w", err)
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reused := s.conn != nil
+	err := s.write(buf)
+	if err != nil && reused {
+		// The server may have closed an idle connection; retry once.
+		err = s.write(buf)
+	}
+	return err
+}
+
+// write sends buf, dialing first if needed. It must be called with s.mu held.
+func (s *LogstashSink) write(buf []byte) error {
+	if s.conn == nil {
+		if err := s.dial(); err != nil {
+			return err
+		}
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout))
+	if _, err := s.conn.Write(buf); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("logstash: %w", err)
+	}
+	return nil
+}
+
+// dial connects to Logstash unless a previous failure is still backing off.
+// It must be called with s.mu held.
+func (s *LogstashSink) dial() error {
+	if now := s.now(); now.Before(s.nextDial) {
+		return fmt.Errorf("logstash: reconnecting in %s", s.nextDial.Sub(now).Round(time.Millisecond))
+	}
+
+	conn, err := dialNetwork("tcp", s.config.Addr, s.config.Timeout, s.config.TLS)
+	if err != nil {
+		s.delay *= 2
+		if s.delay == 0 {
+			s.delay = s.config.ReconnectDelay
+		}
+		if s.delay > s.config.MaxReconnectDelay {
+			s.delay = s.config.MaxReconnectDelay
+		}
+		s.nextDial = s.now().Add(s.delay)
+		return fmt.Errorf("logstash: %w", err)
+	}
+
+	s.conn = conn
+	s.delay = 0
+	s.nextDial = time.Time{}
+	return nil
+}
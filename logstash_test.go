@@ -0,0 +1,149 @@
+package gologger
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// acceptLines accepts a single connection and returns the lines it received.
+func acceptLines(listener net.Listener) <-chan []string {
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+		var lines []string
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		received <- lines
+	}()
+	return received
+}
+
+func TestLogstashSinkJSONLines(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	received := acceptLines(listener)
+
+	sink := NewLogstashSink(LogstashConfig{
+		Addr:   listener.Addr().String(),
+		Fields: map[string]any{"type": "app", "service": "billing"},
+	})
+	entry := Entry{
+		Time:    time.Date(2024, 3, 1, 10, 30, 0, 123e6, time.FixedZone("WIB", 7*3600)),
+		Level:   LevelInfo,
+		Message: "order created",
+		Caller:  "orders/create.go:12",
+		Fields:  map[string]any{"request-id": "req-7", "items": 3},
+	}
+	if err := sink.Write(entry); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	lines := <-received
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line, got %d", len(lines))
+	}
+	var event map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &event); err != nil {
+		t.Fatalf("Invalid JSON line: %v", err)
+	}
+	expected := map[string]any{
+		"@timestamp": "2024-03-01T03:30:00.123Z",
+		"@version":   "1",
+		"message":    "order created",
+		"level":      "INFO",
+		"caller":     "orders/create.go:12",
+		"request-id": "req-7",
+		"items":      float64(3),
+		"type":       "app",
+		"service":    "billing",
+	}
+	for key, want := range expected {
+		if event[key] != want {
+			t.Errorf("Expected %s=%v, got %v", key, want, event[key])
+		}
+	}
+}
+
+func TestLogstashSinkReconnects(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	// The first connection is closed by the server right away.
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			conn.Close()
+		}
+	}()
+
+	sink := NewLogstashSink(LogstashConfig{Addr: listener.Addr().String()})
+	defer sink.Close()
+	if err := sink.Write(Entry{Level: LevelInfo, Message: "first"}); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Sync(); err != nil {
+		t.Fatalf("Unexpected sync error: %v", err)
+	}
+
+	// Wait until the close is observable, then write on the stale connection.
+	time.Sleep(50 * time.Millisecond)
+	received := acceptLines(listener)
+	for i := 0; i < 2; i++ {
+		_ = sink.Write(Entry{Level: LevelInfo, Message: "second"})
+		_ = sink.Sync()
+	}
+	sink.Close()
+
+	lines := <-received
+	if len(lines) == 0 || !strings.Contains(lines[len(lines)-1], `"second"`) {
+		t.Errorf("Expected entries on the new connection, got %v", lines)
+	}
+}
+
+func TestLogstashSinkReconnectBackoff(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	now := time.Unix(1700000000, 0)
+	sink := NewLogstashSink(LogstashConfig{Addr: addr, ReconnectDelay: time.Second, MaxReconnectDelay: 3 * time.Second})
+	defer sink.Close()
+	sink.now = func() time.Time { return now }
+
+	send := func() error { return sink.send([]Entry{{Level: LevelInfo, Message: "x"}}) }
+
+	for _, want := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second} {
+		if err := send(); err == nil {
+			t.Fatal("Expected dial error")
+		}
+		if sink.delay != want {
+			t.Errorf("Expected reconnect delay %s, got %s", want, sink.delay)
+		}
+		if err := send(); err == nil || !strings.Contains(err.Error(), "reconnecting in") {
+			t.Errorf("Expected back-off error, got %v", err)
+		}
+		now = now.Add(want)
+	}
+}
@@ -0,0 +1,89 @@
+package gologger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Masker returns the value logged in place of the value of a field, e.g. a
+// partially hidden phone number. It is called for the fields whose keys
+// match its key in LoggerConfig.Maskers, from the goroutines logging them.
+type Masker func(value any) any
+
+// MaskLast returns a Masker keeping the last n characters of values, e.g.
+// the last 4 digits of phone or card numbers, and replacing the others with
+// "*". Values that are not strings are formatted as by fmt.Sprint. nil
+// values are kept.
+func MaskLast(n int) Masker {
+	return func(value any) any {
+		if value == nil {
+			return nil
+		}
+		s := maskString(value)
+		hidden := utf8.RuneCountInString(s) - n
+		if hidden <= 0 {
+			return s
+		}
+		var b strings.Builder
+		b.Grow(len(s))
+		for i, r := range []rune(s) {
+			if i < hidden {
+				b.WriteByte('*')
+			} else {
+				b.WriteRune(r)
+			}
+		}
+		return b.String()
+	}
+}
+
+// HashEmailDomain is a Masker keeping the local part of email addresses and
+// replacing their domain with the first 12 hex digits of its SHA-256 hash,
+// e.g. "alice@3b0aa3bd6b2f", so addresses of the same domain can still be
+// grouped without revealing it. Values without "@" are hashed whole.
+func HashEmailDomain(value any) any {
+	if value == nil {
+		return nil
+	}
+	s := maskString(value)
+	i := strings.LastIndexByte(s, '@')
+	if i < 0 {
+		return shortHash(s)
+	}
+	return s[:i+1] + shortHash(strings.ToLower(s[i+1:]))
+}
+
+// Hash is a Masker replacing values with the first 12 hex digits of their
+// SHA-256 hash, so entries about the same value can be correlated without
+// revealing it. Values that are not strings are formatted as by fmt.Sprint.
+func Hash(value any) any {
+	if value == nil {
+		return nil
+	}
+	return shortHash(maskString(value))
+}
+
+// shortHash returns the first 12 hex digits of the SHA-256 hash of s.
+func shortHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:6])
+}
+
+// maskString returns the string form of a field value.
+func maskString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,105 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestMaskLast(t *testing.T) {
+	mask := MaskLast(4)
+	tests := []struct {
+		value    any
+		expected any
+	}{
+		{"+1 555 123 4567", "***********4567"},
+		{"4111111111111111", "************1111"},
+		{"123", "123"},
+		{"ñandú-0042", "******0042"},
+		{5551234567, "******4567"},
+		{[]byte("0612345678"), "******5678"},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := mask(tt.value); got != tt.expected {
+			t.Errorf("MaskLast(4)(%v) = %v, expected %v", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestHashEmailDomain(t *testing.T) {
+	alice := HashEmailDomain("alice@example.com").(string)
+	bob := HashEmailDomain("bob@Example.COM").(string)
+	if !strings.HasPrefix(alice, "alice@") || len(alice) != len("alice@")+12 || strings.Contains(alice, "example") {
+		t.Errorf("Expected the local part and a hashed domain, got %q", alice)
+	}
+	if alice[len("alice@"):] != bob[len("bob@"):] {
+		t.Errorf("Expected the same hash for the same domain, got %q and %q", alice, bob)
+	}
+	if other := HashEmailDomain("alice@example.org"); other == alice {
+		t.Errorf("Expected another hash for another domain, got %q", other)
+	}
+	if hashed := HashEmailDomain("not-an-email").(string); len(hashed) != 12 || hashed != Hash("not-an-email") {
+		t.Errorf("Expected values without @ to be hashed whole, got %q", hashed)
+	}
+	if HashEmailDomain(nil) != nil {
+		t.Error("Expected nil to be kept")
+	}
+}
+
+func TestHash(t *testing.T) {
+	if Hash("user-42") != Hash("user-42") || Hash("user-42") == Hash("user-43") {
+		t.Error("Expected equal hashes for equal values only")
+	}
+	if Hash(errors.New("user-42")) != Hash("user-42") {
+		t.Error("Expected errors to be hashed as their message")
+	}
+	if Hash(nil) != nil {
+		t.Error("Expected nil to be kept")
+	}
+}
+
+func TestMaskersConfig(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		RedactKeys: []string{"*secret*"},
+		Maskers: map[string]Masker{
+			"phone":    MaskLast(4),
+			"*phone*":  Hash,
+			"*email*":  HashEmailDomain,
+			"secret_*": Hash,
+		},
+		Sinks: []Sink{capture},
+	})
+	ctx := WithFields(context.Background(), "Email", "alice@example.com")
+
+	log.WithContext(ctx).Info("contact updated").
+		Data("phone", "+1 555 123 4567").
+		Data("backup_phone", "+1 555 765 4321").
+		Data("secret_answer", "rosebud").
+		Data("user", "alice").
+		Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].Fields
+	if fields["phone"] != "***********4567" {
+		t.Errorf("Expected the exact key masker to take precedence, got %v", fields["phone"])
+	}
+	if fields["backup_phone"] != Hash("+1 555 765 4321") {
+		t.Errorf("Expected the pattern masker, got %v", fields["backup_phone"])
+	}
+	if fields["Email"] != HashEmailDomain("alice@example.com") {
+		t.Errorf("Expected context fields to be masked, got %v", fields["Email"])
+	}
+	if fields["secret_answer"] != Redacted {
+		t.Errorf("Expected RedactKeys to take precedence, got %v", fields["secret_answer"])
+	}
+	if fields["user"] != "alice" {
+		t.Errorf("Expected other fields unchanged, got %v", fields["user"])
+	}
+}
@@ -0,0 +1,220 @@
+package gologger
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"time"
+)
+
+// RequestIDHeader is the header HTTPMiddleware reads the request ID from and
+// writes it to.
+const RequestIDHeader = "X-Request-ID"
+
+// loggerKey is the context key of the logger stored by NewContext.
+const loggerKey contextKey = "gologger-logger"
+
+// NewContext returns a copy of ctx carrying log, for FromContext.
+func NewContext(ctx context.Context, log Logger) context.Context {
+	return context.WithValue(ctx, loggerKey, log)
+}
+
+// FromContext returns the logger stored in ctx by NewContext or
+// HTTPMiddleware, bound to ctx so its request and trace IDs are logged, or
+// the global logger L if ctx carries none.
+func FromContext(ctx context.Context) Logger {
+	log, ok := ctx.Value(loggerKey).(Logger)
+	if !ok {
+		log = L()
+	}
+	return log.WithContext(ctx)
+}
+
+// HTTPMiddleware returns net/http middleware that logs every request with log:
+//
+//	http.ListenAndServe(":8080", gologger.HTTPMiddleware(log)(mux))
+//
+// It takes the request ID from the context, from the X-Request-ID header or
+// generates one, stores it in the request context and sets it on the
+// response. Handlers get the logger with FromContext(r.Context()), so their
+// entries carry the request ID. A debug entry is logged when a request starts
+// and an entry with its status, response size and duration when it
+// completes, at info level, warn for 4xx or error for 5xx responses.
+func HTTPMiddleware(log Logger) func(http.Handler) http.Handler {
+	return HTTPMiddlewareWithConfig(log, HTTPMiddlewareConfig{})
+}
+
+// HTTPMiddlewareConfig holds options for HTTPMiddlewareWithConfig.
+type HTTPMiddlewareConfig struct {
+	AccessLog *AccessLogger // Also write a Common or Combined Log Format line for each completed request (optional)
+}
+
+// HTTPMiddlewareWithConfig returns net/http middleware that logs every
+// request with log as HTTPMiddleware does, with the options of config, e.g.
+// to also write the access log lines of an AccessLogger:
+//
+//	access := gologger.NewAccessLogger(gologger.AccessLogConfig{LogDir: "logs"})
+//	handler := gologger.HTTPMiddlewareWithConfig(log, gologger.HTTPMiddlewareConfig{AccessLog: access})(mux)
+//
+// Access log lines are written when a request completes, after its entry,
+// with the request context. Failures to write them are logged at error
+// level.
+func HTTPMiddlewareWithConfig(log Logger, config HTTPMiddlewareConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ctx := r.Context()
+			requestID := GetRequestID(ctx)
+			if requestID == "" {
+				requestID = RequestIDFromHeader(r.Header.Get(RequestIDHeader))
+				ctx = WithRequestID(ctx, requestID)
+			}
+			ctx = NewContext(ctx, log)
+			w.Header().Set(RequestIDHeader, requestID)
+
+			reqLog := log.WithContext(ctx)
+			reqLog.Debug("http request started").
+				Data("method", r.Method).
+				Data("path", r.URL.Path).
+				Data("remote_addr", r.RemoteAddr).
+				Data("user_agent", r.UserAgent()).
+				Send()
+
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			status := rec.status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			switch {
+			case status >= http.StatusInternalServerError:
+				reqLog = reqLog.Error("http request completed")
+			case status >= http.StatusBadRequest:
+				reqLog = reqLog.Warn("http request completed")
+			default:
+				reqLog = reqLog.Info("http request completed")
+			}
+			reqLog.Data("method", r.Method).
+				Data("path", r.URL.Path).
+				Data("status", status).
+				Data("bytes", rec.bytes).
+				Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+				Send()
+
+			if config.AccessLog != nil {
+				if err := config.AccessLog.Log(ctx, NewAccessLogEntry(r, status, rec.bytes, start)); err != nil {
+					log.WithContext(ctx).Error("http access log write failed").ErrorData(err).Send()
+				}
+			}
+		})
+	}
+}
+
+// RecoverMiddleware returns net/http middleware that recovers the panics of
+// handlers and logs them with log, as "http request panicked" at error level
+// with the panic value, its stack, the method and path, and the request ID
+// of the request context. It answers 500 Internal Server Error unless the
+// handler already wrote a response. Placed inside HTTPMiddleware, the
+// completion entry of the request logs the 500 status:
+//
+//	handler := gologger.HTTPMiddleware(log)(gologger.RecoverMiddleware(log)(mux))
+//
+// It replaces the recoverers of routers such as chi's middleware.Recoverer,
+// which print stacks as unstructured text. Panics with http.ErrAbortHandler,
+// which abort a response on purpose, are passed on without logging.
+func RecoverMiddleware(log Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &statusRecorder{ResponseWriter: w}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if p == http.ErrAbortHandler {
+					panic(p)
+				}
+				log.WithContext(r.Context()).Error("http request panicked").
+					Data("panic", fmt.Sprint(p)).
+					Data("stack", string(debug.Stack())).
+					Data("method", r.Method).
+					Data("path", r.URL.Path).
+					Send()
+				if rec.status == 0 {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// RequestIDFromHeader returns the request ID sent by a client in a header
+// such as X-Request-ID, or a new random one if it is missing, longer than 128
+// bytes or contains spaces, quotes or control characters, so clients cannot
+// inject arbitrary text into the logs. HTTPMiddleware uses it; framework
+// middleware can too.
+func RequestIDFromHeader(id string) string {
+	if id != "" && len(id) <= 128 {
+		valid := true
+		for i := 0; i < len(id) && valid; i++ {
+			c := id[i]
+			valid = c > ' ' && c < 0x7f && c != '"' && c != '\\'
+		}
+		if valid {
+			return id
+		}
+	}
+	return newRequestID()
+}
+
+// newRequestID returns a random 128-bit request ID in hex.
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// statusRecorder records the status code and body size of a response.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int64
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(data []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(data)
+	r.bytes += int64(n)
+	return n, err
+}
+
+// Flush and Hijack keep streaming responses and WebSocket upgrades working
+// through the recorder.
+func (r *statusRecorder) Flush() {
+	_ = http.NewResponseController(r.ResponseWriter).Flush()
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(r.ResponseWriter).Hijack()
+}
+
+// Unwrap returns the underlying writer for http.ResponseController.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
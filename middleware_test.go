@@ -0,0 +1,218 @@
+package gologger
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	log, capture := NewTestLogger()
+	handler := HTTPMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("loading user").Send()
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, "created")
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/users?id=1", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Header().Get(RequestIDHeader) != "req-42" {
+		t.Errorf("Expected the request ID on the response, got %q", rec.Header().Get(RequestIDHeader))
+	}
+	entries := capture.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected start, handler and finish entries, got %d", len(entries))
+	}
+	for _, entry := range entries {
+		if entry.Fields["request-id"] != "req-42" {
+			t.Errorf("Expected the request ID on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	start, finish := entries[0], entries[2]
+	if start.Level != LevelDebug || start.Fields["method"] != "POST" || start.Fields["path"] != "/users" {
+		t.Errorf("Unexpected start entry %+v", start)
+	}
+	if finish.Level != LevelInfo || finish.Fields["status"] != int64(http.StatusCreated) || finish.Fields["bytes"] != int64(7) {
+		t.Errorf("Unexpected finish entry %+v", finish)
+	}
+	if _, ok := finish.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected a duration, got %v", finish.Fields)
+	}
+}
+
+func TestHTTPMiddlewareAccessLog(t *testing.T) {
+	log, capture := NewTestLogger()
+	var lines strings.Builder
+	access := NewAccessLogger(AccessLogConfig{Format: AccessLogCommon, Output: &lines, Logger: &log})
+	handler := HTTPMiddlewareWithConfig(log, HTTPMiddlewareConfig{AccessLog: access})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, "missing")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/users/42", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	req.Header.Set(RequestIDHeader, "req-42")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	if line := lines.String(); !strings.HasPrefix(line, "10.0.0.1 - - [") || !strings.HasSuffix(line, `"GET /users/42 HTTP/1.1" 404 7`+"\n") {
+		t.Errorf("Expected a Common Log Format line, got %q", line)
+	}
+	entries := capture.FilterMessage("http request")
+	if len(entries) != 1 || entries[0].Fields["request-id"] != "req-42" || entries[0].Fields["status"] != int64(http.StatusNotFound) {
+		t.Errorf("Expected the access entry with the request ID of the middleware, got %+v", entries)
+	}
+}
+
+func TestHTTPMiddlewareStatusLevels(t *testing.T) {
+	tests := []struct {
+		status int
+		level  string
+	}{
+		{0, LevelInfo},
+		{http.StatusNotFound, LevelWarn},
+		{http.StatusBadGateway, LevelError},
+	}
+
+	for _, tt := range tests {
+		log, capture := NewTestLogger()
+		handler := HTTPMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if tt.status != 0 {
+				w.WriteHeader(tt.status)
+			}
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		finish := capture.FilterMessage("http request completed")
+		if len(finish) != 1 || finish[0].Level != tt.level {
+			t.Errorf("Status %d: expected a %s entry, got %+v", tt.status, tt.level, finish)
+		}
+	}
+}
+
+func TestHTTPMiddlewareRequestID(t *testing.T) {
+	log, _ := NewTestLogger()
+	var seen []string
+	handler := HTTPMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, GetRequestID(r.Context()))
+	}))
+
+	for _, header := range []string{"", "bad id\nforged", strings.Repeat("x", 129)} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(RequestIDHeader, header)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if id := seen[len(seen)-1]; len(id) != 32 || rec.Header().Get(RequestIDHeader) != id {
+			t.Errorf("Header %q: expected a generated request ID, got %q", header, id)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req = req.WithContext(WithRequestID(req.Context(), "from-context"))
+	req.Header.Set(RequestIDHeader, "from-header")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	if seen[len(seen)-1] != "from-context" {
+		t.Errorf("Expected the request ID already in the context, got %q", seen[len(seen)-1])
+	}
+}
+
+func TestHTTPMiddlewareFlush(t *testing.T) {
+	log, _ := NewTestLogger()
+	handler := HTTPMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "chunk")
+		w.(http.Flusher).Flush()
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !rec.Flushed {
+		t.Error("Expected Flush to reach the underlying writer")
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	log, capture := NewTestLogger()
+	handler := HTTPMiddleware(log)(RecoverMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("nil pointer")
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders/7", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", rec.Code)
+	}
+	panicked := capture.FilterMessage("http request panicked")
+	if len(panicked) != 1 {
+		t.Fatalf("Expected a panic entry, got %+v", capture.Entries())
+	}
+	entry := panicked[0]
+	if entry.Level != LevelError || entry.Fields["panic"] != "nil pointer" || entry.Fields["request-id"] != "req-42" ||
+		entry.Fields["method"] != "GET" || entry.Fields["path"] != "/orders/7" {
+		t.Errorf("Unexpected panic entry %+v", entry)
+	}
+	if stack, _ := entry.Fields["stack"].(string); !strings.Contains(stack, "middleware_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+	if finish := capture.FilterMessage("http request completed"); len(finish) != 1 || finish[0].Fields["status"] != int64(500) {
+		t.Errorf("Expected the request to complete with 500, got %+v", finish)
+	}
+}
+
+func TestRecoverMiddlewareWrittenResponse(t *testing.T) {
+	log, capture := NewTestLogger()
+	handler := RecoverMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("after write")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+	if rec.Code != http.StatusAccepted || rec.Body.Len() != 0 {
+		t.Errorf("Expected the written response to be kept, got %d %q", rec.Code, rec.Body.String())
+	}
+	if capture.Len() != 1 {
+		t.Errorf("Expected a panic entry, got %+v", capture.Entries())
+	}
+}
+
+func TestRecoverMiddlewareAbortHandler(t *testing.T) {
+	log, capture := NewTestLogger()
+	handler := RecoverMiddleware(log)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if r := recover(); r != http.ErrAbortHandler {
+			t.Errorf("Expected http.ErrAbortHandler to be passed on, got %v", r)
+		}
+		if capture.Len() != 0 {
+			t.Errorf("Expected aborts not to be logged, got %+v", capture.Entries())
+		}
+	}()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestFromContext(t *testing.T) {
+	log, capture := NewTestLogger()
+	ctx := WithRequestID(context.Background(), "req-7")
+	FromContext(NewContext(ctx, log.Named("billing"))).Info("charged").Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Logger != "billing" || entries[0].Fields["request-id"] != "req-7" {
+		t.Errorf("Expected the stored logger bound to the context, got %+v", entries)
+	}
+
+	defer ReplaceGlobals(log)()
+	FromContext(context.Background()).Info("global").Send()
+	if len(capture.FilterMessage("global")) != 1 {
+		t.Error("Expected the global logger without a logger in the context")
+	}
+}
@@ -0,0 +1,196 @@
+package gologger
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// msgpackEncoder writes each entry as a MessagePack map with the same flat
+// layout as the JSON encoding:
+//
+//	timestamp   timestamp extension (type -1)
+//	level       string, e.g. "INFO"
+//	msg         string
+//	caller      string "file:line" (when caller information is enabled)
+//	stacktrace  string (when a stack trace was captured)
+//	<field>     Data fields with their native MessagePack types
+//
+// Entries are self-delimiting and written back to back without separators.
+type msgpackEncoder struct {
+	fieldRecorder
+}
+
+func newMsgPackEncoder() *msgpackEncoder {
+	return &msgpackEncoder{}
+}
+
+func (e *msgpackEncoder) Clone() zapcore.Encoder {
+	return &msgpackEncoder{fieldRecorder: e.clone()}
+}
+
+func (e *msgpackEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := consoleBufferPool.Get()
+	buf.Write(appendMsgPackEntry(nil, entryFromZap(ent, e.with(fields))))
+	return buf, nil
+}
+
+// appendMsgPackEntry appends the MessagePack encoding of entry to dst.
+func appendMsgPackEntry(dst []byte, entry Entry) []byte {
+	obj := make(map[string]any, len(entry.Fields)+5)
+	for key, value := range entry.Fields {
+		obj[key] = normalizeValue(value)
+	}
+	obj["level"] = strings.ToUpper(entry.Level)
+	obj["msg"] = entry.Message
+	if entry.Caller != "" {
+		obj["caller"] = entry.Caller
+	}
+	if entry.Stack != "" {
+		obj["stacktrace"] = entry.Stack
+	}
+	delete(obj, "timestamp")
+
+	dst = appendMsgPackMapHeader(dst, len(obj)+1)
+	dst = appendMsgPackString(dst, "timestamp")
+	dst = appendMsgPackTime(dst, entry.Time.Unix(), entry.Time.Nanosecond())
+	for _, key := range sortedKeys(obj) {
+		dst = appendMsgPackString(dst, key)
+		dst = appendMsgPackValue(dst, obj[key])
+	}
+	return dst
+}
+
+// appendMsgPackValue appends a normalized value to dst.
+func appendMsgPackValue(dst []byte, value any) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(dst, 0xc0)
+	case bool:
+		if v {
+			return append(dst, 0xc3)
+		}
+		return append(dst, 0xc2)
+	case int64:
+		return appendMsgPackInt(dst, v)
+	case uint64:
+		return appendMsgPackUint(dst, v)
+	case float64:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcb), math.Float64bits(v))
+	case string:
+		return appendMsgPackString(dst, v)
+	case []byte:
+		return append(appendMsgPackBinHeader(dst, len(v)), v...)
+	case []any:
+		dst = appendMsgPackArrayHeader(dst, len(v))
+		for _, item := range v {
+			dst = appendMsgPackValue(dst, item)
+		}
+		return dst
+	case map[string]any:
+		dst = appendMsgPackMapHeader(dst, len(v))
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			dst = appendMsgPackString(dst, key)
+			dst = appendMsgPackValue(dst, v[key])
+		}
+		return dst
+	default:
+		return append(dst, 0xc0)
+	}
+}
+
+func appendMsgPackInt(dst []byte, v int64) []byte {
+	switch {
+	case v >= 0:
+		return appendMsgPackUint(dst, uint64(v))
+	case v >= -32:
+		return append(dst, byte(v))
+	case v >= math.MinInt8:
+		return append(dst, 0xd0, byte(v))
+	case v >= math.MinInt16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xd1), uint16(v))
+	case v >= math.MinInt32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xd2), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xd3), uint64(v))
+	}
+}
+
+func appendMsgPackUint(dst []byte, v uint64) []byte {
+	switch {
+	case v <= 0x7f:
+		return append(dst, byte(v))
+	case v <= math.MaxUint8:
+		return append(dst, 0xcc, byte(v))
+	case v <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xcd), uint16(v))
+	case v <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(dst, 0xce), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, 0xcf), v)
+	}
+}
+
+func appendMsgPackString(dst []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		dst = append(dst, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		dst = append(dst, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		dst = binary.BigEndian.AppendUint16(append(dst, 0xda), uint16(n))
+	default:
+		dst = binary.BigEndian.AppendUint32(append(dst, 0xdb), uint32(n))
+	}
+	return append(dst, s...)
+}
+
+func appendMsgPackBinHeader(dst []byte, n int) []byte {
+	switch {
+	case n <= math.MaxUint8:
+		return append(dst, 0xc4, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xc5), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xc6), uint32(n))
+	}
+}
+
+func appendMsgPackArrayHeader(dst []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(dst, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xdc), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xdd), uint32(n))
+	}
+}
+
+func appendMsgPackMapHeader(dst []byte, n int) []byte {
+	switch {
+	case n <= 15:
+		return append(dst, 0x80|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(dst, 0xde), uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32(append(dst, 0xdf), uint32(n))
+	}
+}
+
+// appendMsgPackTime appends a timestamp extension in its 96-bit form, which
+// covers every time value.
+func appendMsgPackTime(dst []byte, sec int64, nsec int) []byte {
+	dst = append(dst, 0xc7, 12, 0xff)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(nsec))
+	return binary.BigEndian.AppendUint64(dst, uint64(sec))
+}
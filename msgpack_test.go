@@ -0,0 +1,193 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// decodeMsgPack decodes one MessagePack value from data, returning the
+// value and the remaining bytes. Timestamps are decoded as time.Time.
+func decodeMsgPack(t *testing.T, data []byte) (any, []byte) {
+	t.Helper()
+	b := data[0]
+	data = data[1:]
+	readN := func(n int) []byte {
+		chunk := data[:n]
+		data = data[n:]
+		return chunk
+	}
+	length := func(size int) int {
+		switch size {
+		case 1:
+			return int(readN(1)[0])
+		case 2:
+			return int(binary.BigEndian.Uint16(readN(2)))
+		default:
+			return int(binary.BigEndian.Uint32(readN(4)))
+		}
+	}
+	decodeMap := func(n int) any {
+		m := make(map[string]any, n)
+		for i := 0; i < n; i++ {
+			var key, value any
+			key, data = decodeMsgPack(t, data)
+			value, data = decodeMsgPack(t, data)
+			m[key.(string)] = value
+		}
+		return m
+	}
+	decodeArray := func(n int) any {
+		a := make([]any, n)
+		for i := range a {
+			a[i], data = decodeMsgPack(t, data)
+		}
+		return a
+	}
+
+	switch {
+	case b <= 0x7f:
+		return int64(b), data
+	case b >= 0xe0:
+		return int64(int8(b)), data
+	case b&0xf0 == 0x80:
+		return decodeMap(int(b & 0x0f)), data
+	case b&0xf0 == 0x90:
+		return decodeArray(int(b & 0x0f)), data
+	case b&0xe0 == 0xa0:
+		return string(readN(int(b & 0x1f))), data
+	}
+	switch b {
+	case 0xc0:
+		return nil, data
+	case 0xc2:
+		return false, data
+	case 0xc3:
+		return true, data
+	case 0xc4, 0xc5, 0xc6:
+		return append([]byte(nil), readN(length(1<<(b-0xc4)))...), data
+	case 0xc7:
+		if n, typ := readN(1)[0], readN(1)[0]; n != 12 || typ != 0xff {
+			t.Fatalf("Unexpected extension len=%d type=%d", n, typ)
+		}
+		nsec := binary.BigEndian.Uint32(readN(4))
+		sec := int64(binary.BigEndian.Uint64(readN(8)))
+		return time.Unix(sec, int64(nsec)), data
+	case 0xcb:
+		return math.Float64frombits(binary.BigEndian.Uint64(readN(8))), data
+	case 0xcc:
+		return int64(readN(1)[0]), data
+	case 0xcd:
+		return int64(binary.BigEndian.Uint16(readN(2))), data
+	case 0xce:
+		return int64(binary.BigEndian.Uint32(readN(4))), data
+	case 0xcf:
+		return binary.BigEndian.Uint64(readN(8)), data
+	case 0xd0:
+		return int64(int8(readN(1)[0])), data
+	case 0xd1:
+		return int64(int16(binary.BigEndian.Uint16(readN(2)))), data
+	case 0xd2:
+		return int64(int32(binary.BigEndian.Uint32(readN(4)))), data
+	case 0xd3:
+		return int64(binary.BigEndian.Uint64(readN(8))), data
+	case 0xd9, 0xda, 0xdb:
+		return string(readN(length(1 << (b - 0xd9)))), data
+	case 0xdc, 0xdd:
+		return decodeArray(length(2 << (b - 0xdc))), data
+	case 0xde, 0xdf:
+		return decodeMap(length(2 << (b - 0xde))), data
+	}
+	t.Fatalf("Unexpected MessagePack type byte 0x%x", b)
+	return nil, nil
+}
+
+func TestMsgPackEntry(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 10, 30, 0, 123456789, time.UTC)
+	entry := Entry{
+		Time:    ts,
+		Level:   LevelWarn,
+		Message: "disk almost full",
+		Caller:  "monitor/disk.go:42",
+		Fields: map[string]any{
+			"usage":    93,
+			"negative": -40000,
+			"big":      uint64(math.MaxUint64),
+			"ratio":    0.93,
+			"ok":       false,
+			"none":     nil,
+			"raw":      []byte{1, 2, 3},
+			"tags":     []string{"disk", "ops"},
+			"mount":    map[string]any{"path": "/var", "ro": true},
+			"long":     string(bytes.Repeat([]byte("x"), 300)),
+		},
+	}
+
+	decoded, rest := decodeMsgPack(t, appendMsgPackEntry(nil, entry))
+	if len(rest) != 0 {
+		t.Fatalf("Unexpected %d trailing bytes", len(rest))
+	}
+	doc := decoded.(map[string]any)
+	if got := doc["timestamp"].(time.Time); !got.Equal(ts) {
+		t.Errorf("Expected timestamp %v, got %v", ts, got)
+	}
+	expected := map[string]any{
+		"level":    "WARN",
+		"msg":      "disk almost full",
+		"caller":   "monitor/disk.go:42",
+		"usage":    int64(93),
+		"negative": int64(-40000),
+		"big":      uint64(math.MaxUint64),
+		"ratio":    0.93,
+		"ok":       false,
+		"none":     nil,
+		"raw":      []byte{1, 2, 3},
+		"tags":     []any{"disk", "ops"},
+		"mount":    map[string]any{"path": "/var", "ro": true},
+		"long":     string(bytes.Repeat([]byte("x"), 300)),
+	}
+	for key, want := range expected {
+		if !reflect.DeepEqual(doc[key], want) {
+			t.Errorf("Expected %s=%v, got %v", key, want, doc[key])
+		}
+	}
+	if len(doc) != len(expected)+1 {
+		t.Errorf("Expected %d keys, got %d: %v", len(expected)+1, len(doc), doc)
+	}
+}
+
+func TestMsgPackIntegers(t *testing.T) {
+	for _, v := range []int64{0, 127, 128, 255, 256, 65535, 65536, math.MaxInt64, -1, -32, -33, -128, -129, -32768, -32769, math.MinInt32 - 1, math.MinInt64} {
+		decoded, _ := decodeMsgPack(t, appendMsgPackValue(nil, v))
+		if fmt.Sprint(decoded) != fmt.Sprint(v) {
+			t.Errorf("Round trip of %d gave %v", v, decoded)
+		}
+	}
+}
+
+func TestMsgPackEncodingConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogDir:     tempDir,
+		Encoding:   EncodingMsgPack,
+	})
+	log.Info("first").Data("n", 1).Send()
+	log.Error("second").Send()
+	log.Close()
+
+	data := []byte(readTestLogFile(t, tempDir))
+	var messages []string
+	for len(data) > 0 {
+		var decoded any
+		decoded, data = decodeMsgPack(t, data)
+		messages = append(messages, decoded.(map[string]any)["msg"].(string))
+	}
+	if !reflect.DeepEqual(messages, []string{"first", "second"}) {
+		t.Errorf("Expected two back-to-back entries, got %v", messages)
+	}
+}
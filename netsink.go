@@ -0,0 +1,171 @@
+package gologger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+)
+
+// Network sink transports.
+const (
+	NetworkTransportTCP = "tcp"
+	NetworkTransportUDP = "udp"
+)
+
+// NetworkConfig holds configuration options for the network sink.
+type NetworkConfig struct {
+	Addr          string        // Collector address, e.g. "collector:7000" (required)
+	Transport     string        // Transport: NetworkTransportTCP or NetworkTransportUDP (default: NetworkTransportTCP)
+	Encoding      string        // Entry encoding: EncodingJSON, EncodingMsgPack or EncodingProtobuf (default: EncodingJSON)
+	TLS           *TLSConfig    // TLS client options for TCP (optional)
+	BatchSize     int           // Maximum entries per batch (default: 100)
+	FlushInterval time.Duration // Maximum time an entry waits before being sent (default: 1s)
+	Timeout       time.Duration // Dial and write timeout (default: 5s)
+}
+
+// NetworkSink streams encoded entries to a collector over TCP or UDP. Over
+// TCP, JSON entries are newline-delimited, MessagePack entries are written
+// back to back and protobuf records are length-prefixed; over UDP, each
+// entry is sent as one datagram.
+type NetworkSink struct {
+	config NetworkConfig
+	encode func(dst []byte, entry Entry) ([]byte, error)
+	err    error // configuration error, returned by every flush
+	batch  *batcher
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewNetworkSink creates a network sink. Unset options fall back to their
+// defaults. The connection is established lazily on the first flush.
+func NewNetworkSink(config NetworkConfig) *NetworkSink {
+	if config.Transport == "" {
+		config.Transport = NetworkTransportTCP
+	}
+	if config.Encoding == "" {
+		config.Encoding = EncodingJSON
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	s := &NetworkSink{config: config}
+	switch config.Encoding {
+	case EncodingJSON:
+		s.encode = appendJSONLine
+	case EncodingMsgPack:
+		s.encode = func(dst []byte, entry Entry) ([]byte, error) {
+			return appendMsgPackEntry(dst, entry), nil
+		}
+	case EncodingProtobuf:
+		s.encode = func(dst []byte, entry Entry) ([]byte, error) {
+			return appendProtobufRecord(dst, entry), nil
+		}
+	default:
+		s.err = fmt.Errorf("network: unsupported encoding %q", config.Encoding)
+	}
+	if config.Addr == "" {
+		s.err = errors.New("network: address is required")
+	}
+	s.batch = newBatcher(config.BatchSize, config.FlushInterval, s.send)
+	return s
+}
+
+// Write queues an entry for sending.
+func (s *NetworkSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync sends all queued entries.
+func (s *NetworkSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close sends all queued entries and closes the connection.
+func (s *NetworkSink) Close() error {
+	err := s.batch.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// send encodes and writes a batch of entries.
+func (s *NetworkSink) send(entries []Entry) error {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.Transport == NetworkTransportUDP {
+		var errs []error
+		for _, entry := range entries {
+			data, err := s.encode(nil, entry)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("network: encode entry: %w", err))
+				continue
+			}
+			errs = append(errs, s.write(nil, data))
+		}
+		return errors.Join(errs...)
+	}
+
+	var buf []byte
+	for _, entry := range entries {
+		var err error
+		if buf, err = s.encode(buf, entry); err != nil {
+			return fmt.Errorf("network: encode entry: %w", err)
+		}
+	}
+	reused := s.conn != nil
+	err := s.write(s.config.TLS, buf)
+	if err != nil && reused {
+		// The connection may have been closed by the server; retry once.
+		err = s.write(s.config.TLS, buf)
+	}
+	return err
+}
+
+// write writes data on the connection, dialing it first if needed. It must
+// be called with s.mu held.
+func (s *NetworkSink) write(tlsOptions *TLSConfig, data []byte) error {
+	if s.conn == nil {
+		conn, err := dialNetwork(s.config.Transport, s.config.Addr, s.config.Timeout, tlsOptions)
+		if err != nil {
+			return fmt.Errorf("network: %w", err)
+		}
+		s.conn = conn
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout))
+	if _, err := s.conn.Write(data); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("network: %w", err)
+	}
+	return nil
+}
+
+// appendJSONLine appends the JSON encoding of entry and a newline to dst.
+func appendJSONLine(dst []byte, entry Entry) ([]byte, error) {
+	data, err := entryJSON(entry)
+	if err != nil {
+		return dst, err
+	}
+	return append(append(dst, data...), '\n'), nil
+}
@@ -0,0 +1,161 @@
+package gologger
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// acceptAll accepts one connection and returns everything written to it.
+func acceptAll(t *testing.T, listener net.Listener) <-chan []byte {
+	t.Helper()
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			received <- nil
+			return
+		}
+		defer conn.Close()
+		data, _ := io.ReadAll(conn)
+		received <- data
+	}()
+	return received
+}
+
+func TestNetworkSinkJSON(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	received := acceptAll(t, listener)
+
+	sink := NewNetworkSink(NetworkConfig{Addr: listener.Addr().String()})
+	for _, msg := range []string{"first", "second"} {
+		if err := sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: msg, Fields: map[string]any{"n": 1}}); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(string(<-received)))
+	var messages []string
+	for scanner.Scan() {
+		var doc map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		messages = append(messages, doc["msg"].(string))
+	}
+	if strings.Join(messages, ",") != "first,second" {
+		t.Errorf("Expected two JSON lines, got %v", messages)
+	}
+}
+
+func TestNetworkSinkMsgPack(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	received := acceptAll(t, listener)
+
+	sink := NewNetworkSink(NetworkConfig{Addr: listener.Addr().String(), Encoding: EncodingMsgPack})
+	for _, msg := range []string{"first", "second"} {
+		if err := sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: msg}); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	data := <-received
+	var messages []string
+	for len(data) > 0 {
+		var decoded any
+		decoded, data = decodeMsgPack(t, data)
+		messages = append(messages, decoded.(map[string]any)["msg"].(string))
+	}
+	if strings.Join(messages, ",") != "first,second" {
+		t.Errorf("Expected two MessagePack entries, got %v", messages)
+	}
+}
+
+func TestNetworkSinkProtobuf(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	received := acceptAll(t, listener)
+
+	sink := NewNetworkSink(NetworkConfig{Addr: listener.Addr().String(), Encoding: EncodingProtobuf})
+	for _, msg := range []string{"first", "second"} {
+		if err := sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: msg}); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	data := <-received
+	var messages []string
+	for len(data) > 0 {
+		var length uint64
+		length, data = readVarint(t, data)
+		messages = append(messages, string(protoFields(t, data[:length])[3][0]))
+		data = data[length:]
+	}
+	if strings.Join(messages, ",") != "first,second" {
+		t.Errorf("Expected two protobuf records, got %v", messages)
+	}
+}
+
+func TestNetworkSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	sink := NewNetworkSink(NetworkConfig{Addr: conn.LocalAddr().String(), Transport: NetworkTransportUDP})
+	if err := sink.Write(Entry{Time: time.Now(), Level: LevelError, Message: "datagram"}); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	buf := make([]byte, 65535)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+	if !strings.Contains(string(buf[:n]), `"msg":"datagram"`) {
+		t.Errorf("Unexpected datagram %q", buf[:n])
+	}
+}
+
+func TestNetworkSinkConfigErrors(t *testing.T) {
+	tests := map[string]NetworkConfig{
+		"address is required":  {},
+		"unsupported encoding": {Addr: "localhost:1", Encoding: EncodingConsole},
+	}
+	for want, config := range tests {
+		sink := NewNetworkSink(config)
+		_ = sink.Write(Entry{Message: "dropped"})
+		if err := sink.Close(); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected error containing %q, got %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package gologger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Payload compression algorithms for network sinks.
+const (
+	CompressionNone = ""
+	CompressionGzip = "gzip"
+	CompressionZstd = "zstd"
+)
+
+// TLSConfig holds TLS client options shared by network sinks.
+type TLSConfig struct {
+	CAFile             string // PEM file with CA certificates used to verify the server (default: system roots)
+	CertFile           string // PEM client certificate for mutual TLS (optional)
+	KeyFile            string // PEM client private key for mutual TLS (optional)
+	ServerName         string // Server name used for SNI and verification (default: taken from the address)
+	InsecureSkipVerify bool   // Skip server certificate verification (testing only)
+}
+
+// build creates a *tls.Config from the options.
+func (c *TLSConfig) build() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         c.ServerName,
+		InsecureSkipVerify: c.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tls: no certificates found in %s", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// dialNetwork connects to addr, wrapping the connection in TLS when
+// tlsOptions is set.
+func dialNetwork(network, addr string, timeout time.Duration, tlsOptions *TLSConfig) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: timeout}
+	if tlsOptions == nil {
+		return dialer.Dial(network, addr)
+	}
+
+	tlsConfig, err := tlsOptions.build()
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig.ServerName == "" {
+		if host, _, err := net.SplitHostPort(addr); err == nil {
+			tlsConfig.ServerName = host
+		}
+	}
+	return tls.DialWithDialer(dialer, network, addr, tlsConfig)
+}
+
+// newHTTPClient returns client unchanged if set, otherwise a client using
+// the TLS options. HTTP/2 is kept enabled for custom TLS configurations.
+func newHTTPClient(client *http.Client, tlsOptions *TLSConfig) (*http.Client, error) {
+	if client != nil {
+		return client, nil
+	}
+	if tlsOptions == nil {
+		return &http.Client{}, nil
+	}
+
+	tlsConfig, err := tlsOptions.build()
+	if err != nil {
+		return &http.Client{}, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	transport.ForceAttemptHTTP2 = true
+	return &http.Client{Transport: transport}, nil
+}
+
+// zstdEncoder is shared by all sinks; EncodeAll is safe for concurrent use.
+var (
+	zstdOnce    sync.Once
+	zstdEncoder *zstd.Encoder
+	zstdErr     error
+)
+
+// compressPayload compresses data with the given algorithm.
+func compressPayload(data []byte, compression string) ([]byte, error) {
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionGzip:
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case CompressionZstd:
+		zstdOnce.Do(func() {
+			zstdEncoder, zstdErr = zstd.NewWriter(nil)
+		})
+		if zstdErr != nil {
+			return nil, zstdErr
+		}
+		return zstdEncoder.EncodeAll(data, nil), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+}
@@ -0,0 +1,175 @@
+package gologger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/tls"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// writeServerCA writes the certificate of a TLS test server as a PEM CA file.
+func writeServerCA(t *testing.T, server *httptest.Server) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write CA file: %v", err)
+	}
+	return path
+}
+
+func TestCompressPayload(t *testing.T) {
+	data := []byte(strings.Repeat(`{"msg":"hello"}`, 50))
+
+	gz, err := compressPayload(data, CompressionGzip)
+	if err != nil {
+		t.Fatalf("Unexpected gzip error: %v", err)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		t.Fatalf("Invalid gzip payload: %v", err)
+	}
+	if decoded, _ := io.ReadAll(reader); !bytes.Equal(decoded, data) {
+		t.Error("Gzip round trip mismatch")
+	}
+
+	zs, err := compressPayload(data, CompressionZstd)
+	if err != nil {
+		t.Fatalf("Unexpected zstd error: %v", err)
+	}
+	decoder, _ := zstd.NewReader(nil)
+	defer decoder.Close()
+	if decoded, err := decoder.DecodeAll(zs, nil); err != nil || !bytes.Equal(decoded, data) {
+		t.Errorf("Zstd round trip mismatch: %v", err)
+	}
+	if len(zs) >= len(data) || len(gz) >= len(data) {
+		t.Error("Expected compressed payloads to be smaller")
+	}
+
+	if plain, _ := compressPayload(data, CompressionNone); !bytes.Equal(plain, data) {
+		t.Error("Expected no compression to return the payload unchanged")
+	}
+	if _, err := compressPayload(data, "brotli"); err == nil {
+		t.Error("Expected error for unsupported compression")
+	}
+}
+
+func TestTLSConfigBuildErrors(t *testing.T) {
+	if _, err := (&TLSConfig{CAFile: filepath.Join(t.TempDir(), "missing.pem")}).build(); err == nil {
+		t.Error("Expected error for missing CA file")
+	}
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalid, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&TLSConfig{CAFile: invalid}).build(); err == nil {
+		t.Error("Expected error for CA file without certificates")
+	}
+
+	if _, err := (&TLSConfig{CertFile: invalid}).build(); err == nil {
+		t.Error("Expected error for invalid client certificate")
+	}
+}
+
+func TestOTLPSinkCompression(t *testing.T) {
+	received := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if enc := r.Header.Get("Content-Encoding"); enc != "gzip" {
+			t.Errorf("Expected gzip content encoding, got %q", enc)
+		}
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("Invalid gzip body: %v", err)
+			return
+		}
+		var body map[string]any
+		if err := json.NewDecoder(reader).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		received <- body
+	}))
+	defer server.Close()
+
+	sink := NewOTLPSink(OTLPConfig{Endpoint: server.URL, Compression: CompressionGzip})
+	if err := sink.Write(testOTLPEntry()); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if body := <-received; body["resourceLogs"] == nil {
+		t.Errorf("Expected resourceLogs in body, got %v", body)
+	}
+}
+
+func TestOTLPSinkTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	sink := NewOTLPSink(OTLPConfig{
+		Endpoint: server.URL,
+		TLS:      &TLSConfig{CAFile: writeServerCA(t, server)},
+	})
+	if err := sink.Write(testOTLPEntry()); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Expected export over TLS to succeed, got %v", err)
+	}
+
+	// Without the CA the server certificate is not trusted.
+	untrusted := NewOTLPSink(OTLPConfig{Endpoint: server.URL, TLS: &TLSConfig{}})
+	_ = untrusted.Write(testOTLPEntry())
+	if err := untrusted.Close(); err == nil {
+		t.Error("Expected certificate verification error")
+	}
+
+	invalid := NewOTLPSink(OTLPConfig{Endpoint: server.URL, TLS: &TLSConfig{CAFile: "missing.pem"}})
+	_ = invalid.Write(testOTLPEntry())
+	if err := invalid.Close(); err == nil || !strings.Contains(err.Error(), "CA file") {
+		t.Errorf("Expected CA file error, got %v", err)
+	}
+}
+
+func TestRedisSinkTLS(t *testing.T) {
+	// Borrow the test certificate of an httptest TLS server.
+	certServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer certServer.Close()
+
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := &fakeRedis{listener: tls.NewListener(raw, certServer.TLS)}
+	go server.serve()
+	defer raw.Close()
+
+	sink := NewRedisSink(RedisConfig{
+		Addr: raw.Addr().String(),
+		TLS:  &TLSConfig{CAFile: writeServerCA(t, certServer)},
+	})
+	if err := sink.Write(Entry{Level: LevelInfo, Message: "secure"}); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	commands := server.Commands()
+	if len(commands) != 1 || commands[0][0] != "XADD" {
+		t.Errorf("Expected one XADD over TLS, got %v", commands)
+	}
+}
@@ -0,0 +1,167 @@
+// Package otellog connects gologger with OpenTelemetry logs and traces, in
+// both directions. NewLoggerProvider implements the OpenTelemetry Logs Bridge
+// API, so log records emitted by OpenTelemetry instrumentation and bridges
+// are written through gologger:
+//
+//	global.SetLoggerProvider(otellog.NewLoggerProvider(log))
+//
+// NewSpanProcessor mirrors the spans and span events recorded with an
+// OpenTelemetry tracer provider into gologger, and NewSink appends the
+// entries of gologger to an OpenTelemetry SDK logger provider, which exports
+// them with its processors and exporters.
+//
+// It is a separate module, so applications not using OpenTelemetry do not
+// depend on it.
+package otellog
+
+import (
+	"context"
+	"runtime"
+	"strings"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/embedded"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/gologger"
+)
+
+// LoggerProvider is an OpenTelemetry log.LoggerProvider writing records
+// through a gologger.Logger.
+type LoggerProvider struct {
+	embedded.LoggerProvider
+	log gologger.Logger
+}
+
+var _ log.LoggerProvider = (*LoggerProvider)(nil)
+
+// NewLoggerProvider returns a logger provider writing records through log.
+// The loggers it returns log each record with its body as message and its
+// attributes as data fields, at the level of its severity: trace and debug
+// severities at debug level, info at info, warn at warn, and error and
+// fatal at error level. Entries carry the request ID of the context passed
+// to Emit, and the trace and span IDs of its span. The caller of entries is
+// the first function outside OpenTelemetry and log/slog, usually the
+// function calling the bridged logger.
+//
+// Do not pass the provider to the NewSink of log, or entries would be logged
+// in a loop.
+func NewLoggerProvider(log gologger.Logger) *LoggerProvider {
+	return &LoggerProvider{log: log}
+}
+
+// Logger returns a logger whose entries are logged by a logger named name,
+// the instrumentation scope, below the logger of the provider.
+func (p *LoggerProvider) Logger(name string, _ ...log.LoggerOption) log.Logger {
+	l := p.log
+	if name != "" {
+		l = l.Named(name)
+	}
+	return &Logger{log: l}
+}
+
+// Logger is an OpenTelemetry log.Logger writing records through a
+// gologger.Logger.
+type Logger struct {
+	embedded.Logger
+	log gologger.Logger
+}
+
+var _ log.Logger = (*Logger)(nil)
+
+// Emit logs record.
+func (l *Logger) Emit(ctx context.Context, record log.Record) {
+	if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+		ctx = gologger.WithTraceContext(ctx, span.TraceID().String(), span.SpanID().String())
+	}
+	entry := l.log.WithContext(ctx).WithCallerSkip(callerSkip()).
+		Log(level(record.Severity()), record.Body().String())
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		entry = entry.Data(kv.Key, value(kv.Value))
+		return true
+	})
+	entry.Send()
+}
+
+// Enabled reports whether the level of records of the severity of record
+// is at or above the level of the logger.
+func (l *Logger) Enabled(_ context.Context, record log.Record) bool {
+	return rank(level(record.Severity())) >= rank(l.log.GetLevel())
+}
+
+// level returns the gologger level of an OpenTelemetry severity. Records
+// without severity are logged at info level.
+func level(severity log.Severity) string {
+	switch {
+	case severity == log.SeverityUndefined:
+		return gologger.LevelInfo
+	case severity < log.SeverityInfo:
+		return gologger.LevelDebug
+	case severity < log.SeverityWarn:
+		return gologger.LevelInfo
+	case severity < log.SeverityError:
+		return gologger.LevelWarn
+	default:
+		return gologger.LevelError
+	}
+}
+
+// rank orders the levels from debug to error.
+func rank(level string) int {
+	switch level {
+	case gologger.LevelInfo:
+		return 1
+	case gologger.LevelWarn:
+		return 2
+	case gologger.LevelError:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// value converts an OpenTelemetry log value to a data field value: maps
+// become map[string]any and slices []any.
+func value(v log.Value) any {
+	switch v.Kind() {
+	case log.KindBool:
+		return v.AsBool()
+	case log.KindFloat64:
+		return v.AsFloat64()
+	case log.KindInt64:
+		return v.AsInt64()
+	case log.KindString:
+		return v.AsString()
+	case log.KindBytes:
+		return v.AsBytes()
+	case log.KindSlice:
+		values := make([]any, 0, len(v.AsSlice()))
+		for _, item := range v.AsSlice() {
+			values = append(values, value(item))
+		}
+		return values
+	case log.KindMap:
+		fields := make(map[string]any, len(v.AsMap()))
+		for _, kv := range v.AsMap() {
+			fields[kv.Key] = value(kv.Value)
+		}
+		return fields
+	default:
+		return nil
+	}
+}
+
+// callerSkip returns the number of frames between Emit and the first caller
+// outside OpenTelemetry and log/slog, whose depth depends on the bridge.
+func callerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, callerSkip and Emit.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	skip := 1
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "go.opentelemetry.io/") && !strings.HasPrefix(frame.Function, "log/slog.") {
+			return skip
+		}
+		skip++
+	}
+}
@@ -0,0 +1,90 @@
+package otellog
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/gologger"
+)
+
+func TestLoggerProvider(t *testing.T) {
+	logger, capture := gologger.NewTestLogger()
+	otelLogger := NewLoggerProvider(logger).Logger("checkout")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(gologger.WithRequestID(context.Background(), "req-42"),
+		trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	var record log.Record
+	record.SetSeverity(log.SeverityWarn2)
+	record.SetBody(log.StringValue("payment retried"))
+	record.AddAttributes(
+		log.Int("attempt", 2),
+		log.Bool("idempotent", true),
+		log.Map("card", log.String("brand", "visa")),
+		log.Slice("codes", log.StringValue("timeout")),
+	)
+	otelLogger.Emit(ctx, record)
+
+	var polling log.Record
+	polling.SetSeverity(log.SeverityTrace)
+	polling.SetBody(log.StringValue("polling"))
+	otelLogger.Emit(context.Background(), polling)
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v", entries)
+	}
+	entry := entries[0]
+	if entry.Level != gologger.LevelWarn || entry.Message != "payment retried" || entry.Logger != "checkout" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Fields["request-id"] != "req-42" || entry.Fields["trace_id"] != traceID.String() ||
+		entry.Fields["span_id"] != spanID.String() {
+		t.Errorf("Expected the request and trace IDs of the context, got %v", entry.Fields)
+	}
+	if entry.Fields["attempt"] != int64(2) || entry.Fields["idempotent"] != true {
+		t.Errorf("Expected the attributes, got %v", entry.Fields)
+	}
+	if card, _ := entry.Fields["card"].(map[string]any); card["brand"] != "visa" {
+		t.Errorf("Expected a map attribute, got %v", entry.Fields["card"])
+	}
+	if codes, _ := entry.Fields["codes"].([]any); len(codes) != 1 || codes[0] != "timeout" {
+		t.Errorf("Expected a slice attribute, got %v", entry.Fields["codes"])
+	}
+	if !strings.Contains(entry.Caller, "otellog_test.go:") {
+		t.Errorf("Expected the caller of Emit, got %q", entry.Caller)
+	}
+	if entries[1].Level != gologger.LevelDebug {
+		t.Errorf("Expected trace records at debug level, got %s", entries[1].Level)
+	}
+}
+
+func TestLoggerEnabled(t *testing.T) {
+	logger, _ := gologger.NewTestLogger()
+	if err := logger.SetLevel(gologger.LevelWarn); err != nil {
+		t.Fatal(err)
+	}
+	otelLogger := NewLoggerProvider(logger).Logger("")
+
+	tests := []struct {
+		severity log.Severity
+		enabled  bool
+	}{
+		{log.SeverityDebug, false},
+		{log.SeverityInfo4, false},
+		{log.SeverityWarn, true},
+		{log.SeverityFatal, true},
+	}
+	for _, tt := range tests {
+		var record log.Record
+		record.SetSeverity(tt.severity)
+		if got := otelLogger.Enabled(context.Background(), record); got != tt.enabled {
+			t.Errorf("Enabled(%s) = %v, want %v", tt.severity, got, tt.enabled)
+		}
+	}
+}
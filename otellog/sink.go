@@ -0,0 +1,182 @@
+package otellog
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/gologger"
+)
+
+// defaultScope is the instrumentation scope of entries of unnamed loggers.
+const defaultScope = "go.risoftinc.com/gologger"
+
+// Sink is a gologger.Sink appending entries to an OpenTelemetry logger
+// provider, usually the LoggerProvider of the OpenTelemetry SDK, so they are
+// processed and exported with the records of OpenTelemetry instrumentation.
+type Sink struct {
+	provider log.LoggerProvider
+	loggers  sync.Map // instrumentation scope -> log.Logger
+}
+
+var _ gologger.Sink = (*Sink)(nil)
+
+// NewSink returns a sink emitting entries to the loggers of provider:
+//
+//	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter)))
+//	log := gologger.NewLoggerWithConfig(gologger.LoggerConfig{Sinks: []gologger.Sink{otellog.NewSink(provider)}})
+//
+// Entries are emitted as records with their message as body, their level
+// as severity and their data fields as attributes, by the logger whose
+// instrumentation scope is the name given to Named, or "go.risoftinc.com/gologger"
+// for unnamed loggers. Trace and span IDs become the trace context of the
+// record, the caller becomes the code.filepath and code.lineno attributes,
+// and the stack trace exception.stacktrace, as in OTLPSink.
+//
+// The provider is not shut down by Close; the application owns it.
+func NewSink(provider log.LoggerProvider) *Sink {
+	return &Sink{provider: provider}
+}
+
+// Write emits entry as a record.
+func (s *Sink) Write(entry gologger.Entry) error {
+	var record log.Record
+	record.SetTimestamp(entry.Time)
+	record.SetSeverity(severity(entry.Level))
+	record.SetSeverityText(strings.ToUpper(entry.Level))
+	record.SetBody(log.StringValue(entry.Message))
+
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	for _, key := range sortedKeys(entry.Fields) {
+		switch key {
+		case gologger.TraceIDField:
+			if id, err := trace.TraceIDFromHex(fmt.Sprint(entry.Fields[key])); err == nil {
+				traceID = id
+				continue
+			}
+		case gologger.SpanIDField:
+			if id, err := trace.SpanIDFromHex(fmt.Sprint(entry.Fields[key])); err == nil {
+				spanID = id
+				continue
+			}
+		}
+		record.AddAttributes(log.KeyValue{Key: key, Value: logValue(entry.Fields[key])})
+	}
+	if entry.Caller != "" {
+		file := entry.Caller
+		if i := strings.LastIndexByte(file, ':'); i >= 0 {
+			if line, err := strconv.Atoi(file[i+1:]); err == nil {
+				file = file[:i]
+				record.AddAttributes(log.Int("code.lineno", line))
+			}
+		}
+		record.AddAttributes(log.String("code.filepath", file))
+	}
+	if entry.Stack != "" {
+		record.AddAttributes(log.String("exception.stacktrace", entry.Stack))
+	}
+
+	ctx := context.Background()
+	if traceID.IsValid() {
+		ctx = trace.ContextWithSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	}
+	s.logger(entry.Logger).Emit(ctx, record)
+	return nil
+}
+
+// Sync flushes the records buffered by the provider, if it can be flushed.
+func (s *Sink) Sync() error {
+	if flusher, ok := s.provider.(interface{ ForceFlush(context.Context) error }); ok {
+		return flusher.ForceFlush(context.Background())
+	}
+	return nil
+}
+
+// Close flushes the records buffered by the provider.
+func (s *Sink) Close() error {
+	return s.Sync()
+}
+
+// logger returns the logger of the provider for the entries of the
+// gologger logger named name.
+func (s *Sink) logger(name string) log.Logger {
+	if name == "" {
+		name = defaultScope
+	}
+	if logger, ok := s.loggers.Load(name); ok {
+		return logger.(log.Logger)
+	}
+	logger, _ := s.loggers.LoadOrStore(name, s.provider.Logger(name))
+	return logger.(log.Logger)
+}
+
+// severity maps a level name to an OpenTelemetry severity.
+func severity(level string) log.Severity {
+	switch level {
+	case gologger.LevelDebug:
+		return log.SeverityDebug
+	case gologger.LevelInfo:
+		return log.SeverityInfo
+	case gologger.LevelWarn:
+		return log.SeverityWarn
+	case gologger.LevelError:
+		return log.SeverityError
+	case "dpanic":
+		return log.SeverityError2
+	case "panic", "fatal":
+		return log.SeverityFatal
+	default:
+		return log.SeverityUndefined
+	}
+}
+
+// logValue converts a data field value, normalized as in gologger.Entry, to
+// an OpenTelemetry log value.
+func logValue(v any) log.Value {
+	switch v := v.(type) {
+	case nil:
+		return log.Value{}
+	case string:
+		return log.StringValue(v)
+	case bool:
+		return log.BoolValue(v)
+	case int64:
+		return log.Int64Value(v)
+	case uint64:
+		return log.Int64Value(int64(v))
+	case float64:
+		return log.Float64Value(v)
+	case []byte:
+		return log.BytesValue(v)
+	case []any:
+		values := make([]log.Value, 0, len(v))
+		for _, item := range v {
+			values = append(values, logValue(item))
+		}
+		return log.SliceValue(values...)
+	case map[string]any:
+		kvs := make([]log.KeyValue, 0, len(v))
+		for _, key := range sortedKeys(v) {
+			kvs = append(kvs, log.KeyValue{Key: key, Value: logValue(v[key])})
+		}
+		return log.MapValue(kvs...)
+	default:
+		return log.StringValue(fmt.Sprint(v))
+	}
+}
+
+// sortedKeys returns the keys of fields in lexical order.
+func sortedKeys(fields map[string]any) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,92 @@
+package otellog
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	"go.risoftinc.com/gologger"
+)
+
+// memoryExporter records the exported records.
+type memoryExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+	flushes int
+}
+
+func (e *memoryExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, record := range records {
+		e.records = append(e.records, record.Clone())
+	}
+	return nil
+}
+
+func (e *memoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+func (e *memoryExporter) ForceFlush(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.flushes++
+	return nil
+}
+
+func TestSink(t *testing.T) {
+	exporter := &memoryExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+	logger := gologger.NewLoggerWithConfig(gologger.LoggerConfig{
+		OutputMode: gologger.OutputDiscard,
+		LogLevel:   gologger.LevelDebug,
+		ShowCaller: true,
+		Sinks:      []gologger.Sink{NewSink(provider)},
+	})
+
+	ctx := gologger.WithTraceContext(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	logger.WithContext(ctx).Named("billing").Error("charge failed").
+		Data("amount", 12.5).
+		Data("order", map[string]any{"id": 7}).
+		ErrorData(errors.New("card declined")).
+		Send()
+	logger.Info("started").Send()
+	if err := logger.Sync(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(exporter.records) != 2 {
+		t.Fatalf("Expected two records, got %d", len(exporter.records))
+	}
+	record := exporter.records[0]
+	if record.Body().AsString() != "charge failed" || record.Severity() != 17 || record.SeverityText() != "ERROR" {
+		t.Errorf("Unexpected record %v %v %q", record.Body(), record.Severity(), record.SeverityText())
+	}
+	if record.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || record.SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("Expected the trace context of the entry, got %s %s", record.TraceID(), record.SpanID())
+	}
+	if scope := record.InstrumentationScope().Name; scope != "billing" {
+		t.Errorf("Expected the logger name as scope, got %q", scope)
+	}
+	attrs := map[string]string{}
+	record.WalkAttributes(func(kv log.KeyValue) bool {
+		attrs[kv.Key] = kv.Value.String()
+		return true
+	})
+	if attrs["amount"] != "12.5" || attrs["error"] != "card declined" || attrs["code.filepath"] == "" || attrs["code.lineno"] == "" {
+		t.Errorf("Unexpected attributes %v", attrs)
+	}
+	if _, ok := attrs["trace_id"]; ok {
+		t.Errorf("Expected the trace ID to be the trace context, not an attribute: %v", attrs)
+	}
+	if scope := exporter.records[1].InstrumentationScope().Name; scope != "go.risoftinc.com/gologger" {
+		t.Errorf("Expected the default scope for unnamed loggers, got %q", scope)
+	}
+	if exporter.flushes == 0 {
+		t.Error("Expected Sync to flush the provider")
+	}
+}
@@ -0,0 +1,102 @@
+package otellog
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.risoftinc.com/gologger"
+)
+
+// SpanConfig holds configuration options for NewSpanProcessor.
+type SpanConfig struct {
+	Level string // Level of spans and span events (default: LevelDebug); failed spans and exception events are logged at error level
+}
+
+// SpanProcessor is an OpenTelemetry SDK span processor logging ended spans
+// and their events through a gologger.Logger.
+type SpanProcessor struct {
+	log   gologger.Logger
+	level string
+}
+
+var _ sdktrace.SpanProcessor = (*SpanProcessor)(nil)
+
+// NewSpanProcessor returns a span processor logging with log, to register
+// with the tracer provider next to its exporters:
+//
+//	provider := sdktrace.NewTracerProvider(
+//		sdktrace.WithBatcher(exporter),
+//		sdktrace.WithSpanProcessor(otellog.NewSpanProcessor(log.Named("trace"), otellog.SpanConfig{})),
+//	)
+//
+// When a span ends, each of its events is logged as "span event", with its
+// name as "event" and its attributes, then the span as "span ended" with its
+// name as "span", its kind, attributes, status and duration, and the ID of
+// its parent span as "parent_span_id". All entries carry the trace and span
+// IDs of the span. Spans whose status is an error are logged at error level
+// with the status description as "error", and exception events, recorded
+// with RecordError, at error level too.
+func NewSpanProcessor(log gologger.Logger, config SpanConfig) *SpanProcessor {
+	level := config.Level
+	if level == "" {
+		level = gologger.LevelDebug
+	}
+	return &SpanProcessor{log: log, level: level}
+}
+
+// OnStart does nothing; spans are logged when they end.
+func (p *SpanProcessor) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+// OnEnd logs the events of span and span.
+func (p *SpanProcessor) OnEnd(span sdktrace.ReadOnlySpan) {
+	sc := span.SpanContext()
+	log := p.log.WithContext(gologger.WithTraceContext(context.Background(), sc.TraceID().String(), sc.SpanID().String()))
+
+	for _, event := range span.Events() {
+		level := p.level
+		if event.Name == semconv.ExceptionEventName {
+			level = gologger.LevelError
+		}
+		attributes(log.Log(level, "span event").Data("event", event.Name), event.Attributes).Send()
+	}
+
+	level := p.level
+	status := span.Status()
+	if status.Code == codes.Error {
+		level = gologger.LevelError
+	}
+	entry := log.Log(level, "span ended").
+		Data("span", span.Name()).
+		Data("span_kind", span.SpanKind().String()).
+		Data("status", status.Code.String()).
+		Data("duration_ms", float64(span.EndTime().Sub(span.StartTime()))/float64(time.Millisecond))
+	if status.Code == codes.Error && status.Description != "" {
+		entry = entry.Data("error", status.Description)
+	}
+	if parent := span.Parent(); parent.IsValid() {
+		entry = entry.Data("parent_span_id", parent.SpanID().String())
+	}
+	attributes(entry, span.Attributes()).Send()
+}
+
+// Shutdown does nothing.
+func (p *SpanProcessor) Shutdown(context.Context) error {
+	return nil
+}
+
+// ForceFlush syncs the logger.
+func (p *SpanProcessor) ForceFlush(context.Context) error {
+	return p.log.Sync()
+}
+
+// attributes adds attrs to log as data fields.
+func attributes(log gologger.Logger, attrs []attribute.KeyValue) gologger.Logger {
+	for _, attr := range attrs {
+		log = log.Data(string(attr.Key), attr.Value.AsInterface())
+	}
+	return log
+}
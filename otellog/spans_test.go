@@ -0,0 +1,60 @@
+package otellog
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.risoftinc.com/gologger"
+)
+
+func TestSpanProcessor(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(NewSpanProcessor(log, SpanConfig{Level: gologger.LevelInfo})))
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(context.Background(), "checkout")
+	_, span := tracer.Start(ctx, "charge", trace.WithAttributes(attribute.String("card", "visa")))
+	span.AddEvent("authorized", trace.WithAttributes(attribute.Int("amount", 12)))
+	span.RecordError(errors.New("card declined"))
+	span.SetStatus(codes.Error, "card declined")
+	span.End()
+	parent.End()
+
+	entries := capture.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected two events and two spans, got %+v", entries)
+	}
+	event, exception, charge, checkout := entries[0], entries[1], entries[2], entries[3]
+	sc := span.SpanContext()
+	for _, entry := range entries[:3] {
+		if entry.Fields["trace_id"] != sc.TraceID().String() || entry.Fields["span_id"] != sc.SpanID().String() {
+			t.Errorf("Expected the trace and span IDs on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	if event.Level != gologger.LevelInfo || event.Message != "span event" || event.Fields["event"] != "authorized" ||
+		event.Fields["amount"] != int64(12) {
+		t.Errorf("Unexpected event entry %+v", event)
+	}
+	if exception.Level != gologger.LevelError || exception.Fields["exception.message"] != "card declined" {
+		t.Errorf("Unexpected exception entry %+v", exception)
+	}
+	if charge.Level != gologger.LevelError || charge.Message != "span ended" || charge.Fields["span"] != "charge" ||
+		charge.Fields["error"] != "card declined" || charge.Fields["card"] != "visa" || charge.Fields["span_kind"] != "internal" ||
+		charge.Fields["parent_span_id"] != parent.SpanContext().SpanID().String() {
+		t.Errorf("Unexpected span entry %+v", charge)
+	}
+	if _, ok := charge.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", charge.Fields)
+	}
+	if checkout.Level != gologger.LevelInfo || checkout.Fields["span"] != "checkout" || checkout.Fields["status"] != "Unset" {
+		t.Errorf("Unexpected parent span entry %+v", checkout)
+	}
+	if _, ok := checkout.Fields["parent_span_id"]; ok {
+		t.Errorf("Expected no parent for the root span, got %v", checkout.Fields)
+	}
+}
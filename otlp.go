@@ -0,0 +1,427 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTLP transport protocols.
+const (
+	OTLPProtocolHTTPJSON     = "http/json"
+	OTLPProtocolHTTPProtobuf = "http/protobuf"
+	OTLPProtocolGRPC         = "grpc"
+)
+
+const (
+	otlpLogsPath   = "/v1/logs"
+	otlpGRPCMethod = "/opentelemetry.proto.collector.logs.v1.LogsService/Export"
+	otlpScopeName  = "go.risoftinc.com/gologger"
+)
+
+// OTLPConfig holds configuration options for the OTLP logs exporter.
+type OTLPConfig struct {
+	Endpoint           string            // Collector URL (default: "http://localhost:4318"); "/v1/logs" is appended for HTTP when no path is given
+	Protocol           string            // Protocol: OTLPProtocolHTTPJSON, OTLPProtocolHTTPProtobuf or OTLPProtocolGRPC (default: OTLPProtocolHTTPJSON)
+	Headers            map[string]string // Extra request headers, e.g. authentication tokens
+	ServiceName        string            // Value of the service.name resource attribute (optional)
+	ResourceAttributes map[string]any    // Additional resource attributes (optional)
+	BatchSize          int               // Maximum entries per export request (default: 100)
+	FlushInterval      time.Duration     // Maximum time an entry waits before export (default: 1s)
+	Timeout            time.Duration     // Timeout for a single export request (default: 10s)
+	HTTPClient         *http.Client      // HTTP client used for exports (optional, overrides TLS)
+	TLS                *TLSConfig        // TLS client options, e.g. custom CA or mTLS (optional)
+	Compression        string            // Payload compression: CompressionNone, CompressionGzip or CompressionZstd (default: none)
+}
+
+// OTLPSink exports entries to an OpenTelemetry collector as OTLP log records.
+// The gRPC protocol is spoken over HTTP/2, which the standard library only
+// negotiates for https endpoints; use an HTTP protocol for plaintext collectors.
+type OTLPSink struct {
+	endpoint string
+	protocol string
+	headers  map[string]string
+	resource map[string]any
+	timeout  time.Duration
+	client   *http.Client
+	compress string
+	err      error // configuration error returned by every export
+	batch    *batcher
+}
+
+// NewOTLPSink creates an OTLP sink. Unset options fall back to their defaults.
+func NewOTLPSink(config OTLPConfig) *OTLPSink {
+	protocol := config.Protocol
+	if protocol == "" {
+		protocol = OTLPProtocolHTTPJSON
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "http://localhost:4318"
+	}
+	if u, err := url.Parse(endpoint); err == nil && (u.Path == "" || u.Path == "/") {
+		u.Path = otlpLogsPath
+		if protocol == OTLPProtocolGRPC {
+			u.Path = otlpGRPCMethod
+		}
+		endpoint = u.String()
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	client, err := newHTTPClient(config.HTTPClient, config.TLS)
+
+	resource := make(map[string]any, len(config.ResourceAttributes)+1)
+	for key, value := range config.ResourceAttributes {
+		resource[key] = value
+	}
+	if config.ServiceName != "" {
+		resource["service.name"] = config.ServiceName
+	}
+
+	s := &OTLPSink{
+		endpoint: endpoint,
+		protocol: protocol,
+		headers:  config.Headers,
+		resource: resource,
+		timeout:  timeout,
+		client:   client,
+		compress: config.Compression,
+		err:      err,
+	}
+	s.batch = newBatcher(batchSize, flushInterval, s.export)
+	return s
+}
+
+// Write queues an entry for export.
+func (s *OTLPSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync exports all queued entries.
+func (s *OTLPSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close exports all queued entries and stops the background flush.
+func (s *OTLPSink) Close() error {
+	return s.batch.Close()
+}
+
+// export sends a batch of entries in a single request.
+func (s *OTLPSink) export(entries []Entry) error {
+	if s.err != nil {
+		return fmt.Errorf("otlp: %w", s.err)
+	}
+
+	var body []byte
+	var contentType string
+	switch s.protocol {
+	case OTLPProtocolHTTPProtobuf, OTLPProtocolGRPC:
+		body = s.marshalProto(entries)
+		contentType = "application/x-protobuf"
+	default:
+		data, err := json.Marshal(s.jsonRequest(entries))
+		if err != nil {
+			return fmt.Errorf("otlp: marshal request: %w", err)
+		}
+		body = data
+		contentType = "application/json"
+	}
+
+	body, err := compressPayload(body, s.compress)
+	if err != nil {
+		return fmt.Errorf("otlp: compress: %w", err)
+	}
+
+	if s.protocol == OTLPProtocolGRPC {
+		frame := make([]byte, 5, 5+len(body))
+		if s.compress != CompressionNone {
+			frame[0] = 1 // compressed-flag
+		}
+		binary.BigEndian.PutUint32(frame[1:], uint32(len(body)))
+		body = append(frame, body...)
+		contentType = "application/grpc"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("otlp: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	if s.protocol == OTLPProtocolGRPC {
+		req.Header.Set("TE", "trailers")
+		if s.compress != CompressionNone {
+			req.Header.Set("Grpc-Encoding", s.compress)
+		}
+	} else if s.compress != CompressionNone {
+		req.Header.Set("Content-Encoding", s.compress)
+	}
+	for key, value := range s.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("otlp: export: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("otlp: export: unexpected status %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if s.protocol == OTLPProtocolGRPC {
+		status := resp.Trailer.Get("Grpc-Status")
+		message := resp.Trailer.Get("Grpc-Message")
+		if status == "" {
+			status = resp.Header.Get("Grpc-Status")
+			message = resp.Header.Get("Grpc-Message")
+		}
+		if status != "" && status != "0" {
+			return fmt.Errorf("otlp: export: grpc status %s: %s", status, message)
+		}
+	}
+	return nil
+}
+
+// otlpSeverity maps a level name to an OTel severity number.
+func otlpSeverity(level string) int {
+	switch level {
+	case LevelDebug:
+		return 5
+	case LevelInfo:
+		return 9
+	case LevelWarn:
+		return 13
+	case LevelError:
+		return 17
+	case "dpanic":
+		return 18
+	case "panic", "fatal":
+		return 21
+	default:
+		return 0
+	}
+}
+
+// otlpRecord is the sink-independent view of an entry in the OTel model.
+type otlpRecord struct {
+	timeUnixNano uint64
+	severity     int
+	severityText string
+	body         string
+	attributes   map[string]any
+	traceID      []byte
+	spanID       []byte
+}
+
+func (s *OTLPSink) record(entry Entry) otlpRecord {
+	rec := otlpRecord{
+		timeUnixNano: uint64(entry.Time.UnixNano()),
+		severity:     otlpSeverity(entry.Level),
+		severityText: strings.ToUpper(entry.Level),
+		body:         entry.Message,
+		attributes:   make(map[string]any, len(entry.Fields)+2),
+	}
+
+	for key, value := range entry.Fields {
+		switch key {
+		case TraceIDField:
+			if id, err := hex.DecodeString(fmt.Sprint(value)); err == nil && len(id) == 16 {
+				rec.traceID = id
+				continue
+			}
+		case SpanIDField:
+			if id, err := hex.DecodeString(fmt.Sprint(value)); err == nil && len(id) == 8 {
+				rec.spanID = id
+				continue
+			}
+		}
+		rec.attributes[key] = value
+	}
+
+	if entry.Caller != "" {
+		file, line := splitCaller(entry.Caller)
+		rec.attributes["code.filepath"] = file
+		if line > 0 {
+			rec.attributes["code.lineno"] = line
+		}
+	}
+	if entry.Stack != "" {
+		rec.attributes["exception.stacktrace"] = entry.Stack
+	}
+	return rec
+}
+
+// jsonRequest builds an ExportLogsServiceRequest in the OTLP/JSON encoding.
+func (s *OTLPSink) jsonRequest(entries []Entry) map[string]any {
+	records := make([]map[string]any, 0, len(entries))
+	observed := strconv.FormatInt(time.Now().UnixNano(), 10)
+	for _, entry := range entries {
+		rec := s.record(entry)
+		record := map[string]any{
+			"timeUnixNano":         strconv.FormatUint(rec.timeUnixNano, 10),
+			"observedTimeUnixNano": observed,
+			"severityNumber":       rec.severity,
+			"severityText":         rec.severityText,
+			"body":                 otlpJSONValue(rec.body),
+			"attributes":           otlpJSONKeyValues(rec.attributes),
+		}
+		if rec.traceID != nil {
+			record["traceId"] = hex.EncodeToString(rec.traceID)
+		}
+		if rec.spanID != nil {
+			record["spanId"] = hex.EncodeToString(rec.spanID)
+		}
+		records = append(records, record)
+	}
+
+	return map[string]any{
+		"resourceLogs": []any{map[string]any{
+			"resource": map[string]any{"attributes": otlpJSONKeyValues(s.resource)},
+			"scopeLogs": []any{map[string]any{
+				"scope":      map[string]any{"name": otlpScopeName},
+				"logRecords": records,
+			}},
+		}},
+	}
+}
+
+func otlpJSONKeyValues(fields map[string]any) []any {
+	values := make([]any, 0, len(fields))
+	for _, key := range sortedKeys(fields) {
+		values = append(values, map[string]any{"key": key, "value": otlpJSONValue(fields[key])})
+	}
+	return values
+}
+
+func otlpJSONValue(v any) map[string]any {
+	switch v := normalizeValue(v).(type) {
+	case string:
+		return map[string]any{"stringValue": v}
+	case bool:
+		return map[string]any{"boolValue": v}
+	case int64:
+		return map[string]any{"intValue": strconv.FormatInt(v, 10)}
+	case uint64:
+		if v > math.MaxInt64 {
+			return map[string]any{"stringValue": strconv.FormatUint(v, 10)}
+		}
+		return map[string]any{"intValue": strconv.FormatUint(v, 10)}
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return map[string]any{"stringValue": strconv.FormatFloat(v, 'g', -1, 64)}
+		}
+		return map[string]any{"doubleValue": v}
+	case []byte:
+		return map[string]any{"bytesValue": base64.StdEncoding.EncodeToString(v)}
+	case []any:
+		values := make([]any, 0, len(v))
+		for _, item := range v {
+			values = append(values, otlpJSONValue(item))
+		}
+		return map[string]any{"arrayValue": map[string]any{"values": values}}
+	case map[string]any:
+		return map[string]any{"kvlistValue": map[string]any{"values": otlpJSONKeyValues(v)}}
+	default:
+		return map[string]any{}
+	}
+}
+
+// marshalProto builds an ExportLogsServiceRequest in the protobuf encoding.
+func (s *OTLPSink) marshalProto(entries []Entry) []byte {
+	observed := uint64(time.Now().UnixNano())
+
+	var scopeLogs []byte
+	scopeLogs = appendBytesField(scopeLogs, 1, appendStringField(nil, 1, otlpScopeName))
+	for _, entry := range entries {
+		rec := s.record(entry)
+		var record []byte
+		record = appendFixed64Field(record, 1, rec.timeUnixNano)
+		record = appendVarintField(record, 2, uint64(rec.severity))
+		record = appendStringField(record, 3, rec.severityText)
+		record = appendBytesField(record, 5, appendOTLPValue(nil, rec.body))
+		record = appendOTLPKeyValues(record, 6, rec.attributes)
+		if rec.traceID != nil {
+			record = appendBytesField(record, 9, rec.traceID)
+		}
+		if rec.spanID != nil {
+			record = appendBytesField(record, 10, rec.spanID)
+		}
+		record = appendFixed64Field(record, 11, observed)
+		scopeLogs = appendBytesField(scopeLogs, 2, record)
+	}
+
+	var resourceLogs []byte
+	resourceLogs = appendBytesField(resourceLogs, 1, appendOTLPKeyValues(nil, 1, s.resource))
+	resourceLogs = appendBytesField(resourceLogs, 2, scopeLogs)
+
+	return appendBytesField(nil, 1, resourceLogs)
+}
+
+// appendOTLPKeyValues appends fields as repeated KeyValue messages.
+func appendOTLPKeyValues(b []byte, field int, fields map[string]any) []byte {
+	for _, key := range sortedKeys(fields) {
+		kv := appendStringField(nil, 1, key)
+		kv = appendBytesField(kv, 2, appendOTLPValue(nil, fields[key]))
+		b = appendBytesField(b, field, kv)
+	}
+	return b
+}
+
+// appendOTLPValue appends the body of an AnyValue message.
+func appendOTLPValue(b []byte, v any) []byte {
+	switch v := normalizeValue(v).(type) {
+	case string:
+		return appendStringField(b, 1, v)
+	case bool:
+		return appendBoolField(b, 2, v)
+	case int64:
+		return appendVarintField(b, 3, uint64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return appendStringField(b, 1, strconv.FormatUint(v, 10))
+		}
+		return appendVarintField(b, 3, v)
+	case float64:
+		return appendDoubleField(b, 4, v)
+	case []byte:
+		return appendBytesField(b, 7, v)
+	case []any:
+		var values []byte
+		for _, item := range v {
+			values = appendBytesField(values, 1, appendOTLPValue(nil, item))
+		}
+		return appendBytesField(b, 5, values)
+	case map[string]any:
+		return appendBytesField(b, 6, appendOTLPKeyValues(nil, 1, v))
+	default:
+		return b
+	}
+}
@@ -0,0 +1,205 @@
+package gologger
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testOTLPEntry() Entry {
+	return Entry{
+		Time:    time.Unix(1700000000, 5),
+		Level:   LevelError,
+		Message: "payment failed",
+		Caller:  "billing/charge.go:88",
+		Fields: map[string]any{
+			"request-id": "req-9",
+			"amount":     int64(1250),
+			"retry":      true,
+			TraceIDField: "4bf92f3577b34da6a3ce929d0e0e4736",
+			SpanIDField:  "00f067aa0ba902b7",
+		},
+	}
+}
+
+func TestOTLPSinkHTTPJSON(t *testing.T) {
+	received := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/logs" {
+			t.Errorf("Expected path /v1/logs, got %s", r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Expected JSON content type, got %s", ct)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token" {
+			t.Errorf("Expected Authorization header, got %s", auth)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		received <- body
+	}))
+	defer server.Close()
+
+	sink := NewOTLPSink(OTLPConfig{
+		Endpoint:    server.URL,
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "billing",
+	})
+	if err := sink.Write(testOTLPEntry()); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	body := <-received
+	resourceLogs := body["resourceLogs"].([]any)[0].(map[string]any)
+	resourceAttrs := resourceLogs["resource"].(map[string]any)["attributes"].([]any)
+	serviceName := resourceAttrs[0].(map[string]any)
+	if serviceName["key"] != "service.name" {
+		t.Errorf("Expected service.name resource attribute, got %v", serviceName)
+	}
+
+	scopeLogs := resourceLogs["scopeLogs"].([]any)[0].(map[string]any)
+	record := scopeLogs["logRecords"].([]any)[0].(map[string]any)
+
+	if record["severityNumber"] != float64(17) {
+		t.Errorf("Expected severity 17, got %v", record["severityNumber"])
+	}
+	if record["severityText"] != "ERROR" {
+		t.Errorf("Expected severity text ERROR, got %v", record["severityText"])
+	}
+	if record["timeUnixNano"] != "1700000000000000005" {
+		t.Errorf("Unexpected timestamp %v", record["timeUnixNano"])
+	}
+	if record["traceId"] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("Unexpected trace ID %v", record["traceId"])
+	}
+	if record["spanId"] != "00f067aa0ba902b7" {
+		t.Errorf("Unexpected span ID %v", record["spanId"])
+	}
+	if body := record["body"].(map[string]any); body["stringValue"] != "payment failed" {
+		t.Errorf("Unexpected body %v", body)
+	}
+
+	attrs := map[string]any{}
+	for _, item := range record["attributes"].([]any) {
+		kv := item.(map[string]any)
+		attrs[kv["key"].(string)] = kv["value"]
+	}
+	if _, ok := attrs[TraceIDField]; ok {
+		t.Error("Expected trace ID to be moved out of the attributes")
+	}
+	if v := attrs["amount"].(map[string]any); v["intValue"] != "1250" {
+		t.Errorf("Unexpected amount attribute %v", v)
+	}
+	if v := attrs["retry"].(map[string]any); v["boolValue"] != true {
+		t.Errorf("Unexpected retry attribute %v", v)
+	}
+	if v := attrs["code.filepath"].(map[string]any); v["stringValue"] != "billing/charge.go" {
+		t.Errorf("Unexpected code.filepath attribute %v", v)
+	}
+}
+
+func TestOTLPSinkHTTPProtobuf(t *testing.T) {
+	received := make(chan []byte, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-protobuf" {
+			t.Errorf("Expected protobuf content type, got %s", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		received <- body
+	}))
+	defer server.Close()
+
+	sink := NewOTLPSink(OTLPConfig{Endpoint: server.URL, Protocol: OTLPProtocolHTTPProtobuf})
+	_ = sink.Write(testOTLPEntry())
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	body := <-received
+	if len(body) == 0 || body[0] != 0x0a {
+		t.Fatalf("Expected body to start with resource_logs field tag, got %x", body)
+	}
+	if !strings.Contains(string(body), "payment failed") {
+		t.Error("Expected body to contain the message")
+	}
+}
+
+func TestOTLPSinkGRPC(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/opentelemetry.proto.collector.logs.v1.LogsService/Export" {
+			t.Errorf("Unexpected gRPC path %s", r.URL.Path)
+		}
+		if r.ProtoMajor != 2 {
+			t.Errorf("Expected HTTP/2, got %s", r.Proto)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if len(body) < 5 || int(binary.BigEndian.Uint32(body[1:5])) != len(body)-5 {
+			t.Errorf("Invalid gRPC frame")
+		}
+		w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+		w.Header().Set("Content-Type", "application/grpc")
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Grpc-Status", "3")
+		w.Header().Set("Grpc-Message", "invalid argument")
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	sink := NewOTLPSink(OTLPConfig{
+		Endpoint:   server.URL,
+		Protocol:   OTLPProtocolGRPC,
+		HTTPClient: server.Client(),
+	})
+	_ = sink.Write(testOTLPEntry())
+
+	err := sink.Close()
+	if err == nil || !strings.Contains(err.Error(), "grpc status 3") {
+		t.Errorf("Expected grpc status error, got %v", err)
+	}
+}
+
+func TestOTLPSinkReportsHTTPErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	sink := NewOTLPSink(OTLPConfig{Endpoint: server.URL})
+	_ = sink.Write(testOTLPEntry())
+
+	if err := sink.Sync(); err == nil || !strings.Contains(err.Error(), "quota exceeded") {
+		t.Errorf("Expected export error, got %v", err)
+	}
+	_ = sink.Close()
+}
+
+func TestOTLPSeverity(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected int
+	}{
+		{LevelDebug, 5},
+		{LevelInfo, 9},
+		{LevelWarn, 13},
+		{LevelError, 17},
+		{"fatal", 21},
+		{"unknown", 0},
+	}
+
+	for _, test := range tests {
+		if result := otlpSeverity(test.level); result != test.expected {
+			t.Errorf("otlpSeverity(%s): expected %d, got %d", test.level, test.expected, result)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package gologger
+
+import (
+	"io"
+	"os"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Output types of OutputConfig, in addition to OutputTerminal, OutputStdout,
+// OutputFile and OutputDiscard.
+const (
+	OutputStderr  = "stderr"  // all entries to stderr, without colors
+	OutputNetwork = "network" // entries streamed to a collector, see NewNetworkSink
+)
+
+// outputTypes lists the valid OutputConfig types.
+var outputTypes = []string{OutputTerminal, OutputStdout, OutputStderr, OutputFile, OutputNetwork, OutputDiscard}
+
+// OutputConfig describes one output of LoggerConfig.Outputs with its own
+// encoding, level and destination. Options of other output types are ignored.
+type OutputConfig struct {
+	Type        string             // Output type: OutputTerminal (stderr, colored on terminals), OutputStdout, OutputStderr, OutputFile, OutputNetwork or OutputDiscard (required)
+	Encoding    string             // Encoding of the output; network outputs support EncodingJSON, EncodingMsgPack and EncodingProtobuf (default: LoggerConfig.Encoding, or EncodingJSON for network outputs)
+	Level       string             // Minimum level of the output, above the logger's level, e.g. LevelWarn for a terminal next to a debug file (default: the logger's level)
+	LogDir      string             // Directory of a file output (default: LoggerConfig.LogDir)
+	Name        string             // File name prefix of a file output, e.g. "audit" for "audit-2024-06-15.log" (default: "logger")
+	LogRotation *LogRotationConfig // Rotation of a file output (default: LoggerConfig.LogRotation)
+	Network     *NetworkConfig     // Collector of a network output; Encoding replaces its Encoding if set (required for network outputs)
+}
+
+// configuredOutputs creates the cores of LoggerConfig.Outputs, together with
+// the log file writers and cleanup functions of the outputs.
+func configuredOutputs(config LoggerConfig, level zapcore.LevelEnabler, stats *loggerStats) ([]zapcore.Core, []*rotatingFile, []func() error) {
+	var cores []zapcore.Core
+	var files []*rotatingFile
+	var closers []func() error
+	for _, output := range config.Outputs {
+		enabler := level
+		if output.Level != "" {
+			min := getLogLevel(output.Level)
+			enabler = zap.LevelEnablerFunc(func(l zapcore.Level) bool {
+				return l >= min && level.Enabled(l)
+			})
+		}
+		encoding := outputEncoding(output.Encoding, config.Encoding)
+		name := output.Type
+		if output.Name != "" {
+			name += "-" + output.Name
+		}
+
+		switch output.Type {
+		case OutputTerminal:
+			encoder := getEncoder(encoding, config, terminalSupportsColor(os.Stderr))
+			cores = append(cores, zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{os.Stderr}), name, stats}, enabler))
+		case OutputStdout, OutputStderr:
+			stream := os.Stdout
+			if output.Type == OutputStderr {
+				stream = os.Stderr
+			}
+			encoder := getEncoder(encoding, config, false)
+			cores = append(cores, zapcore.NewCore(encoder, reportingWriteSyncer{zapcore.Lock(stdStream{stream}), name, stats}, enabler))
+		case OutputFile:
+			logDir := output.LogDir
+			if logDir == "" {
+				logDir = config.LogDir
+			}
+			if logDir == "" {
+				logDir = DefaultLogDir(config.ServiceName)
+			}
+			fileName := output.Name
+			if fileName == "" {
+				fileName = "logger"
+			}
+			rotation := output.LogRotation
+			if rotation == nil {
+				rotation = config.LogRotation
+			}
+			file := getLogWriter(logDir, fileName, rotation)
+			fileCore, fileClosers := fileOutput(file, name, rotation, config, getEncoder(encoding, config, false), enabler, stats)
+			files = append(files, file)
+			closers = append(closers, fileClosers...)
+			cores = append(cores, fileCore)
+		case OutputNetwork:
+			var network NetworkConfig
+			if output.Network != nil {
+				network = *output.Network
+			}
+			if output.Encoding != "" {
+				network.Encoding = output.Encoding
+			}
+			sink := NewNetworkSink(network)
+			cores = append(cores, &sinkCore{LevelEnabler: enabler, sink: sink, name: name, stats: stats})
+			closers = append(closers, sink.Close)
+		case OutputDiscard:
+			cores = append(cores, zapcore.NewCore(getEncoder(encoding, config, false), zapcore.AddSync(io.Discard), enabler))
+		}
+	}
+	return cores, files, closers
+}
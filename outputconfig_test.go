@@ -0,0 +1,131 @@
+package gologger
+
+import (
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutputs(t *testing.T) {
+	tempDir := t.TempDir()
+	stdoutR, stdoutW, _ := os.Pipe()
+	origStdout := os.Stdout
+	os.Stdout = stdoutW
+	defer func() {
+		os.Stdout = origStdout
+	}()
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputBoth,
+		LogLevel:   LevelDebug,
+		LogDir:     tempDir,
+		Outputs: []OutputConfig{
+			{Type: OutputStdout, Encoding: EncodingConsole, Level: LevelWarn},
+			{Type: OutputFile},
+			{Type: OutputFile, Name: "audit", Encoding: EncodingCLEF, Level: LevelError},
+		},
+	})
+	log.Debug("debug message").Send()
+	log.Warn("warn message").Data("user", "alice").Send()
+	log.Error("error message").Send()
+	log.Close()
+
+	stdoutW.Close()
+	stdout, _ := io.ReadAll(stdoutR)
+	if strings.Contains(string(stdout), "debug message") || !strings.Contains(string(stdout), "warn message") || strings.Contains(string(stdout), `"msg"`) {
+		t.Errorf("Expected console entries from warn on stdout, got %q", stdout)
+	}
+
+	date := time.Now().Format("2006-01-02")
+	main, _ := os.ReadFile(filepath.Join(tempDir, "logger-"+date+".log"))
+	if !strings.Contains(string(main), `"msg":"debug message"`) || !strings.Contains(string(main), `"msg":"error message"`) {
+		t.Errorf("Expected every entry as JSON in the main file, got %q", main)
+	}
+	audit, _ := os.ReadFile(filepath.Join(tempDir, "audit-"+date+".log"))
+	if !strings.Contains(string(audit), `"@m":"error message"`) || strings.Contains(string(audit), "warn message") {
+		t.Errorf("Expected only errors as CLEF in the audit file, got %q", audit)
+	}
+	if strings.Count(string(main), "debug message") != 1 {
+		t.Error("Expected OutputMode to be ignored when Outputs are set")
+	}
+
+	config := log.Config()
+	if config.OutputMode != "" || len(config.Outputs) != 3 || config.Outputs[1].Name != "logger" || config.Outputs[1].Level != LevelDebug || config.Outputs[2].LogDir != tempDir {
+		t.Errorf("Expected the outputs with defaults applied, got %+v", config.Outputs)
+	}
+}
+
+func TestNetworkOutput(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+	received := acceptAll(t, listener)
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		LogLevel: LevelInfo,
+		Encoding: EncodingConsole,
+		Outputs: []OutputConfig{
+			{Type: OutputDiscard},
+			{Type: OutputNetwork, Level: LevelWarn, Network: &NetworkConfig{Addr: listener.Addr().String()}},
+		},
+	})
+	log.Info("info message").Send()
+	log.Warn("warn message").Send()
+	log.Close()
+
+	data := string(<-received)
+	if !strings.Contains(data, `"msg":"warn message"`) || strings.Contains(data, "info message") {
+		t.Errorf("Expected the warn entry as JSON on the network output, got %q", data)
+	}
+}
+
+func TestOutputsFromConfigFile(t *testing.T) {
+	path := writeConfigFile(t, "logger.yaml", `
+log_level: info
+outputs:
+  - type: terminal
+    encoding: console
+    level: warn
+  - type: file
+    name: app
+    log_rotation: {max_age: 3}
+  - type: network
+    encoding: msgpack
+    network: {addr: "collector:7000", transport: udp}
+`)
+	config, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(config.Outputs) != 3 || config.Outputs[0].Level != LevelWarn || config.Outputs[1].LogRotation.MaxAge != 3 ||
+		config.Outputs[2].Network.Transport != NetworkTransportUDP {
+		t.Errorf("Unexpected outputs %+v", config.Outputs)
+	}
+}
+
+func TestOutputsValidation(t *testing.T) {
+	err := LoggerConfig{Outputs: []OutputConfig{
+		{},
+		{Type: "kafka"},
+		{Type: OutputFile, Level: "verbose", Encoding: "xml"},
+		{Type: OutputNetwork, Encoding: EncodingConsole},
+	}}.Validate()
+	for _, expected := range []string{
+		"Outputs[0].Type: must be set",
+		`Outputs[1].Type: unknown value "kafka"`,
+		`Outputs[2].Level: unknown value "verbose"`,
+		`Outputs[2].Encoding: unknown value "xml"`,
+		`Outputs[3].Encoding: unknown value "console"`,
+		"Outputs[3].Network.Addr: must be set",
+	} {
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Errorf("Expected error containing %q, got %v", expected, err)
+		}
+	}
+}
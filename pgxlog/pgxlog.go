@@ -0,0 +1,96 @@
+// Package pgxlog logs the queries of pgx v5 connections and pools with
+// gologger:
+//
+//	config, err := pgxpool.ParseConfig(dsn)
+//	config.ConnConfig.Tracer = pgxlog.New(log.Named("db"), gologger.SQLLogConfig{SlowThreshold: 200 * time.Millisecond})
+//	pool, err := pgxpool.NewWithConfig(ctx, config)
+//
+// It is a separate module, so applications not using pgx do not depend on
+// it. Applications using pgx through database/sql can use
+// gologger.WrapSQLConnector instead.
+package pgxlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"go.risoftinc.com/gologger"
+)
+
+// Tracer is a pgx.QueryTracer logging the queries of a connection.
+type Tracer struct {
+	log    gologger.Logger
+	level  string
+	slow   time.Duration
+	redact bool
+}
+
+var _ pgx.QueryTracer = (*Tracer)(nil)
+
+// queryKey is the context key of the query traced by TraceQueryStart.
+type queryKey struct{}
+
+// tracedQuery is a query started with its start time.
+type tracedQuery struct {
+	start time.Time
+	sql   string
+	args  []any
+}
+
+// New returns a tracer logging with log. Each call to Query, QueryRow or
+// Exec is logged as "sql query" with the statement as "query", its arguments
+// as "args", the number of rows returned or affected as "rows" and its
+// duration, as the database/sql wrappers of gologger log statements. Entries
+// carry the request ID of the context passed to pgx.
+//
+// Successful queries are logged at config.Level, slow ones at warn and failed
+// ones at error level, with the error. With config.RedactArgs, arguments are
+// logged as "[REDACTED]".
+func New(log gologger.Logger, config gologger.SQLLogConfig) *Tracer {
+	level := config.Level
+	if level == "" {
+		level = gologger.LevelDebug
+	}
+	return &Tracer{log: log, level: level, slow: config.SlowThreshold, redact: config.RedactArgs}
+}
+
+// TraceQueryStart keeps the query and its start time in the returned
+// context, which pgx passes to TraceQueryEnd.
+func (t *Tracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryKey{}, &tracedQuery{start: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+// TraceQueryEnd logs the query started by TraceQueryStart.
+func (t *Tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	query, ok := ctx.Value(queryKey{}).(*tracedQuery)
+	if !ok {
+		return
+	}
+	duration := time.Since(query.start)
+	level := t.level
+	switch {
+	case data.Err != nil:
+		level = gologger.LevelError
+	case t.slow > 0 && duration >= t.slow && level != gologger.LevelError:
+		level = gologger.LevelWarn
+	}
+	entry := t.log.WithContext(ctx).Log(level, "sql query").
+		ErrorData(data.Err).
+		Data("duration_ms", float64(duration)/float64(time.Millisecond)).
+		Data("query", query.sql)
+	if len(query.args) > 0 {
+		args := query.args
+		if t.redact {
+			args = make([]any, len(query.args))
+			for i := range args {
+				args[i] = "[REDACTED]"
+			}
+		}
+		entry = entry.Data("args", args)
+	}
+	if data.Err == nil {
+		entry = entry.Data("rows", data.CommandTag.RowsAffected())
+	}
+	entry.Send()
+}
@@ -0,0 +1,82 @@
+package pgxlog
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.risoftinc.com/gologger"
+)
+
+// trace runs a query through tracer as pgx does, taking delay.
+func trace(tracer *Tracer, ctx context.Context, sql string, args []any, tag string, err error, delay time.Duration) {
+	ctx = tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql, Args: args})
+	time.Sleep(delay)
+	tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag(tag), Err: err})
+}
+
+func TestTracer(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	tracer := New(log, gologger.SQLLogConfig{})
+	ctx := gologger.WithRequestID(context.Background(), "req-42")
+
+	trace(tracer, ctx, "SELECT name FROM users WHERE id = $1", []any{7}, "SELECT 1", nil, 0)
+	trace(tracer, ctx, "UPDATE users SET name = $1", []any{"alice"}, "", errors.New("permission denied"), 0)
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v", entries)
+	}
+	query := entries[0]
+	if query.Level != gologger.LevelDebug || query.Message != "sql query" || query.Fields["request-id"] != "req-42" ||
+		query.Fields["query"] != "SELECT name FROM users WHERE id = $1" || query.Fields["rows"] != int64(1) {
+		t.Errorf("Unexpected entry %+v", query)
+	}
+	if args, _ := query.Fields["args"].([]any); len(args) != 1 || args[0] != 7 {
+		t.Errorf("Expected the arguments, got %v", query.Fields["args"])
+	}
+	if _, ok := query.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", query.Fields)
+	}
+	failed := entries[1]
+	if failed.Level != gologger.LevelError || failed.Fields["error"] != "permission denied" {
+		t.Errorf("Unexpected failed entry %+v", failed)
+	}
+	if _, ok := failed.Fields["rows"]; ok {
+		t.Errorf("Expected no rows for a failed query, got %v", failed.Fields)
+	}
+}
+
+func TestTracerConfig(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	tracer := New(log, gologger.SQLLogConfig{Level: gologger.LevelInfo, SlowThreshold: 5 * time.Millisecond, RedactArgs: true})
+
+	trace(tracer, context.Background(), "SELECT 1 WHERE $1", []any{"secret"}, "SELECT 1", nil, 0)
+	trace(tracer, context.Background(), "SELECT pg_sleep(1)", nil, "SELECT 1", nil, 10*time.Millisecond)
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v", entries)
+	}
+	if args, _ := entries[0].Fields["args"].([]any); entries[0].Level != gologger.LevelInfo || len(args) != 1 || args[0] != "[REDACTED]" {
+		t.Errorf("Expected redacted arguments at info level, got %+v", entries[0])
+	}
+	if entries[1].Level != gologger.LevelWarn {
+		t.Errorf("Expected a slow query at warn level, got %s", entries[1].Level)
+	}
+	if _, ok := entries[1].Fields["args"]; ok {
+		t.Errorf("Expected no args for a query without arguments, got %v", entries[1].Fields)
+	}
+}
+
+func TestTracerUnstarted(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	New(log, gologger.SQLLogConfig{}).TraceQueryEnd(context.Background(), nil, pgx.TraceQueryEndData{})
+
+	if capture.Len() != 0 {
+		t.Errorf("Expected queries not started by the tracer to be ignored, got %+v", capture.Entries())
+	}
+}
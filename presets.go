@@ -0,0 +1,41 @@
+package gologger
+
+// NewDevelopment creates a Logger for local development: colored console
+// output on the terminal at debug level, with caller information and stack
+// traces for warnings and errors, like zap.NewDevelopment.
+func NewDevelopment() Logger {
+	return NewLoggerWithConfig(DevelopmentConfig())
+}
+
+// NewProduction creates a Logger for production: JSON on the terminal at
+// info level, sampled and with stack traces for errors, like
+// zap.NewProduction.
+func NewProduction() Logger {
+	return NewLoggerWithConfig(ProductionConfig())
+}
+
+// DevelopmentConfig returns the configuration of NewDevelopment, to adjust
+// before calling NewLoggerWithConfig.
+func DevelopmentConfig() LoggerConfig {
+	return LoggerConfig{
+		OutputMode:      OutputTerminal,
+		LogLevel:        LevelDebug,
+		ShowCaller:      true,
+		Encoding:        EncodingConsole,
+		StacktraceLevel: LevelWarn,
+	}
+}
+
+// ProductionConfig returns the configuration of NewProduction, to adjust
+// before calling NewLoggerWithConfig. Entries are sampled per second: the
+// first 100 with the same level and message, then every 100th.
+func ProductionConfig() LoggerConfig {
+	return LoggerConfig{
+		OutputMode:      OutputTerminal,
+		LogLevel:        LevelInfo,
+		ShowCaller:      true,
+		Encoding:        EncodingJSON,
+		StacktraceLevel: LevelError,
+		Sampling:        &SamplingConfig{Initial: 100, Thereafter: 100},
+	}
+}
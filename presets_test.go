@@ -0,0 +1,47 @@
+package gologger
+
+import (
+	"testing"
+)
+
+func TestPresetConfigs(t *testing.T) {
+	dev := DevelopmentConfig()
+	if dev.Encoding != EncodingConsole || dev.LogLevel != LevelDebug || !dev.ShowCaller || dev.StacktraceLevel != LevelWarn || dev.Sampling != nil {
+		t.Errorf("Unexpected development config: %+v", dev)
+	}
+	prod := ProductionConfig()
+	if prod.Encoding != EncodingJSON || prod.LogLevel != LevelInfo || prod.StacktraceLevel != LevelError || prod.Sampling == nil {
+		t.Errorf("Unexpected production config: %+v", prod)
+	}
+	for _, config := range []LoggerConfig{dev, prod} {
+		if err := config.Validate(); err != nil {
+			t.Errorf("Unexpected validation error: %v", err)
+		}
+	}
+	NewDevelopment().Close()
+	NewProduction().Close()
+}
+
+func TestPresetStacktraces(t *testing.T) {
+	capture := NewCaptureSink()
+	config := ProductionConfig()
+	config.OutputMode = OutputDiscard
+	config.Sinks = []Sink{capture}
+	log := NewLoggerWithConfig(config)
+	defer log.Close()
+
+	log.Debug("dropped").Send()
+	log.Warn("warned").Send()
+	log.Error("failed").Send()
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries at info and above, got %+v", entries)
+	}
+	if entries[0].Stack != "" {
+		t.Errorf("Expected no stack trace for warnings, got %q", entries[0].Stack)
+	}
+	if entries[1].Stack == "" {
+		t.Error("Expected a stack trace for errors")
+	}
+}
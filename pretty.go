@@ -0,0 +1,43 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// prettyJSONEncoder wraps a JSON encoder and indents each entry over
+// multiple lines, so large nested payloads stay readable on a terminal.
+type prettyJSONEncoder struct {
+	zapcore.Encoder
+}
+
+func newPrettyJSONEncoder(inner zapcore.Encoder) *prettyJSONEncoder {
+	return &prettyJSONEncoder{Encoder: inner}
+}
+
+func (e *prettyJSONEncoder) Clone() zapcore.Encoder {
+	return &prettyJSONEncoder{Encoder: e.Encoder.Clone()}
+}
+
+func (e *prettyJSONEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	compact, err := e.Encoder.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	defer compact.Free()
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, bytes.TrimRight(compact.Bytes(), "\n"), "", "  "); err != nil {
+		// Fall back to the compact line rather than dropping the entry.
+		buf := consoleBufferPool.Get()
+		buf.Write(compact.Bytes())
+		return buf, nil
+	}
+	buf := consoleBufferPool.Get()
+	buf.Write(indented.Bytes())
+	buf.AppendByte('\n')
+	return buf, nil
+}
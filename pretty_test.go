@@ -0,0 +1,71 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestPrettyJSONEncoder(t *testing.T) {
+	var out bytes.Buffer
+	enc := getEncoder(EncodingPretty, LoggerConfig{}, false)
+	core := zapcore.NewCore(enc, zapcore.AddSync(&out), zapcore.DebugLevel)
+	zap.New(core).With(zap.String("service", "billing")).Info("payload received",
+		zap.Any("payload", map[string]any{"items": []int{1, 2}}))
+
+	expected := []string{
+		`  "msg": "payload received",`,
+		`  "service": "billing",`,
+		`  "payload": {`,
+		`    "items": [`,
+		`      1,`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(out.String(), want+"\n") {
+			t.Errorf("Expected line %q in:\n%s", want, out.String())
+		}
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(out.Bytes(), &doc); err != nil {
+		t.Errorf("Expected valid JSON, got %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "}\n") {
+		t.Errorf("Expected entry to end with a newline, got %q", out.String())
+	}
+}
+
+func TestPrettyTerminalCompactFile(t *testing.T) {
+	tempDir := t.TempDir()
+	stderrR, stderrW, _ := os.Pipe()
+	origStderr := os.Stderr
+	os.Stderr = stderrW
+	defer func() {
+		os.Stderr = origStderr
+	}()
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:       OutputBoth,
+		LogDir:           tempDir,
+		TerminalEncoding: EncodingPretty,
+	})
+	log.Info("pretty message").Data("user", "alice").Send()
+	log.Close()
+
+	stderrW.Close()
+	stderr, _ := io.ReadAll(stderrR)
+	if !strings.HasPrefix(string(stderr), "{\n  ") {
+		t.Errorf("Expected indented JSON on the terminal, got %s", stderr)
+	}
+
+	content := readTestLogFile(t, tempDir)
+	if strings.Count(content, "\n") != 1 || !strings.Contains(content, `"msg":"pretty message"`) {
+		t.Errorf("Expected compact JSON in the file, got %s", content)
+	}
+}
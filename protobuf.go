@@ -0,0 +1,59 @@
+package gologger
+
+import (
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// protobufEncoder writes each entry as a varint length-prefixed LogRecord
+// message, as defined in proto/logrecord.proto.
+type protobufEncoder struct {
+	fieldRecorder
+}
+
+func newProtobufEncoder() *protobufEncoder {
+	return &protobufEncoder{}
+}
+
+func (e *protobufEncoder) Clone() zapcore.Encoder {
+	return &protobufEncoder{fieldRecorder: e.clone()}
+}
+
+func (e *protobufEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := consoleBufferPool.Get()
+	buf.Write(appendProtobufRecord(nil, entryFromZap(ent, e.with(fields))))
+	return buf, nil
+}
+
+// appendProtobufRecord appends the length-prefixed LogRecord for entry to dst.
+func appendProtobufRecord(dst []byte, entry Entry) []byte {
+	var record []byte
+	if !entry.Time.IsZero() {
+		record = appendFixed64Field(record, 1, uint64(entry.Time.UnixNano()))
+	}
+	if level := protobufLevel(entry.Level); level != 0 {
+		record = appendVarintField(record, 2, level)
+	}
+	if entry.Message != "" {
+		record = appendStringField(record, 3, entry.Message)
+	}
+	if entry.Caller != "" {
+		record = appendStringField(record, 4, entry.Caller)
+	}
+	if entry.Stack != "" {
+		record = appendStringField(record, 5, entry.Stack)
+	}
+	record = appendOTLPKeyValues(record, 6, entry.Fields)
+
+	dst = appendVarint(dst, uint64(len(record)))
+	return append(dst, record...)
+}
+
+// protobufLevel maps a level name to its Level enum value.
+func protobufLevel(level string) uint64 {
+	var l zapcore.Level
+	if err := l.UnmarshalText([]byte(level)); err != nil {
+		return 0
+	}
+	return uint64(l-zapcore.DebugLevel) + 1
+}
@@ -0,0 +1,131 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+// readVarint reads a varint from the start of data.
+func readVarint(t *testing.T, data []byte) (uint64, []byte) {
+	t.Helper()
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		t.Fatalf("Invalid varint in %x", data)
+	}
+	return v, data[n:]
+}
+
+// protoFields splits a message into its fields, keyed by field number.
+// Varint and fixed64 fields are returned as their raw value bytes.
+func protoFields(t *testing.T, msg []byte) map[uint64][][]byte {
+	t.Helper()
+	fields := make(map[uint64][][]byte)
+	for len(msg) > 0 {
+		var tag uint64
+		tag, msg = readVarint(t, msg)
+		var value []byte
+		switch tag & 7 {
+		case wireVarint:
+			v, rest := readVarint(t, msg)
+			value = binary.AppendUvarint(nil, v)
+			msg = rest
+		case wireFixed64:
+			value, msg = msg[:8], msg[8:]
+		case wireBytes:
+			var n uint64
+			n, msg = readVarint(t, msg)
+			value, msg = msg[:n], msg[n:]
+		default:
+			t.Fatalf("Unexpected wire type %d", tag&7)
+		}
+		fields[tag>>3] = append(fields[tag>>3], value)
+	}
+	return fields
+}
+
+func TestProtobufRecord(t *testing.T) {
+	ts := time.Date(2024, 3, 1, 10, 30, 0, 123456789, time.UTC)
+	entry := Entry{
+		Time:    ts,
+		Level:   LevelWarn,
+		Message: "disk almost full",
+		Caller:  "monitor/disk.go:42",
+		Stack:   "main.main",
+		Fields:  map[string]any{"usage": 93, "mount": "/var"},
+	}
+
+	data := appendProtobufRecord(nil, entry)
+	length, record := readVarint(t, data)
+	if int(length) != len(record) {
+		t.Fatalf("Length prefix %d does not match record size %d", length, len(record))
+	}
+
+	fields := protoFields(t, record)
+	if got := binary.LittleEndian.Uint64(fields[1][0]); got != uint64(ts.UnixNano()) {
+		t.Errorf("Expected time_unix_nano %d, got %d", ts.UnixNano(), got)
+	}
+	if level, _ := readVarint(t, fields[2][0]); level != 3 {
+		t.Errorf("Expected LEVEL_WARN (3), got %d", level)
+	}
+	for num, want := range map[uint64]string{3: "disk almost full", 4: "monitor/disk.go:42", 5: "main.main"} {
+		if string(fields[num][0]) != want {
+			t.Errorf("Field %d: expected %q, got %q", num, want, fields[num][0])
+		}
+	}
+
+	if len(fields[6]) != 2 {
+		t.Fatalf("Expected 2 Field messages, got %d", len(fields[6]))
+	}
+	mount := protoFields(t, fields[6][0])
+	if string(mount[1][0]) != "mount" || !bytes.Equal(mount[2][0], appendStringField(nil, 1, "/var")) {
+		t.Errorf("Unexpected first field %x", fields[6][0])
+	}
+	usage := protoFields(t, fields[6][1])
+	if string(usage[1][0]) != "usage" || !bytes.Equal(usage[2][0], appendVarintField(nil, 3, 93)) {
+		t.Errorf("Unexpected second field %x", fields[6][1])
+	}
+}
+
+func TestProtobufLevel(t *testing.T) {
+	tests := map[string]uint64{
+		LevelDebug: 1,
+		LevelInfo:  2,
+		LevelWarn:  3,
+		LevelError: 4,
+		"dpanic":   5,
+		"panic":    6,
+		"fatal":    7,
+		"bogus":    0,
+	}
+	for level, want := range tests {
+		if got := protobufLevel(level); got != want {
+			t.Errorf("protobufLevel(%q) = %d, want %d", level, got, want)
+		}
+	}
+}
+
+func TestProtobufEncodingConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogDir:     tempDir,
+		Encoding:   EncodingProtobuf,
+	})
+	log.Info("first").Send()
+	log.Error("second").Send()
+	log.Close()
+
+	data := []byte(readTestLogFile(t, tempDir))
+	var messages []string
+	for len(data) > 0 {
+		var length uint64
+		length, data = readVarint(t, data)
+		messages = append(messages, string(protoFields(t, data[:length])[3][0]))
+		data = data[length:]
+	}
+	if len(messages) != 2 || messages[0] != "first" || messages[1] != "second" {
+		t.Errorf("Expected two length-prefixed records, got %v", messages)
+	}
+}
@@ -0,0 +1,35 @@
+package gologger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAppendVarint(t *testing.T) {
+	tests := []struct {
+		input    uint64
+		expected []byte
+	}{
+		{0, []byte{0x00}},
+		{1, []byte{0x01}},
+		{300, []byte{0xac, 0x02}},
+	}
+
+	for _, test := range tests {
+		if result := appendVarint(nil, test.input); !bytes.Equal(result, test.expected) {
+			t.Errorf("appendVarint(%d): expected %x, got %x", test.input, test.expected, result)
+		}
+	}
+}
+
+func TestAppendFields(t *testing.T) {
+	if result := appendStringField(nil, 2, "testing"); !bytes.Equal(result, []byte{0x12, 0x07, 't', 'e', 's', 't', 'i', 'n', 'g'}) {
+		t.Errorf("Unexpected string field encoding %x", result)
+	}
+	if result := appendVarintField(nil, 1, 150); !bytes.Equal(result, []byte{0x08, 0x96, 0x01}) {
+		t.Errorf("Unexpected varint field encoding %x", result)
+	}
+	if result := appendFixed64Field(nil, 1, 1); !bytes.Equal(result, []byte{0x09, 1, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Errorf("Unexpected fixed64 field encoding %x", result)
+	}
+}
@@ -0,0 +1,203 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	pubsubScope           = "https://www.googleapis.com/auth/pubsub"
+	pubsubMaxMessages     = 1000
+	pubsubMaxRequestBytes = 10 << 20
+)
+
+// PubSubConfig holds configuration options for the Google Cloud Pub/Sub sink.
+type PubSubConfig struct {
+	ProjectID        string                                    // Google Cloud project ID (required)
+	Topic            string                                    // Topic ID (required)
+	Endpoint         string                                    // API endpoint, e.g. a regional endpoint for ordered delivery (default: "https://pubsub.googleapis.com", or PUBSUB_EMULATOR_HOST)
+	CredentialsFile  string                                    // Service account key file (default: GOOGLE_APPLICATION_CREDENTIALS, then the metadata server)
+	TokenSource      func(ctx context.Context) (string, error) // Supplies OAuth2 access tokens, overriding CredentialsFile (optional)
+	OrderingKeyField string                                    // Field whose value becomes the message ordering key, e.g. "request-id" (optional)
+	Attributes       map[string]string                         // Static attributes added to every message (optional)
+	BatchSize        int                                       // Maximum messages per publish request, capped at 1000 (default: 100)
+	FlushInterval    time.Duration                             // Maximum time an entry waits before being published (default: 1s)
+	Timeout          time.Duration                             // Timeout for a single publish request (default: 10s)
+	HTTPClient       *http.Client                              // HTTP client used for API calls (optional)
+}
+
+// PubSubSink publishes entries to a Pub/Sub topic as JSON messages with a
+// "level" attribute. When OrderingKeyField is set, entries carrying that
+// field are published with it as ordering key, so subscribers with message
+// ordering enabled receive them in order.
+type PubSubSink struct {
+	url         string
+	token       func(ctx context.Context) (string, error)
+	orderingKey string
+	attributes  map[string]string
+	timeout     time.Duration
+	client      *http.Client
+	batch       *batcher
+}
+
+// pubsubMessage is a message of a publish request.
+type pubsubMessage struct {
+	Data        []byte            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	OrderingKey string            `json:"orderingKey,omitempty"`
+}
+
+// NewPubSubSink creates a Pub/Sub sink. Unset options fall back to their defaults.
+func NewPubSubSink(config PubSubConfig) *PubSubSink {
+	client := config.HTTPClient
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	endpoint := config.Endpoint
+	token := config.TokenSource
+	if endpoint == "" {
+		if host := os.Getenv("PUBSUB_EMULATOR_HOST"); host != "" {
+			// The emulator accepts unauthenticated plaintext requests.
+			endpoint = "http://" + host
+			if token == nil {
+				token = func(context.Context) (string, error) { return "", nil }
+			}
+		} else {
+			endpoint = "https://pubsub.googleapis.com"
+		}
+	}
+	if token == nil {
+		token = newGCPTokenSource(client, config.CredentialsFile, pubsubScope).Token
+	}
+
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	if batchSize > pubsubMaxMessages {
+		batchSize = pubsubMaxMessages
+	}
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	s := &PubSubSink{
+		url:         strings.TrimRight(endpoint, "/") + "/v1/projects/" + config.ProjectID + "/topics/" + config.Topic + ":publish",
+		token:       token,
+		orderingKey: config.OrderingKeyField,
+		attributes:  config.Attributes,
+		timeout:     timeout,
+		client:      client,
+	}
+	s.batch = newBatcher(batchSize, flushInterval, s.publish)
+	return s
+}
+
+// Write queues an entry for publishing.
+func (s *PubSubSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync publishes all queued entries.
+func (s *PubSubSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close publishes all queued entries and stops the background flush.
+func (s *PubSubSink) Close() error {
+	return s.batch.Close()
+}
+
+// publish encodes a batch of entries and publishes them in as many requests
+// as the request size limit requires.
+func (s *PubSubSink) publish(entries []Entry) error {
+	messages := make([]pubsubMessage, 0, len(entries))
+	for _, entry := range entries {
+		data, err := entryJSON(entry)
+		if err != nil {
+			return fmt.Errorf("pubsub: encode entry: %w", err)
+		}
+		attributes := make(map[string]string, len(s.attributes)+1)
+		for key, value := range s.attributes {
+			attributes[key] = value
+		}
+		attributes["level"] = entry.Level
+
+		message := pubsubMessage{Data: data, Attributes: attributes}
+		if s.orderingKey != "" {
+			if value, ok := entry.Fields[s.orderingKey]; ok {
+				message.OrderingKey = fmt.Sprint(value)
+			}
+		}
+		messages = append(messages, message)
+	}
+
+	// Base64 inflates data by a third; keep well below the request limit.
+	for start := 0; start < len(messages); {
+		end, size := start, 0
+		for end < len(messages) {
+			n := len(messages[end].Data)*4/3 + 256
+			if end > start && size+n > pubsubMaxRequestBytes {
+				break
+			}
+			size += n
+			end++
+		}
+		if err := s.send(messages[start:end]); err != nil {
+			return err
+		}
+		start = end
+	}
+	return nil
+}
+
+// send performs a single publish request.
+func (s *PubSubSink) send(messages []pubsubMessage) error {
+	body, err := json.Marshal(map[string]any{"messages": messages})
+	if err != nil {
+		return fmt.Errorf("pubsub: marshal request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	token, err := s.token(ctx)
+	if err != nil {
+		return fmt.Errorf("pubsub: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("pubsub: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("pubsub: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("pubsub: publish returned %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
@@ -0,0 +1,112 @@
+package gologger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPubSubSinkPublish(t *testing.T) {
+	received := make(chan map[string]any, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/projects/analytics/topics/app-logs:publish" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token-1" {
+			t.Errorf("Unexpected Authorization header %q", auth)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		received <- body
+		_, _ = w.Write([]byte(`{"messageIds":["1","2"]}`))
+	}))
+	defer server.Close()
+
+	sink := NewPubSubSink(PubSubConfig{
+		ProjectID:        "analytics",
+		Topic:            "app-logs",
+		Endpoint:         server.URL,
+		TokenSource:      func(context.Context) (string, error) { return "token-1", nil },
+		OrderingKeyField: "request-id",
+		Attributes:       map[string]string{"service": "billing"},
+	})
+	_ = sink.Write(Entry{Level: LevelInfo, Message: "ordered", Fields: map[string]any{"request-id": "req-5"}})
+	_ = sink.Write(Entry{Level: LevelError, Message: "unordered"})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	messages := (<-received)["messages"].([]any)
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+	first := messages[0].(map[string]any)
+	if first["orderingKey"] != "req-5" {
+		t.Errorf("Expected ordering key req-5, got %v", first["orderingKey"])
+	}
+	attributes := first["attributes"].(map[string]any)
+	if attributes["level"] != LevelInfo || attributes["service"] != "billing" {
+		t.Errorf("Unexpected attributes %v", attributes)
+	}
+	var data []byte
+	_ = json.Unmarshal([]byte(`"`+first["data"].(string)+`"`), &data)
+	if !strings.Contains(string(data), `"msg":"ordered"`) {
+		t.Errorf("Unexpected message data %s", data)
+	}
+
+	second := messages[1].(map[string]any)
+	if _, ok := second["orderingKey"]; ok {
+		t.Error("Expected no ordering key without the field")
+	}
+}
+
+func TestPubSubSinkErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error":{"status":"NOT_FOUND"}}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sink := NewPubSubSink(PubSubConfig{
+		ProjectID:   "p",
+		Topic:       "missing",
+		Endpoint:    server.URL,
+		TokenSource: func(context.Context) (string, error) { return "t", nil },
+	})
+	defer sink.Close()
+	if err := sink.publish([]Entry{{Level: LevelInfo}}); err == nil || !strings.Contains(err.Error(), "NOT_FOUND") {
+		t.Errorf("Expected publish error, got %v", err)
+	}
+
+	failing := NewPubSubSink(PubSubConfig{
+		ProjectID:   "p",
+		Topic:       "t",
+		Endpoint:    server.URL,
+		TokenSource: func(context.Context) (string, error) { return "", errors.New("no credentials") },
+	})
+	defer failing.Close()
+	if err := failing.publish([]Entry{{Level: LevelInfo}}); err == nil || !strings.Contains(err.Error(), "no credentials") {
+		t.Errorf("Expected token error, got %v", err)
+	}
+}
+
+func TestPubSubSinkEmulator(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("Expected no Authorization header for the emulator, got %q", auth)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("PUBSUB_EMULATOR_HOST", strings.TrimPrefix(server.URL, "http://"))
+
+	sink := NewPubSubSink(PubSubConfig{ProjectID: "p", Topic: "t"})
+	defer sink.Close()
+	if err := sink.publish([]Entry{{Level: LevelInfo}}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package gologger
+
+import (
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// rateLimitCore drops the entries of a level beyond its limit per second,
+// set by LoggerConfig.RateLimits, counting them in Stats.
+type rateLimitCore struct {
+	zapcore.Core
+	limiter *rateLimiter
+}
+
+// rateLimiter counts the entries of the limited levels in the current second,
+// shared by the cores returned by With.
+type rateLimiter struct {
+	mu     sync.Mutex
+	limits map[zapcore.Level]int
+	second map[zapcore.Level]int64 // Unix second of the entries counted
+	counts map[zapcore.Level]int
+	stats  *loggerStats
+}
+
+// newRateLimitCore wraps core so entries beyond limits, keyed by level name,
+// are dropped. Unknown level names, rejected by Validate, are ignored.
+func newRateLimitCore(core zapcore.Core, limits map[string]int, stats *loggerStats) zapcore.Core {
+	limiter := &rateLimiter{
+		limits: make(map[zapcore.Level]int, len(limits)),
+		second: make(map[zapcore.Level]int64, len(limits)),
+		counts: make(map[zapcore.Level]int, len(limits)),
+		stats:  stats,
+	}
+	for name, limit := range limits {
+		if level, err := parseLogLevel(name); err == nil {
+			limiter.limits[level] = limit
+		}
+	}
+	return &rateLimitCore{Core: core, limiter: limiter}
+}
+
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), limiter: c.limiter}
+}
+
+func (c *rateLimitCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Core.Enabled(entry.Level) {
+		return checked
+	}
+	if !c.limiter.allow(entry) {
+		c.limiter.stats.countDroppedEntry(entry.Level.String())
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
+
+// allow reports whether entry is within the limit of its level for the
+// second it was logged in, counting it if so.
+func (r *rateLimiter) allow(entry zapcore.Entry) bool {
+	limit, ok := r.limits[entry.Level]
+	if !ok {
+		return true
+	}
+	second := entry.Time.Unix()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if second > r.second[entry.Level] {
+		r.second[entry.Level] = second
+		r.counts[entry.Level] = 0
+	}
+	if r.counts[entry.Level] >= limit {
+		return false
+	}
+	r.counts[entry.Level]++
+	return true
+}
@@ -0,0 +1,71 @@
+package gologger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRateLimits(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelDebug,
+		Sinks:      []Sink{capture},
+		RateLimits: map[string]int{LevelDebug: 3, LevelWarn: 0},
+	})
+	defer log.Close()
+
+	for i := 0; i < 10; i++ {
+		log.Debug("polling").Data("attempt", i).Send()
+		log.Error("request failed").Send()
+	}
+	log.Warn("disk almost full").Send()
+
+	debug := len(capture.FilterLevel(LevelDebug))
+	// The loop may straddle two seconds, each with its own limit.
+	if debug < 3 || debug > 6 {
+		t.Errorf("Expected 3 to 6 debug entries, got %d", debug)
+	}
+	if n := len(capture.FilterLevel(LevelError)); n != 10 {
+		t.Errorf("Expected every error entry, got %d", n)
+	}
+	if n := len(capture.FilterLevel(LevelWarn)); n != 0 {
+		t.Errorf("Expected warn entries to be dropped, got %d", n)
+	}
+
+	dropped := log.Stats().DroppedEntries
+	if dropped[LevelDebug] != uint64(10-debug) || dropped[LevelWarn] != 1 || len(dropped) != 2 {
+		t.Errorf("Expected the dropped entries to be counted per level, got %v", dropped)
+	}
+}
+
+func TestRateLimiterSeconds(t *testing.T) {
+	core := newRateLimitCore(zapcore.NewNopCore(), map[string]int{LevelInfo: 2}, newLoggerStats(nil))
+	limiter := core.(*rateLimitCore).limiter
+	start := time.Unix(1700000000, 0)
+
+	steps := []struct {
+		offset  time.Duration
+		allowed bool
+	}{
+		{0, true},
+		{100 * time.Millisecond, true},
+		{900 * time.Millisecond, false},
+		{time.Second, true},
+		{1500 * time.Millisecond, true},
+		{900 * time.Millisecond, false}, // Late entries count against the current second
+		{1999 * time.Millisecond, false},
+		{3 * time.Second, true},
+	}
+	for i, step := range steps {
+		entry := zapcore.Entry{Level: zapcore.InfoLevel, Time: start.Add(step.offset)}
+		if allowed := limiter.allow(entry); allowed != step.allowed {
+			t.Errorf("Step %d: expected allowed %v, got %v", i, step.allowed, allowed)
+		}
+	}
+	if !limiter.allow(zapcore.Entry{Level: zapcore.ErrorLevel, Time: start}) {
+		t.Error("Expected levels without a limit to be allowed")
+	}
+}
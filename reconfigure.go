@@ -0,0 +1,243 @@
+package gologger
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// outputs holds the cores built from a configuration and the resources they
+// use, which Reconfigure replaces as a whole.
+type outputs struct {
+	core      zapcore.Core         // Outputs, sinks, sampling, component levels and flight recorder, with GlobalFields
+	unsampled zapcore.Core         // core without LoggerConfig.Sampling, for entries with their own sampling
+	level     zapcore.LevelEnabler // Level of the outputs: the component levels or the logger's level
+	files     []*rotatingFile      // Log file outputs: the main file, then per-level files
+	closers   []func() error       // Cleanup functions for the outputs, run when they are replaced or closed
+	sinkIDs   []string             // IDs of the sinks attached from LoggerConfig.Sinks
+	config    *LoggerConfig        // Configuration the outputs were built from, defaults applied
+	release   zapcore.Core         // Ends the write of an entry, see outputSwitch.acquire
+	active    atomic.Int64         // Entries being written
+	retired   atomic.Bool          // Set once replaced; entries are no longer accepted
+}
+
+func newOutputs(core zapcore.Core, level zapcore.LevelEnabler, files []*rotatingFile, closers []func() error) *outputs {
+	out := &outputs{core: core, unsampled: core, level: level, files: files, closers: closers}
+	out.release = &releaseCore{outputs: out}
+	return out
+}
+
+// outputSwitch holds the current outputs of a logger. It is shared by all
+// copies of the logger.
+type outputSwitch struct {
+	mu      sync.Mutex // Serializes Reconfigure
+	current atomic.Pointer[outputs]
+}
+
+func (s *outputSwitch) load() *outputs {
+	return s.current.Load()
+}
+
+// acquire returns the current outputs for writing an entry. They are not
+// closed until the entry is released by their release core.
+func (s *outputSwitch) acquire() *outputs {
+	for {
+		out := s.current.Load()
+		out.active.Add(1)
+		if !out.retired.Load() {
+			return out
+		}
+		// Replaced in the meantime; use the new outputs.
+		out.active.Add(-1)
+	}
+}
+
+// retire stops out from accepting entries and waits for the entries being
+// written to it, so its resources can be closed.
+func (s *outputSwitch) retire(out *outputs) {
+	out.retired.Store(true)
+	for out.active.Load() > 0 {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Enabled reports whether the current outputs accept level, so that sinks
+// follow the component levels of the current configuration.
+func (s *outputSwitch) Enabled(level zapcore.Level) bool {
+	return s.load().level.Enabled(level)
+}
+
+// switchCore passes entries to the current outputs of a logger, so all copies
+// of the logger follow Reconfigure.
+type switchCore struct {
+	outputs   *outputSwitch
+	fields    []zapcore.Field
+	unsampled bool // Write to the outputs without LoggerConfig.Sampling
+}
+
+// withoutSampling returns core, the core of a Logger, writing to the outputs
+// without LoggerConfig.Sampling, for use with zap.WrapCore.
+func withoutSampling(core zapcore.Core) zapcore.Core {
+	if c, ok := core.(*switchCore); ok {
+		return &switchCore{outputs: c.outputs, fields: c.fields, unsampled: true}
+	}
+	return core
+}
+
+// core returns the core of out the entries are written to.
+func (c *switchCore) core(out *outputs) zapcore.Core {
+	core := out.core
+	if c.unsampled {
+		core = out.unsampled
+	}
+	if len(c.fields) > 0 {
+		core = core.With(c.fields)
+	}
+	return core
+}
+
+func (c *switchCore) Enabled(level zapcore.Level) bool {
+	return c.outputs.load().core.Enabled(level)
+}
+
+func (c *switchCore) With(fields []zapcore.Field) zapcore.Core {
+	return &switchCore{
+		outputs:   c.outputs,
+		fields:    append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...),
+		unsampled: c.unsampled,
+	}
+}
+
+func (c *switchCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	out := c.outputs.acquire()
+	if ce = c.core(out).Check(ent, ce); ce == nil {
+		out.active.Add(-1)
+		return nil
+	}
+	return ce.AddCore(ent, out.release)
+}
+
+func (c *switchCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.core(c.outputs.load()).Write(ent, fields)
+}
+
+func (c *switchCore) Sync() error {
+	return c.outputs.load().core.Sync()
+}
+
+// releaseCore is added last to the entries checked by switchCore, to mark
+// their write to the outputs as finished.
+type releaseCore struct {
+	outputs *outputs
+}
+
+func (c *releaseCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *releaseCore) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+func (c *releaseCore) Check(_ zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce
+}
+
+func (c *releaseCore) Write(zapcore.Entry, []zapcore.Field) error {
+	c.outputs.active.Add(-1)
+	return nil
+}
+
+func (c *releaseCore) Sync() error {
+	return nil
+}
+
+// Reconfigure replaces the outputs, sinks, level, Sanitize, RedactKeys,
+// Maskers and AllowKeys of the logger with those of config while it is in
+// use, e.g. after a configuration change, for every copy of the logger.
+// Entries being written when it is called complete on the previous outputs,
+// which are then flushed and closed; later entries go to the new ones, so
+// none are lost. The sinks of config replace those of the previous
+// configuration; sinks attached with AddSink are kept, as are hooks.
+// RequestIDKey, ShowCaller, StacktraceLevel, OnSinkError, FlightRecorder,
+// DebugOnSignal and DebugTimeout keep the values the logger was created
+// with. An invalid config is rejected, as by Validate, and leaves the logger
+// unchanged; otherwise the errors of closing the previous outputs are
+// returned. Do not call Reconfigure after Close.
+func (l Logger) Reconfigure(config LoggerConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if config.TwelveFactor {
+		config = twelveFactorConfig(config)
+	}
+
+	l.outputs.mu.Lock()
+	defer l.outputs.mu.Unlock()
+	previous := l.outputs.load()
+	components := newComponentLevels(config.ComponentLevels, l.minLevel)
+	var level zapcore.LevelEnabler = l.minLevel
+	if components != nil {
+		level = components
+	}
+	out := initLogWithConfig(config, level, components, l.sinks, l.hooks, l.recorder, l.stats)
+	out.config = effectiveConfig(config, out.files)
+	keepCreationOptions(out.config, previous.config)
+
+	// Replace the sinks before switching, so no entry written to the new
+	// outputs reaches a sink being closed.
+	var detached []attachedSink
+	out.sinkIDs, detached = l.sinks.replace(previous.sinkIDs, config.Sinks)
+	l.minLevel.SetLevel(getLogLevel(componentDefaultLevel(config)))
+	l.filters.store(config)
+	l.outputs.current.Store(out)
+	l.outputs.retire(previous)
+
+	errs := []error{previous.core.Sync()}
+	for _, attached := range detached {
+		errs = append(errs, attached.sink.Close())
+	}
+	for _, closer := range previous.closers {
+		errs = append(errs, closer())
+	}
+	return errors.Join(errs...)
+}
+
+// keepCreationOptions copies the options that Reconfigure does not apply from
+// the configuration the logger was created with.
+func keepCreationOptions(config, created *LoggerConfig) {
+	config.RequestIDKey = created.RequestIDKey
+	config.ShowCaller = created.ShowCaller
+	config.StacktraceLevel = created.StacktraceLevel
+	config.OnSinkError = created.OnSinkError
+	config.FlightRecorder = created.FlightRecorder
+	config.DebugOnSignal = created.DebugOnSignal
+	config.DebugTimeout = created.DebugTimeout
+}
+
+// changedCreationOptions returns the names of the options kept by
+// keepCreationOptions whose values differ between previous and config,
+// except OnSinkError, which cannot be compared.
+func changedCreationOptions(previous, config LoggerConfig) []string {
+	var changed []string
+	for _, option := range []struct {
+		name  string
+		equal bool
+	}{
+		{"RequestIDKey", previous.RequestIDKey == config.RequestIDKey},
+		{"ShowCaller", previous.ShowCaller == config.ShowCaller},
+		{"StacktraceLevel", previous.StacktraceLevel == config.StacktraceLevel},
+		{"FlightRecorder", reflect.DeepEqual(previous.FlightRecorder, config.FlightRecorder)},
+		{"DebugOnSignal", previous.DebugOnSignal == config.DebugOnSignal},
+		{"DebugTimeout", previous.DebugTimeout == config.DebugTimeout},
+	} {
+		if !option.equal {
+			changed = append(changed, option.name)
+		}
+	}
+	return changed
+}
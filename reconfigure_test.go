@@ -0,0 +1,199 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// countingSink counts its entries and the entries written after Close.
+type countingSink struct {
+	written     atomic.Int64
+	afterClose  atomic.Int64
+	closed      atomic.Bool
+	closedCalls atomic.Int64
+}
+
+func (s *countingSink) Write(Entry) error {
+	if s.closed.Load() {
+		s.afterClose.Add(1)
+	}
+	s.written.Add(1)
+	return nil
+}
+
+func (s *countingSink) Sync() error {
+	return nil
+}
+
+func (s *countingSink) Close() error {
+	s.closed.Store(true)
+	s.closedCalls.Add(1)
+	return nil
+}
+
+func TestReconfigure(t *testing.T) {
+	firstDir, secondDir := t.TempDir(), t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputFile, LogDir: firstDir, LogLevel: LevelDebug})
+	defer log.Close()
+	named := log.Named("worker")
+	log.Debug("before").Send()
+
+	err := log.Reconfigure(LoggerConfig{
+		OutputMode:   OutputFile,
+		LogDir:       secondDir,
+		LogLevel:     LevelWarn,
+		RequestIDKey: "trace",
+		GlobalFields: map[string]any{"generation": 2},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	named.Info("dropped").Send()
+	named.Warn("after").Send()
+	if err := log.Rotate(); err != nil {
+		t.Errorf("Expected Rotate to rotate the new file, got %v", err)
+	}
+
+	first, _ := os.ReadFile(currentLogFile(firstDir))
+	if !strings.Contains(string(first), `"msg":"before"`) || strings.Contains(string(first), "after") {
+		t.Errorf("Expected only the entry before Reconfigure in the first file, got %q", first)
+	}
+	matches, _ := filepath.Glob(filepath.Join(secondDir, "logger-*"))
+	var second []byte
+	for _, match := range matches {
+		data, _ := os.ReadFile(match)
+		second = append(second, data...)
+	}
+	if !strings.Contains(string(second), `"msg":"after","generation":2`) || strings.Contains(string(second), "dropped") {
+		t.Errorf("Expected the copy to follow the new file, level and fields, got %q", second)
+	}
+
+	config := log.Config()
+	if config.LogDir != secondDir || config.LogLevel != LevelWarn || config.RequestIDKey != "request-id" {
+		t.Errorf("Expected the new configuration with the original request ID key, got %+v", config)
+	}
+}
+
+func TestReconfigureSinks(t *testing.T) {
+	old, kept, replacement := &countingSink{}, &countingSink{}, &countingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{old}})
+	defer log.Close()
+	log.AddSink(kept)
+
+	if err := log.Reconfigure(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{replacement}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Info("after").Send()
+
+	if !old.closed.Load() || old.written.Load() != 0 {
+		t.Error("Expected the previous configuration's sink to be closed and receive nothing")
+	}
+	if kept.closed.Load() || kept.written.Load() != 1 || replacement.written.Load() != 1 {
+		t.Error("Expected the attached and the new sinks to receive the entry")
+	}
+	if len(log.Config().Sinks) != 2 {
+		t.Errorf("Expected 2 attached sinks, got %d", len(log.Config().Sinks))
+	}
+}
+
+func TestReconfigureInvalid(t *testing.T) {
+	log, capture := NewTestLogger()
+	defer log.Close()
+
+	err := log.Reconfigure(LoggerConfig{OutputMode: OutputDiscard, LogLevel: "verbose"})
+	if err == nil || !strings.Contains(err.Error(), `LogLevel: unknown value "verbose"`) {
+		t.Errorf("Expected a validation error, got %v", err)
+	}
+	log.Debug("kept").Send()
+	if capture.Len() != 1 || log.GetLevel() != LevelDebug {
+		t.Error("Expected an invalid configuration to leave the logger unchanged")
+	}
+}
+
+func TestReconfigureConcurrent(t *testing.T) {
+	first := &countingSink{}
+	sinks := []*countingSink{first}
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{first}})
+
+	var sent atomic.Int64
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctxLog := log.Named("worker")
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				ctxLog.Info("entry").Data("n", 1).Send()
+				sent.Add(1)
+			}
+		}()
+	}
+
+	for i := 0; i < 10; i++ {
+		sink := &countingSink{}
+		sinks = append(sinks, sink)
+		if err := log.Reconfigure(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{sink}}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(stop)
+	wg.Wait()
+	log.Close()
+
+	var written int64
+	for i, sink := range sinks {
+		written += sink.written.Load()
+		if sink.afterClose.Load() != 0 {
+			t.Errorf("Sink %d received %d entries after it was closed", i, sink.afterClose.Load())
+		}
+		if sink.closedCalls.Load() != 1 {
+			t.Errorf("Sink %d was closed %d times", i, sink.closedCalls.Load())
+		}
+	}
+	if written != sent.Load() {
+		t.Errorf("Expected every one of %d entries in exactly one sink, got %d", sent.Load(), written)
+	}
+}
+
+func TestReconfigureFilters(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, RedactKeys: []string{"token"}})
+	defer log.Close()
+	capture := NewCaptureSink()
+	log.AddSink(capture)
+	named := log.Named("worker")
+
+	err := log.Reconfigure(LoggerConfig{
+		OutputMode: OutputDiscard,
+		RedactKeys: []string{"password"},
+		AllowKeys:  []string{"user", "password", "token"},
+		Sanitize:   SanitizeEscape,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	named.Info("login\nforged").Data("user", "ana").Data("password", "secret").Data("token", "abc").Data("body", "x").Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %+v", entries)
+	}
+	fields := entries[0].Fields
+	if fields["password"] != Redacted || fields["token"] != "abc" || fields["user"] != "ana" || fields["body"] != nil {
+		t.Errorf("Expected the new RedactKeys and AllowKeys, got %v", fields)
+	}
+	if entries[0].Message != `login\nforged` {
+		t.Errorf("Expected the new Sanitize mode, got %q", entries[0].Message)
+	}
+}
@@ -0,0 +1,112 @@
+package gologger
+
+import (
+	"errors"
+	"os"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// FlightRecorderConfig holds configuration options for the crash flight recorder.
+type FlightRecorderConfig struct {
+	Size   int  // Number of recent entries retained in memory (default: 1000)
+	Output Sink // Destination of dumps (default: JSON lines on stderr)
+}
+
+// flightRecorder keeps the most recent entries at every level, including
+// those below the configured log level, in a ring buffer.
+type flightRecorder struct {
+	mu      sync.Mutex
+	entries []Entry
+	next    int
+	full    bool
+	output  Sink
+}
+
+func newFlightRecorder(config FlightRecorderConfig) *flightRecorder {
+	size := config.Size
+	if size <= 0 {
+		size = 1000
+	}
+	output := config.Output
+	if output == nil {
+		output = NewWriterSink(os.Stderr)
+	}
+	return &flightRecorder{
+		entries: make([]Entry, size),
+		output:  output,
+	}
+}
+
+// record stores an entry, overwriting the oldest one when the buffer is full.
+func (r *flightRecorder) record(entry Entry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// drain returns the retained entries, oldest first, and empties the buffer.
+func (r *flightRecorder) drain() []Entry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var entries []Entry
+	if r.full {
+		entries = append(entries, r.entries[r.next:]...)
+	}
+	entries = append(entries, r.entries[:r.next]...)
+
+	r.entries = make([]Entry, len(r.entries))
+	r.next = 0
+	r.full = false
+	return entries
+}
+
+// dump writes the retained entries to the output and empties the buffer.
+func (r *flightRecorder) dump() error {
+	var errs []error
+	for _, entry := range r.drain() {
+		errs = append(errs, r.output.Write(entry))
+	}
+	errs = append(errs, r.output.Sync())
+	return errors.Join(errs...)
+}
+
+// recorderCore feeds a flightRecorder from zap and dumps it when a panic
+// or fatal entry is written.
+type recorderCore struct {
+	recorder *flightRecorder
+	fields   []zapcore.Field
+}
+
+func (c *recorderCore) Enabled(zapcore.Level) bool {
+	return true
+}
+
+func (c *recorderCore) With(fields []zapcore.Field) zapcore.Core {
+	return &recorderCore{
+		recorder: c.recorder,
+		fields:   append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...),
+	}
+}
+
+func (c *recorderCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c *recorderCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	c.recorder.record(entryFromZap(ent, append(c.fields[:len(c.fields):len(c.fields)], fields...)))
+	if ent.Level > zapcore.ErrorLevel {
+		return c.recorder.dump()
+	}
+	return nil
+}
+
+func (c *recorderCore) Sync() error {
+	return nil
+}
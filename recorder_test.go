@@ -0,0 +1,72 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFlightRecorderDumpRecent(t *testing.T) {
+	output := &recordingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:     OutputDiscard,
+		LogLevel:       LevelWarn,
+		FlightRecorder: &FlightRecorderConfig{Size: 3, Output: output},
+	})
+	defer log.Close()
+
+	log.Debug("one").Send()
+	log.Info("two").Send()
+	log.Debug("three").Data("step", 3).Send()
+	log.Warn("four").Send()
+
+	if err := log.DumpRecent(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var messages []string
+	for _, entry := range output.Entries() {
+		messages = append(messages, entry.Message)
+	}
+	if strings.Join(messages, ",") != "two,three,four" {
+		t.Errorf("Expected the last 3 entries in order, got %v", messages)
+	}
+	if output.Entries()[1].Fields["step"] != int64(3) {
+		t.Errorf("Expected fields to be retained, got %v", output.Entries()[1].Fields)
+	}
+
+	// The buffer is emptied by a dump
+	_ = log.DumpRecent()
+	if len(output.Entries()) != 3 {
+		t.Errorf("Expected no new entries after a second dump, got %d", len(output.Entries()))
+	}
+}
+
+func TestFlightRecorderDumpsOnPanic(t *testing.T) {
+	output := &recordingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:     OutputDiscard,
+		LogLevel:       LevelError,
+		FlightRecorder: &FlightRecorderConfig{Output: output},
+	})
+	defer log.Close()
+
+	log.Debug("context before crash").Send()
+	func() {
+		defer func() { _ = recover() }()
+		log.Panic("crash").Send()
+	}()
+
+	entries := output.Entries()
+	if len(entries) != 2 || entries[0].Message != "context before crash" || entries[1].Level != "panic" {
+		t.Errorf("Expected debug context and panic entry to be dumped, got %v", entries)
+	}
+}
+
+func TestDumpRecentDisabled(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard})
+	defer log.Close()
+
+	if err := log.DumpRecent(); err == nil {
+		t.Error("Expected error when the flight recorder is disabled")
+	}
+}
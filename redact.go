@@ -0,0 +1,162 @@
+package gologger
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// Redacted replaces the values of fields whose keys match
+// LoggerConfig.RedactKeys.
+const Redacted = "[REDACTED]"
+
+// redactor matches field keys against the patterns of RedactKeys and the
+// keys of Maskers.
+type redactor struct {
+	keys    *keyPatterns // RedactKeys
+	maskers []keyMasker  // Maskers, exact keys first, then patterns in sorted order
+}
+
+// keyMasker is a masker with the lowercased key pattern it applies to.
+type keyMasker struct {
+	pattern string
+	glob    bool
+	mask    Masker
+}
+
+// getRedactor returns the redactor of the RedactKeys patterns and Maskers,
+// or nil when both are empty.
+func getRedactor(patterns []string, maskers map[string]Masker) *redactor {
+	if len(patterns) == 0 && len(maskers) == 0 {
+		return nil
+	}
+	r := &redactor{keys: newKeyPatterns(patterns)}
+	for pattern, mask := range maskers {
+		if mask != nil {
+			pattern = strings.ToLower(pattern)
+			r.maskers = append(r.maskers, keyMasker{pattern: pattern, glob: isKeyPattern(pattern), mask: mask})
+		}
+	}
+	sort.Slice(r.maskers, func(i, j int) bool {
+		if r.maskers[i].glob != r.maskers[j].glob {
+			return !r.maskers[i].glob
+		}
+		return r.maskers[i].pattern < r.maskers[j].pattern
+	})
+	return r
+}
+
+// keyPatterns matches field keys against patterns, ignoring case, as
+// RedactKeys and AllowKeys do.
+type keyPatterns struct {
+	exact map[string]bool // Lowercased patterns without wildcards
+	globs []string        // Lowercased patterns with wildcards
+}
+
+// newKeyPatterns returns the keyPatterns of patterns.
+func newKeyPatterns(patterns []string) *keyPatterns {
+	p := &keyPatterns{exact: make(map[string]bool)}
+	for _, pattern := range patterns {
+		pattern = strings.ToLower(pattern)
+		if isKeyPattern(pattern) {
+			p.globs = append(p.globs, pattern)
+		} else {
+			p.exact[pattern] = true
+		}
+	}
+	return p
+}
+
+// isKeyPattern reports whether pattern has wildcards.
+func isKeyPattern(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
+
+// match reports whether key matches one of the patterns, ignoring case.
+// Malformed patterns, rejected by Validate, match nothing.
+func (p *keyPatterns) match(key string) bool {
+	key = strings.ToLower(key)
+	if p.exact[key] {
+		return true
+	}
+	for _, glob := range p.globs {
+		if ok, _ := path.Match(glob, key); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether key matches one of the RedactKeys patterns.
+func (r *redactor) match(key string) bool {
+	return r.keys.match(key)
+}
+
+// masker returns the masker of key, ignoring case, or nil if it has none.
+func (r *redactor) masker(key string) Masker {
+	key = strings.ToLower(key)
+	for _, m := range r.maskers {
+		if !m.glob {
+			if m.pattern == key {
+				return m.mask
+			}
+		} else if ok, _ := path.Match(m.pattern, key); ok {
+			return m.mask
+		}
+	}
+	return nil
+}
+
+// redactFields replaces the values of the key-value pairs of fields whose
+// keys match the RedactKeys of r with Redacted, and masks the values of
+// those with a masker. The keys of maps and structs in the other values are
+// redacted and masked the same way, see redactValue.
+func (r *redactor) redactFields(fields []any) {
+	for i := 0; i+1 < len(fields); i += 2 {
+		key, ok := fields[i].(string)
+		if !ok {
+			continue
+		}
+		if r.match(key) {
+			fields[i+1] = Redacted
+		} else if mask := r.masker(key); mask != nil {
+			fields[i+1] = mask(fields[i+1])
+		} else {
+			fields[i+1] = r.redactValue(fields[i+1])
+		}
+	}
+}
+
+// redactValue returns value with the values of the keys of its maps and
+// structs that match r redacted or masked. Maps and structs are converted as
+// by DataStruct, so the caller's values are not changed.
+func (r *redactor) redactValue(value any) any {
+	if nested, ok := nestedValue(value); ok {
+		return r.redactNested(nested)
+	}
+	return value
+}
+
+// redactNested redacts the keys of value, as converted by structValue.
+func (r *redactor) redactNested(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if r.match(key) {
+				v[key] = Redacted
+			} else if mask := r.masker(key); mask != nil {
+				v[key] = mask(nested)
+			} else {
+				v[key] = r.redactNested(nested)
+			}
+		}
+		return v
+	case []any:
+		for i, nested := range v {
+			v[i] = r.redactNested(nested)
+		}
+		return v
+	default:
+		return value
+	}
+}
@@ -0,0 +1,110 @@
+package gologger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	if getRedactor(nil, nil) != nil {
+		t.Error("Expected no redactor by default")
+	}
+	r := getRedactor([]string{"Authorization", "*password*", "api_?ey", "*secret["}, nil)
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"authorization", true},
+		{"AUTHORIZATION", true},
+		{"authorization_header", false},
+		{"password", true},
+		{"db_password_hash", true},
+		{"Password", true},
+		{"api_key", true},
+		{"api_keys", false},
+		{"my_secret[", false},
+		{"user", false},
+	}
+	for _, tt := range tests {
+		if got := r.match(tt.key); got != tt.expected {
+			t.Errorf("match(%q) = %v, expected %v", tt.key, got, tt.expected)
+		}
+	}
+}
+
+func TestRedactKeysConfig(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		RedactKeys: []string{"authorization", "*password*"},
+		Sinks:      []Sink{capture},
+	})
+	ctx := WithFields(context.Background(), "Authorization", "Bearer abc123")
+
+	log.WithContext(ctx).Info("user created").
+		Data("user", "alice").
+		Data("password", "hunter2").
+		Data("new_password_confirm", []byte("hunter2")).
+		Data("attempts", 3).
+		Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	fields := entries[0].Fields
+	for _, key := range []string{"Authorization", "password", "new_password_confirm"} {
+		if fields[key] != Redacted {
+			t.Errorf("Expected %q to be redacted, got %v", key, fields[key])
+		}
+	}
+	if fields["user"] != "alice" || fields["attempts"] != int64(3) {
+		t.Errorf("Expected other fields unchanged, got %v", fields)
+	}
+	if entries[0].Message != "user created" {
+		t.Errorf("Expected the message unchanged, got %q", entries[0].Message)
+	}
+}
+
+func TestRedactKeysNested(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		RedactKeys: []string{"authorization", "*password*"},
+		Maskers:    map[string]Masker{"phone": MaskLast(4)},
+		Sinks:      []Sink{capture},
+	})
+	type header struct {
+		Authorization string
+		Accept        string
+	}
+	type request struct {
+		Headers []header          `json:"headers"`
+		Contact map[string]string `json:"contact"`
+	}
+	req := map[string]any{"user": "alice", "password": "hunter2"}
+
+	log.Info("request received").
+		Data("req", req).
+		Data("request", &request{
+			Headers: []header{{Authorization: "Bearer abc123", Accept: "*/*"}},
+			Contact: map[string]string{"phone": "5551234567"},
+		}).
+		Send()
+
+	fields := capture.Entries()[0].Fields
+	if got := fields["req"].(map[string]any); got["password"] != Redacted || got["user"] != "alice" {
+		t.Errorf("Expected the nested map key to be redacted, got %v", got)
+	}
+	if req["password"] != "hunter2" {
+		t.Error("Expected the logged map to be left unchanged")
+	}
+	logged := fields["request"].(map[string]any)
+	headers := logged["headers"].([]any)[0].(map[string]any)
+	if headers["Authorization"] != Redacted || headers["Accept"] != "*/*" {
+		t.Errorf("Expected the struct field in a slice to be redacted, got %v", headers)
+	}
+	if contact := logged["contact"].(map[string]any); contact["phone"] != "******4567" {
+		t.Errorf("Expected the nested key to be masked, got %v", contact)
+	}
+}
@@ -0,0 +1,287 @@
+package gologger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RedisConfig holds configuration options for the Redis Streams sink.
+type RedisConfig struct {
+	Addr          string        // Redis server address (default: "localhost:6379")
+	Username      string        // ACL username (optional)
+	Password      string        // Password (optional)
+	DB            int           // Database number (default: 0)
+	Stream        string        // Stream key (default: "logs")
+	MaxLen        int64         // Approximate maximum stream length; 0 disables trimming
+	BatchSize     int           // Maximum entries per pipelined batch (default: 100)
+	FlushInterval time.Duration // Maximum time an entry waits before being sent (default: 1s)
+	Timeout       time.Duration // Dial and I/O timeout (default: 5s)
+	TLS           *TLSConfig    // TLS client options; enables TLS when set (optional)
+}
+
+// RedisSink appends entries to a Redis stream with XADD. Each stream entry
+// has a "level" field and an "entry" field holding the JSON-encoded entry.
+// Batches are pipelined over a single connection. A batch is resent only when
+// none of it reached the connection, so entries are never duplicated, but a
+// batch whose replies are lost is reported as failed although Redis may have
+// added it.
+type RedisSink struct {
+	config RedisConfig
+	batch  *batcher
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisSink creates a Redis Streams sink. Unset options fall back to their defaults.
+// The connection is established lazily on the first flush.
+func NewRedisSink(config RedisConfig) *RedisSink {
+	if config.Addr == "" {
+		config.Addr = "localhost:6379"
+	}
+	if config.Stream == "" {
+		config.Stream = "logs"
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	s := &RedisSink{config: config}
+	s.batch = newBatcher(config.BatchSize, config.FlushInterval, s.send)
+	return s
+}
+
+// Write queues an entry for XADD.
+func (s *RedisSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync sends all queued entries.
+func (s *RedisSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close sends all queued entries and closes the connection.
+func (s *RedisSink) Close() error {
+	err := s.batch.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// send pipelines one XADD per entry and reads all replies.
+func (s *RedisSink) send(entries []Entry) error {
+	var buf []byte
+	for _, entry := range entries {
+		data, err := entryJSON(entry)
+		if err != nil {
+			return fmt.Errorf("redis: encode entry: %w", err)
+		}
+		args := []string{"XADD", s.config.Stream}
+		if s.config.MaxLen > 0 {
+			args = append(args, "MAXLEN", "~", strconv.FormatInt(s.config.MaxLen, 10))
+		}
+		args = append(args, "*", "level", entry.Level, "entry", string(data))
+		buf = appendRESPCommand(buf, args...)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	reused := s.conn != nil
+	err := s.pipeline(buf, len(entries))
+	if err != nil && reused && isUnsentError(err) {
+		// The pooled connection may have gone stale; retry once on a fresh one.
+		// Batches that were partly written are not resent, as Redis may have
+		// run some of their commands.
+		err = s.pipeline(buf, len(entries))
+	}
+	return err
+}
+
+// pipeline writes the buffered commands and reads n replies. It must be
+// called with s.mu held.
+func (s *RedisSink) pipeline(buf []byte, n int) error {
+	if s.conn == nil {
+		if err := s.connect(); err != nil {
+			return err
+		}
+	}
+
+	_ = s.conn.SetDeadline(time.Now().Add(s.config.Timeout))
+	if n, err := s.conn.Write(buf); err != nil {
+		s.resetConn()
+		return &redisConnError{err: err, unsent: n == 0}
+	}
+
+	var firstErr error
+	for i := 0; i < n; i++ {
+		if _, err := readRESP(s.reader); err != nil {
+			var replyErr redisReplyError
+			if !errors.As(err, &replyErr) {
+				s.resetConn()
+				return &redisConnError{err: err}
+			}
+			if firstErr == nil {
+				firstErr = fmt.Errorf("redis: XADD: %w", err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// connect dials the server and runs AUTH and SELECT as needed. It must be
+// called with s.mu held.
+func (s *RedisSink) connect() error {
+	conn, err := dialNetwork("tcp", s.config.Addr, s.config.Timeout, s.config.TLS)
+	if err != nil {
+		return &redisConnError{err: err, unsent: true}
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+
+	var setup [][]string
+	if s.config.Password != "" {
+		if s.config.Username != "" {
+			setup = append(setup, []string{"AUTH", s.config.Username, s.config.Password})
+		} else {
+			setup = append(setup, []string{"AUTH", s.config.Password})
+		}
+	}
+	if s.config.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(s.config.DB)})
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(s.config.Timeout))
+	for _, args := range setup {
+		if _, err := conn.Write(appendRESPCommand(nil, args...)); err != nil {
+			s.resetConn()
+			return &redisConnError{err: err, unsent: true}
+		}
+		if _, err := readRESP(s.reader); err != nil {
+			s.resetConn()
+			return fmt.Errorf("redis: %s: %w", args[0], err)
+		}
+	}
+	return nil
+}
+
+func (s *RedisSink) resetConn() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn = nil
+	s.reader = nil
+}
+
+// redisConnError marks errors caused by the connection rather than the server.
+// unsent is set when no command of the batch was written.
+type redisConnError struct {
+	err    error
+	unsent bool
+}
+
+func (e *redisConnError) Error() string { return "redis: " + e.err.Error() }
+func (e *redisConnError) Unwrap() error { return e.err }
+
+func isUnsentError(err error) bool {
+	var connErr *redisConnError
+	return errors.As(err, &connErr) && connErr.unsent
+}
+
+// redisReplyError is an error reply sent by the server.
+type redisReplyError string
+
+func (e redisReplyError) Error() string { return string(e) }
+
+// appendRESPCommand encodes a command as a RESP array of bulk strings.
+func appendRESPCommand(b []byte, args ...string) []byte {
+	b = append(b, '*')
+	b = strconv.AppendInt(b, int64(len(args)), 10)
+	b = append(b, '\r', '\n')
+	for _, arg := range args {
+		b = append(b, '$')
+		b = strconv.AppendInt(b, int64(len(arg)), 10)
+		b = append(b, '\r', '\n')
+		b = append(b, arg...)
+		b = append(b, '\r', '\n')
+	}
+	return b
+}
+
+// readRESP reads a single RESP value. Arrays are returned as []any, bulk and
+// simple strings as string and integers as int64. Error replies are returned
+// as redisReplyError after the full reply has been consumed.
+func readRESP(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || line[len(line)-2] != '\r' {
+		return nil, fmt.Errorf("invalid RESP line %q", line)
+	}
+	kind, payload := line[0], line[1:len(line)-2]
+
+	switch kind {
+	case '+':
+		return payload, nil
+	case '-':
+		return nil, redisReplyError(payload)
+	case ':':
+		return strconv.ParseInt(payload, 10, 64)
+	case '$':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		data := make([]byte, n+2)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		return string(data[:n]), nil
+	case '*':
+		n, err := strconv.Atoi(payload)
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, nil
+		}
+		values := make([]any, 0, n)
+		var replyErr error
+		for i := 0; i < n; i++ {
+			value, err := readRESP(r)
+			if err != nil {
+				if _, ok := err.(redisReplyError); !ok {
+					return nil, err
+				}
+				replyErr = err
+			}
+			values = append(values, value)
+		}
+		return values, replyErr
+	default:
+		return nil, fmt.Errorf("unexpected RESP type %q", kind)
+	}
+}
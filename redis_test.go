@@ -0,0 +1,201 @@
+package gologger
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a minimal RESP server that records received commands. An
+// empty reply drops the connection.
+type fakeRedis struct {
+	listener net.Listener
+	mu       sync.Mutex
+	commands [][]string
+	reply    func(args []string) string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	f := &fakeRedis{listener: listener}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		value, err := readRESP(reader)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range value.([]any) {
+			args = append(args, arg.(string))
+		}
+
+		f.mu.Lock()
+		f.commands = append(f.commands, args)
+		reply := f.reply
+		f.mu.Unlock()
+
+		response := "+OK\r\n"
+		if reply != nil {
+			response = reply(args)
+		} else if args[0] == "XADD" {
+			response = "$3\r\n1-0\r\n"
+		}
+		if response == "" {
+			return
+		}
+		if _, err := conn.Write([]byte(response)); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRedis) Commands() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.commands...)
+}
+
+func TestRedisSinkXADD(t *testing.T) {
+	server := newFakeRedis(t)
+
+	sink := NewRedisSink(RedisConfig{
+		Addr:     server.listener.Addr().String(),
+		Password: "secret",
+		DB:       2,
+		Stream:   "app-logs",
+		MaxLen:   1000,
+	})
+
+	for i := 0; i < 3; i++ {
+		_ = sink.Write(Entry{
+			Time:    time.Now(),
+			Level:   LevelInfo,
+			Message: "order created",
+			Fields:  map[string]any{"order_id": i},
+		})
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	commands := server.Commands()
+	if len(commands) != 5 {
+		t.Fatalf("Expected AUTH, SELECT and 3 XADD commands, got %v", commands)
+	}
+	if strings.Join(commands[0], " ") != "AUTH secret" {
+		t.Errorf("Expected AUTH command, got %v", commands[0])
+	}
+	if strings.Join(commands[1], " ") != "SELECT 2" {
+		t.Errorf("Expected SELECT command, got %v", commands[1])
+	}
+
+	xadd := commands[2]
+	if strings.Join(xadd[:8], " ") != "XADD app-logs MAXLEN ~ 1000 * level info" {
+		t.Errorf("Unexpected XADD command %v", xadd)
+	}
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(xadd[9]), &entry); err != nil {
+		t.Fatalf("Expected JSON entry, got %s", xadd[9])
+	}
+	if entry["msg"] != "order created" || entry["level"] != "INFO" || entry["order_id"] != float64(0) {
+		t.Errorf("Unexpected entry %v", entry)
+	}
+}
+
+func TestRedisSinkReportsReplyErrors(t *testing.T) {
+	server := newFakeRedis(t)
+	server.mu.Lock()
+	server.reply = func(args []string) string {
+		return "-WRONGTYPE Operation against a key holding the wrong kind of value\r\n"
+	}
+	server.mu.Unlock()
+
+	sink := NewRedisSink(RedisConfig{Addr: server.listener.Addr().String()})
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "hello"})
+
+	err := sink.Sync()
+	if err == nil || !strings.Contains(err.Error(), "WRONGTYPE") {
+		t.Errorf("Expected WRONGTYPE error, got %v", err)
+	}
+	_ = sink.Close()
+}
+
+func TestRedisSinkDoesNotResendWrittenBatch(t *testing.T) {
+	server := newFakeRedis(t)
+	sink := NewRedisSink(RedisConfig{Addr: server.listener.Addr().String()})
+	defer sink.Close()
+
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "first"})
+	if err := sink.Sync(); err != nil {
+		t.Fatalf("Unexpected sync error: %v", err)
+	}
+
+	// The server takes the next XADD but drops the connection before replying.
+	server.mu.Lock()
+	server.reply = func(args []string) string { return "" }
+	server.mu.Unlock()
+
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "second"})
+	if err := sink.Sync(); err == nil {
+		t.Error("Expected connection error")
+	}
+
+	if commands := server.Commands(); len(commands) != 2 {
+		t.Errorf("Expected 2 XADD commands without a resend, got %d", len(commands))
+	}
+}
+
+func TestRedisSinkConnectionError(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	listener.Close()
+
+	sink := NewRedisSink(RedisConfig{Addr: addr, Timeout: 100 * time.Millisecond})
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "hello"})
+
+	if err := sink.Sync(); err == nil {
+		t.Error("Expected connection error")
+	}
+	_ = sink.Close()
+}
+
+func TestReadRESP(t *testing.T) {
+	input := "*3\r\n$3\r\nfoo\r\n:42\r\n$-1\r\n"
+	value, err := readRESP(bufio.NewReader(strings.NewReader(input)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	values := value.([]any)
+	if values[0] != "foo" || values[1] != int64(42) || values[2] != nil {
+		t.Errorf("Unexpected values %v", values)
+	}
+
+	if _, err := readRESP(bufio.NewReader(strings.NewReader("-ERR bad\r\n"))); err == nil || err.Error() != "ERR bad" {
+		t.Errorf("Expected error reply, got %v", err)
+	}
+}
@@ -0,0 +1,201 @@
+// Package redishook logs the commands of go-redis clients with gologger:
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	rdb.AddHook(redishook.New(log.Named("redis"), redishook.Config{}))
+//
+// Entries carry the request ID of the context passed to the client, so
+// commands can be correlated with the requests that issued them.
+//
+// It is a separate module, so applications not using go-redis do not depend
+// on it.
+package redishook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.risoftinc.com/gologger"
+)
+
+// Config holds configuration options for the hook.
+type Config struct {
+	Level         string              // Level of successful commands; failed ones are logged at error level (default: LevelDebug)
+	SlowThreshold time.Duration       // Log commands taking at least this long at warn level (optional)
+	KeyPattern    func(string) string // Returns the form of keys that is logged (default: KeyPattern)
+}
+
+// Hook is a redis.Hook logging the commands, pipelines and failed dials of
+// a client.
+type Hook struct {
+	log        gologger.Logger
+	level      string
+	slow       time.Duration
+	keyPattern func(string) string
+}
+
+var _ redis.Hook = (*Hook)(nil)
+
+// New returns a hook logging with log. Each command is logged as
+// "redis command" with its name as "command", the pattern of its first key
+// as "key" and its duration. Argument values are never logged, since they may
+// hold personal data or credentials, and keys are logged as patterns for the
+// same reason. Pipelines and transactions are logged as one "redis pipeline"
+// entry with the names of their commands.
+//
+// Entries are logged at config.Level, slow ones at warn and failed ones at
+// error level with the error. redis.Nil, returned for missing keys, is not a
+// failure. The request ID is taken from the context of the command.
+func New(log gologger.Logger, config Config) *Hook {
+	level := config.Level
+	if level == "" {
+		level = gologger.LevelDebug
+	}
+	keyPattern := config.KeyPattern
+	if keyPattern == nil {
+		keyPattern = KeyPattern
+	}
+	return &Hook{log: log, level: level, slow: config.SlowThreshold, keyPattern: keyPattern}
+}
+
+// DialHook logs the connections that could not be established.
+func (h *Hook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		start := time.Now()
+		conn, err := next(ctx, network, addr)
+		if err != nil {
+			h.entry(ctx, "redis dial", start, err).
+				Data("addr", addr).
+				Send()
+		}
+		return conn, err
+	}
+}
+
+// ProcessHook logs each command.
+func (h *Hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		entry := h.entry(ctx, "redis command", start, err).Data("command", cmd.FullName())
+		if key, ok := firstKey(cmd); ok {
+			entry = entry.Data("key", h.keyPattern(key))
+		}
+		entry.Send()
+		return err
+	}
+}
+
+// ProcessPipelineHook logs each pipeline or transaction with the names of
+// its commands and the first error among them.
+func (h *Hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		failure := err
+		names := make([]string, len(cmds))
+		for i, cmd := range cmds {
+			names[i] = cmd.FullName()
+			if failure == nil || errors.Is(failure, redis.Nil) {
+				failure = cmd.Err()
+			}
+		}
+		h.entry(ctx, "redis pipeline", start, failure).
+			Data("commands", names).
+			Data("count", len(cmds)).
+			Send()
+		return err
+	}
+}
+
+// entry returns the entry of an operation started at start, with the level
+// given by its outcome and duration. Its request ID is taken from ctx.
+func (h *Hook) entry(ctx context.Context, msg string, start time.Time, err error) gologger.Logger {
+	if errors.Is(err, redis.Nil) {
+		err = nil
+	}
+	duration := time.Since(start)
+	level := h.level
+	switch {
+	case err != nil:
+		level = gologger.LevelError
+	case h.slow > 0 && duration >= h.slow && level != gologger.LevelError:
+		level = gologger.LevelWarn
+	}
+	return h.log.WithContext(ctx).Log(level, msg).
+		ErrorData(err).
+		Data("duration_ms", float64(duration)/float64(time.Millisecond))
+}
+
+// keylessCommands are the commands whose first argument is not a key, some
+// of them taking credentials, such as AUTH and HELLO.
+var keylessCommands = map[string]bool{
+	"acl": true, "auth": true, "bgrewriteaof": true, "bgsave": true,
+	"client": true, "cluster": true, "command": true, "config": true,
+	"dbsize": true, "debug": true, "discard": true, "echo": true,
+	"exec": true, "failover": true, "flushall": true, "flushdb": true,
+	"function": true, "hello": true, "info": true, "lastsave": true,
+	"latency": true, "lolwut": true, "module": true, "monitor": true,
+	"multi": true, "ping": true, "psubscribe": true, "publish": true,
+	"pubsub": true, "punsubscribe": true, "quit": true, "readonly": true,
+	"readwrite": true, "replicaof": true, "role": true, "save": true,
+	"scan": true, "script": true, "select": true, "sentinel": true,
+	"shutdown": true, "slaveof": true, "slowlog": true, "spublish": true,
+	"ssubscribe": true, "subscribe": true, "sunsubscribe": true,
+	"swapdb": true, "time": true, "unsubscribe": true, "unwatch": true,
+	"wait": true, "waitaof": true,
+}
+
+// firstKey returns the first key of cmd, if it has one.
+func firstKey(cmd redis.Cmder) (string, bool) {
+	args := cmd.Args()
+	pos := 1
+	switch name := cmd.Name(); {
+	case name == "memory":
+		if len(args) < 2 || !strings.EqualFold(fmt.Sprint(args[1]), "usage") {
+			return "", false
+		}
+		pos = 2
+	case keylessCommands[name]:
+		return "", false
+	case name == "eval" || name == "evalsha" || name == "eval_ro" || name == "evalsha_ro" ||
+		name == "fcall" || name == "fcall_ro":
+		// The number of keys precedes them.
+		if len(args) < 3 || fmt.Sprint(args[2]) == "0" {
+			return "", false
+		}
+		pos = 3
+	case name == "xread" || name == "xreadgroup":
+		// The keys follow the STREAMS option.
+		pos = len(args)
+		for i, arg := range args {
+			if s, ok := arg.(string); ok && strings.EqualFold(s, "streams") {
+				pos = i + 1
+				break
+			}
+		}
+	}
+	if pos >= len(args) {
+		return "", false
+	}
+	key, ok := args[pos].(string)
+	return key, ok
+}
+
+// KeyPattern returns key with the segments separated by ':' that contain a
+// digit replaced by "*", so user:42:profile is logged as user:*:profile.
+// Segments holding IDs or other variable values then do not make each key
+// unique, and entries can be aggregated by pattern.
+func KeyPattern(key string) string {
+	segments := strings.Split(key, ":")
+	for i, segment := range segments {
+		if strings.ContainsAny(segment, "0123456789") {
+			segments[i] = "*"
+		}
+	}
+	return strings.Join(segments, ":")
+}
@@ -0,0 +1,169 @@
+package redishook
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.risoftinc.com/gologger"
+)
+
+// newClient returns a client of a miniredis server, with a hook of config
+// logging to the returned capture.
+func newClient(t *testing.T, config Config) (*redis.Client, *miniredis.Miniredis, *gologger.CaptureSink) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	log, capture := gologger.NewTestLogger()
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	client.AddHook(New(log, config))
+	t.Cleanup(func() { client.Close() })
+	return client, server, capture
+}
+
+func TestProcessHook(t *testing.T) {
+	client, _, capture := newClient(t, Config{})
+	ctx := gologger.WithRequestID(context.Background(), "req-42")
+
+	if err := client.Set(ctx, "user:42:email", "alice@example.com", 0).Err(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Get(ctx, "session:missing").Err(); err != redis.Nil {
+		t.Fatalf("Expected redis.Nil, got %v", err)
+	}
+
+	entries := capture.FilterMessage("redis command")
+	if len(entries) != 2 {
+		t.Fatalf("Expected two command entries, got %+v", capture.Entries())
+	}
+	set := entries[0]
+	if set.Level != gologger.LevelDebug || set.Fields["command"] != "set" || set.Fields["key"] != "user:*:email" ||
+		set.Fields["request-id"] != "req-42" {
+		t.Errorf("Unexpected set entry %+v", set)
+	}
+	if _, ok := set.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", set.Fields)
+	}
+	for _, entry := range capture.Entries() {
+		for key, value := range entry.Fields {
+			if s, ok := value.(string); ok && strings.Contains(s, "alice") {
+				t.Errorf("Expected values to be redacted, got %s=%q", key, s)
+			}
+		}
+	}
+	if get := entries[1]; get.Level != gologger.LevelDebug || get.Fields["key"] != "session:missing" || get.Fields["error"] != nil {
+		t.Errorf("Expected missing keys not to be failures, got %+v", get)
+	}
+}
+
+func TestProcessHookError(t *testing.T) {
+	client, _, capture := newClient(t, Config{Level: gologger.LevelInfo})
+	ctx := context.Background()
+
+	client.Set(ctx, "counter", "abc", 0)
+	if err := client.Incr(ctx, "counter").Err(); err == nil {
+		t.Fatal("Expected incr of a string to fail")
+	}
+
+	entries := capture.FilterMessage("redis command")
+	if len(entries) != 2 || entries[0].Level != gologger.LevelInfo {
+		t.Fatalf("Unexpected entries %+v", capture.Entries())
+	}
+	if failed := entries[1]; failed.Level != gologger.LevelError || failed.Fields["command"] != "incr" || failed.Fields["error"] == nil {
+		t.Errorf("Expected an error entry, got %+v", failed)
+	}
+}
+
+func TestProcessHookSlow(t *testing.T) {
+	client, _, capture := newClient(t, Config{SlowThreshold: time.Nanosecond})
+
+	client.Ping(context.Background())
+
+	entries := capture.FilterMessage("redis command")
+	if len(entries) != 1 || entries[0].Level != gologger.LevelWarn {
+		t.Fatalf("Expected a slow warn entry, got %+v", capture.Entries())
+	}
+	if _, ok := entries[0].Fields["key"]; ok {
+		t.Errorf("Expected no key for ping, got %v", entries[0].Fields)
+	}
+}
+
+func TestProcessPipelineHook(t *testing.T) {
+	client, _, capture := newClient(t, Config{})
+	ctx := gologger.WithRequestID(context.Background(), "req-7")
+
+	_, err := client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, "a", "1", 0)
+		pipe.Get(ctx, "b")
+		return nil
+	})
+	if err != redis.Nil {
+		t.Fatalf("Expected redis.Nil, got %v", err)
+	}
+
+	entries := capture.FilterMessage("redis pipeline")
+	if len(entries) != 1 {
+		t.Fatalf("Expected one pipeline entry, got %+v", capture.Entries())
+	}
+	entry := entries[0]
+	if entry.Level != gologger.LevelDebug || entry.Fields["count"] != int64(4) || entry.Fields["request-id"] != "req-7" {
+		t.Errorf("Unexpected pipeline entry %+v", entry)
+	}
+	commands, _ := entry.Fields["commands"].([]any)
+	if len(commands) != 4 || commands[0] != "multi" || commands[1] != "set" || commands[3] != "exec" {
+		t.Errorf("Unexpected commands %v", entry.Fields["commands"])
+	}
+}
+
+func TestDialHook(t *testing.T) {
+	client, server, capture := newClient(t, Config{})
+	addr := server.Addr()
+	server.Close()
+
+	if err := client.Ping(context.Background()).Err(); err == nil {
+		t.Fatal("Expected ping to fail")
+	}
+
+	dials := capture.FilterMessage("redis dial")
+	if len(dials) == 0 || dials[0].Level != gologger.LevelError || dials[0].Fields["addr"] != addr {
+		t.Errorf("Expected a failed dial entry, got %+v", capture.Entries())
+	}
+}
+
+func TestFirstKey(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		cmd  redis.Cmder
+		key  string
+		want bool
+	}{
+		{redis.NewStringCmd(ctx, "get", "k"), "k", true},
+		{redis.NewStatusCmd(ctx, "auth", "user", "secret"), "", false},
+		{redis.NewCmd(ctx, "eval", "return 1", 0), "", false},
+		{redis.NewCmd(ctx, "eval", "return 1", 1, "k", "v"), "k", true},
+		{redis.NewIntCmd(ctx, "memory", "usage", "k"), "k", true},
+		{redis.NewXStreamSliceCmd(ctx, "xread", "count", 10, "streams", "s", "0"), "s", true},
+		{redis.NewIntCmd(ctx, "publish", "channel", "message"), "", false},
+	}
+	for _, tt := range tests {
+		if key, ok := firstKey(tt.cmd); key != tt.key || ok != tt.want {
+			t.Errorf("firstKey(%v) = %q, %v, want %q, %v", tt.cmd.Args(), key, ok, tt.key, tt.want)
+		}
+	}
+}
+
+func TestKeyPattern(t *testing.T) {
+	tests := map[string]string{
+		"user:42:profile":   "user:*:profile",
+		"session:abc":       "session:abc",
+		"cache:v2:items:7f": "cache:*:items:*",
+		"plain":             "plain",
+	}
+	for key, want := range tests {
+		if got := KeyPattern(key); got != want {
+			t.Errorf("KeyPattern(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package gologger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RemoteLevelConfig holds configuration options for polling the log level
+// from a central configuration service.
+type RemoteLevelConfig struct {
+	URL        string                                    // Endpoint returning the level as plain text ("debug") or JSON ({"level":"debug"}), e.g. another service's LevelHandler or a Consul key with ?raw
+	Headers    map[string]string                         // Extra request headers, e.g. authentication tokens
+	Fetch      func(ctx context.Context) (string, error) // Returns the level from another source such as an etcd key, instead of URL (optional)
+	Interval   time.Duration                             // Time between polls (default: 30s)
+	Timeout    time.Duration                             // Timeout for a single poll (default: 10s)
+	HTTPClient *http.Client                              // HTTP client used for polling (optional)
+	OnError    func(err error)                           // Called when a poll fails or returns an unknown level (optional)
+}
+
+// PollLevel polls the level set in config on every Interval and applies it
+// with SetLevel, so the verbosity of a whole fleet can be changed from one
+// place. The first poll happens immediately. A failed poll or an unknown
+// level is reported to OnError and keeps the current level. An empty
+// response also keeps it, so clearing the key does not change the level.
+// Polling stops when the returned function is called; call it before Close.
+func (l Logger) PollLevel(config RemoteLevelConfig) (func(), error) {
+	fetch := config.Fetch
+	if fetch == nil {
+		if config.URL == "" {
+			return nil, errors.New("gologger: remote level needs a URL or Fetch")
+		}
+		client := config.HTTPClient
+		if client == nil {
+			client = &http.Client{}
+		}
+		fetch = func(ctx context.Context) (string, error) {
+			return fetchLevel(ctx, client, config.URL, config.Headers)
+		}
+	}
+	interval := config.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	onError := config.OnError
+	if onError == nil {
+		onError = func(error) {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	poll := func() {
+		pollCtx, cancelPoll := context.WithTimeout(ctx, timeout)
+		defer cancelPoll()
+		level, err := fetch(pollCtx)
+		if err == nil && level != "" {
+			err = l.SetLevel(strings.ToLower(level))
+		}
+		if err != nil && ctx.Err() == nil {
+			onError(fmt.Errorf("gologger: remote level: %w", err))
+		}
+	}
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			poll()
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			<-stopped
+		})
+	}, nil
+}
+
+// fetchLevel requests the level from url.
+func fetchLevel(ctx context.Context, client *http.Client, url string, headers map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	body = bytes.TrimSpace(body)
+	if bytes.HasPrefix(body, []byte("{")) {
+		var payload levelPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return "", fmt.Errorf("invalid response: %w", err)
+		}
+		return payload.Level, nil
+	}
+	return string(body), nil
+}
@@ -0,0 +1,112 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPollLevel(t *testing.T) {
+	var mu sync.Mutex
+	response := "warn"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		w.Write([]byte(response))
+	}))
+	defer server.Close()
+	setResponse := func(body string) {
+		mu.Lock()
+		response = body
+		mu.Unlock()
+	}
+
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelInfo})
+	defer log.Close()
+	errs := make(chan error, 10)
+	stop, err := log.PollLevel(RemoteLevelConfig{
+		URL:      server.URL,
+		Headers:  map[string]string{"Authorization": "Bearer token"},
+		Interval: 10 * time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer stop()
+
+	waitForLevel(t, log.minLevel, getLogLevel(LevelWarn))
+	setResponse(`{"level":"DEBUG"}`)
+	waitForLevel(t, log.minLevel, getLogLevel(LevelDebug))
+
+	// An unknown level is reported and keeps the current one.
+	setResponse("verbose")
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), `unknown level "verbose"`) {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the poll error")
+	}
+	if log.GetLevel() != LevelDebug {
+		t.Errorf("Expected the level to stay debug, got %s", log.GetLevel())
+	}
+}
+
+func TestPollLevelFetch(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelInfo})
+	defer log.Close()
+	errs := make(chan error, 10)
+	var calls atomic.Int32
+	stop, err := log.PollLevel(RemoteLevelConfig{
+		Fetch: func(ctx context.Context) (string, error) {
+			if calls.Add(1) > 1 {
+				return "", errors.New("etcd unavailable")
+			}
+			return LevelError, nil
+		},
+		Interval: 10 * time.Millisecond,
+		OnError: func(err error) {
+			select {
+			case errs <- err:
+			default:
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	select {
+	case err := <-errs:
+		if !strings.Contains(err.Error(), "gologger: remote level: etcd unavailable") {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for the poll error")
+	}
+	stop()
+	stop()
+	if log.GetLevel() != LevelError {
+		t.Errorf("Expected the fetched level, got %s", log.GetLevel())
+	}
+
+	if _, err := log.PollLevel(RemoteLevelConfig{}); err == nil {
+		t.Error("Expected error without URL or Fetch")
+	}
+}
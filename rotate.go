@@ -0,0 +1,751 @@
+package gologger
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Rotation intervals.
+const (
+	RotateHourly = "hourly"
+	RotateDaily  = "daily"
+	RotateWeekly = "weekly"
+)
+
+// fileCheckInterval is how often writes check the file for external changes.
+const fileCheckInterval = time.Second
+
+// backupTimeFormat is the format of the rotation time in the names of
+// size-rotated backups, e.g. "logger-2024-03-01-2024-03-01T10-30-00.000.log".
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// File layouts.
+const (
+	LayoutFlat  = "flat"  // Dated file names in the log directory: "logger-2024-06-15.log"
+	LayoutDated = "dated" // A directory per day: "2024/06/15/logger.log"
+)
+
+// rotatingFile writes to a dated file in a directory, named after the start
+// of the current rotation period ("logger-2024-03-01.log", or
+// "logger-2024-03-01-15.log" when rotating hourly), or placed in a directory
+// per day with LayoutDated ("2024/03/01/logger.log"). It switches to a new file
+// when the period ends and moves the file aside when it grows past the size
+// limit. Finished files are compressed, passed to the OnRotate hook and old
+// backups removed in the background; files of earlier periods are only
+// removed with PrunePeriods.
+type rotatingFile struct {
+	dir        string
+	name       string
+	interval   string
+	layout     string
+	maxSize    int64
+	maxBackups int
+	maxAge     time.Duration
+	maxTotal   int64
+	prune      bool // Whether MaxBackups and MaxAge apply to the files of earlier periods
+	compress   bool
+	onRotate   func(path string) error
+	link       string
+	onError    func(error)    // Receives background errors (optional)
+	encrypt    *fileEncrypter // Encrypts writes (nil if disabled)
+	fsync      bool           // Whether Sync and closing a file flush it to stable storage
+	now        func() time.Time
+
+	mu      sync.Mutex
+	file    *os.File
+	path    string
+	size    int64
+	end     time.Time
+	checked time.Time // When the file was last opened or verified
+	segment bool      // Whether an encryption header was written since the file was opened
+
+	millMu sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// newRotatingFile creates a rotating file writer for files named name in dir,
+// applying the default rotation values for unset options.
+func newRotatingFile(dir, name string, rotationConfig *LogRotationConfig) *rotatingFile {
+	cfg := resolveRotation(rotationConfig)
+	now := time.Now
+	if cfg.UTC {
+		now = func() time.Time { return time.Now().UTC() }
+	}
+
+	return &rotatingFile{
+		dir:        dir,
+		name:       name,
+		interval:   cfg.Interval,
+		layout:     cfg.Layout,
+		maxSize:    int64(cfg.MaxSize) * 1024 * 1024,
+		maxBackups: cfg.MaxBackups,
+		maxAge:     time.Duration(cfg.MaxAge) * 24 * time.Hour,
+		maxTotal:   int64(cfg.MaxTotalSizeMB) * 1024 * 1024,
+		prune:      cfg.PrunePeriods,
+		compress:   cfg.Compress,
+		onRotate:   cfg.OnRotate,
+		link:       cfg.CurrentLink,
+		now:        now,
+	}
+}
+
+// resolveRotation returns rotationConfig with the default rotation values
+// applied to unset options.
+func resolveRotation(rotationConfig *LogRotationConfig) LogRotationConfig {
+	// Set default rotation values if not provided
+	cfg := LogRotationConfig{
+		MaxSize:    10,
+		MaxBackups: 3,
+		MaxAge:     28,
+		Compress:   true,
+		Interval:   RotateDaily,
+		Layout:     LayoutFlat,
+	}
+	if rotationConfig == nil {
+		return cfg
+	}
+
+	if rotationConfig.MaxSize > 0 {
+		cfg.MaxSize = rotationConfig.MaxSize
+	}
+	if rotationConfig.MaxBackups >= 0 {
+		cfg.MaxBackups = rotationConfig.MaxBackups
+	}
+	if rotationConfig.MaxAge > 0 {
+		cfg.MaxAge = rotationConfig.MaxAge
+	}
+	if rotationConfig.MaxTotalSizeMB > 0 {
+		cfg.MaxTotalSizeMB = rotationConfig.MaxTotalSizeMB
+	}
+	cfg.Compress = rotationConfig.Compress
+	if rotationConfig.Interval != "" {
+		cfg.Interval = rotationConfig.Interval
+	}
+	if rotationConfig.Layout != "" {
+		cfg.Layout = rotationConfig.Layout
+	}
+	cfg.PrunePeriods = rotationConfig.PrunePeriods
+	cfg.RotateOnSIGHUP = rotationConfig.RotateOnSIGHUP
+	cfg.CleanupInterval = rotationConfig.CleanupInterval
+	cfg.OnRotate = rotationConfig.OnRotate
+	cfg.CurrentLink = rotationConfig.CurrentLink
+	cfg.UTC = rotationConfig.UTC
+	return cfg
+}
+
+// Write writes p to the file of the current period, switching files first
+// when the period has ended or the file would exceed the size limit. At most
+// once per fileCheckInterval, it also checks that the file was not moved,
+// removed or truncated by another process.
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.file == nil || !now.Before(r.end) {
+		if err := r.openPeriod(now); err != nil {
+			return 0, err
+		}
+	} else if now.Sub(r.checked) >= fileCheckInterval {
+		if err := r.verify(now); err != nil {
+			return 0, err
+		}
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(now); err != nil {
+			return 0, err
+		}
+	}
+	if r.encrypt != nil {
+		return r.writeEncrypted(p)
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// writeEncrypted writes p as an encrypted record, preceded by a header when
+// it is the first write since the file was opened. It must be called with r.mu held.
+func (r *rotatingFile) writeEncrypted(p []byte) (int, error) {
+	var buf []byte
+	var err error
+	if !r.segment {
+		if buf, err = r.encrypt.header(buf); err != nil {
+			return 0, err
+		}
+	}
+	if buf, err = r.encrypt.seal(buf, p); err != nil {
+		return 0, err
+	}
+
+	n, err := r.file.Write(buf)
+	r.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	r.segment = true
+	return len(p), nil
+}
+
+// Sync flushes the current file to stable storage if fsync is enabled.
+// Otherwise it is a no-op; writes go directly to the file.
+func (r *rotatingFile) Sync() error {
+	if !r.fsync {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Sync()
+}
+
+// Rotate moves the current file aside and starts a new one. If the file was
+// moved or removed by an external tool such as logrotate, it is reopened instead.
+func (r *rotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.file == nil || !now.Before(r.end) || r.moved() {
+		if err := r.openPeriod(now); err != nil {
+			return err
+		}
+	}
+	if r.size == 0 {
+		return nil
+	}
+	return r.rotate(now)
+}
+
+// Close closes the current file and waits for background compression and
+// cleanup to finish. A later Write reopens the file.
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	err := r.closeFile()
+	r.mu.Unlock()
+
+	r.wg.Wait()
+	return err
+}
+
+// closeFile closes the current file, flushing it to stable storage first if
+// fsync is enabled. It must be called with r.mu held.
+func (r *rotatingFile) closeFile() error {
+	if r.file == nil {
+		return nil
+	}
+	var err error
+	if r.fsync {
+		err = r.file.Sync()
+	}
+	err = errors.Join(err, r.file.Close())
+	r.file = nil
+	return err
+}
+
+// openPeriod closes the current file and opens, or creates, the file of the
+// period containing now. It must be called with r.mu held.
+func (r *rotatingFile) openPeriod(now time.Time) error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+
+	start := periodStart(now, r.interval)
+	path := r.periodPath(start)
+	var finished string
+	if r.path != "" && r.path != path {
+		finished = r.path
+	}
+	r.end = periodEnd(start, r.interval)
+	r.path = path
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("rotate: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	r.checked = now
+	r.segment = false
+	if r.link != "" {
+		r.report(r.updateLink())
+	}
+	// Files are only processed when a period ends, never when the first one
+	// is opened, so starting a logger does not remove existing files.
+	if finished != "" {
+		r.startMill(now, finished)
+	}
+	return nil
+}
+
+// periodPath returns the path of the file of the period starting at start.
+func (r *rotatingFile) periodPath(start time.Time) string {
+	if r.layout == LayoutDated {
+		name := r.name
+		if r.interval == RotateHourly {
+			name += start.Format("-15")
+		}
+		return filepath.Join(r.dir, start.Format("2006"), start.Format("01"), start.Format("02"), name+".log")
+	}
+	return filepath.Join(r.dir, r.name+"-"+periodStamp(start, r.interval)+".log")
+}
+
+// updateLink points the CurrentLink symlink at the current file. The link
+// is replaced atomically, so readers following it never see it missing.
+// It must be called with r.mu held.
+func (r *rotatingFile) updateLink() error {
+	link := filepath.Join(r.dir, r.link)
+	tmp := link + ".tmp"
+	_ = os.Remove(tmp)
+	target, err := filepath.Rel(r.dir, r.path)
+	if err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	if err := os.Symlink(target, tmp); err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("rotate: %w", err)
+	}
+	return nil
+}
+
+// verify reopens the file if it was moved or removed by another process, so
+// entries are not written to a deleted file, and picks up its new size if it
+// was truncated, e.g. by logrotate's copytruncate. It must be called with r.mu held.
+func (r *rotatingFile) verify(now time.Time) error {
+	r.checked = now
+	if r.moved() {
+		return r.openPeriod(now)
+	}
+	if info, err := r.file.Stat(); err == nil && info.Size() < r.size {
+		r.size = info.Size()
+		r.segment = false
+	}
+	return nil
+}
+
+// moved reports whether the open file is no longer at its path.
+// It must be called with r.mu held.
+func (r *rotatingFile) moved() bool {
+	pathInfo, err := os.Stat(r.path)
+	if err != nil {
+		return true
+	}
+	fileInfo, err := r.file.Stat()
+	if err != nil {
+		return true
+	}
+	return !os.SameFile(pathInfo, fileInfo)
+}
+
+// rotate moves the current file aside under a timestamped name and opens
+// a new one in its place. It must be called with r.mu held.
+func (r *rotatingFile) rotate(now time.Time) error {
+	if err := r.closeFile(); err != nil {
+		return err
+	}
+
+	backup := strings.TrimSuffix(r.path, ".log") + "-" + now.Format(backupTimeFormat) + ".log"
+	if err := os.Rename(r.path, backup); err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("rotate: %w", err)
+	}
+	r.file = file
+	r.size = 0
+	r.segment = false
+	r.startMill(now, backup)
+	return nil
+}
+
+// startMill processes the just finished file, if any, and compresses and
+// removes old files in the background. It must be called with r.mu held.
+func (r *rotatingFile) startMill(now time.Time, finished string) {
+	current := r.path
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.report(r.mill(now, finished, current))
+	}()
+}
+
+// report passes a background error to onError, if set.
+func (r *rotatingFile) report(err error) {
+	if err != nil && r.onError != nil {
+		r.onError(err)
+	}
+}
+
+// mill compresses the finished file and passes it to the OnRotate hook.
+// It then compresses the size-rotated backups of current, or all
+// finished files with PrunePeriods, and removes those beyond MaxBackups or
+// last modified before MaxAge, then the oldest finished files while the
+// directory is larger than MaxTotalSizeMB. The file being written is left alone.
+func (r *rotatingFile) mill(now time.Time, finished, current string) error {
+	r.millMu.Lock()
+	defer r.millMu.Unlock()
+
+	var errs []error
+	if finished != "" {
+		if r.compress {
+			if _, err := os.Stat(finished + ".gz"); err == nil {
+				// Already compressed by an earlier pass over the directory.
+				finished += ".gz"
+			} else if err := gzipFile(finished); err != nil {
+				errs = append(errs, fmt.Errorf("rotate: %w", err))
+			} else {
+				finished += ".gz"
+			}
+		}
+		if r.onRotate != nil {
+			if err := r.onRotate(finished); err != nil {
+				errs = append(errs, fmt.Errorf("rotate: %s: %w", filepath.Base(finished), err))
+			}
+		}
+	}
+
+	files, err := r.oldFiles()
+	if err != nil {
+		return errors.Join(append(errs, err)...)
+	}
+
+	var kept []rotatedFile
+	cutoff := now.Add(-r.maxAge)
+	backups := 0
+	for _, file := range files {
+		if !r.prune && !isBackupOf(file.path, current) {
+			kept = append(kept, file)
+			continue
+		}
+		if (r.maxBackups > 0 && backups >= r.maxBackups) || (r.maxAge > 0 && file.modTime.Before(cutoff)) {
+			errs = append(errs, r.remove(file.path))
+			continue
+		}
+		backups++
+		if r.compress && !strings.HasSuffix(file.path, ".gz") {
+			if err := gzipFile(file.path); err != nil {
+				errs = append(errs, err)
+			} else {
+				file.path += ".gz"
+			}
+		}
+		kept = append(kept, file)
+	}
+
+	if r.maxTotal > 0 {
+		errs = append(errs, r.capTotalSize(kept))
+	}
+	return errors.Join(errs...)
+}
+
+// capTotalSize removes files, oldest first, until all files in the directory
+// take at most MaxTotalSizeMB. Files of other outputs count toward the total
+// but are never removed.
+func (r *rotatingFile) capTotalSize(files []rotatedFile) error {
+	total, err := dirSize(r.dir)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for i := len(files) - 1; i >= 0 && total > r.maxTotal; i-- {
+		info, err := os.Stat(files[i].path)
+		if err != nil {
+			continue
+		}
+		if err := r.remove(files[i].path); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		total -= info.Size()
+	}
+	return errors.Join(errs...)
+}
+
+// remove removes a finished file. With LayoutDated, the directories left
+// empty are removed as well.
+func (r *rotatingFile) remove(path string) error {
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	if r.layout == LayoutDated {
+		rel, err := filepath.Rel(r.dir, filepath.Dir(path))
+		for ; err == nil && rel != "."; rel = filepath.Dir(rel) {
+			if os.Remove(filepath.Join(r.dir, rel)) != nil {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// dirSize returns the total size of the regular files in dir and its subdirectories.
+func dirSize(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		if info, err := entry.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total, err
+}
+
+// rotatedFile is a finished log file found in the log directory.
+type rotatedFile struct {
+	path    string
+	modTime time.Time
+}
+
+// oldFiles returns the finished files of this writer, newest first. Names
+// embed the period and rotation time, so they sort in the order files were written.
+func (r *rotatingFile) oldFiles() ([]rotatedFile, error) {
+	var files []rotatedFile
+	err := filepath.WalkDir(r.dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != r.dir && r.layout != LayoutDated {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() || !r.owns(path) || r.isCurrent(path) {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			files = append(files, rotatedFile{path: path, modTime: info.ModTime()})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].path > files[j].path
+	})
+	return files, nil
+}
+
+// owns reports whether the file at path was written by this writer: a
+// "name-*.log" file in the log directory, or a "name.log" or "name-*.log"
+// file in a day directory with LayoutDated, optionally compressed.
+func (r *rotatingFile) owns(path string) bool {
+	name := filepath.Base(path)
+	if !strings.HasSuffix(name, ".log") && !strings.HasSuffix(name, ".log.gz") {
+		return false
+	}
+	rel, err := filepath.Rel(r.dir, path)
+	if err != nil {
+		return false
+	}
+	if r.layout == LayoutDated {
+		return strings.Count(filepath.ToSlash(rel), "/") == 3 &&
+			(strings.HasPrefix(name, r.name+".") || strings.HasPrefix(name, r.name+"-"))
+	}
+	return rel == name && strings.HasPrefix(name, r.name+"-")
+}
+
+// isCurrent reports whether path is the file being written. Once a file is
+// finished it does not become current again, as periods only move forward.
+func (r *rotatingFile) isCurrent(path string) bool {
+	return path == r.currentPath()
+}
+
+// currentPath returns the path of the file being written, or "" before the
+// first write.
+func (r *rotatingFile) currentPath() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.path
+}
+
+// isBackupOf reports whether path is a size-rotated backup of the file at
+// current, optionally compressed.
+func isBackupOf(path, current string) bool {
+	if current == "" {
+		return false
+	}
+	stamp, ok := strings.CutPrefix(strings.TrimSuffix(path, ".gz"), strings.TrimSuffix(current, ".log")+"-")
+	if !ok {
+		return false
+	}
+	_, err := time.Parse(backupTimeFormat, strings.TrimSuffix(stamp, ".log"))
+	return err == nil
+}
+
+// gzipFile compresses the file at path to path+".gz" and removes the
+// original. The compressed file keeps the original modification time.
+func gzipFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return err
+	}
+
+	dst, err := os.OpenFile(path+".gz", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	w := gzip.NewWriter(dst)
+	if _, err := io.Copy(w, src); err != nil {
+		_ = dst.Close()
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	if err := errors.Join(w.Close(), dst.Close()); err != nil {
+		_ = os.Remove(path + ".gz")
+		return err
+	}
+	_ = os.Chtimes(path+".gz", info.ModTime(), info.ModTime())
+	return os.Remove(path)
+}
+
+// startFileTasks starts the background tasks of file enabled in cfg: SIGHUP
+// handling and periodic cleanup. It returns a function stopping them, or nil
+// if none is enabled.
+func startFileTasks(file *rotatingFile, cfg *LogRotationConfig) func() {
+	if cfg == nil {
+		return nil
+	}
+	var stops []func()
+	if cfg.RotateOnSIGHUP {
+		stops = append(stops, notifyRotate(file))
+	}
+	if cfg.CleanupInterval > 0 {
+		stops = append(stops, pruneEvery(file, cfg.CleanupInterval))
+	}
+	if len(stops) == 0 {
+		return nil
+	}
+	return func() {
+		for _, stop := range stops {
+			stop()
+		}
+	}
+}
+
+// pruneEvery compresses and removes old files of file on every interval
+// until the returned stop function is called, so MaxAge and MaxTotalSizeMB
+// are honoured even when nothing is written for a long time. Failures are
+// reported to the file's onError.
+func pruneEvery(file *rotatingFile, interval time.Duration) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				file.report(file.mill(file.now(), "", file.currentPath()))
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+		})
+	}
+}
+
+// notifyRotate rotates file whenever the process receives SIGHUP, until the
+// returned stop function is called. Failed rotations are reported to the
+// file's onError.
+func notifyRotate(file *rotatingFile) func() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-signals:
+				file.report(file.Rotate())
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+}
+
+// periodStart returns the start of the rotation period containing t.
+// Weeks start on Monday.
+func periodStart(t time.Time, interval string) time.Time {
+	year, month, day := t.Date()
+	switch interval {
+	case RotateHourly:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, t.Location())
+	case RotateWeekly:
+		return time.Date(year, month, day-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// periodEnd returns the end of the rotation period starting at start.
+func periodEnd(start time.Time, interval string) time.Time {
+	switch interval {
+	case RotateHourly:
+		return start.Add(time.Hour)
+	case RotateWeekly:
+		return start.AddDate(0, 0, 7)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// periodStamp formats the start of a rotation period for file names.
+func periodStamp(start time.Time, interval string) string {
+	if interval == RotateHourly {
+		return start.Format("2006-01-02-15")
+	}
+	return start.Format("2006-01-02")
+}
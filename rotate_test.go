@@ -0,0 +1,533 @@
+package gologger
+
+import (
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// testClock returns a clock for rotatingFile.now that can be moved forward.
+func testClock(start time.Time) (func() time.Time, func(time.Duration)) {
+	now := start
+	return func() time.Time { return now }, func(d time.Duration) { now = now.Add(d) }
+}
+
+func dirFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRotatingFileDaily(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false})
+	now, advance := testClock(time.Date(2024, 3, 1, 23, 59, 30, 0, time.Local))
+	file.now = now
+
+	if _, err := file.Write([]byte("before midnight\n")); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	advance(time.Minute)
+	if _, err := file.Write([]byte("after midnight\n")); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	expected := map[string]string{
+		"logger-2024-03-01.log": "before midnight\n",
+		"logger-2024-03-02.log": "after midnight\n",
+	}
+	for name, want := range expected {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		if string(data) != want {
+			t.Errorf("Expected %s to contain %q, got %q", name, want, data)
+		}
+	}
+}
+
+func TestRotatingFileHourly(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Interval: RotateHourly, Compress: true})
+	now, advance := testClock(time.Date(2024, 3, 1, 9, 15, 0, 0, time.Local))
+	file.now = now
+
+	for i := 0; i < 3; i++ {
+		if _, err := file.Write([]byte("line\n")); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+		advance(time.Hour)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	got := strings.Join(dirFiles(t, dir), ",")
+	want := "logger-2024-03-01-09.log.gz,logger-2024-03-01-10.log.gz,logger-2024-03-01-11.log"
+	if got != want {
+		t.Errorf("Expected files %s, got %s", want, got)
+	}
+}
+
+func TestRotatingFileSize(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "access", &LogRotationConfig{MaxBackups: 2, Compress: true})
+	file.maxSize = 10
+	now, advance := testClock(time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local))
+	file.now = now
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+		advance(time.Second)
+	}
+	if err := file.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	names := dirFiles(t, dir)
+	if len(names) != 3 {
+		t.Fatalf("Expected the current file and 2 backups, got %v", names)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "access-2024-03-01.log"))
+	if string(data) != "fourth\n" {
+		t.Errorf("Expected current file to contain the last line, got %q", data)
+	}
+
+	var backups []string
+	for _, name := range names {
+		if name == "access-2024-03-01.log" {
+			continue
+		}
+		if !strings.HasSuffix(name, ".log.gz") {
+			t.Fatalf("Expected compressed backup, got %s", name)
+		}
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Failed to open backup: %v", err)
+		}
+		reader, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf("Invalid gzip backup: %v", err)
+		}
+		content, _ := io.ReadAll(reader)
+		f.Close()
+		backups = append(backups, string(content))
+	}
+	if strings.Join(backups, "") != "second\nthird\n" {
+		t.Errorf("Expected the two newest backups to be kept, got %q", backups)
+	}
+}
+
+func TestRotatingFileKeepsEarlierPeriods(t *testing.T) {
+	dir := t.TempDir()
+	today := time.Date(2024, 3, 20, 10, 0, 0, 0, time.Local)
+	var existing []string
+	for day := 10; day > 0; day-- {
+		name := "logger-" + today.AddDate(0, 0, -day).Format("2006-01-02") + ".log"
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte("entry\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+		old := time.Now().AddDate(0, 0, -60)
+		os.Chtimes(path, old, old)
+		existing = append(existing, name)
+	}
+
+	file := newRotatingFile(dir, "logger", nil)
+	file.maxSize = 10
+	now, advance := testClock(today)
+	file.now = now
+	for i := 0; i < 6; i++ {
+		if _, err := file.Write([]byte("a new line\n")); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+		advance(time.Second)
+	}
+	advance(24 * time.Hour)
+	file.Write([]byte("tomorrow\n"))
+	file.Close()
+
+	var backups int
+	names := dirFiles(t, dir)
+	for _, name := range names {
+		if strings.HasPrefix(name, "logger-2024-03-20-") {
+			backups++
+		}
+	}
+	if backups != 3 {
+		t.Errorf("Expected MaxBackups to keep 3 backups of the current file, got %v", names)
+	}
+	for _, name := range existing {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Expected the file of an earlier period to be kept untouched: %v", err)
+		}
+	}
+}
+
+func TestRotatingFileAppends(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logger-"+time.Now().Format("2006-01-02")+".log")
+	if err := os.WriteFile(path, []byte("existing\n"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	file := newRotatingFile(dir, "logger", nil)
+	if _, err := file.Write([]byte("appended\n")); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	file.Close()
+
+	data, _ := os.ReadFile(path)
+	if string(data) != "existing\nappended\n" {
+		t.Errorf("Expected the existing file to be appended to, got %q", data)
+	}
+}
+
+func TestRotatingFileTotalSize(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"other.txt", "logger-2024-03-01.log.gz", "logger-2024-03-02.log.gz", "logger-2024-03-03.log"} {
+		if err := os.WriteFile(filepath.Join(dir, name), make([]byte, 20), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{MaxBackups: 0, Compress: false})
+	file.maxAge = 0
+	file.maxTotal = 70
+	file.path = filepath.Join(dir, "logger-2024-03-03.log")
+	if err := file.mill(time.Now(), "", file.path); err != nil {
+		t.Fatalf("Unexpected mill error: %v", err)
+	}
+
+	got := strings.Join(dirFiles(t, dir), ",")
+	want := "logger-2024-03-02.log.gz,logger-2024-03-03.log,other.txt"
+	if got != want {
+		t.Errorf("Expected files %s, got %s", want, got)
+	}
+}
+
+func TestRotatingFileOnRotate(t *testing.T) {
+	dir := t.TempDir()
+	var rotated []string
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{
+		Compress: true,
+		OnRotate: func(path string) error {
+			rotated = append(rotated, filepath.Base(path))
+			return errors.New("upload failed")
+		},
+	})
+	var reported []error
+	file.onError = func(err error) { reported = append(reported, err) }
+	now, advance := testClock(time.Date(2024, 3, 1, 23, 0, 0, 0, time.Local))
+	file.now = now
+
+	file.Write([]byte("first\n"))
+	file.Rotate()
+	file.wg.Wait()
+	file.Write([]byte("second\n"))
+	advance(time.Hour)
+	file.Write([]byte("third\n"))
+	file.Close()
+
+	want := "logger-2024-03-01-2024-03-01T23-00-00.000.log.gz,logger-2024-03-01.log.gz"
+	if got := strings.Join(rotated, ","); got != want {
+		t.Errorf("Expected hook calls for %s, got %s", want, got)
+	}
+	if len(reported) != 2 || !strings.Contains(reported[0].Error(), "upload failed") {
+		t.Errorf("Expected hook errors to be reported, got %v", reported)
+	}
+}
+
+func TestRotatingFileCurrentLink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Symlinks require extra privileges on Windows")
+	}
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false, CurrentLink: "current.log"})
+	var reported []error
+	file.onError = func(err error) { reported = append(reported, err) }
+	now, advance := testClock(time.Date(2024, 3, 1, 23, 30, 0, 0, time.Local))
+	file.now = now
+	defer file.Close()
+
+	link := filepath.Join(dir, "current.log")
+	for _, day := range []string{"2024-03-01", "2024-03-02"} {
+		file.Write([]byte(day + "\n"))
+		target, err := os.Readlink(link)
+		if err != nil {
+			t.Fatalf("Failed to read link: %v", err)
+		}
+		if target != "logger-"+day+".log" {
+			t.Errorf("Expected link to logger-%s.log, got %s", day, target)
+		}
+		data, _ := os.ReadFile(link)
+		if string(data) != day+"\n" {
+			t.Errorf("Expected to read the active file through the link, got %q", data)
+		}
+		advance(time.Hour)
+	}
+	if len(reported) != 0 {
+		t.Errorf("Unexpected errors %v", reported)
+	}
+}
+
+func TestRotatingFileDatedLayout(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "app", &LogRotationConfig{Layout: LayoutDated, MaxBackups: 1, PrunePeriods: true, Compress: false})
+	now, advance := testClock(time.Date(2024, 6, 14, 12, 0, 0, 0, time.Local))
+	file.now = now
+
+	for i := 0; i < 3; i++ {
+		if _, err := file.Write([]byte("line\n")); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+		advance(24 * time.Hour)
+	}
+	file.Close()
+
+	var got []string
+	filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		rel, _ := filepath.Rel(dir, path)
+		got = append(got, filepath.ToSlash(rel))
+		return nil
+	})
+	want := ".,2024,2024/06,2024/06/15,2024/06/15/app.log,2024/06/16,2024/06/16/app.log"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Expected %s, got %s", want, strings.Join(got, ","))
+	}
+
+	hourly := newRotatingFile(dir, "app", &LogRotationConfig{Layout: LayoutDated, Interval: RotateHourly})
+	path := hourly.periodPath(time.Date(2024, 6, 15, 9, 0, 0, 0, time.Local))
+	if want := filepath.Join(dir, "2024", "06", "15", "app-09.log"); path != want {
+		t.Errorf("Expected hourly path %s, got %s", want, path)
+	}
+}
+
+func TestRotatingFileExternalChanges(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false})
+	now, advance := testClock(time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local))
+	file.now = now
+	defer file.Close()
+	path := filepath.Join(dir, "logger-2024-03-01.log")
+
+	// Truncated in place, as logrotate's copytruncate does.
+	file.Write([]byte("before truncate\n"))
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatalf("Failed to truncate: %v", err)
+	}
+	advance(fileCheckInterval)
+	file.Write([]byte("after truncate\n"))
+	if data, _ := os.ReadFile(path); string(data) != "after truncate\n" {
+		t.Errorf("Expected writes to continue at the start of the truncated file, got %q", data)
+	}
+	if file.size != int64(len("after truncate\n")) {
+		t.Errorf("Expected size to be picked up after truncation, got %d", file.size)
+	}
+
+	// Deleted while open.
+	if err := os.Remove(path); err != nil {
+		t.Fatalf("Failed to remove: %v", err)
+	}
+	file.Write([]byte("within check interval\n"))
+	advance(fileCheckInterval)
+	file.Write([]byte("after delete\n"))
+	if data, _ := os.ReadFile(path); string(data) != "after delete\n" {
+		t.Errorf("Expected the deleted file to be recreated, got %q", data)
+	}
+}
+
+func TestRotatingFileUTC(t *testing.T) {
+	if local := newRotatingFile(t.TempDir(), "logger", nil); local.now().Location() != time.Local {
+		t.Error("Expected local time by default")
+	}
+
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{UTC: true, Compress: false})
+	if file.now().Location() != time.UTC {
+		t.Fatal("Expected UTC clock")
+	}
+	file.now, _ = testClock(time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC))
+	file.Write([]byte("entry\n"))
+	file.Rotate()
+	file.Close()
+
+	names := strings.Join(dirFiles(t, dir), ",")
+	if names != "logger-2024-03-01-2024-03-01T23-30-00.000.log,logger-2024-03-01.log" {
+		t.Errorf("Unexpected files: %s", names)
+	}
+
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:  OutputFile,
+		LogDir:      dir,
+		LogRotation: &LogRotationConfig{UTC: true},
+	})
+	log.Info("utc entry").Send()
+	log.Close()
+	utc := &rotatingFile{dir: dir, name: "logger", interval: RotateDaily}
+	if _, err := os.Stat(utc.periodPath(time.Now().UTC())); err != nil {
+		t.Errorf("Expected the log file to be named after the UTC date: %v", err)
+	}
+}
+
+func TestPruneEvery(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "logger-2024-01-01.log")
+	recent := filepath.Join(dir, "logger-2024-03-01.log")
+	for _, path := range []string{old, recent} {
+		if err := os.WriteFile(path, []byte("entry\n"), 0644); err != nil {
+			t.Fatalf("Failed to write file: %v", err)
+		}
+	}
+	now := time.Now()
+	os.Chtimes(old, now.AddDate(0, 0, -10), now.AddDate(0, 0, -10))
+
+	rotation := &LogRotationConfig{MaxAge: 7, PrunePeriods: true, CleanupInterval: 10 * time.Millisecond}
+	file := newRotatingFile(dir, "logger", rotation)
+	stop := startFileTasks(file, rotation)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if _, err := os.Stat(old); os.IsNotExist(err) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stop()
+	stop()
+
+	names := strings.Join(dirFiles(t, dir), ",")
+	if names != "logger-2024-03-01.log" {
+		t.Errorf("Expected the old file removed without any write, got %s", names)
+	}
+	if startFileTasks(file, &LogRotationConfig{}) != nil {
+		t.Error("Expected no background tasks by default")
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	// Thursday
+	at := time.Date(2024, 2, 29, 17, 42, 10, 0, time.UTC)
+	tests := []struct {
+		interval string
+		start    time.Time
+		end      time.Time
+		stamp    string
+	}{
+		{RotateHourly, time.Date(2024, 2, 29, 17, 0, 0, 0, time.UTC), time.Date(2024, 2, 29, 18, 0, 0, 0, time.UTC), "2024-02-29-17"},
+		{RotateDaily, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), "2024-02-29"},
+		{RotateWeekly, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), "2024-02-26"},
+	}
+	for _, tt := range tests {
+		start := periodStart(at, tt.interval)
+		if !start.Equal(tt.start) {
+			t.Errorf("%s: expected start %v, got %v", tt.interval, tt.start, start)
+		}
+		if end := periodEnd(start, tt.interval); !end.Equal(tt.end) {
+			t.Errorf("%s: expected end %v, got %v", tt.interval, tt.end, end)
+		}
+		if stamp := periodStamp(start, tt.interval); stamp != tt.stamp {
+			t.Errorf("%s: expected stamp %s, got %s", tt.interval, tt.stamp, stamp)
+		}
+	}
+}
+
+func TestRotatingFileRotate(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false})
+	now, advance := testClock(time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local))
+	file.now = now
+
+	// Nothing written yet, so there is nothing to move aside.
+	if err := file.Rotate(); err != nil {
+		t.Fatalf("Unexpected rotate error: %v", err)
+	}
+	file.Write([]byte("before\n"))
+	advance(time.Second)
+	if err := file.Rotate(); err != nil {
+		t.Fatalf("Unexpected rotate error: %v", err)
+	}
+	file.Write([]byte("after\n"))
+	file.Close()
+
+	got := strings.Join(dirFiles(t, dir), ",")
+	want := "logger-2024-03-01-2024-03-01T10-00-01.000.log,logger-2024-03-01.log"
+	if got != want {
+		t.Fatalf("Expected files %s, got %s", want, got)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "logger-2024-03-01.log"))
+	if string(data) != "after\n" {
+		t.Errorf("Expected new file to contain %q, got %q", "after\n", data)
+	}
+}
+
+func TestRotatingFileReopen(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false})
+	file.now, _ = testClock(time.Date(2024, 3, 1, 10, 0, 0, 0, time.Local))
+
+	path := filepath.Join(dir, "logger-2024-03-01.log")
+	file.Write([]byte("before\n"))
+	// Move the file away as logrotate does before sending SIGHUP.
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatalf("Failed to move file: %v", err)
+	}
+	if err := file.Rotate(); err != nil {
+		t.Fatalf("Unexpected rotate error: %v", err)
+	}
+	file.Write([]byte("after\n"))
+	file.Close()
+
+	got := strings.Join(dirFiles(t, dir), ",")
+	if got != "logger-2024-03-01.log,logger-2024-03-01.log.1" {
+		t.Fatalf("Expected the file to be reopened, got %s", got)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != "after\n" {
+		t.Errorf("Expected reopened file to contain %q, got %q", "after\n", data)
+	}
+}
+
+func TestNotifyRotate(t *testing.T) {
+	dir := t.TempDir()
+	file := newRotatingFile(dir, "logger", &LogRotationConfig{Compress: false})
+	stop := notifyRotate(file)
+	defer stop()
+
+	file.Write([]byte("before\n"))
+	process, _ := os.FindProcess(os.Getpid())
+	if err := process.Signal(syscall.SIGHUP); err != nil {
+		t.Skipf("Cannot send SIGHUP: %v", err)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for len(dirFiles(t, dir)) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected SIGHUP to rotate the file, got %v", dirFiles(t, dir))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	stop()
+	file.Close()
+}
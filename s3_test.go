@@ -0,0 +1,88 @@
+package gologger
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestS3UploaderUpload(t *testing.T) {
+	type request struct {
+		method, path, contentType, auth, sha string
+		body                                 string
+	}
+	received := make(chan request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- request{r.Method, r.URL.EscapedPath(), r.Header.Get("Content-Type"), r.Header.Get("Authorization"), r.Header.Get("X-Amz-Content-Sha256"), string(body)}
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "logger-2024-03-01.log.gz")
+	if err := os.WriteFile(path, []byte("archive"), 0644); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	uploader := NewS3Uploader(S3UploaderConfig{
+		Bucket:            "app-logs",
+		Prefix:            "web 1/",
+		Region:            "ap-southeast-3",
+		Endpoint:          server.URL,
+		AccessKeyID:       "AKID",
+		SecretAccessKey:   "secret",
+		DeleteAfterUpload: true,
+	})
+	if err := uploader.Upload(path); err != nil {
+		t.Fatalf("Unexpected upload error: %v", err)
+	}
+
+	req := <-received
+	if req.method != http.MethodPut || req.path != "/app-logs/web%201/logger-2024-03-01.log.gz" {
+		t.Errorf("Unexpected request %s %s", req.method, req.path)
+	}
+	if req.contentType != "application/gzip" || req.body != "archive" {
+		t.Errorf("Unexpected content %q of type %s", req.body, req.contentType)
+	}
+	if !strings.HasPrefix(req.auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(req.auth, "/ap-southeast-3/s3/aws4_request") {
+		t.Errorf("Unexpected Authorization header %q", req.auth)
+	}
+	if req.sha != sha256Hex([]byte("archive")) {
+		t.Errorf("Unexpected payload hash %s", req.sha)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Expected the file to be deleted after upload")
+	}
+}
+
+func TestS3UploaderErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "logger-2024-03-01.log")
+	_ = os.WriteFile(path, []byte("line\n"), 0644)
+
+	uploader := NewS3Uploader(S3UploaderConfig{Bucket: "b", Region: "us-east-1", Endpoint: server.URL, DeleteAfterUpload: true})
+	err := uploader.Upload(path)
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected AccessDenied error, got %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("Expected the file to be kept after a failed upload")
+	}
+	if err := uploader.Upload(filepath.Join(t.TempDir(), "missing.log")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestS3UploaderDefaultURL(t *testing.T) {
+	uploader := NewS3Uploader(S3UploaderConfig{Bucket: "app-logs", Region: "eu-west-1"})
+	if uploader.url != "https://app-logs.s3.eu-west-1.amazonaws.com" {
+		t.Errorf("Unexpected URL %s", uploader.url)
+	}
+}
@@ -0,0 +1,128 @@
+package gologger
+
+import (
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// SamplingConfig limits the number of entries with the same level and
+// message logged per Tick: the first Initial are logged, then every
+// Thereafter-th, so hot loops cannot flood the outputs while still showing
+// that the event occurs.
+type SamplingConfig struct {
+	Initial    int           // Entries logged per Tick before sampling starts (default: 100)
+	Thereafter int           // Log every Thereafter-th entry after Initial; 0 drops them all (default: 0)
+	Tick       time.Duration // Period after which the counters are reset (default: 1s)
+}
+
+// sampleCore wraps core so entries are sampled as set by cfg.
+func sampleCore(core zapcore.Core, cfg SamplingConfig) zapcore.Core {
+	if cfg.Initial <= 0 {
+		cfg.Initial = 100
+	}
+	if cfg.Tick <= 0 {
+		cfg.Tick = time.Second
+	}
+	return zapcore.NewSamplerWithOptions(core, cfg.Tick, cfg.Initial, cfg.Thereafter)
+}
+
+// Sample sets the sampling of the entry, overriding LoggerConfig.Sampling
+// for this call, e.g. to sample a hot loop harder than the rest of the
+// application, or to sample one when the logger does not:
+//
+//	log.Debug("cache miss").Sample(gologger.SamplingConfig{Initial: 10, Thereafter: 1000}).Data("key", key).Send()
+//
+// Entries are counted per level and message, as by LoggerConfig.Sampling,
+// by all copies of the logger calling Sample, with the defaults of
+// SamplingConfig for unset options. Like LoggerConfig.Sampling, a fixed
+// number of counters is kept per level, selected by a hash of the message,
+// so rare messages may share a counter with others; messages should be
+// constant, with variable parts in Data. Entries of disabled levels are
+// neither counted nor logged.
+func (l Logger) Sample(config SamplingConfig) Logger {
+	l.sampling = &config
+	return l
+}
+
+// Unsampled logs the entry whatever LoggerConfig.Sampling, for entries that
+// must not be lost among sampled ones, such as the first failure of a
+// dependency.
+func (l Logger) Unsampled() Logger {
+	l.sampling = noSampling
+	return l
+}
+
+// noSampling is the sampling of Unsampled entries.
+var noSampling = &SamplingConfig{}
+
+// callSampler counts the entries sampled with Logger.Sample. As zap's
+// sampler, it keeps a fixed number of counters per level, selected by a hash
+// of the message, so its memory does not grow with the messages logged;
+// messages sharing a counter are sampled together.
+type callSampler struct {
+	once     sync.Once
+	counters *[sampleLevels][sampleCounters]sampleCounter
+}
+
+const (
+	sampleLevels   = int(zapcore.FatalLevel-zapcore.DebugLevel) + 1
+	sampleCounters = 4096
+)
+
+// sampleCounter counts the entries of a counter in the current tick.
+type sampleCounter struct {
+	resetAt atomic.Int64 // Unix nanoseconds at which the tick ends
+	n       atomic.Uint64
+}
+
+// newCallSampler returns a sampler whose counters are allocated when it is
+// first used, since most loggers never call Sample.
+func newCallSampler() *callSampler {
+	return &callSampler{}
+}
+
+// allow reports whether an entry with level and message is logged with
+// sampling cfg: the first Initial of each Tick, then every Thereafter-th.
+func (s *callSampler) allow(level zapcore.Level, message string, cfg *SamplingConfig) bool {
+	if cfg == noSampling || level < zapcore.DebugLevel || level > zapcore.FatalLevel {
+		return true
+	}
+	initial, tick := uint64(max(cfg.Initial, 0)), cfg.Tick
+	if initial == 0 {
+		initial = 100
+	}
+	if tick <= 0 {
+		tick = time.Second
+	}
+
+	s.once.Do(func() {
+		s.counters = new([sampleLevels][sampleCounters]sampleCounter)
+	})
+	hash := fnv.New32a()
+	hash.Write([]byte(message))
+	counter := &s.counters[level-zapcore.DebugLevel][hash.Sum32()%sampleCounters]
+	n := counter.inc(time.Now(), tick)
+	if n <= initial {
+		return true
+	}
+	return cfg.Thereafter > 0 && (n-initial)%uint64(cfg.Thereafter) == 0
+}
+
+// inc counts an entry logged at now, starting a new tick if the current one
+// ended, and returns the number of entries of the tick.
+func (c *sampleCounter) inc(now time.Time, tick time.Duration) uint64 {
+	resetAt := c.resetAt.Load()
+	if resetAt > now.UnixNano() {
+		return c.n.Add(1)
+	}
+	c.n.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now.Add(tick).UnixNano()) {
+		// Another entry started the tick.
+		return c.n.Add(1)
+	}
+	return 1
+}
@@ -0,0 +1,125 @@
+package gologger
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampling(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		Sinks:      []Sink{capture},
+		Sampling:   &SamplingConfig{Initial: 3, Thereafter: 5, Tick: time.Minute},
+	})
+	defer log.Close()
+
+	for i := 0; i < 20; i++ {
+		log.Info("hot loop").Data("i", i).Send()
+	}
+	log.Info("other").Send()
+	log.Warn("hot loop").Send()
+
+	// 3 initial entries, then the 5th, 10th and 15th of the remaining 17.
+	var got []string
+	for _, entry := range capture.FilterMessage("hot loop") {
+		got = append(got, fmt.Sprint(entry.Level, entry.Fields["i"]))
+	}
+	expected := fmt.Sprint([]string{"info0", "info1", "info2", "info7", "info12", "info17", "warn<nil>"})
+	if fmt.Sprint(got) != expected {
+		t.Errorf("Expected %s, got %v", expected, got)
+	}
+	if len(capture.FilterMessage("other")) != 1 {
+		t.Error("Expected messages to be sampled separately")
+	}
+}
+
+func TestSampleOverride(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		Sinks:      []Sink{capture},
+		Sampling:   &SamplingConfig{Initial: 2, Tick: time.Minute},
+	})
+	defer log.Close()
+
+	for i := 0; i < 10; i++ {
+		log.Info("hot loop").Sample(SamplingConfig{Initial: 1, Thereafter: 3, Tick: time.Minute}).Data("i", i).Send()
+		log.Warn("dependency down").Unsampled().Data("i", i).Send()
+		log.Info("default").Send()
+	}
+
+	// The first, then every third of the remaining 9.
+	var got []any
+	for _, entry := range capture.FilterMessage("hot loop") {
+		got = append(got, entry.Fields["i"])
+	}
+	if fmt.Sprint(got) != "[0 3 6 9]" {
+		t.Errorf("Expected the per-call sampling, got %v", got)
+	}
+	if n := len(capture.FilterMessage("dependency down")); n != 10 {
+		t.Errorf("Expected every unsampled entry, got %d", n)
+	}
+	if n := len(capture.FilterMessage("default")); n != 2 {
+		t.Errorf("Expected LoggerConfig.Sampling for other entries, got %d", n)
+	}
+}
+
+func TestSampleWithoutConfig(t *testing.T) {
+	log, capture := NewTestLogger()
+
+	for i := 0; i < 5; i++ {
+		log.WithContext(WithRequestID(context.Background(), "req-42")).
+			Debug("polling").Sample(SamplingConfig{Initial: 2, Tick: time.Minute}).Send()
+	}
+	entries := capture.FilterMessage("polling")
+	if len(entries) != 2 || entries[0].Fields["request-id"] != "req-42" {
+		t.Errorf("Expected 2 sampled entries, got %+v", entries)
+	}
+	if !strings.Contains(entries[0].Caller, "sample_test.go:") {
+		t.Errorf("Expected the caller of Send, got %q", entries[0].Caller)
+	}
+}
+
+func TestSampleDisabledLevel(t *testing.T) {
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard, Sinks: []Sink{capture}, LogLevel: "info"})
+	defer log.Close()
+
+	sampling := SamplingConfig{Initial: 1, Tick: time.Minute}
+	for i := 0; i < 5; i++ {
+		log.Debug("polling").Sample(sampling).Send()
+	}
+	if log.sampler.counters != nil {
+		t.Error("Expected entries of disabled levels not to be counted")
+	}
+
+	if err := log.SetLevel("debug"); err != nil {
+		t.Fatal(err)
+	}
+	log.Debug("polling").Sample(sampling).Send()
+	log.Debug("polling").Sample(sampling).Send()
+	if n := len(capture.FilterMessage("polling")); n != 1 {
+		t.Errorf("Expected the first enabled entry logged, got %d", n)
+	}
+}
+
+func TestSampleBoundedCounters(t *testing.T) {
+	log, capture := NewTestLogger()
+
+	// Unique messages share the fixed counters instead of adding new ones.
+	sampling := SamplingConfig{Initial: 1000, Tick: time.Minute}
+	for i := 0; i < 2*sampleCounters; i++ {
+		log.Info(fmt.Sprint("unique ", i)).Sample(sampling).Send()
+	}
+	if n := len(capture.Entries()); n != 2*sampleCounters {
+		t.Errorf("Expected every entry within Initial logged, got %d", n)
+	}
+	log.Info("unique 0").Sample(SamplingConfig{Initial: 1, Tick: time.Minute}).Send()
+	if n := len(capture.FilterMessage("unique 0")); n != 1 {
+		t.Errorf("Expected a message counted with the ones sharing its counter, got %d", n)
+	}
+}
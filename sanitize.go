@@ -0,0 +1,100 @@
+package gologger
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sanitization modes for messages and string fields.
+const (
+	SanitizeStrip  = "strip"  // Remove control characters and replace invalid UTF-8 with U+FFFD
+	SanitizeEscape = "escape" // Replace control characters and invalid UTF-8 bytes with visible escapes
+)
+
+// getSanitizer returns the function applied to messages and string fields
+// for a Sanitize mode, or nil when sanitization is disabled.
+func getSanitizer(mode string) func(string) string {
+	switch mode {
+	case SanitizeStrip:
+		return sanitizeStrip
+	case SanitizeEscape:
+		return sanitizeEscape
+	default:
+		return nil
+	}
+}
+
+// unsafeRune reports whether r is a control character or line separator
+// that could split or forge log lines.
+func unsafeRune(r rune) bool {
+	return r < 0x20 || r == 0x7f || (r >= 0x80 && r <= 0x9f) || r == '\u2028' || r == '\u2029'
+}
+
+// needsSanitizing reports whether s contains unsafe runes or invalid UTF-8.
+func needsSanitizing(s string) bool {
+	for _, r := range s {
+		if r == utf8.RuneError || unsafeRune(r) {
+			return true
+		}
+	}
+	return false
+}
+
+func sanitizeStrip(s string) string {
+	if !needsSanitizing(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if !unsafeRune(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+func sanitizeEscape(s string) string {
+	if !needsSanitizing(s) {
+		return s
+	}
+	var b strings.Builder
+	b.Grow(len(s) + 8)
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&b, `\x%02x`, s[i])
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, r)
+		case unsafeRune(r):
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return b.String()
+}
+
+// sanitizeValue applies sanitize to string, error and fmt.Stringer values.
+// Other values are returned unchanged.
+func sanitizeValue(v any, sanitize func(string) string) any {
+	switch v := v.(type) {
+	case string:
+		return sanitize(v)
+	case error:
+		return sanitize(v.Error())
+	case fmt.Stringer:
+		return sanitize(v.String())
+	default:
+		return v
+	}
+}
@@ -0,0 +1,91 @@
+package gologger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSanitizeStrip(t *testing.T) {
+	tests := map[string]string{
+		"plain text":                    "plain text",
+		"user\r\nINFO forged entry":     "userINFO forged entry",
+		"tab\there":                     "tabhere",
+		"bell\x07 and del\x7f":          "bell and del",
+		"bad \xff byte":                 "bad � byte",
+		"line\u2028separator\u0085next": "lineseparatornext",
+		"café ✓":                        "café ✓",
+	}
+	for input, want := range tests {
+		if got := sanitizeStrip(input); got != want {
+			t.Errorf("sanitizeStrip(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSanitizeEscape(t *testing.T) {
+	tests := map[string]string{
+		"plain text":                "plain text",
+		"user\r\nINFO forged entry": `user\r\nINFO forged entry`,
+		"tab\there":                 `tab\there`,
+		"bell\x07":                  `bell\x07`,
+		"bad \xff byte":             `bad \xff byte`,
+		"line\u2028end":             `line\u2028end`,
+		"café":                      "café",
+	}
+	for input, want := range tests {
+		if got := sanitizeEscape(input); got != want {
+			t.Errorf("sanitizeEscape(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestSanitizeConfig(t *testing.T) {
+	if getSanitizer("") != nil {
+		t.Error("Expected no sanitizer by default")
+	}
+
+	capture := NewCaptureSink()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		Sanitize:   SanitizeEscape,
+		Sinks:      []Sink{capture},
+	})
+	log.Info("login\nforged").
+		Data("user", "alice\r\nadmin").
+		Data("count", 3).
+		ErrorData(errors.New("bad\x00input")).
+		Send()
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Message != `login\nforged` {
+		t.Errorf("Expected escaped message, got %q", entry.Message)
+	}
+	if entry.Fields["user"] != `alice\r\nadmin` || entry.Fields["error"] != `bad\x00input` {
+		t.Errorf("Expected escaped string fields, got %v", entry.Fields)
+	}
+	if entry.Fields["count"] != int64(3) {
+		t.Errorf("Expected non-string fields unchanged, got %v", entry.Fields["count"])
+	}
+}
+
+func TestSanitizeConsoleOutput(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputFile,
+		LogDir:     tempDir,
+		Encoding:   EncodingConsole,
+		Sanitize:   SanitizeStrip,
+	})
+	log.Info("user alice\r\n2024-03-01 10:30:00.000 INFO  granted admin").Send()
+	log.Close()
+
+	content := readTestLogFile(t, tempDir)
+	if lines := strings.Count(content, "\n"); lines != 1 {
+		t.Errorf("Expected a single line, got %d: %q", lines, content)
+	}
+}
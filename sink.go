@@ -0,0 +1,404 @@
+package gologger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Entry is a single log record as delivered to a Sink.
+type Entry struct {
+	Time    time.Time      // Time the entry was created
+	Level   string         // Level name: "debug", "info", "warn", "error", "panic" or "fatal"
+	Message string         // Log message
+	Caller  string         // Caller as "file:line" (empty when caller information is disabled)
+	Stack   string         // Stack trace, if one was captured
+	Fields  map[string]any // Data fields, including request and trace IDs
+	Logger  string         // Component name given to Named (empty for unnamed loggers)
+}
+
+// Sink is an additional log destination that receives every entry at or above
+// the configured log level. Sinks are attached through LoggerConfig.Sinks and
+// closed by Logger.Close.
+type Sink interface {
+	// Write delivers a single entry to the sink.
+	Write(entry Entry) error
+	// Sync flushes any buffered entries.
+	Sync() error
+	// Close flushes buffered entries and releases the sink's resources.
+	Close() error
+}
+
+// attachedSink is a sink or raw core attached to a logger under an ID.
+type attachedSink struct {
+	id   string
+	core zapcore.Core
+	sink Sink // nil for raw cores
+}
+
+// sinkSet holds the sinks attached to a logger. It is shared by all copies of
+// the logger, and sinks may be added or removed while the logger is in use.
+type sinkSet struct {
+	mu     sync.Mutex
+	level  zapcore.LevelEnabler
+	stats  *loggerStats
+	nextID int
+	sinks  atomic.Pointer[[]attachedSink]
+}
+
+func newSinkSet(level zapcore.LevelEnabler, stats *loggerStats) *sinkSet {
+	set := &sinkSet{level: level, stats: stats}
+	set.sinks.Store(&[]attachedSink{})
+	return set
+}
+
+// load returns the currently attached sinks.
+func (s *sinkSet) load() []attachedSink {
+	return *s.sinks.Load()
+}
+
+// addSink attaches a sink at the set's level and returns its ID.
+func (s *sinkSet) addSink(sink Sink) string {
+	return s.add(func(id string) zapcore.Core {
+		return s.coreFor(id, sink)
+	}, sink)
+}
+
+// coreFor returns the core writing to a sink attached under id.
+func (s *sinkSet) coreFor(id string, sink Sink) zapcore.Core {
+	return &sinkCore{LevelEnabler: s.level, sink: sink, name: id, stats: s.stats}
+}
+
+// addCore attaches a raw core and returns its ID.
+func (s *sinkSet) addCore(core zapcore.Core) string {
+	return s.add(func(string) zapcore.Core { return core }, nil)
+}
+
+// add attaches the core built for a new ID (and the sink it wraps, if any)
+// and returns the ID.
+func (s *sinkSet) add(build func(id string) zapcore.Core, sink Sink) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	id := "sink-" + strconv.Itoa(s.nextID)
+	core := build(id)
+	current := s.load()
+	updated := append(make([]attachedSink, 0, len(current)+1), current...)
+	updated = append(updated, attachedSink{id: id, core: core, sink: sink})
+	s.sinks.Store(&updated)
+	return id
+}
+
+// remove detaches the sink with the given ID.
+func (s *sinkSet) remove(id string) (attachedSink, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.load()
+	for i, attached := range current {
+		if attached.id == id {
+			updated := append(make([]attachedSink, 0, len(current)-1), current[:i]...)
+			updated = append(updated, current[i+1:]...)
+			s.sinks.Store(&updated)
+			return attached, true
+		}
+	}
+	return attachedSink{}, false
+}
+
+// replace detaches the sinks with the given IDs and attaches sinks in their
+// place in one step, so every entry reaches either the old or the new sinks.
+// It returns the IDs of the new sinks and the detached ones.
+func (s *sinkSet) replace(ids []string, sinks []Sink) ([]string, []attachedSink) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	current := s.load()
+	updated := make([]attachedSink, 0, len(current)+len(sinks))
+	var detached []attachedSink
+	for _, attached := range current {
+		if slices.Contains(ids, attached.id) {
+			detached = append(detached, attached)
+		} else {
+			updated = append(updated, attached)
+		}
+	}
+	newIDs := make([]string, 0, len(sinks))
+	for _, sink := range sinks {
+		s.nextID++
+		id := "sink-" + strconv.Itoa(s.nextID)
+		updated = append(updated, attachedSink{id: id, core: s.coreFor(id, sink), sink: sink})
+		newIDs = append(newIDs, id)
+	}
+	s.sinks.Store(&updated)
+	return newIDs, detached
+}
+
+// closeAll syncs every attached core and closes every attached sink.
+func (s *sinkSet) closeAll() error {
+	var errs []error
+	for _, attached := range s.load() {
+		if attached.sink != nil {
+			errs = append(errs, attached.sink.Close())
+		} else {
+			errs = append(errs, attached.core.Sync())
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// dynamicCore tees entries to the sinks currently attached to a sinkSet.
+type dynamicCore struct {
+	set    *sinkSet
+	fields []zapcore.Field
+}
+
+func (c *dynamicCore) Enabled(level zapcore.Level) bool {
+	for _, attached := range c.set.load() {
+		if attached.core.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *dynamicCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dynamicCore{
+		set:    c.set,
+		fields: append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...),
+	}
+}
+
+func (c *dynamicCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	for _, attached := range c.set.load() {
+		core := attached.core
+		if len(c.fields) > 0 {
+			core = core.With(c.fields)
+		}
+		ce = core.Check(ent, ce)
+	}
+	return ce
+}
+
+func (c *dynamicCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	var errs []error
+	for _, attached := range c.set.load() {
+		errs = append(errs, attached.core.Write(ent, append(c.fields[:len(c.fields):len(c.fields)], fields...)))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *dynamicCore) Sync() error {
+	var errs []error
+	for _, attached := range c.set.load() {
+		errs = append(errs, attached.core.Sync())
+	}
+	return errors.Join(errs...)
+}
+
+// sinkCore adapts a Sink to zapcore.Core so it can be teed with the
+// terminal and file outputs. Failed writes and syncs are reported under
+// the sink's name.
+type sinkCore struct {
+	zapcore.LevelEnabler
+	sink   Sink
+	name   string
+	stats  *loggerStats
+	fields []zapcore.Field
+}
+
+func (c *sinkCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := *c
+	clone.fields = append(append(make([]zapcore.Field, 0, len(c.fields)+len(fields)), c.fields...), fields...)
+	return &clone
+}
+
+func (c *sinkCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *sinkCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	err := c.sink.Write(entryFromZap(ent, append(c.fields[:len(c.fields):len(c.fields)], fields...)))
+	if err != nil {
+		c.stats.reportSinkError(c.name, err)
+	}
+	if ent.Level > zapcore.ErrorLevel {
+		// Flush buffered entries before a panic or fatal exit, like zap's own cores.
+		_ = c.Sync()
+	}
+	return err
+}
+
+func (c *sinkCore) Sync() error {
+	err := c.sink.Sync()
+	if err != nil {
+		c.stats.reportSinkError(c.name, err)
+	}
+	return err
+}
+
+// entryFromZap converts a zap entry and its fields into an Entry.
+func entryFromZap(ent zapcore.Entry, fields []zapcore.Field) Entry {
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range fields {
+		field.AddTo(enc)
+	}
+
+	entry := Entry{
+		Time:    ent.Time,
+		Level:   ent.Level.String(),
+		Message: ent.Message,
+		Stack:   ent.Stack,
+		Fields:  enc.Fields,
+		Logger:  ent.LoggerName,
+	}
+	if ent.Caller.Defined {
+		entry.Caller = ent.Caller.TrimmedPath()
+	}
+	return entry
+}
+
+// splitCaller splits a "file:line" caller into its file and line parts.
+func splitCaller(caller string) (string, int) {
+	idx := strings.LastIndexByte(caller, ':')
+	if idx < 0 {
+		return caller, 0
+	}
+	line, err := strconv.Atoi(caller[idx+1:])
+	if err != nil {
+		return caller, 0
+	}
+	return caller[:idx], line
+}
+
+// normalizeValue reduces a field value to one of nil, string, bool, int64,
+// uint64, float64, []byte, []any or map[string]any so sinks with their own
+// wire formats only have to handle a small set of types.
+func normalizeValue(v any) any {
+	switch v := v.(type) {
+	case nil, string, bool, int64, uint64, float64, []byte:
+		return v
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint:
+		return uint64(v)
+	case uint8:
+		return uint64(v)
+	case uint16:
+		return uint64(v)
+	case uint32:
+		return uint64(v)
+	case uintptr:
+		return uint64(v)
+	case float32:
+		return float64(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return v.String()
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	case []any:
+		values := make([]any, len(v))
+		for i, item := range v {
+			values[i] = normalizeValue(item)
+		}
+		return values
+	case map[string]any:
+		values := make(map[string]any, len(v))
+		for key, item := range v {
+			values[key] = normalizeValue(item)
+		}
+		return values
+	}
+
+	// Fall back to a JSON round trip for structs, typed slices and maps.
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return fmt.Sprint(v)
+	}
+	return normalizeJSON(decoded)
+}
+
+// normalizeJSON converts json.Number values produced by a UseNumber decoder.
+func normalizeJSON(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeJSON(item)
+		}
+		return v
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeJSON(item)
+		}
+		return v
+	}
+	return v
+}
+
+// sortedKeys returns the keys of fields in lexical order.
+func sortedKeys[V any](fields map[string]V) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// entryJSON encodes an entry as a flat JSON object using the same standard
+// keys as the terminal and file outputs.
+func entryJSON(entry Entry) ([]byte, error) {
+	obj := make(map[string]any, len(entry.Fields)+5)
+	for key, value := range entry.Fields {
+		obj[key] = normalizeValue(value)
+	}
+	obj["timestamp"] = entry.Time.Format("2006-01-02T15:04:05.000Z07:00")
+	obj["level"] = strings.ToUpper(entry.Level)
+	obj["msg"] = entry.Message
+	if entry.Logger != "" {
+		obj["logger"] = entry.Logger
+	}
+	if entry.Caller != "" {
+		obj["caller"] = entry.Caller
+	}
+	if entry.Stack != "" {
+		obj["stacktrace"] = entry.Stack
+	}
+	return json.Marshal(obj)
+}
@@ -0,0 +1,245 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// recordingSink is a Sink that keeps every entry in memory.
+type recordingSink struct {
+	mu      sync.Mutex
+	entries []Entry
+	synced  int
+	closed  bool
+}
+
+func (s *recordingSink) Write(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *recordingSink) Sync() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.synced++
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+func (s *recordingSink) Entries() []Entry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Entry(nil), s.entries...)
+}
+
+func TestSinkReceivesEntries(t *testing.T) {
+	sink := &recordingSink{}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputTerminal,
+		LogLevel:   LevelInfo,
+		ShowCaller: true,
+		Sinks:      []Sink{sink},
+	})
+
+	ctx := WithTraceContext(WithRequestID(context.Background(), "req-1"), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+	log.Debug("filtered out").Send()
+	log.WithContext(ctx).Warn("disk almost full").Data("free_mb", 42).ErrorData(errors.New("low space")).Send()
+	log.Close()
+
+	entries := sink.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+
+	entry := entries[0]
+	if entry.Level != LevelWarn {
+		t.Errorf("Expected level warn, got %s", entry.Level)
+	}
+	if entry.Message != "disk almost full" {
+		t.Errorf("Expected message 'disk almost full', got %s", entry.Message)
+	}
+	if entry.Caller == "" {
+		t.Error("Expected caller to be set")
+	}
+	if entry.Time.IsZero() {
+		t.Error("Expected time to be set")
+	}
+
+	expected := map[string]any{
+		"request-id": "req-1",
+		TraceIDField: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanIDField:  "00f067aa0ba902b7",
+		"free_mb":    int64(42),
+		"error":      "low space",
+	}
+	for key, value := range expected {
+		if entry.Fields[key] != value {
+			t.Errorf("Expected field %s to be %v, got %v", key, value, entry.Fields[key])
+		}
+	}
+
+	if !sink.closed {
+		t.Error("Expected sink to be closed by Logger.Close")
+	}
+}
+
+func TestGetTraceContext_NoTrace(t *testing.T) {
+	traceID, spanID := GetTraceContext(context.Background())
+	if traceID != "" || spanID != "" {
+		t.Errorf("Expected empty trace context, got %q/%q", traceID, spanID)
+	}
+}
+
+func TestSplitCaller(t *testing.T) {
+	tests := []struct {
+		input string
+		file  string
+		line  int
+	}{
+		{"pkg/file.go:42", "pkg/file.go", 42},
+		{"file.go", "file.go", 0},
+		{"file.go:abc", "file.go:abc", 0},
+	}
+
+	for _, test := range tests {
+		file, line := splitCaller(test.input)
+		if file != test.file || line != test.line {
+			t.Errorf("splitCaller(%s): expected %s/%d, got %s/%d", test.input, test.file, test.line, file, line)
+		}
+	}
+}
+
+func TestNormalizeValue(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	tests := []struct {
+		input    any
+		expected any
+	}{
+		{"text", "text"},
+		{42, int64(42)},
+		{uint8(7), uint64(7)},
+		{float32(1.5), 1.5},
+		{2 * time.Second, "2s"},
+		{errors.New("boom"), "boom"},
+	}
+	for _, test := range tests {
+		if result := normalizeValue(test.input); result != test.expected {
+			t.Errorf("normalizeValue(%v): expected %v (%T), got %v (%T)", test.input, test.expected, test.expected, result, result)
+		}
+	}
+
+	obj, ok := normalizeValue(payload{Name: "widget", Count: 3}).(map[string]any)
+	if !ok {
+		t.Fatal("Expected struct to normalize to a map")
+	}
+	if obj["name"] != "widget" || obj["count"] != int64(3) {
+		t.Errorf("Unexpected normalized struct: %v", obj)
+	}
+}
+
+func TestAddAndRemoveSink(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelInfo,
+	})
+	defer log.Close()
+
+	log.Info("before attach").Send()
+
+	sink := &recordingSink{}
+	id := log.AddSink(sink)
+
+	// Copies of the logger share attached sinks
+	ctxLogger := log.WithContext(WithRequestID(context.Background(), "req-1"))
+	ctxLogger.Info("while attached").Send()
+	log.Debug("below level").Send()
+
+	if err := log.RemoveSink(id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Info("after detach").Send()
+
+	entries := sink.Entries()
+	if len(entries) != 1 || entries[0].Message != "while attached" {
+		t.Errorf("Expected only the entry logged while attached, got %v", entries)
+	}
+	if !sink.closed {
+		t.Error("Expected RemoveSink to close the sink")
+	}
+
+	if err := log.RemoveSink(id); err == nil {
+		t.Error("Expected error when removing an unknown sink")
+	}
+}
+
+func TestAddCore(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelWarn,
+	})
+	defer log.Close()
+
+	core, observed := observer.New(zapcore.DebugLevel)
+	id := log.AddCore(core)
+
+	// Raw cores apply their own level, so debug entries reach them
+	log.Debug("debug for observer").Data("key", "value").Send()
+
+	if observed.Len() != 1 {
+		t.Fatalf("Expected 1 observed entry, got %d", observed.Len())
+	}
+	if observed.All()[0].ContextMap()["key"] != "value" {
+		t.Errorf("Unexpected fields %v", observed.All()[0].ContextMap())
+	}
+
+	if err := log.RemoveSink(id); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Warn("after detach").Send()
+	if observed.Len() != 1 {
+		t.Error("Expected no entries after the core was removed")
+	}
+}
+
+func TestConcurrentAddSink(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelInfo,
+	})
+	defer log.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			id := log.AddSink(&recordingSink{})
+			_ = log.RemoveSink(id)
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				log.Info("concurrent").Send()
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,134 @@
+package gologger
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"slices"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// slogHandler is a log/slog handler writing records through a Logger.
+type slogHandler struct {
+	log    Logger
+	base   *zap.Logger
+	prefix string // Open groups, each followed by a dot
+	data   []any  // Fields added by WithAttrs, with their group prefix
+}
+
+// NewSlogHandler returns a log/slog handler writing records through log, so
+// libraries using the standard slog API reach the outputs, sinks and levels
+// of the logger:
+//
+//	slog.SetDefault(slog.New(gologger.NewSlogHandler(log)))
+//
+// Levels below slog.LevelInfo are logged as debug, and levels between the
+// standard ones are rounded down. The request ID and trace context are taken
+// from the context passed to the slog call, e.g. slog.InfoContext, or
+// otherwise from the context of log. Attributes of groups are logged with
+// dotted keys, e.g. "request.method", and the time and caller are those of
+// the slog call; records with a zero time are logged without one.
+func NewSlogHandler(log Logger) slog.Handler {
+	return &slogHandler{log: log, base: log.log.Desugar()}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.base.Core().Enabled(slogLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	entry := h.log.Log(LevelDebug, record.Message)
+	if ctx != nil && hasLogContext(ctx) {
+		entry.ctx = ctx
+	}
+	entry.data = slices.Clip(h.data)
+	record.Attrs(func(attr slog.Attr) bool {
+		entry.data = appendSlogAttr(entry.data, h.prefix, attr)
+		return true
+	})
+	levelName, message, data, ok := entry.before(slogLevel(record.Level).String(), record.Message, entry.fields())
+	if !ok {
+		return nil
+	}
+	level, _ := zapcore.ParseLevel(levelName)
+	message, data = entry.prepare(message, data)
+
+	ce := h.base.Check(level, message)
+	if ce == nil {
+		return nil
+	}
+	// A record without a time is logged without one, as slog requires.
+	ce.Time = record.Time
+	if ce.Caller.Defined && record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		ce.Caller = zapcore.EntryCaller{Defined: true, PC: frame.PC, File: frame.File, Line: frame.Line, Function: frame.Function}
+	}
+	fields := make([]zap.Field, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		fields = append(fields, zap.Any(data[i].(string), data[i+1]))
+	}
+	ce.Write(fields...)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handler := *h
+	handler.data = slices.Clip(h.data)
+	for _, attr := range attrs {
+		handler.data = appendSlogAttr(handler.data, h.prefix, attr)
+	}
+	return &handler
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	handler := *h
+	handler.prefix += name + "."
+	return &handler
+}
+
+// appendSlogAttr appends attr to data as key-value pairs, with the attributes
+// of groups flattened into dotted keys. Empty attributes and groups are
+// dropped, as slog handlers do.
+func appendSlogAttr(data []any, prefix string, attr slog.Attr) []any {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		// A group without a key is inlined.
+		if attr.Key != "" {
+			prefix += attr.Key + "."
+		}
+		for _, member := range value.Group() {
+			data = appendSlogAttr(data, prefix, member)
+		}
+		return data
+	}
+	if attr.Key == "" && value.Any() == nil {
+		return data
+	}
+	return append(data, prefix+attr.Key, value.Any())
+}
+
+// slogLevel returns the level of entries for a slog level.
+func slogLevel(level slog.Level) zapcore.Level {
+	switch {
+	case level < slog.LevelInfo:
+		return zapcore.DebugLevel
+	case level < slog.LevelWarn:
+		return zapcore.InfoLevel
+	case level < slog.LevelError:
+		return zapcore.WarnLevel
+	default:
+		return zapcore.ErrorLevel
+	}
+}
+
+// hasLogContext reports whether ctx carries a request ID, trace context or
+// fields.
+func hasLogContext(ctx context.Context) bool {
+	traceID, spanID := GetTraceContext(ctx)
+	return GetRequestID(ctx) != "" || traceID != "" || spanID != "" || len(GetFields(ctx)) > 0
+}
@@ -0,0 +1,142 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+)
+
+func TestSlogHandler(t *testing.T) {
+	log, capture := NewTestLogger()
+	logger := slog.New(NewSlogHandler(log.Named("library")))
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	logger.InfoContext(ctx, "user created", "id", 7, slog.Duration("took", time.Second), "err", errors.New("retry"))
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != LevelInfo || entry.Message != "user created" || entry.Logger != "library" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Fields["request-id"] != "req-42" || entry.Fields["id"] != int64(7) ||
+		entry.Fields["took"] != time.Second || entry.Fields["err"] != "retry" {
+		t.Errorf("Unexpected fields %v", entry.Fields)
+	}
+	if !strings.HasSuffix(entry.Caller, "/slog_test.go:19") {
+		t.Errorf("Expected the caller of the slog call, got %q", entry.Caller)
+	}
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	log, capture := NewTestLogger()
+	_ = log.SetLevel(LevelInfo)
+	handler := NewSlogHandler(log)
+	logger := slog.New(handler)
+
+	if handler.Enabled(context.Background(), slog.LevelDebug) {
+		t.Error("Expected debug records to be disabled at info level")
+	}
+	logger.Debug("hidden")
+	logger.Log(context.Background(), slog.LevelInfo+2, "notice")
+	logger.Warn("slow")
+	logger.Log(context.Background(), slog.LevelError+4, "fatal-ish")
+
+	var levels []string
+	for _, entry := range capture.Entries() {
+		levels = append(levels, entry.Level)
+	}
+	if got := strings.Join(levels, ","); got != "info,warn,error" {
+		t.Errorf("Expected info,warn,error, got %s", got)
+	}
+}
+
+func TestSlogHandlerGroups(t *testing.T) {
+	log, capture := NewTestLogger()
+	logger := slog.New(NewSlogHandler(log.WithContext(WithRequestID(context.Background(), "req-7")))).
+		With("service", "billing").
+		WithGroup("request").
+		With("method", "POST")
+
+	logger.Info("charged",
+		slog.Group("card", "brand", "visa", "last4", "4242"),
+		slog.Group("", "inline", true),
+		slog.Group("empty"),
+		slog.Attr{},
+	)
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %d", len(entries))
+	}
+	want := map[string]any{
+		"request-id":         "req-7",
+		"service":            "billing",
+		"request.method":     "POST",
+		"request.card.brand": "visa",
+		"request.card.last4": "4242",
+		"request.inline":     true,
+	}
+	if len(entries[0].Fields) != len(want) {
+		t.Errorf("Expected fields %v, got %v", want, entries[0].Fields)
+	}
+	for key, value := range want {
+		if entries[0].Fields[key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, entries[0].Fields[key])
+		}
+	}
+}
+
+func TestSlogHandlerConformance(t *testing.T) {
+	log, capture := NewTestLogger()
+
+	// Dotted keys are the groups of the records.
+	results := func() []map[string]any {
+		var records []map[string]any
+		for _, entry := range capture.Entries() {
+			record := map[string]any{slog.LevelKey: entry.Level, slog.MessageKey: entry.Message}
+			if !entry.Time.IsZero() {
+				record[slog.TimeKey] = entry.Time
+			}
+			for key, value := range entry.Fields {
+				group := record
+				path := strings.Split(key, ".")
+				for _, name := range path[:len(path)-1] {
+					if _, ok := group[name].(map[string]any); !ok {
+						group[name] = map[string]any{}
+					}
+					group = group[name].(map[string]any)
+				}
+				group[path[len(path)-1]] = value
+			}
+			records = append(records, record)
+		}
+		return records
+	}
+	if err := slogtest.TestHandler(NewSlogHandler(log), results); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSlogHandlerZeroTime(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputFile, LogDir: dir})
+
+	record := slog.NewRecord(time.Time{}, slog.LevelInfo, "no time", 0)
+	if err := NewSlogHandler(log).Handle(context.Background(), record); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Close()
+
+	data, _ := os.ReadFile(currentLogFile(dir))
+	if out := string(data); !strings.Contains(out, `"msg":"no time"`) || strings.Contains(out, "timestamp") {
+		t.Errorf("Expected the entry without a timestamp, got %q", out)
+	}
+}
@@ -0,0 +1,204 @@
+package gologger
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SQL dialects supported by the SQL sink.
+const (
+	SQLDialectPostgres = "postgres"
+	SQLDialectSQLite   = "sqlite"
+)
+
+var sqlIdentifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// SQLConfig holds configuration options for the SQL audit sink.
+type SQLConfig struct {
+	DB            *sql.DB          // Database handle (required); the caller owns it and closes it
+	Dialect       string           // Dialect: SQLDialectPostgres or SQLDialectSQLite (default: SQLDialectPostgres)
+	Table         string           // Table name (default: "audit_logs")
+	Levels        []string         // Only record entries with these levels (optional, default: all levels)
+	Filter        func(Entry) bool // Only record entries for which Filter returns true (optional)
+	RequestIDKey  string           // Field copied into the request_id column (default: "request-id")
+	BatchSize     int              // Maximum rows per transaction (default: 100)
+	FlushInterval time.Duration    // Maximum time an entry waits before being inserted (default: 1s)
+	Timeout       time.Duration    // Timeout for a single batch insert (default: 10s)
+}
+
+// SQLSink inserts selected entries into a SQL table for durable, queryable
+// audit records. Rows are inserted in batches, one transaction per batch,
+// through a prepared statement. The table has the columns logged_at, level,
+// message, caller, request_id and fields (JSON); EnsureTable creates it.
+type SQLSink struct {
+	db           *sql.DB
+	dialect      string
+	table        string
+	levels       map[string]bool
+	filter       func(Entry) bool
+	requestIDKey string
+	timeout      time.Duration
+	batch        *batcher
+}
+
+// NewSQLSink creates a SQL sink. Unset options fall back to their defaults.
+// It panics if the table name is not a valid identifier, since the name is
+// interpolated into SQL statements.
+func NewSQLSink(config SQLConfig) *SQLSink {
+	dialect := config.Dialect
+	if dialect == "" {
+		dialect = SQLDialectPostgres
+	}
+	table := config.Table
+	if table == "" {
+		table = "audit_logs"
+	}
+	if !sqlIdentifierPattern.MatchString(table) {
+		panic(fmt.Sprintf("gologger: invalid SQL table name %q", table))
+	}
+	requestIDKey := config.RequestIDKey
+	if requestIDKey == "" {
+		requestIDKey = "request-id"
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	flushInterval := config.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	var levels map[string]bool
+	if len(config.Levels) > 0 {
+		levels = make(map[string]bool, len(config.Levels))
+		for _, level := range config.Levels {
+			levels[level] = true
+		}
+	}
+
+	s := &SQLSink{
+		db:           config.DB,
+		dialect:      dialect,
+		table:        table,
+		levels:       levels,
+		filter:       config.Filter,
+		requestIDKey: requestIDKey,
+		timeout:      timeout,
+	}
+	s.batch = newBatcher(batchSize, flushInterval, s.insert)
+	return s
+}
+
+// EnsureTable creates the audit table if it does not exist yet.
+func (s *SQLSink) EnsureTable(ctx context.Context) error {
+	timestampType := "TIMESTAMPTZ"
+	idType := "BIGSERIAL PRIMARY KEY"
+	if s.dialect == SQLDialectSQLite {
+		timestampType = "TIMESTAMP"
+		idType = "INTEGER PRIMARY KEY AUTOINCREMENT"
+	}
+
+	query := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	id %s,
+	logged_at %s NOT NULL,
+	level TEXT NOT NULL,
+	message TEXT NOT NULL,
+	caller TEXT,
+	request_id TEXT,
+	fields TEXT
+)`, s.table, idType, timestampType)
+
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("sql: create table %s: %w", s.table, err)
+	}
+	return nil
+}
+
+// Write queues an entry for insertion if it passes the level and filter selection.
+func (s *SQLSink) Write(entry Entry) error {
+	if s.levels != nil && !s.levels[entry.Level] {
+		return nil
+	}
+	if s.filter != nil && !s.filter(entry) {
+		return nil
+	}
+	return s.batch.Add(entry)
+}
+
+// Sync inserts all queued entries.
+func (s *SQLSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close inserts all queued entries and stops the background flush.
+// The database handle is left open.
+func (s *SQLSink) Close() error {
+	return s.batch.Close()
+}
+
+// insertQuery returns the INSERT statement for the configured dialect.
+func (s *SQLSink) insertQuery() string {
+	placeholders := "?, ?, ?, ?, ?, ?"
+	if s.dialect == SQLDialectPostgres {
+		placeholders = "$1, $2, $3, $4, $5, $6"
+	}
+	return fmt.Sprintf("INSERT INTO %s (logged_at, level, message, caller, request_id, fields) VALUES (%s)", s.table, placeholders)
+}
+
+// insert writes a batch of entries in a single transaction.
+func (s *SQLSink) insert(entries []Entry) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("sql: begin: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	stmt, err := tx.PrepareContext(ctx, s.insertQuery())
+	if err != nil {
+		return fmt.Errorf("sql: prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, entry := range entries {
+		var requestID sql.NullString
+		fields := make(map[string]any, len(entry.Fields))
+		for key, value := range entry.Fields {
+			if key == s.requestIDKey {
+				requestID = sql.NullString{String: fmt.Sprint(value), Valid: true}
+				continue
+			}
+			fields[key] = normalizeValue(value)
+		}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return fmt.Errorf("sql: encode fields: %w", err)
+		}
+
+		caller := sql.NullString{String: entry.Caller, Valid: entry.Caller != ""}
+		if _, err := stmt.ExecContext(ctx, entry.Time, strings.ToUpper(entry.Level), entry.Message, caller, requestID, string(data)); err != nil {
+			return fmt.Errorf("sql: insert: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("sql: commit: %w", err)
+	}
+	return nil
+}
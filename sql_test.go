@@ -0,0 +1,193 @@
+package gologger
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSQLDriver is a database/sql driver that records executed statements.
+type fakeSQLDriver struct {
+	mu        sync.Mutex
+	prepared  []string
+	execs     [][]driver.Value
+	commits   int
+	rollbacks int
+	failExec  bool
+}
+
+func (d *fakeSQLDriver) Open(name string) (driver.Conn, error) { return &fakeSQLConn{d: d}, nil }
+
+type fakeSQLConn struct{ d *fakeSQLDriver }
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	c.d.prepared = append(c.d.prepared, query)
+	return &fakeSQLStmt{d: c.d}, nil
+}
+func (c *fakeSQLConn) Close() error              { return nil }
+func (c *fakeSQLConn) Begin() (driver.Tx, error) { return &fakeSQLTx{d: c.d}, nil }
+
+type fakeSQLTx struct{ d *fakeSQLDriver }
+
+func (tx *fakeSQLTx) Commit() error {
+	tx.d.mu.Lock()
+	defer tx.d.mu.Unlock()
+	tx.d.commits++
+	return nil
+}
+
+func (tx *fakeSQLTx) Rollback() error {
+	tx.d.mu.Lock()
+	defer tx.d.mu.Unlock()
+	tx.d.rollbacks++
+	return nil
+}
+
+type fakeSQLStmt struct{ d *fakeSQLDriver }
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	if s.d.failExec {
+		return nil, errors.New("disk I/O error")
+	}
+	s.d.execs = append(s.d.execs, args)
+	return driver.RowsAffected(1), nil
+}
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("not supported")
+}
+
+var fakeSQLDrivers sync.Map
+
+func openFakeSQL(t *testing.T) (*sql.DB, *fakeSQLDriver) {
+	t.Helper()
+	d := &fakeSQLDriver{}
+	name := "gologger-fake-" + t.Name()
+	if _, loaded := fakeSQLDrivers.LoadOrStore(name, d); loaded {
+		t.Fatalf("Driver %s already registered", name)
+	}
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, d
+}
+
+func TestSQLSinkInsertsSelectedEntries(t *testing.T) {
+	db, d := openFakeSQL(t)
+
+	sink := NewSQLSink(SQLConfig{
+		DB:     db,
+		Table:  "security_audit",
+		Levels: []string{LevelWarn, LevelError},
+	})
+
+	now := time.Now()
+	_ = sink.Write(Entry{Time: now, Level: LevelInfo, Message: "ignored"})
+	_ = sink.Write(Entry{
+		Time:    now,
+		Level:   LevelWarn,
+		Message: "permission denied",
+		Caller:  "auth/check.go:12",
+		Fields:  map[string]any{"request-id": "req-7", "user_id": int64(42)},
+	})
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	if len(d.prepared) != 1 || d.prepared[0] != "INSERT INTO security_audit (logged_at, level, message, caller, request_id, fields) VALUES ($1, $2, $3, $4, $5, $6)" {
+		t.Errorf("Unexpected prepared statements %v", d.prepared)
+	}
+	if len(d.execs) != 1 {
+		t.Fatalf("Expected 1 insert, got %d", len(d.execs))
+	}
+	if d.commits != 1 {
+		t.Errorf("Expected 1 commit, got %d", d.commits)
+	}
+
+	args := d.execs[0]
+	if args[1] != "WARN" || args[2] != "permission denied" || args[3] != "auth/check.go:12" || args[4] != "req-7" {
+		t.Errorf("Unexpected insert arguments %v", args)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal([]byte(args[5].(string)), &fields); err != nil {
+		t.Fatalf("Expected JSON fields, got %v", args[5])
+	}
+	if _, ok := fields["request-id"]; ok || fields["user_id"] != float64(42) {
+		t.Errorf("Unexpected fields %v", fields)
+	}
+}
+
+func TestSQLSinkFilterAndDialect(t *testing.T) {
+	db, d := openFakeSQL(t)
+
+	sink := NewSQLSink(SQLConfig{
+		DB:      db,
+		Dialect: SQLDialectSQLite,
+		Filter: func(e Entry) bool {
+			return e.Fields["audit"] == true
+		},
+	})
+
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "skip"})
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelInfo, Message: "keep", Fields: map[string]any{"audit": true}})
+	_ = sink.Close()
+
+	if len(d.execs) != 1 || d.execs[0][2] != "keep" {
+		t.Errorf("Expected only the audit entry, got %v", d.execs)
+	}
+	if !strings.HasSuffix(d.prepared[0], "VALUES (?, ?, ?, ?, ?, ?)") {
+		t.Errorf("Expected SQLite placeholders, got %s", d.prepared[0])
+	}
+}
+
+func TestSQLSinkRollsBackOnError(t *testing.T) {
+	db, d := openFakeSQL(t)
+	d.failExec = true
+
+	sink := NewSQLSink(SQLConfig{DB: db})
+	_ = sink.Write(Entry{Time: time.Now(), Level: LevelError, Message: "boom"})
+
+	if err := sink.Sync(); err == nil || !strings.Contains(err.Error(), "disk I/O error") {
+		t.Errorf("Expected insert error, got %v", err)
+	}
+	if d.rollbacks != 1 || d.commits != 0 {
+		t.Errorf("Expected rollback without commit, got %d rollbacks and %d commits", d.rollbacks, d.commits)
+	}
+	_ = sink.Close()
+}
+
+func TestSQLSinkEnsureTable(t *testing.T) {
+	db, d := openFakeSQL(t)
+	sink := NewSQLSink(SQLConfig{DB: db, Dialect: SQLDialectSQLite})
+	defer sink.Close()
+
+	if err := sink.EnsureTable(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(d.prepared) != 1 || !strings.Contains(d.prepared[0], "CREATE TABLE IF NOT EXISTS audit_logs") {
+		t.Errorf("Unexpected statements %v", d.prepared)
+	}
+}
+
+func TestSQLSinkInvalidTable(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for invalid table name")
+		}
+	}()
+	NewSQLSink(SQLConfig{Table: "logs; DROP TABLE users"})
+}
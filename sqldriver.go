@@ -0,0 +1,391 @@
+package gologger
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// SQLLogConfig holds configuration options for the query logging of
+// WrapSQLDriver, WrapSQLConnector and OpenSQL.
+type SQLLogConfig struct {
+	Level         string        // Level of successful statements; failed ones are logged at error level (default: LevelDebug)
+	SlowThreshold time.Duration // Log statements taking at least this long at warn level (optional)
+	RedactArgs    bool          // Log arguments as "[REDACTED]", keeping their number (default: false)
+}
+
+// sqlLogger logs the statements of a wrapped database/sql driver.
+type sqlLogger struct {
+	log    Logger
+	level  string
+	slow   time.Duration
+	redact bool
+}
+
+func newSQLLogger(log Logger, config SQLLogConfig) *sqlLogger {
+	level := config.Level
+	if level == "" {
+		level = LevelDebug
+	}
+	return &sqlLogger{log: log, level: level, slow: config.SlowThreshold, redact: config.RedactArgs}
+}
+
+// entry returns the entry of an operation started at start, with the level
+// given by its outcome and duration. Its request ID is taken from ctx.
+func (s *sqlLogger) entry(ctx context.Context, msg string, start time.Time, err error) Logger {
+	duration := time.Since(start)
+	level := s.level
+	switch {
+	case err != nil:
+		level = LevelError
+	case s.slow > 0 && duration >= s.slow && getLogLevel(level) < getLogLevel(LevelWarn):
+		level = LevelWarn
+	}
+	return s.log.WithContext(ctx).Log(level, msg).
+		ErrorData(err).
+		Data("duration_ms", float64(duration)/float64(time.Millisecond))
+}
+
+// statement logs a query or exec of query with args. Statements the driver
+// skipped, to be retried by database/sql another way, are not logged.
+func (s *sqlLogger) statement(ctx context.Context, msg, query string, args []driver.NamedValue, start time.Time, result driver.Result, err error) {
+	if errors.Is(err, driver.ErrSkip) {
+		return
+	}
+	entry := s.entry(ctx, msg, start, err).Data("query", query)
+	if len(args) > 0 {
+		values := make([]any, len(args))
+		for i, arg := range args {
+			values[i] = arg.Value
+			if s.redact {
+				values[i] = "[REDACTED]"
+			}
+		}
+		entry = entry.Data("args", values)
+	}
+	if result != nil {
+		if rows, err := result.RowsAffected(); err == nil {
+			entry = entry.Data("rows_affected", rows)
+		}
+	}
+	entry.Send()
+}
+
+// WrapSQLDriver returns a database/sql driver that logs the statements run
+// through d with log: every query as "sql query" and exec as "sql exec", with
+// the statement, its arguments and duration, and the number of affected rows
+// for execs; and transactions as "sql begin", "sql commit" and "sql
+// rollback". Entries carry the request ID of the context passed to
+// database/sql, e.g. db.QueryContext(r.Context(), ...). Successful
+// statements are logged at config.Level, slow ones at warn and failed ones
+// at error level, with the error. Register the wrapped driver under its own
+// name, or use OpenSQL:
+//
+//	sql.Register("postgres-logged", gologger.WrapSQLDriver(&pq.Driver{}, log, gologger.SQLLogConfig{}))
+//	db, err := sql.Open("postgres-logged", dsn)
+func WrapSQLDriver(d driver.Driver, log Logger, config SQLLogConfig) driver.Driver {
+	return &sqlDriver{driver: d, log: newSQLLogger(log, config)}
+}
+
+// WrapSQLConnector returns a connector logging the statements run through c
+// as WrapSQLDriver does, for use with sql.OpenDB.
+func WrapSQLConnector(c driver.Connector, log Logger, config SQLLogConfig) driver.Connector {
+	logger := newSQLLogger(log, config)
+	return &sqlConnector{connector: c, driver: &sqlDriver{driver: c.Driver(), log: logger}, log: logger}
+}
+
+// OpenSQL opens a database like sql.Open, with its statements logged as by
+// WrapSQLDriver. The driver must be registered under driverName, usually by
+// importing its package.
+func OpenSQL(driverName, dataSourceName string, log Logger, config SQLLogConfig) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	// sql.Open does not connect; the handle is only needed for its driver.
+	d := db.Driver()
+	_ = db.Close()
+	connector, err := (&sqlDriver{driver: d, log: newSQLLogger(log, config)}).OpenConnector(dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// sqlDriver wraps the connections of a driver with sqlConn.
+type sqlDriver struct {
+	driver driver.Driver
+	log    *sqlLogger
+}
+
+func (d *sqlDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{conn: conn, log: d.log}, nil
+}
+
+func (d *sqlDriver) OpenConnector(name string) (driver.Connector, error) {
+	if dc, ok := d.driver.(driver.DriverContext); ok {
+		connector, err := dc.OpenConnector(name)
+		if err != nil {
+			return nil, err
+		}
+		return &sqlConnector{connector: connector, driver: d, log: d.log}, nil
+	}
+	return &sqlConnector{connector: dsnConnector{name: name, driver: d.driver}, driver: d, log: d.log}, nil
+}
+
+// dsnConnector opens connections of a driver without a connector of its own.
+type dsnConnector struct {
+	name   string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.name)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqlConnector wraps the connections of a connector with sqlConn.
+type sqlConnector struct {
+	connector driver.Connector
+	driver    *sqlDriver
+	log       *sqlLogger
+}
+
+func (c *sqlConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlConn{conn: conn, log: c.log}, nil
+}
+
+func (c *sqlConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqlConn logs the statements and transactions of a connection. It
+// implements the optional interfaces of database/sql/driver, passing the
+// calls on when the connection implements them and otherwise answering as
+// database/sql does without them.
+type sqlConn struct {
+	conn driver.Conn
+	log  *sqlLogger
+}
+
+func (c *sqlConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqlConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	start := time.Now()
+	var stmt driver.Stmt
+	var err error
+	if prep, ok := c.conn.(driver.ConnPrepareContext); ok {
+		stmt, err = prep.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.conn.Prepare(query)
+	}
+	if err != nil {
+		// Prepared statements are logged when they run, so only failures
+		// are logged here.
+		c.log.entry(ctx, "sql prepare", start, err).Data("query", query).Send()
+		return nil, err
+	}
+	return &sqlStmt{stmt: stmt, conn: c.conn, query: query, log: c.log}, nil
+}
+
+func (c *sqlConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *sqlConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *sqlConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	start := time.Now()
+	var tx driver.Tx
+	var err error
+	if begin, ok := c.conn.(driver.ConnBeginTx); ok {
+		tx, err = begin.BeginTx(ctx, opts)
+	} else if opts.Isolation != driver.IsolationLevel(sql.LevelDefault) || opts.ReadOnly {
+		err = errors.New("gologger: driver does not support non-default isolation levels or read-only transactions")
+	} else {
+		tx, err = c.conn.Begin()
+	}
+	c.log.entry(ctx, "sql begin", start, err).Send()
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{tx: tx, ctx: ctx, log: c.log}, nil
+}
+
+func (c *sqlConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.conn.(driver.ExecerContext)
+	if !ok {
+		// database/sql prepares the statement instead, which logs it.
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.log.statement(ctx, "sql exec", query, args, start, result, err)
+	return result, err
+}
+
+func (c *sqlConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.log.statement(ctx, "sql query", query, args, start, nil, err)
+	return rows, err
+}
+
+func (c *sqlConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *sqlConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *sqlConn) IsValid() bool {
+	if validator, ok := c.conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *sqlConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// sqlStmt logs the queries and execs of a prepared statement.
+type sqlStmt struct {
+	stmt  driver.Stmt
+	conn  driver.Conn
+	query string
+	log   *sqlLogger
+}
+
+func (s *sqlStmt) Close() error {
+	return s.stmt.Close()
+}
+
+func (s *sqlStmt) NumInput() int {
+	return s.stmt.NumInput()
+}
+
+func (s *sqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *sqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *sqlStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	var result driver.Result
+	var err error
+	if execer, ok := s.stmt.(driver.StmtExecContext); ok {
+		result, err = execer.ExecContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = plainValues(args); err == nil {
+			result, err = s.stmt.Exec(values)
+		}
+	}
+	s.log.statement(ctx, "sql exec", s.query, args, start, result, err)
+	return result, err
+}
+
+func (s *sqlStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	var rows driver.Rows
+	var err error
+	if queryer, ok := s.stmt.(driver.StmtQueryContext); ok {
+		rows, err = queryer.QueryContext(ctx, args)
+	} else {
+		var values []driver.Value
+		if values, err = plainValues(args); err == nil {
+			rows, err = s.stmt.Query(values)
+		}
+	}
+	s.log.statement(ctx, "sql query", s.query, args, start, nil, err)
+	return rows, err
+}
+
+// CheckNamedValue checks arguments with the statement or, as database/sql
+// would without the wrapper, with its connection.
+func (s *sqlStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	if checker, ok := s.conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// sqlTx logs the end of a transaction, with the context it was begun with.
+type sqlTx struct {
+	tx  driver.Tx
+	ctx context.Context
+	log *sqlLogger
+}
+
+func (t *sqlTx) Commit() error {
+	start := time.Now()
+	err := t.tx.Commit()
+	t.log.entry(t.ctx, "sql commit", start, err).Send()
+	return err
+}
+
+func (t *sqlTx) Rollback() error {
+	start := time.Now()
+	err := t.tx.Rollback()
+	t.log.entry(t.ctx, "sql rollback", start, err).Send()
+	return err
+}
+
+// namedValues converts positional arguments to named values.
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, value := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: value}
+	}
+	return named
+}
+
+// plainValues converts named values for drivers without named parameters.
+func plainValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, errors.New("gologger: driver does not support the use of Named Parameters")
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
@@ -0,0 +1,144 @@
+package gologger
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSQLContextConn is a connection running statements without preparing
+// them, like most modern drivers.
+type fakeSQLContextConn struct {
+	*fakeSQLConn
+	execs []string
+}
+
+func (c *fakeSQLContextConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.execs = append(c.execs, query)
+	return driver.RowsAffected(2), nil
+}
+
+// fakeSQLConnector connects to a fakeSQLDriver with fakeSQLContextConn.
+type fakeSQLConnector struct {
+	d     *fakeSQLDriver
+	conns []*fakeSQLContextConn
+	mu    sync.Mutex
+}
+
+func (c *fakeSQLConnector) Connect(context.Context) (driver.Conn, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	conn := &fakeSQLContextConn{fakeSQLConn: &fakeSQLConn{d: c.d}}
+	c.conns = append(c.conns, conn)
+	return conn, nil
+}
+
+func (c *fakeSQLConnector) Driver() driver.Driver { return c.d }
+
+func TestWrapSQLDriver(t *testing.T) {
+	log, capture := NewTestLogger()
+	d := &fakeSQLDriver{}
+	name := "gologger-logged-" + t.Name()
+	sql.Register(name, WrapSQLDriver(d, log, SQLLogConfig{}))
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id, name) VALUES (?, ?)", 1, "alice"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	execs := capture.FilterMessage("sql exec")
+	if len(execs) != 1 {
+		t.Fatalf("Expected one exec entry, got %+v", capture.Entries())
+	}
+	exec := execs[0]
+	if exec.Level != LevelDebug || exec.Fields["request-id"] != "req-42" ||
+		exec.Fields["query"] != "INSERT INTO users (id, name) VALUES (?, ?)" || exec.Fields["rows_affected"] != int64(1) {
+		t.Errorf("Unexpected exec entry %+v", exec)
+	}
+	if args := fmt.Sprint(exec.Fields["args"]); args != "[1 alice]" {
+		t.Errorf("Expected the arguments, got %s", args)
+	}
+	if _, ok := exec.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected a duration, got %v", exec.Fields)
+	}
+
+	if _, err := db.QueryContext(ctx, "SELECT name FROM users"); err == nil {
+		t.Fatal("Expected the query to fail")
+	}
+	queries := capture.FilterMessage("sql query")
+	if len(queries) != 1 || queries[0].Level != LevelError || queries[0].Fields["error"] != "not supported" {
+		t.Errorf("Expected the failed query at error level, got %+v", queries)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	if _, err := tx.Exec("DELETE FROM users"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	for _, msg := range []string{"sql begin", "sql commit"} {
+		if entries := capture.FilterMessage(msg); len(entries) != 1 || entries[0].Fields["request-id"] != "req-42" {
+			t.Errorf("Expected %q with the request ID of the transaction, got %+v", msg, entries)
+		}
+	}
+}
+
+func TestWrapSQLConnector(t *testing.T) {
+	log, capture := NewTestLogger()
+	connector := &fakeSQLConnector{d: &fakeSQLDriver{}}
+	db := sql.OpenDB(WrapSQLConnector(connector, log, SQLLogConfig{
+		Level:         LevelInfo,
+		SlowThreshold: time.Nanosecond,
+		RedactArgs:    true,
+	}))
+	defer db.Close()
+
+	if _, err := db.Exec("UPDATE users SET password = ?", "secret"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if len(connector.conns) != 1 || len(connector.conns[0].execs) != 1 || len(connector.d.prepared) != 0 {
+		t.Fatal("Expected the statement to run on the connection without preparing it")
+	}
+	execs := capture.FilterMessage("sql exec")
+	if len(execs) != 1 || execs[0].Level != LevelWarn || execs[0].Fields["rows_affected"] != int64(2) {
+		t.Fatalf("Expected one slow exec entry at warn level, got %+v", capture.Entries())
+	}
+	if args := fmt.Sprint(execs[0].Fields["args"]); args != "[[REDACTED]]" {
+		t.Errorf("Expected redacted arguments, got %s", args)
+	}
+}
+
+func TestOpenSQL(t *testing.T) {
+	log, capture := NewTestLogger()
+	d := &fakeSQLDriver{}
+	name := "gologger-open-" + t.Name()
+	sql.Register(name, d)
+
+	db, err := OpenSQL(name, "", log, SQLLogConfig{})
+	if err != nil {
+		t.Fatalf("OpenSQL failed: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("DELETE FROM sessions"); err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	if len(d.prepared) != 1 || len(capture.FilterMessage("sql exec")) != 1 {
+		t.Errorf("Expected the exec on the registered driver to be logged, got %+v", capture.Entries())
+	}
+
+	if _, err := OpenSQL("gologger-unknown", "", log, SQLLogConfig{}); err == nil {
+		t.Error("Expected an error for an unknown driver")
+	}
+}
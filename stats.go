@@ -0,0 +1,118 @@
+package gologger
+
+import (
+	"io"
+	"os"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Stats holds runtime counters of a logger.
+type Stats struct {
+	SinkErrors     map[string]uint64 // Failed writes per output: "terminal", "stdout", "stderr", "file" or a sink ID
+	DroppedFields  map[string]uint64 // Fields dropped by AllowKeys per key
+	DroppedEntries map[string]uint64 // Entries dropped by RateLimits per level: "debug", "info", "warn" or "error"
+}
+
+// loggerStats collects counters shared by all copies of a logger.
+type loggerStats struct {
+	mu             sync.Mutex
+	sinkErrors     map[string]uint64
+	droppedFields  map[string]uint64
+	droppedEntries map[string]uint64
+	onSinkError    func(sink string, err error)
+}
+
+func newLoggerStats(onSinkError func(sink string, err error)) *loggerStats {
+	return &loggerStats{
+		sinkErrors:     make(map[string]uint64),
+		droppedFields:  make(map[string]uint64),
+		droppedEntries: make(map[string]uint64),
+		onSinkError:    onSinkError,
+	}
+}
+
+// reportSinkError counts a failed write and invokes the OnSinkError callback.
+func (s *loggerStats) reportSinkError(sink string, err error) {
+	s.mu.Lock()
+	s.sinkErrors[sink]++
+	callback := s.onSinkError
+	s.mu.Unlock()
+
+	if callback != nil {
+		callback(sink, err)
+	}
+}
+
+// countDroppedFields counts the fields with keys dropped by AllowKeys.
+func (s *loggerStats) countDroppedFields(keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		s.droppedFields[key]++
+	}
+}
+
+// countDroppedEntry counts an entry of level dropped by RateLimits.
+func (s *loggerStats) countDroppedEntry(level string) {
+	s.mu.Lock()
+	s.droppedEntries[level]++
+	s.mu.Unlock()
+}
+
+// snapshot returns a copy of the current counters.
+func (s *loggerStats) snapshot() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := Stats{
+		SinkErrors:     make(map[string]uint64, len(s.sinkErrors)),
+		DroppedFields:  make(map[string]uint64, len(s.droppedFields)),
+		DroppedEntries: make(map[string]uint64, len(s.droppedEntries)),
+	}
+	for sink, count := range s.sinkErrors {
+		stats.SinkErrors[sink] = count
+	}
+	for key, count := range s.droppedFields {
+		stats.DroppedFields[key] = count
+	}
+	for level, count := range s.droppedEntries {
+		stats.DroppedEntries[level] = count
+	}
+	return stats
+}
+
+// stdStream wraps os.Stdout or os.Stderr. Its Sync ignores errors, since
+// syncing a terminal or pipe fails on some platforms ("sync /dev/stderr:
+// invalid argument") without any data being lost.
+type stdStream struct {
+	*os.File
+}
+
+func (s stdStream) Sync() error {
+	_ = s.File.Sync()
+	return nil
+}
+
+// isStdStream reports whether w is os.Stdout or os.Stderr.
+func isStdStream(w io.Writer) bool {
+	return w == io.Writer(os.Stdout) || w == io.Writer(os.Stderr)
+}
+
+// reportingWriteSyncer reports failed writes of a terminal or file output.
+// Sync errors are not reported, since syncing a terminal fails on some
+// platforms without any data being lost.
+type reportingWriteSyncer struct {
+	zapcore.WriteSyncer
+	name  string
+	stats *loggerStats
+}
+
+func (w reportingWriteSyncer) Write(p []byte) (int, error) {
+	n, err := w.WriteSyncer.Write(p)
+	if err != nil {
+		w.stats.reportSinkError(w.name, err)
+	}
+	return n, err
+}
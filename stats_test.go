@@ -0,0 +1,71 @@
+package gologger
+
+import (
+	"os"
+	"sync"
+	"testing"
+)
+
+func TestOnSinkErrorForSinks(t *testing.T) {
+	var mu sync.Mutex
+	reported := map[string]int{}
+
+	broken := &flakySink{broken: true}
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputDiscard,
+		LogLevel:   LevelInfo,
+		Sinks:      []Sink{&recordingSink{}, broken},
+		OnSinkError: func(sink string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			reported[sink]++
+		},
+	})
+	defer log.Close()
+
+	log.Info("first").Send()
+	log.Info("second").Send()
+
+	mu.Lock()
+	if reported["sink-2"] != 2 || len(reported) != 1 {
+		t.Errorf("Expected 2 errors reported for sink-2, got %v", reported)
+	}
+	mu.Unlock()
+
+	stats := log.Stats()
+	if stats.SinkErrors["sink-2"] != 2 {
+		t.Errorf("Expected Stats to count 2 errors for sink-2, got %v", stats.SinkErrors)
+	}
+
+	// Stats returns a snapshot
+	stats.SinkErrors["sink-2"] = 100
+	if log.Stats().SinkErrors["sink-2"] != 2 {
+		t.Error("Expected Stats to return a copy of the counters")
+	}
+}
+
+func TestOnSinkErrorForTerminal(t *testing.T) {
+	r, w, _ := os.Pipe()
+	r.Close()
+	w.Close()
+	origStderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = origStderr }()
+
+	var reportedSink string
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode: OutputTerminal,
+		LogLevel:   LevelInfo,
+		OnSinkError: func(sink string, err error) {
+			reportedSink = sink
+		},
+	})
+	log.Info("lost message").Send()
+
+	if reportedSink != "terminal" {
+		t.Errorf("Expected terminal write error to be reported, got %q", reportedSink)
+	}
+	if log.Stats().SinkErrors["terminal"] != 1 {
+		t.Errorf("Expected 1 terminal error, got %v", log.Stats().SinkErrors)
+	}
+}
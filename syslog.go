@@ -0,0 +1,370 @@
+package gologger
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// Syslog transports.
+const (
+	SyslogTransportUDP = "udp"
+	SyslogTransportTCP = "tcp"
+)
+
+// Syslog facilities.
+const (
+	SyslogFacilityUser     = 1
+	SyslogFacilityDaemon   = 3
+	SyslogFacilityAuth     = 4
+	SyslogFacilityAuthPriv = 10
+	SyslogFacilityLocal0   = 16
+	SyslogFacilityLocal1   = 17
+	SyslogFacilityLocal2   = 18
+	SyslogFacilityLocal3   = 19
+	SyslogFacilityLocal4   = 20
+	SyslogFacilityLocal5   = 21
+	SyslogFacilityLocal6   = 22
+	SyslogFacilityLocal7   = 23
+)
+
+// syslogSDID is the SD-ID of the structured data element holding the
+// entry's fields. 32473 is the private enterprise number reserved for
+// documentation by RFC 5612.
+const syslogSDID = "fields@32473"
+
+// SyslogConfig holds configuration options for the syslog sink.
+type SyslogConfig struct {
+	Addr          string        // Syslog server address (default: "localhost:514")
+	Transport     string        // Transport: SyslogTransportUDP or SyslogTransportTCP (default: SyslogTransportUDP)
+	Facility      int           // Syslog facility, e.g. SyslogFacilityLocal0 (default: SyslogFacilityUser)
+	AppName       string        // Value of the APP-NAME header field (default: program name)
+	Hostname      string        // Value of the HOSTNAME header field (default: os.Hostname())
+	TLS           *TLSConfig    // TLS client options for TCP (optional)
+	BatchSize     int           // Maximum entries per batch (default: 100)
+	FlushInterval time.Duration // Maximum time an entry waits before being sent (default: 1s)
+	Timeout       time.Duration // Dial and write timeout (default: 5s)
+}
+
+// SyslogSink sends entries to a syslog server as RFC 5424 messages. Over UDP
+// each message is sent as one datagram; over TCP, messages use octet-counting
+// framing as described in RFC 6587, which also makes them safe to send over
+// TLS (RFC 5425).
+type SyslogSink struct {
+	config    SyslogConfig
+	formatter *syslogFormatter
+	batch     *batcher
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// NewSyslogSink creates a syslog sink. Unset options fall back to their
+// defaults. The connection is established lazily on the first flush.
+func NewSyslogSink(config SyslogConfig) *SyslogSink {
+	if config.Addr == "" {
+		config.Addr = "localhost:514"
+	}
+	if config.Transport == "" {
+		config.Transport = SyslogTransportUDP
+	}
+	if config.BatchSize <= 0 {
+		config.BatchSize = 100
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = time.Second
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+
+	s := &SyslogSink{
+		config:    config,
+		formatter: newSyslogFormatter(config.Facility, config.AppName, config.Hostname),
+	}
+	s.batch = newBatcher(config.BatchSize, config.FlushInterval, s.send)
+	return s
+}
+
+// Write queues an entry for sending.
+func (s *SyslogSink) Write(entry Entry) error {
+	return s.batch.Add(entry)
+}
+
+// Sync sends all queued entries.
+func (s *SyslogSink) Sync() error {
+	return s.batch.Flush()
+}
+
+// Close sends all queued entries and closes the connection.
+func (s *SyslogSink) Close() error {
+	err := s.batch.Close()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conn != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return err
+}
+
+// send formats and writes a batch of entries.
+func (s *SyslogSink) send(entries []Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.Transport == SyslogTransportTCP {
+		var buf []byte
+		for _, entry := range entries {
+			msg := s.formatter.format(nil, entry)
+			buf = append(strconv.AppendInt(buf, int64(len(msg)), 10), ' ')
+			buf = append(buf, msg...)
+		}
+		reused := s.conn != nil
+		err := s.write("tcp", s.config.TLS, buf)
+		if err != nil && reused {
+			// The connection may have been closed by the server; retry once.
+			err = s.write("tcp", s.config.TLS, buf)
+		}
+		return err
+	}
+
+	var errs []error
+	for _, entry := range entries {
+		errs = append(errs, s.write("udp", nil, s.formatter.format(nil, entry)))
+	}
+	return errors.Join(errs...)
+}
+
+// write writes data on the connection, dialing it first if needed. It must
+// be called with s.mu held.
+func (s *SyslogSink) write(network string, tlsOptions *TLSConfig, data []byte) error {
+	if s.conn == nil {
+		conn, err := dialNetwork(network, s.config.Addr, s.config.Timeout, tlsOptions)
+		if err != nil {
+			return fmt.Errorf("syslog: %w", err)
+		}
+		s.conn = conn
+	}
+
+	_ = s.conn.SetWriteDeadline(time.Now().Add(s.config.Timeout))
+	if _, err := s.conn.Write(data); err != nil {
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("syslog: %w", err)
+	}
+	return nil
+}
+
+// syslogEncoder writes entries as RFC 5424 syslog messages, one per line.
+type syslogEncoder struct {
+	fieldRecorder
+	formatter *syslogFormatter
+}
+
+func newSyslogEncoder(facility int, appName string) *syslogEncoder {
+	return &syslogEncoder{formatter: newSyslogFormatter(facility, appName, "")}
+}
+
+func (e *syslogEncoder) Clone() zapcore.Encoder {
+	return &syslogEncoder{fieldRecorder: e.clone(), formatter: e.formatter}
+}
+
+func (e *syslogEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	buf := consoleBufferPool.Get()
+	buf.Write(e.formatter.format(nil, entryFromZap(ent, e.with(fields))))
+	buf.AppendByte('\n')
+	return buf, nil
+}
+
+// syslogFormatter formats entries as RFC 5424 messages:
+//
+//	<PRI>1 TIMESTAMP HOSTNAME APP-NAME PROCID - [fields@32473 key="value"] MSG
+//
+// Fields, the caller and the stack trace are written as parameters of a
+// single structured data element.
+type syslogFormatter struct {
+	facility int
+	hostname string
+	appName  string
+	procID   string
+}
+
+func newSyslogFormatter(facility int, appName, hostname string) *syslogFormatter {
+	if facility <= 0 || facility > SyslogFacilityLocal7 {
+		facility = SyslogFacilityUser
+	}
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	return &syslogFormatter{
+		facility: facility,
+		hostname: syslogHeaderField(hostname, 255),
+		appName:  syslogHeaderField(appName, 48),
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+}
+
+// format appends the RFC 5424 message for entry to dst.
+func (f *syslogFormatter) format(dst []byte, entry Entry) []byte {
+	dst = append(dst, '<')
+	dst = strconv.AppendInt(dst, int64(f.facility*8+syslogSeverity(entry.Level)), 10)
+	dst = append(dst, ">1 "...)
+	dst = entry.Time.UTC().AppendFormat(dst, "2006-01-02T15:04:05.000000Z07:00")
+	dst = append(dst, ' ')
+	dst = append(dst, f.hostname...)
+	dst = append(dst, ' ')
+	dst = append(dst, f.appName...)
+	dst = append(dst, ' ')
+	dst = append(dst, f.procID...)
+	dst = append(dst, " - "...)
+
+	params := make(map[string]string, len(entry.Fields)+2)
+	for key, value := range entry.Fields {
+		params[syslogParamName(key)] = plainValue(normalizeValue(value))
+	}
+	if entry.Caller != "" {
+		params["caller"] = entry.Caller
+	}
+	if entry.Stack != "" {
+		params["stacktrace"] = entry.Stack
+	}
+	if len(params) == 0 {
+		dst = append(dst, '-')
+	} else {
+		dst = append(dst, '[')
+		dst = append(dst, syslogSDID...)
+		for _, name := range sortedStringKeys(params) {
+			dst = append(dst, ' ')
+			dst = append(dst, name...)
+			dst = append(dst, `="`...)
+			dst = appendSyslogEscaped(dst, params[name])
+			dst = append(dst, '"')
+		}
+		dst = append(dst, ']')
+	}
+
+	if entry.Message != "" {
+		dst = append(dst, ' ')
+		dst = append(dst, entry.Message...)
+	}
+	return dst
+}
+
+// syslogSeverity maps a log level name to its syslog severity.
+func syslogSeverity(level string) int {
+	switch level {
+	case LevelDebug:
+		return 7
+	case LevelInfo:
+		return 6
+	case LevelWarn:
+		return 4
+	case LevelError:
+		return 3
+	case "dpanic":
+		return 2
+	case "panic":
+		return 1
+	case "fatal":
+		return 0
+	default:
+		return 6
+	}
+}
+
+// syslogHeaderField restricts a header field to printable US-ASCII and the
+// given length, using the NILVALUE "-" when nothing is left.
+func syslogHeaderField(s string, max int) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if len(s) > max {
+		s = s[:max]
+	}
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// syslogParamName turns a field key into a valid PARAM-NAME: at most 32
+// printable US-ASCII characters other than '=', ' ', ']' and '"'.
+func syslogParamName(key string) string {
+	name := []byte(key)
+	for i, c := range name {
+		if c < 33 || c > 126 || c == '=' || c == ']' || c == '"' {
+			name[i] = '_'
+		}
+	}
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	if len(name) == 0 {
+		return "_"
+	}
+	return string(name)
+}
+
+// plainValue renders a normalized value as plain text, with composite
+// values as JSON.
+func plainValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case bool, int64, uint64, float64:
+		return fmt.Sprint(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// appendSyslogEscaped appends s with '"', '\' and ']' escaped as RFC 5424
+// requires for PARAM-VALUE.
+func appendSyslogEscaped(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"', '\\', ']':
+			dst = append(dst, '\\')
+		}
+		dst = append(dst, s[i])
+	}
+	return dst
+}
+
+// sortedStringKeys returns the keys of m in lexical order.
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,160 @@
+package gologger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testSyslogEntry() Entry {
+	return Entry{
+		Time:    time.Date(2024, 3, 1, 10, 30, 0, 123456789, time.UTC),
+		Level:   LevelWarn,
+		Message: "disk almost full",
+		Caller:  "monitor/disk.go:42",
+		Fields: map[string]any{
+			"request-id": "req-1",
+			"usage":      int64(93),
+			"path":       `C:\data [main]`,
+			"a b=c":      true,
+		},
+	}
+}
+
+func TestSyslogFormat(t *testing.T) {
+	formatter := newSyslogFormatter(SyslogFacilityLocal0, "billing api", "web-1")
+	msg := string(formatter.format(nil, testSyslogEntry()))
+
+	expected := "<132>1 2024-03-01T10:30:00.123456Z web-1 billingapi " + strconv.Itoa(os.Getpid()) + " - " +
+		`[fields@32473 a_b_c="true" caller="monitor/disk.go:42" path="C:\\data [main\]" request-id="req-1" usage="93"]` +
+		" disk almost full"
+	if msg != expected {
+		t.Errorf("Unexpected message:\n%s\nexpected:\n%s", msg, expected)
+	}
+}
+
+func TestSyslogFormatWithoutFields(t *testing.T) {
+	formatter := newSyslogFormatter(0, "app", "host")
+	msg := string(formatter.format(nil, Entry{Time: time.Unix(0, 0), Level: LevelError, Message: "boom"}))
+	if !strings.HasPrefix(msg, "<11>1 ") || !strings.HasSuffix(msg, " - - boom") {
+		t.Errorf("Expected user facility and NILVALUE structured data, got %q", msg)
+	}
+}
+
+func TestSyslogParamName(t *testing.T) {
+	tests := map[string]string{
+		"user_id":               "user_id",
+		`a"b]c=d e`:             "a_b_c_d_e",
+		"":                      "_",
+		strings.Repeat("k", 40): strings.Repeat("k", 32),
+		"caf\xc3\xa9":           "caf__",
+	}
+	for key, want := range tests {
+		if got := syslogParamName(key); got != want {
+			t.Errorf("syslogParamName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestSyslogSinkUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	sink := NewSyslogSink(SyslogConfig{Addr: conn.LocalAddr().String(), AppName: "app", Hostname: "web-1"})
+	if err := sink.Write(testSyslogEntry()); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	buf := make([]byte, 65535)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+	msg := string(buf[:n])
+	if !strings.HasPrefix(msg, "<12>1 2024-03-01T10:30:00.123456Z web-1 app ") || !strings.HasSuffix(msg, "] disk almost full") {
+		t.Errorf("Unexpected message %q", msg)
+	}
+}
+
+func TestSyslogSinkTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		var messages []string
+		reader := bufio.NewReader(conn)
+		for {
+			length, err := reader.ReadString(' ')
+			if err != nil {
+				break
+			}
+			n, _ := strconv.Atoi(strings.TrimSuffix(length, " "))
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(reader, msg); err != nil {
+				break
+			}
+			messages = append(messages, string(msg))
+		}
+		received <- messages
+	}()
+
+	sink := NewSyslogSink(SyslogConfig{Addr: listener.Addr().String(), Transport: SyslogTransportTCP})
+	for _, level := range []string{LevelInfo, LevelError} {
+		if err := sink.Write(Entry{Time: time.Now(), Level: level, Message: "tcp " + level}); err != nil {
+			t.Fatalf("Unexpected write error: %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Unexpected close error: %v", err)
+	}
+
+	messages := <-received
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 framed messages, got %q", messages)
+	}
+	if !strings.HasPrefix(messages[1], "<11>1 ") || !strings.HasSuffix(messages[1], " - tcp error") {
+		t.Errorf("Unexpected message %q", messages[1])
+	}
+}
+
+func TestSyslogEncodingConfig(t *testing.T) {
+	tempDir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:     OutputFile,
+		LogDir:         tempDir,
+		Encoding:       EncodingSyslog,
+		ServiceName:    "orders",
+		SyslogFacility: SyslogFacilityLocal3,
+	})
+	log.Info("order placed").Data("order_id", 42).Send()
+	log.Close()
+
+	content := readTestLogFile(t, tempDir)
+	if !strings.HasPrefix(content, "<158>1 ") || !strings.HasSuffix(content, `[fields@32473 order_id="42"] order placed`+"\n") {
+		t.Errorf("Unexpected syslog line %q", content)
+	}
+	if !strings.Contains(content, " orders "+strconv.Itoa(os.Getpid())+" - ") {
+		t.Errorf("Expected service name as APP-NAME in %q", content)
+	}
+}
@@ -0,0 +1,82 @@
+package gologger
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+)
+
+// TaskInfo describes a task run by a task queue worker, such as asynq or
+// machinery, for RunTask.
+type TaskInfo struct {
+	ID       string // ID of the task, logged as "task_id"
+	Type     string // Type or name of the task, logged as "task_type" (optional)
+	Queue    string // Queue the task was taken from, logged as "queue" (optional)
+	Retry    int    // Number of times the task was retried before this run, logged as "retry"
+	MaxRetry int    // Maximum number of retries of the task, logged as "max_retry" (optional)
+}
+
+// RunTask runs fn, the handler of task, logging the run with log, as WrapJob
+// does for jobs. It is meant for the task middleware of task queues, which
+// know the task from their context:
+//
+//	srv.Use(func(next asynq.Handler) asynq.Handler {
+//		return asynq.HandlerFunc(func(ctx context.Context, t *asynq.Task) error {
+//			id, _ := asynq.GetTaskID(ctx)
+//			queue, _ := asynq.GetQueueName(ctx)
+//			retry, _ := asynq.GetRetryCount(ctx)
+//			task := gologger.TaskInfo{ID: id, Type: t.Type(), Queue: queue, Retry: retry}
+//			return gologger.RunTask(ctx, log, task, func(ctx context.Context) error { return next.ProcessTask(ctx, t) })
+//		})
+//	})
+//
+// The task ID, type, queue and retry count are added to the context as
+// fields, and the task ID is also its request ID unless ctx has one. The
+// context passed to fn holds log, so fn gets the logger with FromContext(ctx)
+// and every entry logged while the task runs carries them. "task started" is
+// logged at debug level, and "task completed" with the duration at info
+// level, or error level with the error if fn failed. Panics are logged with
+// their stack and returned as errors.
+func RunTask(ctx context.Context, log Logger, task TaskInfo, fn func(ctx context.Context) error) (err error) {
+	start := time.Now()
+	if GetRequestID(ctx) == "" && task.ID != "" {
+		ctx = WithRequestID(ctx, task.ID)
+	}
+	fields := []any{"task_id", task.ID}
+	if task.Type != "" {
+		fields = append(fields, "task_type", task.Type)
+	}
+	if task.Queue != "" {
+		fields = append(fields, "queue", task.Queue)
+	}
+	fields = append(fields, "retry", task.Retry)
+	if task.MaxRetry > 0 {
+		fields = append(fields, "max_retry", task.MaxRetry)
+	}
+	ctx = NewContext(WithFields(ctx, fields...), log)
+
+	taskLog := log.WithContext(ctx)
+	taskLog.Debug("task started").Send()
+	defer func() {
+		if r := recover(); r != nil {
+			taskLog.Error("task panicked").
+				Data("panic", fmt.Sprint(r)).
+				Data("stack", string(debug.Stack())).
+				Send()
+			var ok bool
+			if err, ok = r.(error); !ok {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+		if err != nil {
+			taskLog = taskLog.Error("task completed")
+		} else {
+			taskLog = taskLog.Info("task completed")
+		}
+		taskLog.Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+			ErrorData(err).
+			Send()
+	}()
+	return fn(ctx)
+}
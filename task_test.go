@@ -0,0 +1,87 @@
+package gologger
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRunTask(t *testing.T) {
+	log, capture := NewTestLogger()
+	task := TaskInfo{ID: "task-7", Type: "email:welcome", Queue: "critical", Retry: 2, MaxRetry: 25}
+
+	err := RunTask(context.Background(), log, task, func(ctx context.Context) error {
+		FromContext(ctx).Info("sending email").Send()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := capture.Entries()
+	if len(entries) != 3 {
+		t.Fatalf("Expected start, task and finish entries, got %+v", entries)
+	}
+	for _, entry := range entries {
+		if entry.Fields["task_id"] != "task-7" || entry.Fields["task_type"] != "email:welcome" ||
+			entry.Fields["queue"] != "critical" || entry.Fields["retry"] != int64(2) ||
+			entry.Fields["max_retry"] != int64(25) || entry.Fields["request-id"] != "task-7" {
+			t.Errorf("Expected the task fields on %q, got %v", entry.Message, entry.Fields)
+		}
+	}
+	if entries[0].Level != LevelDebug || entries[0].Message != "task started" {
+		t.Errorf("Unexpected start entry %+v", entries[0])
+	}
+	finish := entries[2]
+	if finish.Level != LevelInfo || finish.Message != "task completed" {
+		t.Errorf("Unexpected finish entry %+v", finish)
+	}
+	if _, ok := finish.Fields["duration_ms"].(float64); !ok {
+		t.Errorf("Expected duration_ms, got %v", finish.Fields)
+	}
+}
+
+func TestRunTaskError(t *testing.T) {
+	log, capture := NewTestLogger()
+	ctx := WithRequestID(context.Background(), "req-42")
+
+	err := RunTask(ctx, log, TaskInfo{ID: "task-8"}, func(ctx context.Context) error {
+		return errors.New("smtp unavailable")
+	})
+	if err == nil || err.Error() != "smtp unavailable" {
+		t.Fatalf("Expected the task error, got %v", err)
+	}
+
+	finish := capture.FilterMessage("task completed")
+	if len(finish) != 1 || finish[0].Level != LevelError || finish[0].Fields["error"] != "smtp unavailable" {
+		t.Fatalf("Expected an error entry, got %+v", capture.Entries())
+	}
+	if finish[0].Fields["request-id"] != "req-42" || finish[0].Fields["retry"] != int64(0) {
+		t.Errorf("Unexpected fields %v", finish[0].Fields)
+	}
+	for _, key := range []string{"task_type", "queue", "max_retry"} {
+		if _, ok := finish[0].Fields[key]; ok {
+			t.Errorf("Expected no %q field, got %v", key, finish[0].Fields)
+		}
+	}
+}
+
+func TestRunTaskPanic(t *testing.T) {
+	log, capture := NewTestLogger()
+
+	err := RunTask(context.Background(), log, TaskInfo{ID: "task-9"}, func(ctx context.Context) error {
+		panic("nil template")
+	})
+	if err == nil || err.Error() != "nil template" {
+		t.Fatalf("Expected the panic as an error, got %v", err)
+	}
+
+	panicked := capture.FilterMessage("task panicked")
+	if len(panicked) != 1 || panicked[0].Fields["task_id"] != "task-9" {
+		t.Fatalf("Expected a panic entry, got %+v", capture.Entries())
+	}
+	if stack, _ := panicked[0].Fields["stack"].(string); !strings.Contains(stack, "task_test.go") {
+		t.Errorf("Expected the stack of the panic, got %q", stack)
+	}
+}
@@ -0,0 +1,158 @@
+package gologger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// AsynqLogger logs the entries of asynq servers and schedulers. It
+// implements the Logger interface of github.com/hibiken/asynq, so gologger
+// does not depend on asynq.
+type AsynqLogger struct {
+	log Logger
+}
+
+// NewAsynqLogger returns an AsynqLogger logging with log, for the Logger of
+// the asynq server configuration:
+//
+//	srv := asynq.NewServer(redisOpt, asynq.Config{Logger: gologger.NewAsynqLogger(log.Named("asynq"))})
+//
+// The arguments of each call are formatted with fmt.Sprint. Fatal entries
+// are logged at error level, leaving exiting to asynq. The caller of entries
+// is the asynq code logging them.
+func NewAsynqLogger(log Logger) *AsynqLogger {
+	return &AsynqLogger{log: log}
+}
+
+// Debug logs args at debug level.
+func (a *AsynqLogger) Debug(args ...any) {
+	sendTaskQueue(a.log, LevelDebug, fmt.Sprint(args...))
+}
+
+// Info logs args at info level.
+func (a *AsynqLogger) Info(args ...any) {
+	sendTaskQueue(a.log, LevelInfo, fmt.Sprint(args...))
+}
+
+// Warn logs args at warn level.
+func (a *AsynqLogger) Warn(args ...any) {
+	sendTaskQueue(a.log, LevelWarn, fmt.Sprint(args...))
+}
+
+// Error logs args at error level.
+func (a *AsynqLogger) Error(args ...any) {
+	sendTaskQueue(a.log, LevelError, fmt.Sprint(args...))
+}
+
+// Fatal logs args at error level.
+func (a *AsynqLogger) Fatal(args ...any) {
+	sendTaskQueue(a.log, LevelError, fmt.Sprint(args...))
+}
+
+// MachineryLogger logs the entries of one level of machinery servers and
+// workers. It implements the LoggerInterface of github.com/RichardKnop/logging,
+// which machinery logs with, so gologger does not depend on machinery.
+type MachineryLogger struct {
+	log   Logger
+	level string
+}
+
+// NewMachineryLogger returns a MachineryLogger logging with log at level.
+// machinery takes a logger per level:
+//
+//	machinerylog.SetDebug(gologger.NewMachineryLogger(log, gologger.LevelDebug))
+//	machinerylog.SetInfo(gologger.NewMachineryLogger(log, gologger.LevelInfo))
+//	machinerylog.SetWarning(gologger.NewMachineryLogger(log, gologger.LevelWarn))
+//	machinerylog.SetError(gologger.NewMachineryLogger(log, gologger.LevelError))
+//	machinerylog.SetFatal(gologger.NewMachineryLogger(log, gologger.LevelError))
+//
+// The Print methods log at level, formatting their arguments as the
+// functions of package fmt do. As in package log, the Fatal methods log at
+// fatal level and exit, and the Panic methods log at panic level and panic.
+// The caller of entries is the machinery code logging them.
+func NewMachineryLogger(log Logger, level string) *MachineryLogger {
+	return &MachineryLogger{log: log, level: getLogLevel(level).String()}
+}
+
+// Print logs args formatted with fmt.Sprint.
+func (m *MachineryLogger) Print(args ...any) {
+	sendTaskQueue(m.log, m.level, fmt.Sprint(args...))
+}
+
+// Printf logs args formatted with fmt.Sprintf.
+func (m *MachineryLogger) Printf(format string, args ...any) {
+	sendTaskQueue(m.log, m.level, fmt.Sprintf(format, args...))
+}
+
+// Println logs args formatted with fmt.Sprintln.
+func (m *MachineryLogger) Println(args ...any) {
+	sendTaskQueue(m.log, m.level, fmt.Sprintln(args...))
+}
+
+// Fatal logs args formatted with fmt.Sprint at fatal level and exits.
+func (m *MachineryLogger) Fatal(args ...any) {
+	sendTaskQueue(m.log, "fatal", fmt.Sprint(args...))
+}
+
+// Fatalf logs args formatted with fmt.Sprintf at fatal level and exits.
+func (m *MachineryLogger) Fatalf(format string, args ...any) {
+	sendTaskQueue(m.log, "fatal", fmt.Sprintf(format, args...))
+}
+
+// Fatalln logs args formatted with fmt.Sprintln at fatal level and exits.
+func (m *MachineryLogger) Fatalln(args ...any) {
+	sendTaskQueue(m.log, "fatal", fmt.Sprintln(args...))
+}
+
+// Panic logs args formatted with fmt.Sprint at panic level and panics.
+func (m *MachineryLogger) Panic(args ...any) {
+	sendTaskQueue(m.log, "panic", fmt.Sprint(args...))
+}
+
+// Panicf logs args formatted with fmt.Sprintf at panic level and panics.
+func (m *MachineryLogger) Panicf(format string, args ...any) {
+	sendTaskQueue(m.log, "panic", fmt.Sprintf(format, args...))
+}
+
+// Panicln logs args formatted with fmt.Sprintln at panic level and panics.
+func (m *MachineryLogger) Panicln(args ...any) {
+	sendTaskQueue(m.log, "panic", fmt.Sprintln(args...))
+}
+
+// sendTaskQueue logs msg, without the line break of the Println methods, at
+// level with the caller of the asynq or machinery code calling the adapter.
+func sendTaskQueue(log Logger, level, msg string) {
+	log = log.WithCallerSkip(taskQueueCallerSkip())
+	msg = strings.TrimSuffix(msg, "\n")
+	// Log normalizes levels to those of LoggerConfig; fatal and panic are
+	// only reachable through their methods.
+	switch level {
+	case "fatal":
+		log.Fatal(msg).Send()
+	case "panic":
+		log.Panic(msg).Send()
+	default:
+		log.Log(level, msg).Send()
+	}
+}
+
+// taskQueueCallerSkip returns the number of frames between sendTaskQueue
+// and the first caller outside the logging packages of asynq and
+// machinery, which wrap the logger they are given.
+func taskQueueCallerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, taskQueueCallerSkip, sendTaskQueue and the
+	// adapter method.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs[:])])
+	skip := 2
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "github.com/hibiken/asynq/internal/log.") &&
+			!strings.HasPrefix(frame.Function, "github.com/RichardKnop/machinery/v1/log.") &&
+			!strings.HasPrefix(frame.Function, "github.com/RichardKnop/machinery/v2/log.") {
+			return skip
+		}
+		skip++
+	}
+}
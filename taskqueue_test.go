@@ -0,0 +1,103 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+)
+
+// asynqLogger is the Logger interface of github.com/hibiken/asynq.
+type asynqLogger interface {
+	Debug(args ...any)
+	Info(args ...any)
+	Warn(args ...any)
+	Error(args ...any)
+	Fatal(args ...any)
+}
+
+// machineryLogger is the LoggerInterface of github.com/RichardKnop/logging.
+type machineryLogger interface {
+	Print(args ...any)
+	Printf(format string, args ...any)
+	Println(args ...any)
+	Fatal(args ...any)
+	Fatalf(format string, args ...any)
+	Fatalln(args ...any)
+	Panic(args ...any)
+	Panicf(format string, args ...any)
+	Panicln(args ...any)
+}
+
+var (
+	_ asynqLogger     = (*AsynqLogger)(nil)
+	_ machineryLogger = (*MachineryLogger)(nil)
+)
+
+func TestAsynqLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	var logger asynqLogger = NewAsynqLogger(log)
+
+	logger.Debug("Starting processing")
+	logger.Info("Send shutdown signal to ", 3, " workers")
+	logger.Warn("Retrying task")
+	logger.Error("Could not dequeue task")
+	logger.Fatal("redis: connection refused")
+
+	expected := []struct{ level, message string }{
+		{LevelDebug, "Starting processing"},
+		{LevelInfo, "Send shutdown signal to 3 workers"},
+		{LevelWarn, "Retrying task"},
+		{LevelError, "Could not dequeue task"},
+		{LevelError, "redis: connection refused"},
+	}
+	entries := capture.Entries()
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, want := range expected {
+		if entries[i].Level != want.level || entries[i].Message != want.message {
+			t.Errorf("Expected %s %q, got %s %q", want.level, want.message, entries[i].Level, entries[i].Message)
+		}
+		if !strings.Contains(entries[i].Caller, "taskqueue_test.go:") {
+			t.Errorf("Expected the caller of the adapter, got %q", entries[i].Caller)
+		}
+	}
+}
+
+func TestMachineryLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	var logger machineryLogger = NewMachineryLogger(log, LevelWarn)
+
+	logger.Print("Broker connection lost")
+	logger.Printf("Retrying in %d seconds", 5)
+	logger.Println("Consumer", "stopped")
+
+	entries := capture.Entries()
+	expected := []string{"Broker connection lost", "Retrying in 5 seconds", "Consumer stopped"}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %d entries, got %+v", len(expected), entries)
+	}
+	for i, message := range expected {
+		if entries[i].Level != LevelWarn || entries[i].Message != message {
+			t.Errorf("Expected warn %q, got %s %q", message, entries[i].Level, entries[i].Message)
+		}
+		if !strings.Contains(entries[i].Caller, "taskqueue_test.go:") {
+			t.Errorf("Expected the caller of the adapter, got %q", entries[i].Caller)
+		}
+	}
+}
+
+func TestMachineryLoggerPanic(t *testing.T) {
+	log, capture := NewTestLogger()
+	logger := NewMachineryLogger(log, LevelError)
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected Panicf to panic")
+		}
+		panicked := capture.FilterLevel("panic")
+		if len(panicked) != 1 || panicked[0].Message != "invalid broker URL amqp:/" {
+			t.Errorf("Expected a panic entry, got %+v", capture.Entries())
+		}
+	}()
+	logger.Panicf("invalid broker URL %s", "amqp:/")
+}
@@ -0,0 +1,86 @@
+package gologger
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// TemporalLogger logs the entries of Temporal workflows, activities and
+// workers. It implements the Logger interface of go.temporal.io/sdk/log, so
+// gologger does not depend on the Temporal SDK.
+type TemporalLogger struct {
+	log Logger
+}
+
+// NewTemporalLogger returns a TemporalLogger logging with log, for the
+// Logger of the Temporal client options:
+//
+//	c, err := client.Dial(client.Options{Logger: gologger.NewTemporalLogger(log.Named("temporal"))})
+//
+// Workflow code and activities then log through the same outputs and sinks
+// as the rest of the application with workflow.GetLogger and
+// activity.GetLogger. The key-value pairs Temporal adds, such as WorkflowID,
+// RunID and ActivityID, become data fields with snake case keys, e.g.
+// "workflow_id", "run_id" and "activity_id". Errors are logged as "error".
+// The caller of entries is the workflow or activity code calling the
+// Temporal logger.
+func NewTemporalLogger(log Logger) *TemporalLogger {
+	return &TemporalLogger{log: log}
+}
+
+// Debug logs msg and keyvals at debug level.
+func (t *TemporalLogger) Debug(msg string, keyvals ...any) {
+	t.send(LevelDebug, msg, keyvals)
+}
+
+// Info logs msg and keyvals at info level.
+func (t *TemporalLogger) Info(msg string, keyvals ...any) {
+	t.send(LevelInfo, msg, keyvals)
+}
+
+// Warn logs msg and keyvals at warn level.
+func (t *TemporalLogger) Warn(msg string, keyvals ...any) {
+	t.send(LevelWarn, msg, keyvals)
+}
+
+// Error logs msg and keyvals at error level.
+func (t *TemporalLogger) Error(msg string, keyvals ...any) {
+	t.send(LevelError, msg, keyvals)
+}
+
+// send logs msg at level with keyvals as data. A key without a value is
+// ignored.
+func (t *TemporalLogger) send(level, msg string, keyvals []any) {
+	log := t.log.WithCallerSkip(temporalCallerSkip()).Log(level, msg)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		key, ok := keyvals[i].(string)
+		if !ok {
+			key = fmt.Sprint(keyvals[i])
+		}
+		key = snakeCase(key)
+		if err, ok := keyvals[i+1].(error); ok && key == "error" {
+			log = log.ErrorData(err)
+			continue
+		}
+		log = log.Data(key, keyvals[i+1])
+	}
+	log.Send()
+}
+
+// temporalCallerSkip returns the number of frames between the Debug, Info,
+// Warn or Error method and the first caller outside the Temporal SDK, which
+// wraps loggers to add fields and to drop entries during workflow replay.
+func temporalCallerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, temporalCallerSkip, send and the level method.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(4, pcs[:])])
+	skip := 2
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "go.temporal.io/sdk/") {
+			return skip
+		}
+		skip++
+	}
+}
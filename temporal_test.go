@@ -0,0 +1,52 @@
+package gologger
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// temporalLogger is the Logger interface of go.temporal.io/sdk/log.
+type temporalLogger interface {
+	Debug(msg string, keyvals ...any)
+	Info(msg string, keyvals ...any)
+	Warn(msg string, keyvals ...any)
+	Error(msg string, keyvals ...any)
+}
+
+var _ temporalLogger = (*TemporalLogger)(nil)
+
+func TestTemporalLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	var logger temporalLogger = NewTemporalLogger(log)
+
+	logger.Info("Activity started", "Namespace", "default", "WorkflowID", "order-7", "RunID", "run-1", "Attempt", 2)
+	logger.Error("Activity failed", "ActivityID", "5", "Error", errors.New("payment declined"), "dangling")
+	logger.Debug("replayed")
+	logger.Warn("slow")
+
+	entries := capture.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected four entries, got %d", len(entries))
+	}
+	started := entries[0]
+	if started.Level != LevelInfo || started.Message != "Activity started" || started.Fields["namespace"] != "default" ||
+		started.Fields["workflow_id"] != "order-7" || started.Fields["run_id"] != "run-1" || started.Fields["attempt"] != int64(2) {
+		t.Errorf("Unexpected entry %+v", started)
+	}
+	failed := entries[1]
+	if failed.Level != LevelError || failed.Fields["activity_id"] != "5" || failed.Fields["error"] != "payment declined" {
+		t.Errorf("Unexpected entry %+v", failed)
+	}
+	if _, ok := failed.Fields["dangling"]; ok {
+		t.Errorf("Expected a key without a value to be ignored, got %v", failed.Fields)
+	}
+	if entries[2].Level != LevelDebug || entries[3].Level != LevelWarn {
+		t.Errorf("Unexpected levels %s, %s", entries[2].Level, entries[3].Level)
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "temporal_test.go:") {
+			t.Errorf("Expected the caller of the Temporal logger, got %q", entry.Caller)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package gologger
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// loggingTransport is an http.RoundTripper logging the requests it sends.
+type loggingTransport struct {
+	next http.RoundTripper
+	log  Logger
+
+	mu       sync.Mutex
+	attempts map[attemptKey]int
+}
+
+// attemptKey identifies the attempts of a request, made with the same
+// context, method and URL.
+type attemptKey struct {
+	ctx    context.Context
+	method string
+	url    string
+}
+
+// WrapTransport returns an http.RoundTripper sending requests with rt, or
+// http.DefaultTransport if rt is nil, and logging them with log, so calls to
+// other services are logged like the requests they serve:
+//
+//	client := &http.Client{Transport: gologger.WrapTransport(nil, log.Named("http-client"))}
+//
+// The request ID of the request context is sent in the X-Request-ID header,
+// unless the request has one, so the other service can log it too. Each call
+// is logged as "http client request" with its method, host, path, status and
+// duration, at info level, warn for 4xx, or error for 5xx responses and
+// failed calls. Calls repeated with the same context, method and URL, as
+// retry loops do, are logged with the number of earlier attempts as "retry";
+// attempts are only counted for contexts that can be canceled.
+func WrapTransport(rt http.RoundTripper, log Logger) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &loggingTransport{next: rt, log: log, attempts: make(map[attemptKey]int)}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	ctx := req.Context()
+	if requestID := GetRequestID(ctx); requestID != "" && req.Header.Get(RequestIDHeader) == "" {
+		// A RoundTripper must not modify the request it is given.
+		req = req.Clone(ctx)
+		req.Header.Set(RequestIDHeader, requestID)
+	}
+	retry := t.attempt(req)
+
+	resp, err := t.next.RoundTrip(req)
+
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	log := t.log.WithContext(ctx)
+	switch {
+	case err != nil || status >= http.StatusInternalServerError:
+		log = log.Error("http client request")
+	case status >= http.StatusBadRequest:
+		log = log.Warn("http client request")
+	default:
+		log = log.Info("http client request")
+	}
+	log = log.Data("method", req.Method).
+		Data("host", req.URL.Host).
+		Data("path", req.URL.Path)
+	if status != 0 {
+		log = log.Data("status", status)
+	}
+	if retry > 0 {
+		log = log.Data("retry", retry)
+	}
+	log.Data("duration_ms", float64(time.Since(start))/float64(time.Millisecond)).
+		ErrorData(err).
+		Send()
+	return resp, err
+}
+
+// attempt returns the number of earlier attempts of req. They are forgotten
+// when the context of req is done.
+func (t *loggingTransport) attempt(req *http.Request) int {
+	ctx := req.Context()
+	if ctx.Done() == nil || !reflect.TypeOf(ctx).Comparable() {
+		return 0
+	}
+	key := attemptKey{ctx: ctx, method: req.Method, url: req.URL.String()}
+	t.mu.Lock()
+	n := t.attempts[key]
+	t.attempts[key] = n + 1
+	t.mu.Unlock()
+	if n == 0 {
+		context.AfterFunc(ctx, func() {
+			t.mu.Lock()
+			delete(t.attempts, key)
+			t.mu.Unlock()
+		})
+	}
+	return n
+}
+
+// CloseIdleConnections closes the idle connections of the wrapped transport,
+// for http.Client.CloseIdleConnections.
+func (t *loggingTransport) CloseIdleConnections() {
+	if closer, ok := t.next.(interface{ CloseIdleConnections() }); ok {
+		closer.CloseIdleConnections()
+	}
+}
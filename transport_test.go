@@ -0,0 +1,118 @@
+package gologger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWrapTransport(t *testing.T) {
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(RequestIDHeader))
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	log, capture := NewTestLogger()
+	client := &http.Client{Transport: WrapTransport(nil, log)}
+
+	ctx := WithRequestID(context.Background(), "req-42")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/users?id=1", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if req.Header.Get(RequestIDHeader) != "" {
+		t.Error("Expected the request of the caller to be left unchanged")
+	}
+
+	req, _ = http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/missing", nil)
+	req.Header.Set(RequestIDHeader, "upstream-7")
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(received) != 2 || received[0] != "req-42" || received[1] != "upstream-7" {
+		t.Errorf("Expected the request ID to be sent unless set, got %v", received)
+	}
+	entries := capture.FilterMessage("http client request")
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %+v", capture.Entries())
+	}
+	ok := entries[0]
+	if ok.Level != LevelInfo || ok.Fields["method"] != "GET" || ok.Fields["host"] != strings.TrimPrefix(server.URL, "http://") ||
+		ok.Fields["path"] != "/users" || ok.Fields["status"] != int64(200) || ok.Fields["request-id"] != "req-42" {
+		t.Errorf("Unexpected entry %+v", ok)
+	}
+	if _, found := ok.Fields["duration_ms"].(float64); !found {
+		t.Errorf("Expected duration_ms, got %v", ok.Fields)
+	}
+	if _, found := ok.Fields["retry"]; found {
+		t.Errorf("Expected no retry on the first attempt, got %v", ok.Fields)
+	}
+	if entries[1].Level != LevelWarn || entries[1].Fields["status"] != int64(404) {
+		t.Errorf("Expected a warn entry for 404, got %+v", entries[1])
+	}
+}
+
+func TestWrapTransportRetries(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	log, capture := NewTestLogger()
+	client := &http.Client{Transport: WrapTransport(http.DefaultTransport, log)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/flaky", nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	cancel()
+
+	entries := capture.FilterMessage("http client request")
+	if len(entries) != 3 {
+		t.Fatalf("Expected three entries, got %+v", capture.Entries())
+	}
+	if entries[0].Level != LevelError || entries[0].Fields["retry"] != nil {
+		t.Errorf("Unexpected first attempt %+v", entries[0])
+	}
+	if entries[1].Fields["retry"] != int64(1) || entries[2].Fields["retry"] != int64(2) || entries[2].Level != LevelInfo {
+		t.Errorf("Expected retries to be counted, got %+v", entries[1:])
+	}
+}
+
+func TestWrapTransportError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+	log, capture := NewTestLogger()
+	client := &http.Client{Transport: WrapTransport(nil, log)}
+
+	if _, err := client.Get(url + "/down"); err == nil {
+		t.Fatal("Expected the request to fail")
+	}
+
+	entries := capture.FilterMessage("http client request")
+	if len(entries) != 1 || entries[0].Level != LevelError || entries[0].Fields["error"] == nil {
+		t.Fatalf("Expected an error entry, got %+v", capture.Entries())
+	}
+	if _, found := entries[0].Fields["status"]; found {
+		t.Errorf("Expected no status for a failed call, got %v", entries[0].Fields)
+	}
+}
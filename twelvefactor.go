@@ -0,0 +1,55 @@
+package gologger
+
+import (
+	"os"
+	"strings"
+)
+
+// twelveFactorConfig adjusts config for TwelveFactor: entries go to stdout
+// only, as single-line JSON, and no log files are written.
+func twelveFactorConfig(config LoggerConfig) LoggerConfig {
+	config.OutputMode = OutputStdout
+	config.Encoding = EncodingJSON
+	config.TerminalEncoding = ""
+	config.FileEncoding = ""
+	config.LevelFiles = nil
+	config.Outputs = nil
+	return config
+}
+
+// containerMarkers are files created by container runtimes inside containers.
+var containerMarkers = []string{"/.dockerenv", "/run/.containerenv"}
+
+// cgroupFile lists the control groups of the process, which name the
+// container runtime inside containers.
+var cgroupFile = "/proc/1/cgroup"
+
+// RunningInContainer reports whether the process seems to run in Kubernetes
+// or in a Docker, Podman or containerd container, e.g. to set
+// LoggerConfig.TwelveFactor automatically:
+//
+//	log := gologger.NewLoggerWithConfig(gologger.LoggerConfig{
+//		LogLevel:     gologger.LevelInfo,
+//		TwelveFactor: gologger.RunningInContainer(),
+//	})
+func RunningInContainer() bool {
+	if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+		return true
+	}
+	for _, marker := range containerMarkers {
+		if _, err := os.Stat(marker); err == nil {
+			return true
+		}
+	}
+	data, err := os.ReadFile(cgroupFile)
+	if err != nil {
+		return false
+	}
+	cgroups := string(data)
+	for _, runtime := range []string{"docker", "kubepods", "containerd", "libpod"} {
+		if strings.Contains(cgroups, runtime) {
+			return true
+		}
+	}
+	return false
+}
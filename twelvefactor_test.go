@@ -0,0 +1,66 @@
+package gologger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTwelveFactor(t *testing.T) {
+	dir := t.TempDir()
+	log := NewLoggerWithConfig(LoggerConfig{
+		OutputMode:   OutputBoth,
+		LogDir:       dir,
+		Encoding:     EncodingPretty,
+		FileEncoding: EncodingConsole,
+		LevelFiles:   map[string]*LogRotationConfig{LevelError: nil},
+		TwelveFactor: true,
+	})
+	log.Error("to stdout").Send()
+	log.Close()
+
+	config := log.Config()
+	if config.OutputMode != OutputStdout || config.TerminalEncoding != EncodingJSON || config.LevelFiles != nil {
+		t.Errorf("Expected JSON on stdout only, got %+v", config)
+	}
+	if files, _ := os.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected no log files, got %v", files)
+	}
+}
+
+func TestRunningInContainer(t *testing.T) {
+	dir := t.TempDir()
+	oldMarkers, oldCgroup := containerMarkers, cgroupFile
+	defer func() { containerMarkers, cgroupFile = oldMarkers, oldCgroup }()
+	containerMarkers = []string{filepath.Join(dir, ".dockerenv")}
+	cgroupFile = filepath.Join(dir, "cgroup")
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+
+	if RunningInContainer() {
+		t.Error("Expected no container without markers")
+	}
+	os.WriteFile(cgroupFile, []byte("0::/kubepods/besteffort/pod1234\n"), 0644)
+	if !RunningInContainer() {
+		t.Error("Expected a container from the cgroup")
+	}
+	os.Remove(cgroupFile)
+	os.WriteFile(containerMarkers[0], nil, 0644)
+	if !RunningInContainer() {
+		t.Error("Expected a container from the marker file")
+	}
+	os.Remove(containerMarkers[0])
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	if !RunningInContainer() {
+		t.Error("Expected a container in Kubernetes")
+	}
+
+	t.Setenv(EnvTwelveFactor, "auto")
+	config, err := ConfigFromEnv()
+	if err != nil || !config.TwelveFactor {
+		t.Errorf("Expected auto to enable TwelveFactor in Kubernetes, got %v, %v", config.TwelveFactor, err)
+	}
+	t.Setenv(EnvTwelveFactor, "sometimes")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Error("Expected error for an invalid value")
+	}
+}
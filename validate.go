@@ -0,0 +1,171 @@
+package gologger
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+)
+
+// NewLoggerWithConfigE creates a Logger like NewLoggerWithConfig, but returns
+// the errors of config.Validate instead of falling back to defaults for
+// invalid options.
+func NewLoggerWithConfigE(config LoggerConfig) (Logger, error) {
+	if err := config.Validate(); err != nil {
+		return Logger{}, err
+	}
+	return NewLoggerWithConfig(config), nil
+}
+
+// Validate checks the options of the configuration and returns an error
+// describing every invalid one, such as an unknown OutputMode or LogLevel,
+// or nil if all are valid. Empty options are valid and use their defaults.
+// NewLoggerWithConfig does not validate; use it in tests or
+// NewLoggerWithConfigE so typos fail fast instead of silently falling back.
+func (c LoggerConfig) Validate() error {
+	if err := validateConfig(c); err != nil {
+		return fmt.Errorf("gologger: invalid config: %w", err)
+	}
+	return nil
+}
+
+// validateConfig returns the problems of config joined in one error.
+func validateConfig(c LoggerConfig) error {
+	v := &configValidator{}
+	v.choice("OutputMode", c.OutputMode, outputModes)
+	v.choice("LogLevel", c.LogLevel, levels)
+	v.choice("Encoding", c.Encoding, encodings)
+	v.choice("TerminalEncoding", c.TerminalEncoding, encodings)
+	v.choice("FileEncoding", c.FileEncoding, encodings)
+	v.choice("FileSync", c.FileSync, []string{SyncNever, SyncOnError, SyncInterval, SyncOnClose})
+	v.choice("CallerFormat", c.CallerFormat, []string{CallerFormatShort, CallerFormatFull, CallerFormatModule, CallerFormatFile})
+	v.choice("TimeFormat", c.TimeFormat, []string{TimeFormatISO8601, TimeFormatEpochSecond, TimeFormatEpochMilli, TimeFormatEpochNano})
+	v.choice("LevelFormat", c.LevelFormat, []string{LevelFormatUpper, LevelFormatLower, LevelFormatSyslog, LevelFormatOTel})
+	v.choice("Sanitize", c.Sanitize, []string{SanitizeStrip, SanitizeEscape})
+	v.choice("StacktraceLevel", c.StacktraceLevel, levels)
+	v.nonNegative("FileSyncInterval", int64(c.FileSyncInterval))
+	v.nonNegative("DebugTimeout", int64(c.DebugTimeout))
+	if c.SyslogFacility < 0 || c.SyslogFacility > 23 {
+		v.add("SyslogFacility: %d is out of range 0-23", c.SyslogFacility)
+	}
+
+	v.rotation("LogRotation", c.LogRotation)
+	for _, name := range sortedKeys(c.LevelFiles) {
+		v.choice("LevelFiles key", name, levels)
+		v.rotation(fmt.Sprintf("LevelFiles[%s]", name), c.LevelFiles[name])
+	}
+	for _, name := range sortedKeys(c.LevelLabels) {
+		v.choice("LevelLabels key", name, append(slices.Clone(levels), "dpanic", "panic", "fatal"))
+	}
+	for i, pattern := range c.RedactKeys {
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			v.add("RedactKeys[%d]: invalid pattern %q", i, pattern)
+		}
+	}
+	for i, pattern := range c.AllowKeys {
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			v.add("AllowKeys[%d]: invalid pattern %q", i, pattern)
+		}
+	}
+	for _, pattern := range sortedKeys(c.Maskers) {
+		if _, err := path.Match(pattern, ""); pattern == "" || err != nil {
+			v.add("Maskers key: invalid pattern %q", pattern)
+		}
+		if c.Maskers[pattern] == nil {
+			v.add("Maskers[%s]: masker is nil", pattern)
+		}
+	}
+	for _, name := range sortedKeys(c.RateLimits) {
+		v.choice("RateLimits key", name, levels)
+		v.nonNegative(fmt.Sprintf("RateLimits[%s]", name), int64(c.RateLimits[name]))
+	}
+	for _, name := range sortedKeys(c.ComponentLevels) {
+		v.choice(fmt.Sprintf("ComponentLevels[%s]", name), c.ComponentLevels[name], levels)
+	}
+
+	if c.Sampling != nil {
+		v.nonNegative("Sampling.Initial", int64(c.Sampling.Initial))
+		v.nonNegative("Sampling.Thereafter", int64(c.Sampling.Thereafter))
+		v.nonNegative("Sampling.Tick", int64(c.Sampling.Tick))
+	}
+	if c.Dedup != nil {
+		v.nonNegative("Dedup.Window", int64(c.Dedup.Window))
+	}
+	if c.FileBuffer != nil {
+		v.nonNegative("FileBuffer.Size", int64(c.FileBuffer.Size))
+		v.nonNegative("FileBuffer.FlushInterval", int64(c.FileBuffer.FlushInterval))
+	}
+	if enc := c.FileEncryption; enc != nil {
+		switch {
+		case enc.WrapKey == nil && enc.Key == nil:
+			v.add("FileEncryption: either Key or WrapKey must be set")
+		case enc.WrapKey == nil && len(enc.Key) != 32:
+			v.add("FileEncryption.Key: expected 32 bytes, got %d", len(enc.Key))
+		}
+	}
+	for i, output := range c.Outputs {
+		name := fmt.Sprintf("Outputs[%d]", i)
+		if output.Type == "" {
+			v.add("%s.Type: must be set", name)
+		}
+		v.choice(name+".Type", output.Type, outputTypes)
+		v.choice(name+".Level", output.Level, levels)
+		if output.Type == OutputNetwork {
+			v.choice(name+".Encoding", output.Encoding, []string{EncodingJSON, EncodingMsgPack, EncodingProtobuf})
+			if output.Network == nil || output.Network.Addr == "" {
+				v.add("%s.Network.Addr: must be set for network outputs", name)
+			}
+		} else {
+			v.choice(name+".Encoding", output.Encoding, encodings)
+		}
+		v.rotation(name+".LogRotation", output.LogRotation)
+	}
+	for i, sink := range c.Sinks {
+		if sink == nil {
+			v.add("Sinks[%d]: sink is nil", i)
+		}
+	}
+	return errors.Join(v.errs...)
+}
+
+// outputModes and levels list the valid OutputMode and LogLevel values.
+var (
+	outputModes = []string{OutputTerminal, OutputFile, OutputBoth, OutputSplit, OutputStdout, OutputDiscard}
+	levels      = []string{LevelDebug, LevelInfo, LevelWarn, LevelError}
+)
+
+// configValidator collects the problems found in a configuration.
+type configValidator struct {
+	errs []error
+}
+
+func (v *configValidator) add(format string, args ...any) {
+	v.errs = append(v.errs, fmt.Errorf(format, args...))
+}
+
+// choice checks that a non-empty value is one of allowed.
+func (v *configValidator) choice(name, value string, allowed []string) {
+	if value != "" && !slices.Contains(allowed, value) {
+		v.add("%s: unknown value %q, expected one of %v", name, value, allowed)
+	}
+}
+
+func (v *configValidator) nonNegative(name string, value int64) {
+	if value < 0 {
+		v.add("%s: must not be negative", name)
+	}
+}
+
+// rotation checks the rotation settings of a log file.
+func (v *configValidator) rotation(name string, cfg *LogRotationConfig) {
+	if cfg == nil {
+		return
+	}
+	v.nonNegative(name+".MaxSize", int64(cfg.MaxSize))
+	v.nonNegative(name+".MaxBackups", int64(cfg.MaxBackups))
+	v.nonNegative(name+".MaxAge", int64(cfg.MaxAge))
+	v.nonNegative(name+".MaxTotalSizeMB", int64(cfg.MaxTotalSizeMB))
+	v.nonNegative(name+".CleanupInterval", int64(cfg.CleanupInterval))
+	v.choice(name+".Interval", cfg.Interval, []string{RotateHourly, RotateDaily, RotateWeekly})
+	v.choice(name+".Layout", cfg.Layout, []string{LayoutFlat, LayoutDated})
+}
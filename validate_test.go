@@ -0,0 +1,86 @@
+package gologger
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	valid := []LoggerConfig{
+		{},
+		defaultConfig(),
+		{
+			OutputMode:      OutputSplit,
+			LogLevel:        LevelWarn,
+			Encoding:        EncodingConsole,
+			FileSync:        SyncInterval,
+			LogRotation:     &LogRotationConfig{Interval: RotateHourly, Layout: LayoutDated},
+			LevelFiles:      map[string]*LogRotationConfig{LevelError: nil},
+			LevelLabels:     map[string]string{"fatal": "CRITICAL"},
+			ComponentLevels: map[string]string{"http": LevelError, AllComponents: LevelInfo},
+			FileEncryption:  &EncryptionConfig{Key: make([]byte, 32)},
+			SyslogFacility:  SyslogFacilityLocal0,
+			RedactKeys:      []string{"authorization", "*password*", "api_?ey"},
+			Maskers:         map[string]Masker{"phone": MaskLast(4), "*email*": HashEmailDomain},
+			AllowKeys:       []string{"user_id", "http_*"},
+		},
+	}
+	for _, config := range valid {
+		if err := config.Validate(); err != nil {
+			t.Errorf("Unexpected error for %+v: %v", config, err)
+		}
+	}
+
+	tests := []struct {
+		config   LoggerConfig
+		expected string
+	}{
+		{LoggerConfig{OutputMode: "trminal"}, `OutputMode: unknown value "trminal", expected one of [terminal file both split stdout discard]`},
+		{LoggerConfig{LogLevel: "warning"}, `LogLevel: unknown value "warning"`},
+		{LoggerConfig{FileEncoding: "yaml"}, `FileEncoding: unknown value "yaml"`},
+		{LoggerConfig{FileSync: "always"}, `FileSync: unknown value "always"`},
+		{LoggerConfig{TimeFormat: "rfc3339"}, `TimeFormat: unknown value "rfc3339"`},
+		{LoggerConfig{LogRotation: &LogRotationConfig{Interval: "daliy"}}, `LogRotation.Interval: unknown value "daliy"`},
+		{LoggerConfig{LogRotation: &LogRotationConfig{MaxAge: -1}}, "LogRotation.MaxAge: must not be negative"},
+		{LoggerConfig{LevelFiles: map[string]*LogRotationConfig{"errors": nil}}, `LevelFiles key: unknown value "errors"`},
+		{LoggerConfig{LevelFiles: map[string]*LogRotationConfig{LevelError: {Layout: "tree"}}}, `LevelFiles[error].Layout: unknown value "tree"`},
+		{LoggerConfig{ComponentLevels: map[string]string{"db": "trace"}}, `ComponentLevels[db]: unknown value "trace"`},
+		{LoggerConfig{FileBuffer: &BufferConfig{FlushInterval: -time.Second}}, "FileBuffer.FlushInterval: must not be negative"},
+		{LoggerConfig{Dedup: &DedupConfig{Window: -time.Second}}, "Dedup.Window: must not be negative"},
+		{LoggerConfig{RateLimits: map[string]int{"verbose": 10}}, `RateLimits key: unknown value "verbose"`},
+		{LoggerConfig{RateLimits: map[string]int{LevelDebug: -1}}, "RateLimits[debug]: must not be negative"},
+		{LoggerConfig{FileEncryption: &EncryptionConfig{Key: []byte("short")}}, "FileEncryption.Key: expected 32 bytes, got 5"},
+		{LoggerConfig{FileEncryption: &EncryptionConfig{}}, "FileEncryption: either Key or WrapKey must be set"},
+		{LoggerConfig{SyslogFacility: 24}, "SyslogFacility: 24 is out of range 0-23"},
+		{LoggerConfig{Sinks: []Sink{nil}}, "Sinks[0]: sink is nil"},
+		{LoggerConfig{RedactKeys: []string{"token", "*secret["}}, `RedactKeys[1]: invalid pattern "*secret["`},
+		{LoggerConfig{RedactKeys: []string{""}}, `RedactKeys[0]: invalid pattern ""`},
+		{LoggerConfig{AllowKeys: []string{"[user"}}, `AllowKeys[0]: invalid pattern "[user"`},
+		{LoggerConfig{Maskers: map[string]Masker{"card[": Hash}}, `Maskers key: invalid pattern "card["`},
+		{LoggerConfig{Maskers: map[string]Masker{"phone": nil}}, "Maskers[phone]: masker is nil"},
+	}
+	for _, tt := range tests {
+		err := tt.config.Validate()
+		if err == nil || !strings.HasPrefix(err.Error(), "gologger: invalid config: ") || !strings.Contains(err.Error(), tt.expected) {
+			t.Errorf("Expected error containing %q, got %v", tt.expected, err)
+		}
+	}
+
+	// Every problem is reported.
+	err := LoggerConfig{OutputMode: "trminal", LogLevel: "verbose"}.Validate()
+	if err == nil || !strings.Contains(err.Error(), "trminal") || !strings.Contains(err.Error(), "verbose") {
+		t.Errorf("Expected both problems to be reported, got %v", err)
+	}
+}
+
+func TestNewLoggerWithConfigE(t *testing.T) {
+	if _, err := NewLoggerWithConfigE(LoggerConfig{OutputMode: "trminal"}); err == nil {
+		t.Error("Expected error for an invalid config")
+	}
+	log, err := NewLoggerWithConfigE(LoggerConfig{OutputMode: OutputDiscard, LogLevel: LevelInfo})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	log.Close()
+}
@@ -0,0 +1,135 @@
+package gologger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// configReloadDelay is how long the configuration file must stay unchanged
+// before it is reloaded, so an editor's burst of writes is applied once.
+const configReloadDelay = 100 * time.Millisecond
+
+// configSource records the configuration file a logger was created from.
+type configSource struct {
+	path string
+
+	mu     sync.Mutex
+	data   []byte       // File contents last applied
+	config LoggerConfig // Configuration the logger was created with
+	stop   func()       // Stops the watcher (nil if not watching)
+}
+
+// WatchConfig watches the configuration file the logger was created from by
+// NewLoggerFromFile and applies changes with Reconfigure while the logger is
+// in use: the level, outputs, encoding, sinks, RedactKeys, AllowKeys and the
+// other options Reconfigure replaces. No entry is lost while the outputs are
+// switched. onReload, if not nil, is called after every reload with its
+// error, if any; a file that fails to load leaves the current configuration
+// in place, and changes to the options Reconfigure keeps, such as
+// request_id_key or show_caller, are reported as an error while the rest of
+// the file is applied. Watching stops when the returned function is called
+// or the logger is closed.
+func (l Logger) WatchConfig(onReload func(err error)) (func(), error) {
+	if l.source == nil {
+		return nil, errors.New("gologger: logger was not created from a configuration file")
+	}
+	if onReload == nil {
+		onReload = func(error) {}
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	// Watch the directory, so the file is still followed after editors or
+	// Kubernetes ConfigMap updates replace it instead of writing it in place.
+	if err := watcher.Add(filepath.Dir(l.source.path)); err != nil {
+		_ = watcher.Close()
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		var reload <-chan time.Time
+		for {
+			select {
+			case <-watcher.Events:
+				reload = time.After(configReloadDelay)
+			case err := <-watcher.Errors:
+				onReload(err)
+			case <-reload:
+				reload = nil
+				if changed, err := l.reloadConfig(); changed || err != nil {
+					onReload(err)
+				}
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	stop := func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			_ = watcher.Close()
+		})
+	}
+	l.source.mu.Lock()
+	previous := l.source.stop
+	l.source.stop = stop
+	l.source.mu.Unlock()
+	if previous != nil {
+		previous()
+	}
+	return stop, nil
+}
+
+// reloadConfig applies the configuration file if its contents changed since
+// it was last applied. It reports whether they changed.
+func (l Logger) reloadConfig() (bool, error) {
+	source := l.source
+	source.mu.Lock()
+	defer source.mu.Unlock()
+
+	data, err := os.ReadFile(source.path)
+	if err != nil {
+		return true, err
+	}
+	if bytes.Equal(data, source.data) {
+		return false, nil
+	}
+	config, err := parseConfigFile(source.path, data)
+	if err != nil {
+		return true, err
+	}
+
+	errs := []error{l.Reconfigure(config)}
+	if changed := changedCreationOptions(source.config, config); len(changed) > 0 {
+		errs = append(errs, fmt.Errorf("gologger: %s cannot be reloaded; restart to apply", strings.Join(changed, ", ")))
+	}
+	source.data = data
+	return true, errors.Join(errs...)
+}
+
+// stopWatching stops the configuration watcher, if any.
+func (s *configSource) stopWatching() {
+	s.mu.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
@@ -0,0 +1,178 @@
+package gologger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWatchConfig(t *testing.T) {
+	dir := t.TempDir()
+	logDir := filepath.Join(dir, "logs")
+	path := filepath.Join(dir, "logger.yaml")
+	writeConfig := func(level, sink string) {
+		t.Helper()
+		content := "output_mode: discard\nlog_level: " + level + "\nsinks:\n  - type: file\n    name: " + sink + "\n    log_dir: " + logDir + "\n"
+		// Replace the file like editors and ConfigMap updates do.
+		if err := os.WriteFile(path+".tmp", []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			t.Fatalf("Failed to replace config: %v", err)
+		}
+	}
+	writeConfig("info", "before")
+
+	log, err := NewLoggerFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer log.Close()
+	reloads := make(chan error, 10)
+	if _, err := log.WatchConfig(func(err error) { reloads <- err }); err != nil {
+		t.Fatalf("Unexpected watch error: %v", err)
+	}
+
+	log.Debug("dropped").Send()
+	log.Info("first").Send()
+	writeConfig("debug", "after")
+	select {
+	case err := <-reloads:
+		if err != nil {
+			t.Fatalf("Unexpected reload error: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for reload")
+	}
+	log.WithContext(context.Background()).Debug("second").Send()
+
+	if !log.minLevel.Enabled(zapcore.DebugLevel) {
+		t.Error("Expected the debug level after reload")
+	}
+	today := time.Now().Format("2006-01-02")
+	before, _ := os.ReadFile(filepath.Join(logDir, "before-"+today+".log"))
+	after, _ := os.ReadFile(filepath.Join(logDir, "after-"+today+".log"))
+	if strings.Contains(string(before), "dropped") || !strings.Contains(string(before), "first") || strings.Contains(string(before), "second") {
+		t.Errorf("Unexpected entries in the replaced sink: %q", before)
+	}
+	if !strings.Contains(string(after), "second") {
+		t.Errorf("Expected the debug entry in the new sink, got %q", after)
+	}
+
+	// An invalid file is reported and leaves the configuration in place.
+	writeConfig("debug\nunknown_key: 1", "broken")
+	select {
+	case err := <-reloads:
+		if err == nil || !strings.Contains(err.Error(), "unknown_key") {
+			t.Errorf("Expected error for the invalid file, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for reload")
+	}
+	if len(log.sinks.load()) != 1 {
+		t.Errorf("Expected the sink of the last valid file to stay attached, got %d sinks", len(log.sinks.load()))
+	}
+}
+
+func TestWatchConfigWithoutFile(t *testing.T) {
+	log := NewLoggerWithConfig(LoggerConfig{OutputMode: OutputDiscard})
+	if _, err := log.WatchConfig(nil); err == nil {
+		t.Error("Expected error watching a logger not created from a file")
+	}
+}
+
+func TestWatchConfigReconfigure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logger.yaml")
+	writeConfig := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path+".tmp", []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		if err := os.Rename(path+".tmp", path); err != nil {
+			t.Fatalf("Failed to replace config: %v", err)
+		}
+	}
+	writeConfig("output_mode: discard\n")
+
+	log, err := NewLoggerFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer log.Close()
+	capture := NewCaptureSink()
+	log.AddSink(capture)
+	reloads := make(chan error, 10)
+	if _, err := log.WatchConfig(func(err error) { reloads <- err }); err != nil {
+		t.Fatalf("Unexpected watch error: %v", err)
+	}
+
+	// Options Reconfigure keeps are reported, the others applied.
+	writeConfig("output_mode: file\nlog_dir: " + filepath.Join(dir, "logs") + "\nencoding: console\nredact_keys: [password]\nrequest_id_key: trace\n")
+	select {
+	case err := <-reloads:
+		if err == nil || !strings.Contains(err.Error(), "RequestIDKey cannot be reloaded") {
+			t.Errorf("Expected an error for request_id_key, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Timed out waiting for reload")
+	}
+	log.Info("login").Data("password", "secret").Send()
+
+	entries := capture.FilterMessage("login")
+	if len(entries) != 1 || entries[0].Fields["password"] != Redacted {
+		t.Errorf("Expected the reloaded redact_keys, got %+v", entries)
+	}
+	config := log.Config()
+	if config.OutputMode != OutputFile || config.Encoding != "console" || config.RequestIDKey != "request-id" {
+		t.Errorf("Expected the reloaded output and encoding with the original request ID key, got %+v", config)
+	}
+	data, _ := os.ReadFile(currentLogFile(filepath.Join(dir, "logs")))
+	if !strings.Contains(string(data), "login") {
+		t.Errorf("Expected the entry in the reloaded file output, got %q", data)
+	}
+}
+
+func TestWatchConfigInvalidClosesSinks(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logger.yaml")
+	if err := os.WriteFile(path, []byte("output_mode: discard\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	log, err := NewLoggerFromFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer log.Close()
+	baseline := runtime.NumGoroutine()
+
+	// The buffered sink starts a goroutine when it is decoded, before the
+	// invalid level is rejected.
+	content := "output_mode: discard\nlog_level: verbose\nsinks:\n  - type: file\n    log_dir: " + dir + "\n    buffer: {size: 10}\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		source := log.source
+		source.mu.Lock()
+		source.data = nil // Reload the unchanged file again
+		source.mu.Unlock()
+		if _, err := log.reloadConfig(); err == nil || !strings.Contains(err.Error(), "LogLevel") {
+			t.Fatalf("Expected the invalid level to be rejected, got %v", err)
+		}
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > baseline && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > baseline {
+		t.Errorf("Expected the sinks of the rejected file to be closed, %d goroutines left over", n-baseline)
+	}
+}
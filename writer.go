@@ -0,0 +1,96 @@
+package gologger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+)
+
+// logWriter logs the lines written to it.
+type logWriter struct {
+	log   Logger
+	level string
+}
+
+// Writer returns an io.Writer logging each line written to it as an entry at
+// level, for code that only accepts an io.Writer. Unknown levels are logged
+// at debug level, as in LoggerConfig. A line is not kept for a later Write,
+// so writers should write whole lines, as the standard log package does.
+// Empty lines are dropped. The caller of entries is the caller of Write.
+func (l Logger) Writer(level string) io.Writer {
+	return l.writer(level, 1)
+}
+
+// StdLogger returns a standard library *log.Logger logging each message as
+// an entry at level, e.g. for http.Server.ErrorLog:
+//
+//	server := &http.Server{ErrorLog: log.Named("http").StdLogger(gologger.LevelWarn)}
+//
+// Messages have no prefix or timestamp, since entries have their own. The
+// caller of entries is the caller of the log.Logger methods.
+func (l Logger) StdLogger(level string) *log.Logger {
+	// Print, Printf and Println call Write through log.Logger.output.
+	return log.New(l.writer(level, 3), "", 0)
+}
+
+// writer returns a logWriter whose entries skip skip frames above Write when
+// reporting their caller.
+func (l Logger) writer(level string, skip int) *logWriter {
+	return &logWriter{log: l.WithCallerSkip(skip), level: level}
+}
+
+func (w *logWriter) Write(p []byte) (int, error) {
+	for _, line := range bytes.Split(p, []byte{'\n'}) {
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+		if len(line) > 0 {
+			w.log.Log(w.level, string(line)).Send()
+		}
+	}
+	return len(p), nil
+}
+
+// PrintLogger logs the messages of Print-style logging interfaces, such as
+// sarama.StdLogger and the Logger of kafka-go, as entries at a fixed level.
+type PrintLogger struct {
+	log   Logger
+	level string
+}
+
+// PrintLogger returns a PrintLogger logging each message at level, for
+// clients that accept a logger with Print, Printf and Println methods:
+//
+//	sarama.Logger = log.Named("sarama").PrintLogger(gologger.LevelInfo)
+//	reader := kafka.NewReader(kafka.ReaderConfig{
+//		Logger:      log.Named("kafka").PrintLogger(gologger.LevelDebug),
+//		ErrorLogger: log.Named("kafka").PrintLogger(gologger.LevelError),
+//	})
+//
+// Unknown levels are logged at debug level. Trailing newlines are removed
+// from messages and empty messages are dropped. The caller of entries is the
+// caller of the Print methods.
+func (l Logger) PrintLogger(level string) *PrintLogger {
+	return &PrintLogger{log: l.WithCallerSkip(1), level: level}
+}
+
+// Print logs the operands formatted as by fmt.Sprint.
+func (p *PrintLogger) Print(v ...any) {
+	if msg := strings.TrimRight(fmt.Sprint(v...), "\r\n"); msg != "" {
+		p.log.Log(p.level, msg).Send()
+	}
+}
+
+// Printf logs the operands formatted as by fmt.Sprintf.
+func (p *PrintLogger) Printf(format string, v ...any) {
+	if msg := strings.TrimRight(fmt.Sprintf(format, v...), "\r\n"); msg != "" {
+		p.log.Log(p.level, msg).Send()
+	}
+}
+
+// Println logs the operands formatted as by fmt.Sprintln.
+func (p *PrintLogger) Println(v ...any) {
+	if msg := strings.TrimRight(fmt.Sprintln(v...), "\r\n"); msg != "" {
+		p.log.Log(p.level, msg).Send()
+	}
+}
@@ -0,0 +1,101 @@
+package gologger
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestLoggerWriter(t *testing.T) {
+	log, capture := NewTestLogger()
+	w := log.Named("legacy").Writer(LevelWarn)
+
+	n, err := fmt.Fprint(w, "disk almost full\r\n\nretrying\n")
+	if err != nil || n != 28 {
+		t.Fatalf("Expected the whole input to be written, got %d, %v", n, err)
+	}
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected one entry per non-empty line, got %d", len(entries))
+	}
+	for i, msg := range []string{"disk almost full", "retrying"} {
+		if entries[i].Message != msg || entries[i].Level != LevelWarn || entries[i].Logger != "legacy" {
+			t.Errorf("Unexpected entry %+v", entries[i])
+		}
+	}
+	if !strings.Contains(entries[0].Caller, "fmt/print.go") {
+		t.Errorf("Expected the caller of Write, got %q", entries[0].Caller)
+	}
+
+	_, _ = log.Writer("verbose").Write([]byte("unknown level"))
+	if entries := capture.FilterMessage("unknown level"); len(entries) != 1 || entries[0].Level != LevelDebug {
+		t.Errorf("Expected unknown levels at debug level, got %+v", entries)
+	}
+}
+
+func TestLoggerStdLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	std := log.StdLogger(LevelError)
+
+	std.Printf("http: TLS handshake error from %s", "10.0.0.1:5000")
+	std.Println("second message")
+
+	entries := capture.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("Expected two entries, got %d", len(entries))
+	}
+	if entries[0].Message != "http: TLS handshake error from 10.0.0.1:5000" || entries[0].Level != LevelError {
+		t.Errorf("Unexpected entry %+v", entries[0])
+	}
+	for _, entry := range entries {
+		if !strings.Contains(entry.Caller, "writer_test.go:") {
+			t.Errorf("Expected the caller of the log.Logger method, got %q", entry.Caller)
+		}
+	}
+}
+
+// saramaStdLogger and kafkaLogger are the logger interfaces of sarama and
+// kafka-go.
+type saramaStdLogger interface {
+	Print(v ...any)
+	Printf(format string, v ...any)
+	Println(v ...any)
+}
+
+type kafkaLogger interface {
+	Printf(string, ...any)
+}
+
+var (
+	_ saramaStdLogger = (*PrintLogger)(nil)
+	_ kafkaLogger     = (*PrintLogger)(nil)
+)
+
+func TestPrintLogger(t *testing.T) {
+	log, capture := NewTestLogger()
+	var printer saramaStdLogger = log.Named("sarama").PrintLogger(LevelInfo)
+
+	printer.Printf("client/metadata fetching metadata for %v from broker %s\n", []string{"orders"}, "kafka-1:9092")
+	printer.Print("consumer/broker/", 1, " added subscription")
+	printer.Println("producer/leader", "selected broker", 2)
+	printer.Println()
+
+	want := []string{
+		"client/metadata fetching metadata for [orders] from broker kafka-1:9092",
+		"consumer/broker/1 added subscription",
+		"producer/leader selected broker 2",
+	}
+	entries := capture.Entries()
+	if len(entries) != len(want) {
+		t.Fatalf("Expected %d entries, got %+v", len(want), entries)
+	}
+	for i, entry := range entries {
+		if entry.Message != want[i] || entry.Level != LevelInfo || entry.Logger != "sarama" {
+			t.Errorf("Unexpected entry %+v", entry)
+		}
+		if !strings.Contains(entry.Caller, "writer_test.go:") {
+			t.Errorf("Expected the caller of the Print method, got %q", entry.Caller)
+		}
+	}
+}
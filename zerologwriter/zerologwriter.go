@@ -0,0 +1,141 @@
+// Package zerologwriter writes the events of zerolog loggers through
+// gologger, so code still using zerolog shares the outputs, sinks and
+// rotation of gologger while it is migrated:
+//
+//	logger := zerolog.New(zerologwriter.New(log))
+//
+// It is a separate module, so applications not using zerolog do not depend
+// on it.
+package zerologwriter
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"go.risoftinc.com/gologger"
+)
+
+// Writer is a zerolog.LevelWriter writing every zerolog event through a
+// gologger.Logger: its message and its fields, in order. The level, time and
+// caller of events are replaced by those of gologger: trace events are
+// logged at debug level, events without a level at info level, and fatal and
+// panic events at error level, leaving exiting and panicking to zerolog. The
+// caller of entries is the caller of the zerolog method ending the event,
+// such as Msg.
+type Writer struct {
+	log gologger.Logger
+}
+
+// New returns a writer writing zerolog events through log.
+func New(log gologger.Logger) *Writer {
+	return &Writer{log: log}
+}
+
+// Write writes an event without a level.
+func (w *Writer) Write(p []byte) (int, error) {
+	return w.write(zerolog.NoLevel, p, callerSkip())
+}
+
+// WriteLevel writes an event at level.
+func (w *Writer) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	return w.write(level, p, callerSkip())
+}
+
+func (w *Writer) write(level zerolog.Level, p []byte, skip int) (int, error) {
+	// write is called by Write or WriteLevel.
+	log := w.log.WithCallerSkip(skip + 1)
+	message, fields, err := decodeEvent(p)
+	if err != nil {
+		// Not a JSON event, e.g. written by a zerolog.ConsoleWriter.
+		message, fields = strings.TrimSpace(string(p)), nil
+	}
+
+	switch level {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		log = log.Debug(message)
+	case zerolog.InfoLevel, zerolog.NoLevel:
+		log = log.Info(message)
+	case zerolog.WarnLevel:
+		log = log.Warn(message)
+	default:
+		log = log.Error(message)
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		log = log.Data(fields[i].(string), fields[i+1])
+	}
+	log.Send()
+	return len(p), nil
+}
+
+// decodeEvent returns the message of a JSON event and its other fields as
+// key-value pairs, in order. Numbers are decoded as int64 if they are
+// integers and float64 otherwise.
+func decodeEvent(p []byte) (string, []any, error) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return "", nil, err
+	}
+	var message string
+	var fields []any
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return "", nil, err
+		}
+		key, _ := token.(string)
+		var value any
+		if err := dec.Decode(&value); err != nil {
+			return "", nil, err
+		}
+		switch key {
+		case zerolog.MessageFieldName:
+			message, _ = value.(string)
+		case zerolog.LevelFieldName, zerolog.TimestampFieldName, zerolog.CallerFieldName:
+		default:
+			fields = append(fields, key, numbers(value))
+		}
+	}
+	return message, fields, nil
+}
+
+// numbers replaces the json.Number values of a decoded value.
+func numbers(value any) any {
+	switch value := value.(type) {
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return n
+		}
+		f, _ := value.Float64()
+		return f
+	case []any:
+		for i := range value {
+			value[i] = numbers(value[i])
+		}
+	case map[string]any:
+		for key := range value {
+			value[key] = numbers(value[key])
+		}
+	}
+	return value
+}
+
+// callerSkip returns the number of frames between Write or WriteLevel and
+// the first caller outside zerolog.
+func callerSkip() int {
+	var pcs [32]uintptr
+	// Skip runtime.Callers, callerSkip and Write or WriteLevel.
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	skip := 1
+	for {
+		frame, more := frames.Next()
+		if !more || !strings.HasPrefix(frame.Function, "github.com/rs/zerolog.") &&
+			!strings.HasPrefix(frame.Function, "github.com/rs/zerolog/") {
+			return skip
+		}
+		skip++
+	}
+}
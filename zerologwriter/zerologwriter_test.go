@@ -0,0 +1,81 @@
+package zerologwriter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"go.risoftinc.com/gologger"
+)
+
+func TestWriter(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	logger := zerolog.New(New(log)).With().Timestamp().Str("service", "billing").Logger()
+
+	logger.Warn().
+		Int("user_id", 7).
+		Float64("amount", 9.5).
+		Dict("card", zerolog.Dict().Str("brand", "visa").Int("exp", 2028)).
+		Err(errors.New("declined")).
+		Msg("charge failed")
+
+	entries := capture.Entries()
+	if len(entries) != 1 {
+		t.Fatalf("Expected one entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != gologger.LevelWarn || entry.Message != "charge failed" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if entry.Fields["service"] != "billing" || entry.Fields["user_id"] != int64(7) ||
+		entry.Fields["amount"] != 9.5 || entry.Fields["error"] != "declined" {
+		t.Errorf("Unexpected fields %v", entry.Fields)
+	}
+	if card, ok := entry.Fields["card"].(map[string]any); !ok || card["brand"] != "visa" || card["exp"] != int64(2028) {
+		t.Errorf("Expected the nested fields, got %v", entry.Fields["card"])
+	}
+	if _, ok := entry.Fields["time"]; ok {
+		t.Errorf("Expected the zerolog timestamp to be dropped, got %v", entry.Fields)
+	}
+	if !strings.Contains(entry.Caller, "zerologwriter_test.go:") {
+		t.Errorf("Expected the caller of Msg, got %q", entry.Caller)
+	}
+}
+
+func TestWriterLevels(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	logger := zerolog.New(New(log)).Level(zerolog.TraceLevel)
+
+	logger.Trace().Msg("trace")
+	logger.Info().Send()
+	logger.Log().Msg("no level")
+	logger.Error().Msgf("failed %d times", 3)
+	func() {
+		defer func() { _ = recover() }()
+		logger.Panic().Msg("panic")
+	}()
+
+	var levels, messages []string
+	for _, entry := range capture.Entries() {
+		levels = append(levels, entry.Level)
+		messages = append(messages, entry.Message)
+	}
+	if got := strings.Join(levels, ","); got != "debug,info,info,error,error" {
+		t.Errorf("Expected debug,info,info,error,error, got %s", got)
+	}
+	if got := strings.Join(messages, ","); got != "trace,,no level,failed 3 times,panic" {
+		t.Errorf("Unexpected messages %s", got)
+	}
+}
+
+func TestWriterPlainText(t *testing.T) {
+	log, capture := gologger.NewTestLogger()
+	if _, err := New(log).Write([]byte("plain text\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	entries := capture.Entries()
+	if len(entries) != 1 || entries[0].Message != "plain text" || entries[0].Level != gologger.LevelInfo {
+		t.Errorf("Expected the text as message, got %+v", entries)
+	}
+}